@@ -33,40 +33,156 @@ func main() {
 	// Initialize repositories
 	userRepo := repositories.NewUserRepository(db)
 	workflowConfigRepo := repositories.NewWorkflowConfigRepository(db)
+	accessPolicyRepo := repositories.NewAccessPolicyRepository(db)
+	sessionRepo := repositories.NewSessionRepository(db)
+	messageRepo := repositories.NewMessageRepository(db)
+	pendingRequestRepo := repositories.NewPendingRequestRepository(db)
+	knowledgeRepo := repositories.NewKnowledgeRepository(db)
+	embeddingSpaceRepo := repositories.NewEmbeddingSpaceRepository(db)
+	embeddingJobRepo := repositories.NewEmbeddingJobRepository(db)
+	roleRepo := repositories.NewRoleRepository(db)
+	apiKeyRepo := repositories.NewAPIKeyRepository(db)
+	auditRepo := repositories.NewAuditRepository(db)
+	subscriptionRepo := repositories.NewSubscriptionRepository(db)
+	signalBroadcastRepo := repositories.NewSignalBroadcastRepository(db)
+	trackedSignalRepo := repositories.NewTrackedSignalRepository(db)
+	signalTemplateRepo := repositories.NewSignalTemplateRepository(db)
+	rejectedSignalRepo := repositories.NewRejectedSignalRepository(db)
+	takeoverRepo := repositories.NewTakeoverRepository(db)
 
 	// Initialize services
 	userService := services.NewUserService(userRepo)
-	workflowConfigService := services.NewWorkflowConfigService(workflowConfigRepo)
+	roleService := services.NewRoleService(roleRepo, userRepo)
+	if err := services.ValidateJWTSecret(config.Auth.JWTSecret); err != nil {
+		log.Fatalf("Invalid auth config: %v", err)
+	}
+	authService := services.NewAuthService(&services.AuthConfig{
+		APIKey:    config.Auth.APIKey,
+		JWTSecret: config.Auth.JWTSecret,
+		JWTExpiry: config.Auth.JWTExpiry,
+	}, apiKeyRepo, userRepo, roleRepo)
+	auditService := services.NewAuditService(auditRepo)
+	subscriptionService := services.NewSubscriptionService(subscriptionRepo, userRepo)
+	// Initialize workflow registry - backends register themselves below
+	workflowRegistry := services.NewWorkflowRegistry(services.WorkflowTypeN8N)
+	workflowConfigService := services.NewWorkflowConfigService(workflowConfigRepo, workflowRegistry)
+	accessPolicyService := services.NewAccessPolicyService(accessPolicyRepo, userService)
+	sessionService := services.NewSessionService(sessionRepo, config.WhatsApp.SessionTimeout)
+	messageService := services.NewMessageService(messageRepo)
+	pendingRequestService := services.NewPendingRequestService(pendingRequestRepo, config.N8N.ResponseTimeout)
+	takeoverService := services.NewTakeoverService(takeoverRepo)
+
+	// Initialize OpenAI-compatible client and knowledge retrieval. Embedding
+	// models come from the embedding space; the client config is shared.
+	openAIClientConfig := &services.OpenAIClientConfig{
+		BaseURL:       config.OpenAI.BaseURL,
+		APIKey:        config.OpenAI.APIKey,
+		Timeout:       time.Duration(config.OpenAI.TimeoutSeconds) * time.Second,
+		RetryAttempts: config.OpenAI.RetryAttempts,
+		RetryDelay:    config.OpenAI.RetryDelay,
+	}
+	openAIClient := services.NewOpenAIClient(openAIClientConfig)
+	embeddingSpaceService := services.NewEmbeddingSpaceService(embeddingSpaceRepo, openAIClientConfig)
+	reembeddingService := services.NewReembeddingService(&services.ReembeddingConfig{
+		BatchSize:  config.OpenAI.ReembedBatchSize,
+		BatchDelay: config.OpenAI.ReembedBatchDelay,
+	}, embeddingJobRepo, knowledgeRepo, embeddingSpaceService)
+	var reranker services.Reranker
+	if config.Rerank.BaseURL != "" {
+		reranker = services.NewHTTPReranker(&services.RerankerConfig{
+			BaseURL: config.Rerank.BaseURL,
+			APIKey:  config.Rerank.APIKey,
+			Model:   config.Rerank.Model,
+			Timeout: time.Duration(config.Rerank.TimeoutSeconds) * time.Second,
+		})
+	}
+	knowledgeService := services.NewKnowledgeService(knowledgeRepo, embeddingSpaceService, services.KnowledgeSearchConfig{
+		TopK:        config.OpenAI.RetrievalTopK,
+		Threshold:   config.OpenAI.RetrievalThreshold,
+		Hybrid:      config.OpenAI.HybridSearch,
+		RRFConstant: config.OpenAI.RRFConstant,
+	}, reranker)
+
+	// Initialize WhatsApp service
+	whatsappService := services.NewWhatsAppService(userService, accessPolicyService, sessionService, messageService, workflowRegistry, workflowConfigService, subscriptionService, takeoverService, auditService, db)
 
-	// Initialize N8N service
+	// Initialize N8N backend
 	n8nConfig := &services.N8NConfig{
-		WorkflowURL: config.N8N.WebhookURL,
-		APIKey:      config.N8N.APIKey,
-		Timeout:     time.Duration(config.N8N.TimeoutSeconds) * time.Second,
+		WorkflowURL:   config.N8N.WebhookURL,
+		APIKey:        config.N8N.APIKey,
+		Timeout:       time.Duration(config.N8N.TimeoutSeconds) * time.Second,
+		RetryAttempts: config.N8N.RetryAttempts,
+		RetryDelay:    config.N8N.RetryDelay,
 	}
-	n8nService := services.NewN8NService(n8nConfig)
+	n8nService := services.NewN8NService(n8nConfig, pendingRequestService, whatsappService)
+	workflowRegistry.Register(n8nService)
 
-	// Initialize Flowise service
+	// Initialize Flowise backend
 	flowiseConfig := &services.FlowiseConfig{
-		BaseURL: config.Flowise.BaseURL,
-		FlowID:  config.Flowise.FlowID,
-		APIKey:  config.Flowise.APIKey,
-		Timeout: time.Duration(config.Flowise.TimeoutSeconds) * time.Second,
+		BaseURL:       config.Flowise.BaseURL,
+		FlowID:        config.Flowise.FlowID,
+		APIKey:        config.Flowise.APIKey,
+		Timeout:       time.Duration(config.Flowise.TimeoutSeconds) * time.Second,
+		RetryAttempts: config.Flowise.RetryAttempts,
+		RetryDelay:    config.Flowise.RetryDelay,
 	}
-	flowiseService := services.NewFlowiseService(flowiseConfig)
+	flowiseService := services.NewFlowiseService(flowiseConfig, pendingRequestService, whatsappService)
+	workflowRegistry.Register(flowiseService)
 
-	// Initialize WhatsApp service
-	whatsappService := services.NewWhatsAppService(userService, n8nService, flowiseService, workflowConfigService, db)
-
-	// Set circular dependencies - workflow services need WhatsApp service for responses
-	n8nService.SetWhatsAppService(whatsappService)
-	flowiseService.SetWhatsAppService(whatsappService)
+	// Initialize OpenAI-compatible RAG backend
+	promptTemplate, err := services.LoadPromptTemplate(config.OpenAI.PromptTemplatePath)
+	if err != nil {
+		log.Printf("OpenAI backend disabled: %v", err)
+	} else {
+		openAIConfig := &services.OpenAIConfig{
+			ChatModel:      config.OpenAI.ChatModel,
+			Temperature:    config.OpenAI.Temperature,
+			MaxTokens:      config.OpenAI.MaxTokens,
+			TopP:           config.OpenAI.TopP,
+			TopK:           config.OpenAI.RetrievalTopK,
+			Threshold:      config.OpenAI.RetrievalThreshold,
+			PromptTemplate: promptTemplate,
+		}
+		workflowRegistry.Register(services.NewOpenAIService(openAIConfig, openAIClient, knowledgeService, whatsappService))
+	}
 
-	// Initialize Signal service
-	signalService := services.NewSignalService(userService, whatsappService)
+	// Initialize workflow watchdog
+	watchdogConfig := &services.WatchdogConfig{
+		Interval:       config.Watchdog.Interval,
+		SoftTimeout:    config.Watchdog.SoftTimeout,
+		SoftMessage:    config.Watchdog.SoftMessage,
+		TimeoutMessage: config.Watchdog.TimeoutMessage,
+	}
+	workflowWatchdog := services.NewWorkflowWatchdog(watchdogConfig, pendingRequestService, whatsappService)
+
+	// Initialize Signal service; broadcasts are sent in the background by the dispatcher
+	signalDispatcher := services.NewSignalDispatcher(&services.SignalDispatchConfig{
+		Workers:       config.Signal.Workers,
+		RatePerSecond: config.Signal.RatePerSecond,
+		Jitter:        config.Signal.Jitter,
+	}, signalBroadcastRepo, whatsappService)
+	signalOutcomeService := services.NewSignalOutcomeService(&services.SignalOutcomeConfig{
+		ExpiryDays: config.Signal.OutcomeExpiryDays,
+	}, trackedSignalRepo)
+	signalTemplateService := services.NewSignalTemplateService(signalTemplateRepo)
+	signalValidator, err := services.NewSignalValidator(&services.SignalValidationConfig{
+		MaxAgeDays:          config.Signal.Validation.MaxAgeDays,
+		TickerPattern:       config.Signal.Validation.TickerPattern,
+		Tickers:             config.Signal.Validation.Tickers,
+		RiskRewardTolerance: config.Signal.Validation.RiskRewardTolerance,
+		MinRiskReward:       config.Signal.Validation.MinRiskReward,
+		MinConfluenceScore:  config.Signal.Validation.MinConfluenceScore,
+		MinBacktestWinRate:  config.Signal.Validation.MinBacktestWinRate,
+		MinTotalTrades:      config.Signal.Validation.MinTotalTrades,
+		DisabledRules:       config.Signal.Validation.DisabledRules,
+	})
+	if err != nil {
+		log.Fatalf("Invalid signal validation config: %v", err)
+	}
+	signalService := services.NewSignalService(signalBroadcastRepo, rejectedSignalRepo, signalValidator, subscriptionService, signalOutcomeService, signalTemplateService, signalDispatcher)
 
 	// Initialize handlers
-	appHandlers := handlers.NewHandlers(db, userService, n8nService, whatsappService, signalService)
+	appHandlers := handlers.NewHandlers(db, userService, n8nService, flowiseService, whatsappService, signalService, messageService, knowledgeService, embeddingSpaceService, reembeddingService, roleService, authService, auditService, subscriptionService, signalOutcomeService, signalTemplateService, workflowConfigService, accessPolicyService, takeoverService)
 
 	// Start WhatsApp service
 	ctx := context.Background()
@@ -75,8 +191,21 @@ func main() {
 		log.Fatalf("Failed to start WhatsApp service: %v", err)
 	}
 
+	// Start workflow watchdog
+	workflowWatchdog.Start(ctx)
+
+	// Start signal workers and resume broadcasts interrupted by a restart
+	if err := signalDispatcher.Start(ctx); err != nil {
+		log.Printf("Failed to resume signal broadcasts: %v", err)
+	}
+
+	// Re-embedding jobs do not survive a restart; mark leftovers so they can be restarted
+	if err := reembeddingService.FailInterrupted(ctx); err != nil {
+		log.Printf("Failed to clean up interrupted embedding jobs: %v", err)
+	}
+
 	// Initialize and start HTTP server
-	srv := server.NewServer(config, appHandlers)
+	srv := server.NewServer(config, appHandlers, authService)
 	if err := srv.Start(); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
@@ -97,6 +226,15 @@ func main() {
 		log.Printf("Error during server shutdown: %v", err)
 	}
 
+	// Stop workflow watchdog before WhatsApp so no notice is sent mid-shutdown
+	workflowWatchdog.Stop()
+
+	// Cancel running re-embedding jobs and record them as cancelled
+	reembeddingService.Stop()
+
+	// Finish in-flight signal sends; queued deliveries resume on the next start
+	signalDispatcher.Stop()
+
 	// Stop WhatsApp service
 	if err := whatsappService.Stop(); err != nil {
 		log.Printf("Error during WhatsApp service shutdown: %v", err)
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/configs"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/repositories"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/services"
+)
+
+type KBConfig struct {
+	ChunkSize    int
+	ChunkOverlap int
+	BatchSize    int
+	DryRun       bool
+	EmbeddingURL string
+	EmbeddingKey string
+	Space        string
+}
+
+// Extensions picked up when ingesting a directory
+var documentExtensions = map[string]bool{".txt": true, ".md": true}
+
+func main() {
+	config := configs.LoadConfig()
+
+	var kbConfig KBConfig
+	flag.IntVar(&kbConfig.ChunkSize, "chunk-size", 500, "Maximum characters per chunk")
+	flag.IntVar(&kbConfig.ChunkOverlap, "chunk-overlap", 50, "Characters shared between neighbouring chunks")
+	flag.IntVar(&kbConfig.BatchSize, "batch-size", 32, "Chunks embedded per request")
+	flag.BoolVar(&kbConfig.DryRun, "dry-run", false, "Report changes without embedding or writing")
+	flag.StringVar(&kbConfig.EmbeddingURL, "embedding-url", config.OpenAI.BaseURL, "OpenAI-compatible API base URL (default: $OPENAI_BASE_URL)")
+	flag.StringVar(&kbConfig.EmbeddingKey, "embedding-key", config.OpenAI.APIKey, "Embedding API key (default: $OPENAI_API_KEY)")
+	flag.StringVar(&kbConfig.Space, "space", "", "Embedding space for new chunks (default: the active space)")
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 2 {
+		printUsage()
+		os.Exit(1)
+	}
+
+	db, err := configs.ConnectDatabase(&config.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer configs.CloseDatabase(db)
+
+	embeddingSpaceService := services.NewEmbeddingSpaceService(repositories.NewEmbeddingSpaceRepository(db), &services.OpenAIClientConfig{
+		BaseURL:       kbConfig.EmbeddingURL,
+		APIKey:        kbConfig.EmbeddingKey,
+		Timeout:       time.Duration(config.OpenAI.TimeoutSeconds) * time.Second,
+		RetryAttempts: config.OpenAI.RetryAttempts,
+		RetryDelay:    config.OpenAI.RetryDelay,
+	})
+	ingestionService := services.NewKnowledgeIngestionService(&services.IngestionConfig{
+		ChunkSize:    kbConfig.ChunkSize,
+		ChunkOverlap: kbConfig.ChunkOverlap,
+		BatchSize:    kbConfig.BatchSize,
+		Space:        kbConfig.Space,
+	}, repositories.NewKnowledgeRepository(db), embeddingSpaceService)
+
+	ctx := context.Background()
+	if kbConfig.DryRun {
+		fmt.Println("Dry run: no embeddings will be computed and nothing will be written")
+	}
+
+	switch command := args[0]; command {
+	case "ingest":
+		failed := false
+		for _, path := range args[1:] {
+			if err := ingestPath(ctx, ingestionService, path, kbConfig.DryRun); err != nil {
+				log.Printf("Failed to ingest %s: %v", path, err)
+				failed = true
+			}
+		}
+		if failed {
+			os.Exit(1)
+		}
+
+	case "delete":
+		for _, source := range args[1:] {
+			deleted, err := ingestionService.DeleteSource(ctx, source, kbConfig.DryRun)
+			if err != nil {
+				log.Fatalf("Failed to delete %s: %v", source, err)
+			}
+			fmt.Printf("%s: %d chunks deleted\n", source, deleted)
+		}
+
+	default:
+		fmt.Printf("Unknown command: %s\n", command)
+		printUsage()
+		os.Exit(1)
+	}
+}
+
+// ingestPath ingests a file, or every document under a directory. Files given
+// directly are keyed by base name; files found in a directory by their path
+// relative to it, so re-running from another working directory matches.
+func ingestPath(ctx context.Context, ingestionService services.KnowledgeIngestionService, path string, dryRun bool) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if !info.IsDir() {
+		return ingestFile(ctx, ingestionService, path, filepath.Base(path), dryRun)
+	}
+
+	return filepath.WalkDir(path, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !documentExtensions[strings.ToLower(filepath.Ext(filePath))] {
+			return nil
+		}
+
+		source, err := filepath.Rel(path, filePath)
+		if err != nil {
+			return err
+		}
+		return ingestFile(ctx, ingestionService, filePath, filepath.ToSlash(source), dryRun)
+	})
+}
+
+func ingestFile(ctx context.Context, ingestionService services.KnowledgeIngestionService, filePath, source string, dryRun bool) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	result, err := ingestionService.Ingest(ctx, source, string(content), dryRun)
+	if err != nil {
+		return fmt.Errorf("%s: %w", source, err)
+	}
+
+	fmt.Printf("%s: %d chunks (%d inserted, %d updated, %d unchanged, %d deleted)\n",
+		result.Source, result.Chunks, result.Inserted, result.Updated, result.Unchanged, result.Deleted)
+	return nil
+}
+
+func printUsage() {
+	fmt.Println("Knowledge Base CLI")
+	fmt.Println()
+	fmt.Println("Usage: go run cmd/kb/main.go [flags] <command> <args...>")
+	fmt.Println()
+	fmt.Println("Flags:")
+	fmt.Println("  -chunk-size int          Maximum characters per chunk (default: 500)")
+	fmt.Println("  -chunk-overlap int       Characters shared between neighbouring chunks (default: 50)")
+	fmt.Println("  -batch-size int          Chunks embedded per request (default: 32)")
+	fmt.Println("  -dry-run                 Report changes without embedding or writing")
+	fmt.Println("  -embedding-url string    OpenAI-compatible API base URL (default: $OPENAI_BASE_URL)")
+	fmt.Println("  -embedding-key string    Embedding API key (default: $OPENAI_API_KEY)")
+	fmt.Println("  -space string            Embedding space for new chunks (default: the active space)")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  ingest PATH...    Ingest files or directories (*.txt, *.md); unchanged chunks are kept")
+	fmt.Println("  delete SOURCE...  Delete every chunk stored for a source")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  go run cmd/kb/main.go ingest ../knowledge-base")
+	fmt.Println("  go run cmd/kb/main.go -dry-run ingest ../knowledge-base/service-catalog.txt")
+	fmt.Println("  go run cmd/kb/main.go -space e5-multilingual ingest ../knowledge-base")
+	fmt.Println("  go run cmd/kb/main.go delete service-catalog.txt")
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/configs"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/repositories"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/services"
+)
+
+type UsersConfig struct {
+	Format string
+	DryRun bool
+	Search string
+	Active string
+}
+
+func main() {
+	config := configs.LoadConfig()
+
+	var usersConfig UsersConfig
+	flag.StringVar(&usersConfig.Format, "format", "", "File format: csv or json (default: from the file extension, else csv)")
+	flag.BoolVar(&usersConfig.DryRun, "dry-run", false, "Validate an import without writing")
+	flag.StringVar(&usersConfig.Search, "search", "", "Export only users whose name, phone or email contains this text")
+	flag.StringVar(&usersConfig.Active, "active", "", "Export only active (true) or inactive (false) users")
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 1 {
+		printUsage()
+		os.Exit(1)
+	}
+
+	db, err := configs.ConnectDatabase(&config.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer configs.CloseDatabase(db)
+
+	userService := services.NewUserService(repositories.NewUserRepository(db))
+	ctx := context.Background()
+
+	switch command := args[0]; command {
+	case "import":
+		if len(args) < 2 {
+			printUsage()
+			os.Exit(1)
+		}
+		failed := false
+		for _, path := range args[1:] {
+			if !importFile(ctx, userService, path, usersConfig) {
+				failed = true
+			}
+		}
+		if failed {
+			os.Exit(1)
+		}
+
+	case "export":
+		path := ""
+		if len(args) > 1 {
+			path = args[1]
+		}
+		if err := exportUsers(ctx, userService, path, usersConfig); err != nil {
+			log.Fatalf("Failed to export users: %v", err)
+		}
+
+	default:
+		fmt.Printf("Unknown command: %s\n", command)
+		printUsage()
+		os.Exit(1)
+	}
+}
+
+// importFile imports one file and prints a line per failed row; it reports
+// whether every row was imported
+func importFile(ctx context.Context, userService services.UserService, path string, usersConfig UsersConfig) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		log.Printf("Failed to open %s: %v", path, err)
+		return false
+	}
+	defer file.Close()
+
+	rows, err := services.ParseUsers(file, fileFormat(usersConfig.Format, path))
+	if err != nil {
+		log.Printf("Failed to read %s: %v", path, err)
+		return false
+	}
+
+	report := userService.ImportUsers(ctx, rows, usersConfig.DryRun)
+	for _, row := range report.Rows {
+		if row.Status == models.UserImportFailed {
+			fmt.Printf("%s row %d (%s): %s\n", path, row.Row, row.Phone, row.Error)
+		}
+	}
+
+	if report.DryRun {
+		fmt.Printf("%s: %d rows (%d valid, %d invalid)\n", path, report.Total, report.Total-report.Failed, report.Failed)
+	} else {
+		fmt.Printf("%s: %d rows (%d created, %d updated, %d failed)\n", path, report.Total, report.Created, report.Updated, report.Failed)
+	}
+
+	return report.Failed == 0
+}
+
+// exportUsers writes the matching users to path, or to stdout when path is empty
+func exportUsers(ctx context.Context, userService services.UserService, path string, usersConfig UsersConfig) error {
+	filter := &models.UserFilter{Search: usersConfig.Search}
+	if usersConfig.Active != "" {
+		active, err := strconv.ParseBool(usersConfig.Active)
+		if err != nil {
+			return fmt.Errorf("-active must be true or false")
+		}
+		filter.IsActive = &active
+	}
+
+	users, err := userService.ExportUsers(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if path != "" {
+		file, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if err := services.WriteUsers(out, fileFormat(usersConfig.Format, path), users); err != nil {
+		return err
+	}
+
+	if path != "" {
+		fmt.Printf("%s: %d users exported\n", path, len(users))
+	}
+	return nil
+}
+
+func fileFormat(format, path string) string {
+	if format != "" {
+		return strings.ToLower(format)
+	}
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".json" {
+		return services.UserFormatJSON
+	}
+	return services.UserFormatCSV
+}
+
+func printUsage() {
+	fmt.Println("User Import/Export CLI")
+	fmt.Println()
+	fmt.Println("Usage: go run cmd/users/main.go [flags] <command> <args...>")
+	fmt.Println()
+	fmt.Println("Flags:")
+	fmt.Println("  -format string    File format: csv or json (default: from the file extension, else csv)")
+	fmt.Println("  -dry-run          Validate an import without writing")
+	fmt.Println("  -search string    Export only users whose name, phone or email contains this text")
+	fmt.Println("  -active string    Export only active (true) or inactive (false) users")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  import FILE...    Create users, or update those with the same phone or email")
+	fmt.Println("  export [FILE]     Write users to FILE, or to stdout")
+	fmt.Println()
+	fmt.Println("CSV files need a header with name, phone and email columns; other columns are ignored.")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  go run cmd/users/main.go -dry-run import cohort.csv")
+	fmt.Println("  go run cmd/users/main.go import cohort.csv")
+	fmt.Println("  go run cmd/users/main.go -active true export users.json")
+}
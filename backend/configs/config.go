@@ -14,6 +14,12 @@ type Config struct {
 	N8N      N8NConfig
 	Flowise  FlowiseConfig
 	WhatsApp WhatsAppConfig
+	Webhook  WebhookConfig
+	Watchdog WatchdogConfig
+	OpenAI   OpenAIConfig
+	Rerank   RerankConfig
+	Auth     AuthConfig
+	Signal   SignalConfig
 }
 
 type ServerConfig struct {
@@ -31,6 +37,7 @@ type N8NConfig struct {
 	RetryAttempts   int
 	RetryDelay      time.Duration
 	APIKey          string
+	ResponseTimeout time.Duration
 }
 
 type FlowiseConfig struct {
@@ -38,6 +45,8 @@ type FlowiseConfig struct {
 	FlowID         string
 	APIKey         string
 	TimeoutSeconds int
+	RetryAttempts  int
+	RetryDelay     time.Duration
 }
 
 type WhatsAppConfig struct {
@@ -45,6 +54,89 @@ type WhatsAppConfig struct {
 	QRTimeout      time.Duration
 }
 
+// WebhookConfig holds the shared secrets used to verify inbound webhook signatures
+type WebhookConfig struct {
+	N8NSecret          string
+	FlowiseSecret      string
+	SignalSecret       string
+	SignatureTolerance time.Duration
+	// SignatureDisabled lets webhooks from sources without a secret through
+	// unverified; otherwise they are refused
+	SignatureDisabled bool
+}
+
+// AuthConfig holds the API credentials. APIKey is a bootstrap key with the admin
+// role; JWTSecret signs the tokens issued by /api/v1/auth/token, which is
+// disabled while it is empty.
+type AuthConfig struct {
+	APIKey    string
+	JWTSecret string
+	JWTExpiry time.Duration
+}
+
+// SignalConfig controls the background fan-out of signals to subscribers
+type SignalConfig struct {
+	Workers       int
+	RatePerSecond float64
+	Jitter        time.Duration
+	// OutcomeExpiryDays is how long a signal may take to reach its stop or target
+	OutcomeExpiryDays int
+	Validation        SignalValidationConfig
+}
+
+// SignalValidationConfig sets the rules incoming signals must pass; zero
+// minimums, an empty pattern and an empty ticker list disable those checks
+type SignalValidationConfig struct {
+	MaxAgeDays          int
+	TickerPattern       string
+	Tickers             []string
+	RiskRewardTolerance float64
+	MinRiskReward       float64
+	MinConfluenceScore  float64
+	MinBacktestWinRate  float64
+	MinTotalTrades      int
+	DisabledRules       []string
+}
+
+// WatchdogConfig controls the notices sent while users wait for a workflow response.
+// The hard deadline is N8NConfig.ResponseTimeout.
+type WatchdogConfig struct {
+	Interval       time.Duration
+	SoftTimeout    time.Duration
+	SoftMessage    string
+	TimeoutMessage string
+}
+
+// OpenAIConfig configures the OpenAI-compatible chat backend and its RAG retrieval.
+// Defaults follow the rag-knowledge-query entry in prompt-templates/parameter-tuning.json.
+type OpenAIConfig struct {
+	BaseURL            string
+	APIKey             string
+	ChatModel          string
+	TimeoutSeconds     int
+	RetryAttempts      int
+	RetryDelay         time.Duration
+	Temperature        float64
+	MaxTokens          int
+	TopP               float64
+	RetrievalTopK      int
+	RetrievalThreshold float64
+	HybridSearch       bool
+	RRFConstant        int
+	ReembedBatchSize   int
+	ReembedBatchDelay  time.Duration
+	PromptTemplatePath string
+}
+
+// RerankConfig configures the optional reranker applied to retrieval candidates.
+// Reranking is disabled when BaseURL is empty.
+type RerankConfig struct {
+	BaseURL        string
+	APIKey         string
+	Model          string
+	TimeoutSeconds int
+}
+
 // LoadConfig loads application configuration from environment variables
 func LoadConfig() *Config {
 	// Load .env file if it exists
@@ -63,22 +155,84 @@ func LoadConfig() *Config {
 		},
 		Database: *LoadDatabaseConfig(),
 		N8N: N8NConfig{
-			WebhookURL:     getEnvString("N8N_WEBHOOK_URL", ""),
-			TimeoutSeconds: getEnvInt("N8N_TIMEOUT_SECONDS", 30),
-			RetryAttempts:  getEnvInt("N8N_RETRY_ATTEMPTS", 3),
-			RetryDelay:     time.Duration(getEnvInt("N8N_RETRY_DELAY_SECONDS", 2)) * time.Second,
-			APIKey:         getEnvString("N8N_API_KEY", ""),
+			WebhookURL:      getEnvString("N8N_WEBHOOK_URL", ""),
+			TimeoutSeconds:  getEnvInt("N8N_TIMEOUT_SECONDS", 30),
+			RetryAttempts:   getEnvInt("N8N_RETRY_ATTEMPTS", 3),
+			RetryDelay:      time.Duration(getEnvInt("N8N_RETRY_DELAY_SECONDS", 2)) * time.Second,
+			APIKey:          getEnvString("N8N_API_KEY", ""),
+			ResponseTimeout: time.Duration(getEnvInt("N8N_RESPONSE_TIMEOUT_SECONDS", 300)) * time.Second,
 		},
 		Flowise: FlowiseConfig{
 			BaseURL:        getEnvString("FLOWISE_BASE_URL", ""),
 			FlowID:         getEnvString("FLOWISE_FLOW_ID", ""),
 			APIKey:         getEnvString("FLOWISE_API_KEY", ""),
 			TimeoutSeconds: getEnvInt("FLOWISE_TIMEOUT_SECONDS", 30),
+			RetryAttempts:  getEnvInt("FLOWISE_RETRY_ATTEMPTS", 3),
+			RetryDelay:     time.Duration(getEnvInt("FLOWISE_RETRY_DELAY_SECONDS", 2)) * time.Second,
 		},
 		WhatsApp: WhatsAppConfig{
 			SessionTimeout: time.Duration(getEnvInt("WHATSAPP_SESSION_TIMEOUT", 3600)) * time.Second,
 			QRTimeout:      time.Duration(getEnvInt("WHATSAPP_QR_TIMEOUT", 120)) * time.Second,
 		},
+		Webhook: WebhookConfig{
+			N8NSecret:          getEnvString("WEBHOOK_N8N_SECRET", ""),
+			FlowiseSecret:      getEnvString("WEBHOOK_FLOWISE_SECRET", ""),
+			SignalSecret:       getEnvString("WEBHOOK_SIGNAL_SECRET", ""),
+			SignatureTolerance: time.Duration(getEnvInt("WEBHOOK_SIGNATURE_TOLERANCE_SECONDS", 300)) * time.Second,
+			SignatureDisabled:  getEnvBool("WEBHOOK_SIGNATURE_DISABLED", false),
+		},
+		Watchdog: WatchdogConfig{
+			Interval:       time.Duration(getEnvInt("WATCHDOG_INTERVAL_SECONDS", 15)) * time.Second,
+			SoftTimeout:    time.Duration(getEnvInt("WATCHDOG_SOFT_TIMEOUT_SECONDS", 60)) * time.Second,
+			SoftMessage:    getEnvString("WATCHDOG_SOFT_MESSAGE", "Still working on your request, please wait a moment..."),
+			TimeoutMessage: getEnvString("WATCHDOG_TIMEOUT_MESSAGE", "Sorry, we couldn't get an answer in time. Please send your message again."),
+		},
+		OpenAI: OpenAIConfig{
+			BaseURL:            getEnvString("OPENAI_BASE_URL", "https://api.openai.com"),
+			APIKey:             getEnvString("OPENAI_API_KEY", ""),
+			ChatModel:          getEnvString("OPENAI_CHAT_MODEL", "gpt-4o-mini"),
+			TimeoutSeconds:     getEnvInt("OPENAI_TIMEOUT_SECONDS", 30),
+			RetryAttempts:      getEnvInt("OPENAI_RETRY_ATTEMPTS", 2),
+			RetryDelay:         time.Duration(getEnvInt("OPENAI_RETRY_DELAY_SECONDS", 1)) * time.Second,
+			Temperature:        getEnvFloat("OPENAI_TEMPERATURE", 0.2),
+			MaxTokens:          getEnvInt("OPENAI_MAX_TOKENS", 300),
+			TopP:               getEnvFloat("OPENAI_TOP_P", 0.85),
+			RetrievalTopK:      getEnvInt("RAG_TOP_K", 3),
+			RetrievalThreshold: getEnvFloat("RAG_SIMILARITY_THRESHOLD", 0.7),
+			HybridSearch:       getEnvBool("RAG_HYBRID_SEARCH", true),
+			RRFConstant:        getEnvInt("RAG_RRF_K", 60),
+			ReembedBatchSize:   getEnvInt("EMBEDDING_JOB_BATCH_SIZE", 32),
+			ReembedBatchDelay:  time.Duration(getEnvInt("EMBEDDING_JOB_BATCH_DELAY_MS", 0)) * time.Millisecond,
+			PromptTemplatePath: getEnvString("RAG_PROMPT_TEMPLATE", "prompt-templates/rag-knowledge-query.txt"),
+		},
+		Rerank: RerankConfig{
+			BaseURL:        getEnvString("RERANK_BASE_URL", ""),
+			APIKey:         getEnvString("RERANK_API_KEY", ""),
+			Model:          getEnvString("RERANK_MODEL", ""),
+			TimeoutSeconds: getEnvInt("RERANK_TIMEOUT_SECONDS", 10),
+		},
+		Auth: AuthConfig{
+			APIKey:    getEnvString("ADMIN_API_KEY", ""),
+			JWTSecret: getEnvString("JWT_SECRET", ""),
+			JWTExpiry: parseDuration(getEnvString("JWT_EXPIRY", "24h"), 24*time.Hour),
+		},
+		Signal: SignalConfig{
+			Workers:           getEnvInt("SIGNAL_WORKERS", 4),
+			RatePerSecond:     getEnvFloat("SIGNAL_RATE_PER_SECOND", 2),
+			Jitter:            time.Duration(getEnvInt("SIGNAL_JITTER_MS", 500)) * time.Millisecond,
+			OutcomeExpiryDays: getEnvInt("SIGNAL_OUTCOME_EXPIRY_DAYS", 10),
+			Validation: SignalValidationConfig{
+				MaxAgeDays:          getEnvInt("SIGNAL_MAX_AGE_DAYS", 5),
+				TickerPattern:       getEnvString("SIGNAL_TICKER_PATTERN", "^[A-Z]{4}$"),
+				Tickers:             getEnvList("SIGNAL_TICKERS"),
+				RiskRewardTolerance: getEnvFloat("SIGNAL_RISK_REWARD_TOLERANCE", 0.05),
+				MinRiskReward:       getEnvFloat("SIGNAL_MIN_RISK_REWARD", 0),
+				MinConfluenceScore:  getEnvFloat("SIGNAL_MIN_CONFLUENCE_SCORE", 0),
+				MinBacktestWinRate:  getEnvFloat("SIGNAL_MIN_BACKTEST_WIN_RATE", 0),
+				MinTotalTrades:      getEnvInt("SIGNAL_MIN_TOTAL_TRADES", 0),
+				DisabledRules:       getEnvList("SIGNAL_DISABLED_RULES"),
+			},
+		},
 	}
 
 	return config
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AdminAccessHandler manages the roles and API keys of users
+type AdminAccessHandler interface {
+	ListRoles(c *gin.Context)
+	GetUserRoles(c *gin.Context)
+	SetUserRoles(c *gin.Context)
+	ListAPIKeys(c *gin.Context)
+	CreateAPIKey(c *gin.Context)
+	RevokeAPIKey(c *gin.Context)
+}
+
+type adminAccessHandler struct {
+	roleService services.RoleService
+	authService services.AuthService
+}
+
+func NewAdminAccessHandler(roleService services.RoleService, authService services.AuthService) AdminAccessHandler {
+	return &adminAccessHandler{
+		roleService: roleService,
+		authService: authService,
+	}
+}
+
+func (h *adminAccessHandler) ListRoles(c *gin.Context) {
+	roles, err := h.roleService.ListRoles(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{Success: false, Error: "Failed to list roles"})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Roles",
+		Data:    roles,
+	})
+}
+
+func (h *adminAccessHandler) GetUserRoles(c *gin.Context) {
+	id, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	roles, err := h.roleService.GetUserRoles(c.Request.Context(), id)
+	if err != nil {
+		respondAccessError(c, err, "Failed to get user roles")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "User roles",
+		Data:    gin.H{"roles": roles},
+	})
+}
+
+func (h *adminAccessHandler) SetUserRoles(c *gin.Context) {
+	id, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	var request models.SetUserRolesRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	roles, err := h.roleService.SetUserRoles(c.Request.Context(), id, request.Roles)
+	if err != nil {
+		respondAccessError(c, err, "Failed to set user roles")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "User roles updated",
+		Data:    gin.H{"roles": roles},
+	})
+}
+
+func (h *adminAccessHandler) ListAPIKeys(c *gin.Context) {
+	id, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	keys, err := h.authService.ListAPIKeys(c.Request.Context(), id)
+	if err != nil {
+		respondAccessError(c, err, "Failed to list API keys")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "API keys",
+		Data:    keys,
+	})
+}
+
+// CreateAPIKey issues a key for the user; the key itself is only returned here
+func (h *adminAccessHandler) CreateAPIKey(c *gin.Context) {
+	id, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	var request models.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	key, err := h.authService.CreateAPIKey(c.Request.Context(), id, request.Name)
+	if err != nil {
+		respondAccessError(c, err, "Failed to create API key")
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "API key created; store it now, it cannot be shown again",
+		Data:    key,
+	})
+}
+
+func (h *adminAccessHandler) RevokeAPIKey(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Error: "Invalid API key ID"})
+		return
+	}
+
+	if err := h.authService.RevokeAPIKey(c.Request.Context(), id); err != nil {
+		respondAccessError(c, err, "Failed to revoke API key")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "API key revoked",
+	})
+}
+
+// respondAccessError maps role and API key errors to 400/404, and anything else to 500
+func respondAccessError(c *gin.Context, err error, failure string) {
+	switch {
+	case errors.Is(err, services.ErrUnknownRole):
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Error: err.Error()})
+	case errors.Is(err, services.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, models.APIResponse{Success: false, Error: "User not found"})
+	case errors.Is(err, services.ErrAPIKeyNotFound):
+		c.JSON(http.StatusNotFound, models.APIResponse{Success: false, Error: "API key not found"})
+	default:
+		log.Printf("[AdminAccessHandler] %s: %v", failure, err)
+		c.JSON(http.StatusInternalServerError, models.APIResponse{Success: false, Error: failure})
+	}
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AdminSubscriptionHandler interface {
+	ListSubscriptions(c *gin.Context)
+	Subscribe(c *gin.Context)
+	Unsubscribe(c *gin.Context)
+	DeleteSubscription(c *gin.Context)
+}
+
+type adminSubscriptionHandler struct {
+	subscriptionService services.SubscriptionService
+}
+
+func NewAdminSubscriptionHandler(subscriptionService services.SubscriptionService) AdminSubscriptionHandler {
+	return &adminSubscriptionHandler{
+		subscriptionService: subscriptionService,
+	}
+}
+
+func (h *adminSubscriptionHandler) ListSubscriptions(c *gin.Context) {
+	id, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	subscriptions, err := h.subscriptionService.ListSubscriptions(c.Request.Context(), id)
+	if err != nil {
+		respondSubscriptionError(c, err, "Failed to list subscriptions")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Subscriptions",
+		Data:    subscriptions,
+	})
+}
+
+// Subscribe creates the user's subscription for a ticker, or replaces its filters
+func (h *adminSubscriptionHandler) Subscribe(c *gin.Context) {
+	id, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	var request models.SubscribeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	subscription, err := h.subscriptionService.Subscribe(c.Request.Context(), id, &request)
+	if err != nil {
+		respondSubscriptionError(c, err, "Failed to subscribe user")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Subscription saved",
+		Data:    subscription,
+	})
+}
+
+// Unsubscribe removes the user's subscription for ?ticker=, or all of them for ?ticker=all
+func (h *adminSubscriptionHandler) Unsubscribe(c *gin.Context) {
+	id, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	removed, err := h.subscriptionService.Unsubscribe(c.Request.Context(), id, c.Query("ticker"))
+	if err != nil {
+		respondSubscriptionError(c, err, "Failed to unsubscribe user")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: fmt.Sprintf("%d subscriptions removed", removed),
+		Data:    gin.H{"removed": removed},
+	})
+}
+
+func (h *adminSubscriptionHandler) DeleteSubscription(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Error: "Invalid subscription ID"})
+		return
+	}
+
+	if err := h.subscriptionService.DeleteSubscription(c.Request.Context(), id); err != nil {
+		respondSubscriptionError(c, err, "Failed to delete subscription")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Subscription deleted",
+	})
+}
+
+// respondSubscriptionError maps subscription errors to 400/404, and anything else to 500
+func respondSubscriptionError(c *gin.Context, err error, failure string) {
+	switch {
+	case errors.Is(err, services.ErrInvalidTicker), errors.Is(err, services.ErrTickerRequired):
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Error: err.Error()})
+	case errors.Is(err, services.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, models.APIResponse{Success: false, Error: "User not found"})
+	case errors.Is(err, services.ErrSubscriptionNotFound):
+		c.JSON(http.StatusNotFound, models.APIResponse{Success: false, Error: "Subscription not found"})
+	default:
+		log.Printf("[AdminSubscriptionHandler] %s: %v", failure, err)
+		c.JSON(http.StatusInternalServerError, models.APIResponse{Success: false, Error: failure})
+	}
+}
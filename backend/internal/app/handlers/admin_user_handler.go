@@ -0,0 +1,352 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AdminUserHandler interface {
+	ListUsers(c *gin.Context)
+	GetUser(c *gin.Context)
+	CreateUser(c *gin.Context)
+	UpdateUser(c *gin.Context)
+	DeleteUser(c *gin.Context)
+	ActivateUser(c *gin.Context)
+	DeactivateUser(c *gin.Context)
+	ImportUsers(c *gin.Context)
+	ExportUsers(c *gin.Context)
+}
+
+// maxUserImportSize bounds the uploaded import file
+const maxUserImportSize = 5 << 20
+
+type adminUserHandler struct {
+	userService services.UserService
+}
+
+func NewAdminUserHandler(userService services.UserService) AdminUserHandler {
+	return &adminUserHandler{
+		userService: userService,
+	}
+}
+
+// ListUsers returns a page of users, optionally filtered by ?search= (name,
+// phone or email) and ?is_active=true|false
+func (h *adminUserHandler) ListUsers(c *gin.Context) {
+	filter, ok := parseUserFilter(c)
+	if !ok {
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	result, err := h.userService.ListUsers(c.Request.Context(), filter, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to list users",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Users",
+		Data:    result,
+	})
+}
+
+func (h *adminUserHandler) GetUser(c *gin.Context) {
+	id, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.userService.GetUserByID(c.Request.Context(), id)
+	if err != nil {
+		respondUserError(c, err, "Failed to get user")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "User",
+		Data:    user,
+	})
+}
+
+func (h *adminUserHandler) CreateUser(c *gin.Context) {
+	var request models.CreateUserRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	user, err := h.userService.CreateUser(c.Request.Context(), &request)
+	if err != nil {
+		respondUserError(c, err, "Failed to create user")
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "User created",
+		Data:    user,
+	})
+}
+
+func (h *adminUserHandler) UpdateUser(c *gin.Context) {
+	id, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	var request models.UpdateUserRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	user, err := h.userService.UpdateUser(c.Request.Context(), id, &request)
+	if err != nil {
+		respondUserError(c, err, "Failed to update user")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "User updated",
+		Data:    user,
+	})
+}
+
+func (h *adminUserHandler) DeleteUser(c *gin.Context) {
+	id, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.userService.DeleteUser(c.Request.Context(), id); err != nil {
+		respondUserError(c, err, "Failed to delete user")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "User deleted",
+	})
+}
+
+func (h *adminUserHandler) ActivateUser(c *gin.Context) {
+	h.setActive(c, true)
+}
+
+func (h *adminUserHandler) DeactivateUser(c *gin.Context) {
+	h.setActive(c, false)
+}
+
+func (h *adminUserHandler) setActive(c *gin.Context, active bool) {
+	id, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.userService.SetUserActive(c.Request.Context(), id, active)
+	if err != nil {
+		respondUserError(c, err, "Failed to update user")
+		return
+	}
+
+	message := "User deactivated"
+	if active {
+		message = "User activated"
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: message,
+		Data:    user,
+	})
+}
+
+// ImportUsers upserts users from a CSV or JSON file, sent either as the request
+// body or as the "file" field of a multipart form. The format comes from
+// ?format=, the file extension or the content type. ?dry_run=true only validates.
+func (h *adminUserHandler) ImportUsers(c *gin.Context) {
+	dryRun, _ := strconv.ParseBool(c.Query("dry_run"))
+
+	body, format, err := readUserImport(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid import file: " + err.Error(),
+		})
+		return
+	}
+
+	rows, err := services.ParseUsers(bytes.NewReader(body), format)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid import file: " + err.Error(),
+		})
+		return
+	}
+
+	report := h.userService.ImportUsers(c.Request.Context(), rows, dryRun)
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: fmt.Sprintf("Imported %d of %d users (%d failed)", report.Created+report.Updated, report.Total, report.Failed),
+		Data:    report,
+	})
+}
+
+// ExportUsers downloads the users matching the list filters as CSV (default) or JSON
+func (h *adminUserHandler) ExportUsers(c *gin.Context) {
+	filter, ok := parseUserFilter(c)
+	if !ok {
+		return
+	}
+
+	format := c.DefaultQuery("format", services.UserFormatCSV)
+	if format != services.UserFormatCSV && format != services.UserFormatJSON {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   services.ErrUnsupportedUserFormat.Error(),
+		})
+		return
+	}
+
+	users, err := h.userService.ExportUsers(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to export users",
+		})
+		return
+	}
+
+	var buffer bytes.Buffer
+	if err := services.WriteUsers(&buffer, format, users); err != nil {
+		log.Printf("[AdminUserHandler] Failed to write user export: %v", err)
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to export users",
+		})
+		return
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if format == services.UserFormatJSON {
+		contentType = "application/json; charset=utf-8"
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="users.%s"`, format))
+	c.Data(http.StatusOK, contentType, buffer.Bytes())
+}
+
+// readUserImport returns the import file and its format
+func readUserImport(c *gin.Context) ([]byte, string, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxUserImportSize)
+
+	format := strings.ToLower(c.Query("format"))
+	var reader io.Reader = c.Request.Body
+
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		header, err := c.FormFile("file")
+		if err != nil {
+			return nil, "", fmt.Errorf("missing file field: %w", err)
+		}
+		file, err := header.Open()
+		if err != nil {
+			return nil, "", err
+		}
+		defer file.Close()
+
+		reader = file
+		if format == "" {
+			format = strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), ".")
+		}
+	}
+
+	if format == "" {
+		switch c.ContentType() {
+		case "text/csv", "application/csv":
+			format = services.UserFormatCSV
+		case "application/json":
+			format = services.UserFormatJSON
+		}
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, "", err
+	}
+	return body, format, nil
+}
+
+// parseUserFilter reads the ?search= and ?is_active= list filters
+func parseUserFilter(c *gin.Context) (*models.UserFilter, bool) {
+	filter := &models.UserFilter{Search: c.Query("search")}
+
+	if value := c.Query("is_active"); value != "" {
+		isActive, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   "is_active must be true or false",
+			})
+			return nil, false
+		}
+		filter.IsActive = &isActive
+	}
+
+	return filter, true
+}
+
+func parseUserID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid user ID",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// respondUserError maps user service errors to 400/404/409, and anything else to 500
+func respondUserError(c *gin.Context, err error, failure string) {
+	switch {
+	case errors.Is(err, services.ErrInvalidPhone):
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Error: "Invalid phone number"})
+	case errors.Is(err, services.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, models.APIResponse{Success: false, Error: "User not found"})
+	case errors.Is(err, services.ErrDuplicateUser):
+		c.JSON(http.StatusConflict, models.APIResponse{Success: false, Error: "A user with this phone or email already exists"})
+	default:
+		log.Printf("[AdminUserHandler] %s: %v", failure, err)
+		c.JSON(http.StatusInternalServerError, models.APIResponse{Success: false, Error: failure})
+	}
+}
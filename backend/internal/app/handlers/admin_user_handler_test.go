@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/services"
+	"github.com/fajarAnd/workshop-brin/wa-service/testutils/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestAdminUserHandler_StatusCodes
+// Summary: Test admin user endpoint status codes
+// Purpose: Validate that missing users return 404, duplicates 409 and bad input or phones 400
+func TestAdminUserHandler_StatusCodes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	id := uuid.New()
+	user := &models.User{ID: id, Name: "Budi Santoso", Phone: "6281234567890", Email: "budi@example.com", IsActive: true}
+	createBody := `{"name":"Budi Santoso","phone":"6281234567890","email":"budi@example.com"}`
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		setup          func(m *mocks.MockUserService)
+		expectedStatus int
+	}{
+		{
+			name:   "Get existing user",
+			method: http.MethodGet,
+			path:   "/admin/users/" + id.String(),
+			setup: func(m *mocks.MockUserService) {
+				m.EXPECT().GetUserByID(mock.Anything, id).Return(user, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "Get missing user",
+			method: http.MethodGet,
+			path:   "/admin/users/" + id.String(),
+			setup: func(m *mocks.MockUserService) {
+				m.EXPECT().GetUserByID(mock.Anything, id).Return(nil, services.ErrUserNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Invalid user ID",
+			method:         http.MethodGet,
+			path:           "/admin/users/not-a-uuid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Create user",
+			method: http.MethodPost,
+			path:   "/admin/users",
+			body:   createBody,
+			setup: func(m *mocks.MockUserService) {
+				m.EXPECT().CreateUser(mock.Anything, mock.Anything).Return(user, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:   "Create duplicate user",
+			method: http.MethodPost,
+			path:   "/admin/users",
+			body:   createBody,
+			setup: func(m *mocks.MockUserService) {
+				m.EXPECT().CreateUser(mock.Anything, mock.Anything).Return(nil, services.ErrDuplicateUser)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:   "Create with invalid phone",
+			method: http.MethodPost,
+			path:   "/admin/users",
+			body:   `{"name":"Budi Santoso","phone":"0812-CALL-NOW","email":"budi@example.com"}`,
+			setup: func(m *mocks.MockUserService) {
+				m.EXPECT().CreateUser(mock.Anything, mock.Anything).Return(nil, services.ErrInvalidPhone)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Create with invalid email",
+			method:         http.MethodPost,
+			path:           "/admin/users",
+			body:           `{"name":"Budi Santoso","phone":"6281234567890","email":"not-an-email"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Delete missing user",
+			method: http.MethodDelete,
+			path:   "/admin/users/" + id.String(),
+			setup: func(m *mocks.MockUserService) {
+				m.EXPECT().DeleteUser(mock.Anything, id).Return(services.ErrUserNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "Deactivate user",
+			method: http.MethodPost,
+			path:   "/admin/users/" + id.String() + "/deactivate",
+			setup: func(m *mocks.MockUserService) {
+				m.EXPECT().SetUserActive(mock.Anything, id, false).Return(user, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "List with search and is_active filter",
+			method: http.MethodGet,
+			path:   "/admin/users?search=budi&is_active=false&page=2",
+			setup: func(m *mocks.MockUserService) {
+				inactive := false
+				m.EXPECT().ListUsers(mock.Anything, &models.UserFilter{Search: "budi", IsActive: &inactive}, 2, 20).
+					Return(&models.UserPage{Users: []*models.User{}, Page: 2, PageSize: 20}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "List with invalid is_active",
+			method:         http.MethodGet,
+			path:           "/admin/users?is_active=maybe",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Unexpected error",
+			method: http.MethodGet,
+			path:   "/admin/users/" + id.String(),
+			setup: func(m *mocks.MockUserService) {
+				m.EXPECT().GetUserByID(mock.Anything, id).Return(nil, fmt.Errorf("connection refused"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserService := mocks.NewMockUserService(t)
+			if tt.setup != nil {
+				tt.setup(mockUserService)
+			}
+
+			handler := NewAdminUserHandler(mockUserService)
+			router := gin.New()
+			router.GET("/admin/users", handler.ListUsers)
+			router.POST("/admin/users", handler.CreateUser)
+			router.GET("/admin/users/:id", handler.GetUser)
+			router.DELETE("/admin/users/:id", handler.DeleteUser)
+			router.POST("/admin/users/:id/deactivate", handler.DeactivateUser)
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler interface {
+	ListEntries(c *gin.Context)
+}
+
+type auditHandler struct {
+	auditService services.AuditService
+}
+
+func NewAuditHandler(auditService services.AuditService) AuditHandler {
+	return &auditHandler{
+		auditService: auditService,
+	}
+}
+
+// ListEntries returns the most recent audit entries, optionally filtered by ?action=
+func (h *auditHandler) ListEntries(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+
+	entries, err := h.auditService.List(c.Request.Context(), c.Query("action"), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{Success: false, Error: "Failed to list audit entries"})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Audit entries",
+		Data:    entries,
+	})
+}
+
+// newAuditEntry attributes action to the authenticated caller of the request
+func newAuditEntry(c *gin.Context, action string, details map[string]string) *models.AuditEntry {
+	entry := &models.AuditEntry{
+		Action:     action,
+		Actor:      "anonymous",
+		RemoteAddr: c.ClientIP(),
+		Details:    details,
+	}
+
+	if principal := CurrentPrincipal(c); principal != nil {
+		entry.Actor = principal.Name
+		entry.ActorUserID = principal.UserID
+		entry.Details["auth_method"] = principal.Method
+	}
+	return entry
+}
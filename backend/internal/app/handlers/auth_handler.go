@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PrincipalKey is the gin context key under which the auth middleware stores
+// the authenticated *models.Principal
+const PrincipalKey = "principal"
+
+type AuthHandler interface {
+	IssueToken(c *gin.Context)
+	Me(c *gin.Context)
+}
+
+type authHandler struct {
+	authService services.AuthService
+}
+
+func NewAuthHandler(authService services.AuthService) AuthHandler {
+	return &authHandler{
+		authService: authService,
+	}
+}
+
+// IssueToken exchanges the API key the request was authenticated with for a JWT
+func (h *authHandler) IssueToken(c *gin.Context) {
+	principal := CurrentPrincipal(c)
+	if principal == nil {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{Success: false, Error: "Authentication required"})
+		return
+	}
+
+	token, err := h.authService.IssueToken(c.Request.Context(), principal)
+	if err != nil {
+		if errors.Is(err, services.ErrTokensDisabled) {
+			c.JSON(http.StatusServiceUnavailable, models.APIResponse{Success: false, Error: "Token authentication is not configured"})
+			return
+		}
+		log.Printf("[AuthHandler] Failed to issue token: %v", err)
+		c.JSON(http.StatusInternalServerError, models.APIResponse{Success: false, Error: "Failed to issue token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Token issued",
+		Data:    token,
+	})
+}
+
+// Me returns the authenticated principal with its roles and permissions
+func (h *authHandler) Me(c *gin.Context) {
+	principal := CurrentPrincipal(c)
+	if principal == nil {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{Success: false, Error: "Authentication required"})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Principal",
+		Data:    principal,
+	})
+}
+
+// CurrentPrincipal returns the authenticated caller, or nil outside authenticated routes
+func CurrentPrincipal(c *gin.Context) *models.Principal {
+	if value, exists := c.Get(PrincipalKey); exists {
+		if principal, ok := value.(*models.Principal); ok {
+			return principal
+		}
+	}
+	return nil
+}
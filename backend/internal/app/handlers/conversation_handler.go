@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/services"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/pkg/phonenumber"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ConversationHandler interface {
+	GetMessages(c *gin.Context)
+	ListTakeovers(c *gin.Context)
+	StartTakeover(c *gin.Context)
+	ReleaseTakeover(c *gin.Context)
+	SendMessage(c *gin.Context)
+}
+
+type conversationHandler struct {
+	messageService  services.MessageService
+	takeoverService services.TakeoverService
+	whatsappService services.WhatsAppService
+	auditService    services.AuditService
+}
+
+func NewConversationHandler(messageService services.MessageService, takeoverService services.TakeoverService, whatsappService services.WhatsAppService, auditService services.AuditService) ConversationHandler {
+	return &conversationHandler{
+		messageService:  messageService,
+		takeoverService: takeoverService,
+		whatsappService: whatsappService,
+		auditService:    auditService,
+	}
+}
+
+func (h *conversationHandler) GetMessages(c *gin.Context) {
+	phone := c.Param("phone")
+	if normalized, err := phonenumber.Normalize(phone); err == nil {
+		phone = normalized
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "50"))
+
+	result, err := h.messageService.GetConversation(c.Request.Context(), phone, page, pageSize)
+	if err != nil {
+		log.Printf("[ConversationHandler] Failed to get messages for %s: %v", phone, err)
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to get conversation messages",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Conversation messages",
+		Data:    result,
+	})
+}
+
+func (h *conversationHandler) ListTakeovers(c *gin.Context) {
+	takeovers, err := h.takeoverService.List(c.Request.Context())
+	if err != nil {
+		respondTakeoverError(c, err, "Failed to list takeovers")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Conversations taken over by agents",
+		Data:    takeovers,
+	})
+}
+
+// StartTakeover stops routing the user's messages to the workflow so the
+// calling agent can answer them
+func (h *conversationHandler) StartTakeover(c *gin.Context) {
+	takeover, err := h.takeoverService.Start(c.Request.Context(), c.Param("phone"), CurrentPrincipal(c))
+	if err != nil {
+		respondTakeoverError(c, err, "Failed to take over conversation")
+		return
+	}
+
+	h.auditService.Record(c.Request.Context(), newAuditEntry(c, models.AuditActionConversationTakeover, map[string]string{
+		"phone": takeover.Phone,
+	}))
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Conversation taken over",
+		Data:    takeover,
+	})
+}
+
+// ReleaseTakeover hands the conversation back to the workflow. Any agent may
+// release it, so a conversation is not stuck when its agent is away.
+func (h *conversationHandler) ReleaseTakeover(c *gin.Context) {
+	phone := c.Param("phone")
+	if normalized, err := phonenumber.Normalize(phone); err == nil {
+		phone = normalized
+	}
+
+	if err := h.takeoverService.Release(c.Request.Context(), phone); err != nil {
+		respondTakeoverError(c, err, "Failed to release conversation")
+		return
+	}
+
+	h.auditService.Record(c.Request.Context(), newAuditEntry(c, models.AuditActionConversationReleased, map[string]string{
+		"phone": phone,
+	}))
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Conversation handed back to the workflow",
+	})
+}
+
+// SendMessage sends a message from the agent holding the takeover
+func (h *conversationHandler) SendMessage(c *gin.Context) {
+	var request models.AgentMessageRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	takeover, err := h.takeoverService.CheckAgent(ctx, c.Param("phone"), CurrentPrincipal(c))
+	if err != nil {
+		respondTakeoverError(c, err, "Failed to send message")
+		return
+	}
+
+	ctx = services.WithMessageMeta(ctx, services.MessageMeta{WorkflowType: services.TakeoverWorkflowType})
+	whatsAppMessageID, err := h.whatsappService.SendMessageWithID(ctx, takeover.Phone, request.Message)
+	if err != nil {
+		log.Printf("[ConversationHandler] Failed to send agent message to %s: %v", takeover.Phone, err)
+		c.JSON(http.StatusBadGateway, models.APIResponse{
+			Success: false,
+			Error:   "Failed to send message: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Message sent",
+		Data:    gin.H{"whatsapp_message_id": whatsAppMessageID},
+	})
+}
+
+func respondTakeoverError(c *gin.Context, err error, failure string) {
+	switch {
+	case errors.Is(err, services.ErrInvalidPhone):
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Error: "Invalid phone number"})
+	case errors.Is(err, services.ErrConversationTakenOver), errors.Is(err, services.ErrNotTakenOver):
+		c.JSON(http.StatusConflict, models.APIResponse{Success: false, Error: err.Error()})
+	default:
+		log.Printf("[ConversationHandler] %s: %v", failure, err)
+		c.JSON(http.StatusInternalServerError, models.APIResponse{Success: false, Error: failure})
+	}
+}
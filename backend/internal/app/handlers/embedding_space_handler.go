@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type EmbeddingSpaceHandler interface {
+	ListSpaces(c *gin.Context)
+	CreateSpace(c *gin.Context)
+	ActivateSpace(c *gin.Context)
+	Reembed(c *gin.Context)
+	ListJobs(c *gin.Context)
+	GetJob(c *gin.Context)
+}
+
+type embeddingSpaceHandler struct {
+	spaceService       services.EmbeddingSpaceService
+	reembeddingService services.ReembeddingService
+}
+
+func NewEmbeddingSpaceHandler(spaceService services.EmbeddingSpaceService, reembeddingService services.ReembeddingService) EmbeddingSpaceHandler {
+	return &embeddingSpaceHandler{
+		spaceService:       spaceService,
+		reembeddingService: reembeddingService,
+	}
+}
+
+// embeddingJobResponse adds the completed fraction to a job
+type embeddingJobResponse struct {
+	*models.EmbeddingJob
+	Progress float64 `json:"progress"`
+}
+
+func newEmbeddingJobResponse(job *models.EmbeddingJob) embeddingJobResponse {
+	return embeddingJobResponse{EmbeddingJob: job, Progress: job.Progress()}
+}
+
+func (h *embeddingSpaceHandler) ListSpaces(c *gin.Context) {
+	spaces, err := h.spaceService.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to list embedding spaces",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Embedding spaces",
+		Data:    spaces,
+	})
+}
+
+func (h *embeddingSpaceHandler) CreateSpace(c *gin.Context) {
+	var request models.CreateEmbeddingSpaceRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	space, err := h.spaceService.Create(c.Request.Context(), &request)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidEmbeddingSpace):
+			c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Error: err.Error()})
+		case errors.Is(err, services.ErrEmbeddingSpaceExists):
+			c.JSON(http.StatusConflict, models.APIResponse{Success: false, Error: "Embedding space already exists"})
+		default:
+			log.Printf("[EmbeddingSpaceHandler] Failed to create embedding space %s: %v", request.Name, err)
+			c.JSON(http.StatusInternalServerError, models.APIResponse{Success: false, Error: "Failed to create embedding space"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Embedding space created",
+		Data:    space,
+	})
+}
+
+func (h *embeddingSpaceHandler) ActivateSpace(c *gin.Context) {
+	name := c.Param("name")
+
+	space, err := h.spaceService.Activate(c.Request.Context(), name)
+	if err != nil {
+		if errors.Is(err, services.ErrEmbeddingSpaceNotFound) {
+			c.JSON(http.StatusNotFound, models.APIResponse{Success: false, Error: "Embedding space not found"})
+			return
+		}
+
+		log.Printf("[EmbeddingSpaceHandler] Failed to activate embedding space %s: %v", name, err)
+		c.JSON(http.StatusInternalServerError, models.APIResponse{Success: false, Error: "Failed to activate embedding space"})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Embedding space activated",
+		Data:    space,
+	})
+}
+
+// Reembed starts a background job embedding the knowledge base into the space
+func (h *embeddingSpaceHandler) Reembed(c *gin.Context) {
+	name := c.Param("name")
+
+	var request models.ReembedRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   "Invalid request format: " + err.Error(),
+			})
+			return
+		}
+	}
+
+	job, err := h.reembeddingService.Start(c.Request.Context(), name, &request)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrEmbeddingSpaceNotFound):
+			c.JSON(http.StatusNotFound, models.APIResponse{Success: false, Error: "Embedding space not found"})
+		case errors.Is(err, services.ErrSameEmbeddingSpace):
+			c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Error: err.Error()})
+		case errors.Is(err, services.ErrEmbeddingJobRunning):
+			c.JSON(http.StatusConflict, models.APIResponse{Success: false, Error: err.Error()})
+		default:
+			log.Printf("[EmbeddingSpaceHandler] Failed to start re-embedding into %s: %v", name, err)
+			c.JSON(http.StatusInternalServerError, models.APIResponse{Success: false, Error: "Failed to start re-embedding job"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusAccepted, models.APIResponse{
+		Success: true,
+		Message: "Re-embedding job started",
+		Data:    newEmbeddingJobResponse(job),
+	})
+}
+
+func (h *embeddingSpaceHandler) ListJobs(c *gin.Context) {
+	jobs, err := h.reembeddingService.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to list embedding jobs",
+		})
+		return
+	}
+
+	response := make([]embeddingJobResponse, len(jobs))
+	for i, job := range jobs {
+		response[i] = newEmbeddingJobResponse(job)
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Embedding jobs",
+		Data:    response,
+	})
+}
+
+func (h *embeddingSpaceHandler) GetJob(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Error: "Invalid job ID"})
+		return
+	}
+
+	job, err := h.reembeddingService.Get(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, services.ErrEmbeddingJobNotFound) {
+			c.JSON(http.StatusNotFound, models.APIResponse{Success: false, Error: "Embedding job not found"})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, models.APIResponse{Success: false, Error: "Failed to get embedding job"})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Embedding job",
+		Data:    newEmbeddingJobResponse(job),
+	})
+}
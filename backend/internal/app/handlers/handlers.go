@@ -7,17 +7,39 @@ import (
 )
 
 type Handlers struct {
-	Health   HealthHandler
-	Webhook  WebhookHandler
-	QR       QRHandler
-	WhatsApp WhatsAppHandler
+	Health       HealthHandler
+	Webhook      WebhookHandler
+	QR           QRHandler
+	WhatsApp     WhatsAppHandler
+	Conversation ConversationHandler
+	Knowledge    KnowledgeHandler
+	Embedding    EmbeddingSpaceHandler
+	AdminUser    AdminUserHandler
+	AdminAccess  AdminAccessHandler
+	Subscription AdminSubscriptionHandler
+	Auth         AuthHandler
+	Operator     OperatorHandler
+	Audit        AuditHandler
+	Signal       SignalHandler
+	Routing      RoutingHandler
 }
 
-func NewHandlers(db *pgxpool.Pool, userService services.UserService, n8nService services.N8NService, whatsappService services.WhatsAppService, signalService services.SignalService) *Handlers {
+func NewHandlers(db *pgxpool.Pool, userService services.UserService, n8nService services.N8NService, flowiseService services.FlowiseService, whatsappService services.WhatsAppService, signalService services.SignalService, messageService services.MessageService, knowledgeService services.KnowledgeService, embeddingSpaceService services.EmbeddingSpaceService, reembeddingService services.ReembeddingService, roleService services.RoleService, authService services.AuthService, auditService services.AuditService, subscriptionService services.SubscriptionService, outcomeService services.SignalOutcomeService, templateService services.SignalTemplateService, workflowConfigService services.WorkflowConfigService, accessPolicyService services.AccessPolicyService, takeoverService services.TakeoverService) *Handlers {
 	return &Handlers{
-		Health:   NewHealthHandler(db),
-		Webhook:  NewWebhookHandler(n8nService, signalService),
-		QR:       NewQRHandler(whatsappService),
-		WhatsApp: NewWhatsAppHandler(whatsappService),
+		Health:       NewHealthHandler(db),
+		Webhook:      NewWebhookHandler(n8nService, flowiseService, signalService, outcomeService),
+		QR:           NewQRHandler(whatsappService),
+		WhatsApp:     NewWhatsAppHandler(whatsappService, auditService),
+		Conversation: NewConversationHandler(messageService, takeoverService, whatsappService, auditService),
+		Knowledge:    NewKnowledgeHandler(knowledgeService),
+		Embedding:    NewEmbeddingSpaceHandler(embeddingSpaceService, reembeddingService),
+		AdminUser:    NewAdminUserHandler(userService),
+		AdminAccess:  NewAdminAccessHandler(roleService, authService),
+		Subscription: NewAdminSubscriptionHandler(subscriptionService),
+		Auth:         NewAuthHandler(authService),
+		Operator:     NewOperatorHandler(authService),
+		Audit:        NewAuditHandler(auditService),
+		Signal:       NewSignalHandler(signalService, outcomeService, templateService),
+		Routing:      NewRoutingHandler(workflowConfigService, accessPolicyService, auditService),
 	}
 }
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type KnowledgeHandler interface {
+	Search(c *gin.Context)
+	ListDocuments(c *gin.Context)
+	GetDocument(c *gin.Context)
+	DeleteDocument(c *gin.Context)
+}
+
+type knowledgeHandler struct {
+	knowledgeService services.KnowledgeService
+}
+
+func NewKnowledgeHandler(knowledgeService services.KnowledgeService) KnowledgeHandler {
+	return &knowledgeHandler{
+		knowledgeService: knowledgeService,
+	}
+}
+
+func (h *knowledgeHandler) Search(c *gin.Context) {
+	var request models.KnowledgeSearchRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	result, err := h.knowledgeService.Search(c.Request.Context(), &request)
+	if err != nil {
+		if errors.Is(err, services.ErrEmptyQuery) || errors.Is(err, services.ErrInvalidThreshold) ||
+			errors.Is(err, services.ErrInvalidSearchMode) || errors.Is(err, services.ErrEmbeddingSpaceNotFound) {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		log.Printf("[KnowledgeHandler] Failed to search knowledge base: %v", err)
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to search knowledge base",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Knowledge search results",
+		Data:    result,
+	})
+}
+
+func (h *knowledgeHandler) ListDocuments(c *gin.Context) {
+	documents, err := h.knowledgeService.ListDocuments(c.Request.Context())
+	if err != nil {
+		log.Printf("[KnowledgeHandler] Failed to list documents: %v", err)
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to list knowledge documents",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Knowledge documents",
+		Data:    documents,
+	})
+}
+
+func (h *knowledgeHandler) GetDocument(c *gin.Context) {
+	source := documentSource(c)
+
+	document, err := h.knowledgeService.GetDocument(c.Request.Context(), source)
+	if err != nil {
+		if errors.Is(err, services.ErrDocumentNotFound) {
+			c.JSON(http.StatusNotFound, models.APIResponse{
+				Success: false,
+				Error:   "Knowledge document not found",
+			})
+			return
+		}
+
+		log.Printf("[KnowledgeHandler] Failed to get document %s: %v", source, err)
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to get knowledge document",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Knowledge document",
+		Data:    document,
+	})
+}
+
+func (h *knowledgeHandler) DeleteDocument(c *gin.Context) {
+	source := documentSource(c)
+
+	deleted, err := h.knowledgeService.DeleteDocument(c.Request.Context(), source)
+	if err != nil {
+		if errors.Is(err, services.ErrDocumentNotFound) {
+			c.JSON(http.StatusNotFound, models.APIResponse{
+				Success: false,
+				Error:   "Knowledge document not found",
+			})
+			return
+		}
+
+		log.Printf("[KnowledgeHandler] Failed to delete document %s: %v", source, err)
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to delete knowledge document",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Knowledge document deleted",
+		Data: gin.H{
+			"source":         source,
+			"deleted_chunks": deleted,
+		},
+	})
+}
+
+// documentSource reads the source from the catch-all route parameter, which keeps
+// its leading slash. Sources ingested from directories contain slashes themselves.
+func documentSource(c *gin.Context) string {
+	return strings.TrimPrefix(c.Param("source"), "/")
+}
@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Operator session and CSRF cookies. The session cookie holds a JWT; the CSRF
+// cookie is echoed back in a form field or header on every unsafe request.
+const (
+	SessionCookieName = "wa_session"
+	CSRFCookieName    = "wa_csrf"
+	CSRFFieldName     = "csrf_token"
+	CSRFHeader        = "X-CSRF-Token"
+
+	OperatorLoginPath = "/api/v1/operator/login"
+	operatorHomePath  = "/api/v1/qr/page"
+	cookiePath        = "/api/v1"
+)
+
+type OperatorHandler interface {
+	ShowLogin(c *gin.Context)
+	Login(c *gin.Context)
+	Logout(c *gin.Context)
+}
+
+type operatorHandler struct {
+	authService services.AuthService
+}
+
+func NewOperatorHandler(authService services.AuthService) OperatorHandler {
+	return &operatorHandler{
+		authService: authService,
+	}
+}
+
+var operatorLoginPage = template.Must(template.New("login").Parse(`
+<!DOCTYPE html>
+<html>
+<head>
+    <title>WhatsApp Bot - Operator Login</title>
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <style>
+        body {
+            font-family: Arial, sans-serif;
+            max-width: 600px;
+            margin: 50px auto;
+            padding: 20px;
+            text-align: center;
+            background-color: #f5f5f5;
+        }
+        .container {
+            background-color: white;
+            padding: 40px;
+            border-radius: 10px;
+            box-shadow: 0 2px 10px rgba(0,0,0,0.1);
+        }
+        .title { color: #25D366; font-size: 24px; margin-bottom: 20px; }
+        .error { color: #c0392b; margin-bottom: 20px; }
+        input[type=password] {
+            width: 100%;
+            padding: 10px;
+            margin-bottom: 20px;
+            box-sizing: border-box;
+        }
+        .login-btn {
+            background-color: #25D366;
+            color: white;
+            padding: 12px 24px;
+            border: none;
+            border-radius: 5px;
+            cursor: pointer;
+            font-size: 16px;
+        }
+        .login-btn:hover { background-color: #128C7E; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="title">🔒 Operator Login</div>
+        {{if .Error}}<div class="error">{{.Error}}</div>{{end}}
+        <form method="POST" action="{{.Action}}">
+            <input type="hidden" name="csrf_token" value="{{.CSRFToken}}" />
+            <input type="hidden" name="next" value="{{.Next}}" />
+            <input type="password" name="api_key" placeholder="API key" autocomplete="off" required autofocus />
+            <button type="submit" class="login-btn">Sign in</button>
+        </form>
+    </div>
+</body>
+</html>`))
+
+func (h *operatorHandler) ShowLogin(c *gin.Context) {
+	h.renderLogin(c, http.StatusOK, "")
+}
+
+// Login exchanges an API key with the whatsapp:manage permission for a session cookie
+func (h *operatorHandler) Login(c *gin.Context) {
+	if !ValidCSRFToken(c) {
+		h.renderLogin(c, http.StatusForbidden, "Your session expired, please try again.")
+		return
+	}
+
+	principal, err := h.authService.Authenticate(c.Request.Context(), strings.TrimSpace(c.PostForm("api_key")))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCredentials) {
+			log.Printf("[OperatorHandler] Failed login from %s", c.ClientIP())
+			h.renderLogin(c, http.StatusUnauthorized, "Invalid API key.")
+			return
+		}
+		log.Printf("[OperatorHandler] Failed to authenticate operator: %v", err)
+		h.renderLogin(c, http.StatusInternalServerError, "Login failed, please try again.")
+		return
+	}
+
+	if !principal.HasPermission(models.PermissionWhatsAppManage) {
+		log.Printf("[OperatorHandler] Refused login of %s: missing permission %s", principal.Name, models.PermissionWhatsAppManage)
+		h.renderLogin(c, http.StatusForbidden, "This key is not allowed to manage the WhatsApp connection.")
+		return
+	}
+
+	token, err := h.authService.IssueToken(c.Request.Context(), principal)
+	if err != nil {
+		if errors.Is(err, services.ErrTokensDisabled) {
+			h.renderLogin(c, http.StatusServiceUnavailable, "Operator login is not configured (JWT_SECRET is not set).")
+			return
+		}
+		log.Printf("[OperatorHandler] Failed to issue session for %s: %v", principal.Name, err)
+		h.renderLogin(c, http.StatusInternalServerError, "Login failed, please try again.")
+		return
+	}
+
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    token.Token,
+		Path:     cookiePath,
+		Expires:  token.ExpiresAt,
+		HttpOnly: true,
+		Secure:   isSecureRequest(c),
+		SameSite: http.SameSiteStrictMode,
+	})
+
+	log.Printf("[OperatorHandler] Operator %s signed in from %s", principal.Name, c.ClientIP())
+	c.Redirect(http.StatusSeeOther, safeNextPath(c.PostForm("next")))
+}
+
+// Logout ends the operator session; it does not touch the WhatsApp connection
+func (h *operatorHandler) Logout(c *gin.Context) {
+	if !ValidCSRFToken(c) {
+		c.JSON(http.StatusForbidden, models.APIResponse{Success: false, Error: "Invalid or missing CSRF token"})
+		return
+	}
+
+	ClearSessionCookie(c)
+	c.Redirect(http.StatusSeeOther, OperatorLoginPath)
+}
+
+func (h *operatorHandler) renderLogin(c *gin.Context, status int, message string) {
+	next := c.PostForm("next")
+	if next == "" {
+		next = c.Query("next")
+	}
+
+	data := struct {
+		Action    string
+		CSRFToken string
+		Next      string
+		Error     string
+	}{
+		Action:    OperatorLoginPath,
+		CSRFToken: EnsureCSRFToken(c),
+		Next:      safeNextPath(next),
+		Error:     message,
+	}
+
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Status(status)
+	if err := operatorLoginPage.Execute(c.Writer, data); err != nil {
+		log.Printf("[OperatorHandler] Failed to render login page: %v", err)
+	}
+}
+
+// EnsureCSRFToken returns the request's CSRF token, issuing a new cookie when
+// there is none, for embedding in forms
+func EnsureCSRFToken(c *gin.Context) string {
+	if token, err := c.Cookie(CSRFCookieName); err == nil && len(token) == 64 {
+		return token
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		log.Printf("[OperatorHandler] Failed to generate CSRF token: %v", err)
+		return ""
+	}
+	token := hex.EncodeToString(buf)
+
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     CSRFCookieName,
+		Value:    token,
+		Path:     cookiePath,
+		HttpOnly: true,
+		Secure:   isSecureRequest(c),
+		SameSite: http.SameSiteStrictMode,
+	})
+	return token
+}
+
+// ValidCSRFToken reports whether the X-CSRF-Token header or csrf_token form
+// field matches the CSRF cookie
+func ValidCSRFToken(c *gin.Context) bool {
+	cookie, err := c.Cookie(CSRFCookieName)
+	if err != nil || cookie == "" {
+		return false
+	}
+
+	token := c.GetHeader(CSRFHeader)
+	if token == "" {
+		token = c.PostForm(CSRFFieldName)
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(cookie)) == 1
+}
+
+// ClearSessionCookie removes the operator session cookie
+func ClearSessionCookie(c *gin.Context) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    "",
+		Path:     cookiePath,
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   isSecureRequest(c),
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+// isSecureRequest reports whether the client connected over HTTPS, directly or
+// through a proxy
+func isSecureRequest(c *gin.Context) bool {
+	return c.Request.TLS != nil || strings.EqualFold(c.GetHeader("X-Forwarded-Proto"), "https")
+}
+
+// safeNextPath only allows redirects to pages of this API, so the login form
+// cannot be used as an open redirect
+func safeNextPath(next string) string {
+	if strings.HasPrefix(next, "/api/v1/") && !strings.ContainsAny(next, "\\\r\n") {
+		return next
+	}
+	return operatorHomePath
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/services"
+	"github.com/fajarAnd/workshop-brin/wa-service/testutils/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestOperatorHandler_Login
+// Summary: Test operator login with an API key
+// Purpose: Validate the CSRF check, refusal of keys without whatsapp:manage, and the session cookie and redirect on success
+func TestOperatorHandler_Login(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	csrfToken := strings.Repeat("c", 64)
+	operator := &models.Principal{Name: "Siti", Permissions: []string{models.PermissionWhatsAppManage}}
+	agent := &models.Principal{Name: "Andi", Permissions: []string{models.PermissionConversationsRead}}
+
+	tests := []struct {
+		name             string
+		csrfField        string
+		next             string
+		setup            func(m *mocks.MockAuthService)
+		expectedStatus   int
+		expectedLocation string
+		expectSession    bool
+	}{
+		{
+			name:      "Operator key",
+			csrfField: csrfToken,
+			next:      "/api/v1/qr/page",
+			setup: func(m *mocks.MockAuthService) {
+				m.EXPECT().Authenticate(mock.Anything, "wak_operator").Return(operator, nil)
+				m.EXPECT().IssueToken(mock.Anything, operator).Return(&models.AuthToken{Token: "a.b.c", ExpiresAt: time.Now().Add(time.Hour)}, nil)
+			},
+			expectedStatus:   http.StatusSeeOther,
+			expectedLocation: "/api/v1/qr/page",
+			expectSession:    true,
+		},
+		{
+			name:      "External redirect is ignored",
+			csrfField: csrfToken,
+			next:      "//evil.example.com/",
+			setup: func(m *mocks.MockAuthService) {
+				m.EXPECT().Authenticate(mock.Anything, "wak_operator").Return(operator, nil)
+				m.EXPECT().IssueToken(mock.Anything, operator).Return(&models.AuthToken{Token: "a.b.c", ExpiresAt: time.Now().Add(time.Hour)}, nil)
+			},
+			expectedStatus:   http.StatusSeeOther,
+			expectedLocation: "/api/v1/qr/page",
+			expectSession:    true,
+		},
+		{
+			name:           "Missing CSRF token",
+			csrfField:      "",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:      "Invalid key",
+			csrfField: csrfToken,
+			setup: func(m *mocks.MockAuthService) {
+				m.EXPECT().Authenticate(mock.Anything, "wak_operator").Return(nil, services.ErrInvalidCredentials)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:      "Key without whatsapp:manage",
+			csrfField: csrfToken,
+			setup: func(m *mocks.MockAuthService) {
+				m.EXPECT().Authenticate(mock.Anything, "wak_operator").Return(agent, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:      "JWT_SECRET not set",
+			csrfField: csrfToken,
+			setup: func(m *mocks.MockAuthService) {
+				m.EXPECT().Authenticate(mock.Anything, "wak_operator").Return(operator, nil)
+				m.EXPECT().IssueToken(mock.Anything, operator).Return(nil, services.ErrTokensDisabled)
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuth := mocks.NewMockAuthService(t)
+			if tt.setup != nil {
+				tt.setup(mockAuth)
+			}
+
+			router := gin.New()
+			router.POST(OperatorLoginPath, NewOperatorHandler(mockAuth).Login)
+
+			form := url.Values{"api_key": {"wak_operator"}, CSRFFieldName: {tt.csrfField}, "next": {tt.next}}
+			req := httptest.NewRequest(http.MethodPost, OperatorLoginPath, strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.AddCookie(&http.Cookie{Name: CSRFCookieName, Value: csrfToken})
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedLocation, w.Header().Get("Location"))
+
+			var session *http.Cookie
+			for _, cookie := range w.Result().Cookies() {
+				if cookie.Name == SessionCookieName {
+					session = cookie
+				}
+			}
+			if !tt.expectSession {
+				assert.Nil(t, session)
+				return
+			}
+			assert.NotNil(t, session)
+			assert.Equal(t, "a.b.c", session.Value)
+			assert.True(t, session.HttpOnly)
+			assert.Equal(t, http.SameSiteStrictMode, session.SameSite)
+		})
+	}
+}
+
+// TestShowLogin_EscapesNext
+// Summary: Test rendering of the operator login page
+// Purpose: Validate that the page sets a CSRF cookie, embeds its token and does not echo unsafe redirect targets
+func TestShowLogin_EscapesNext(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET(OperatorLoginPath, NewOperatorHandler(mocks.NewMockAuthService(t)).ShowLogin)
+
+	req := httptest.NewRequest(http.MethodGet, OperatorLoginPath+`?next="><script>alert(1)</script>`, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	cookies := w.Result().Cookies()
+	if assert.Len(t, cookies, 1) {
+		assert.Equal(t, CSRFCookieName, cookies[0].Name)
+		assert.Contains(t, w.Body.String(), `value="`+cookies[0].Value+`"`)
+	}
+	assert.NotContains(t, w.Body.String(), "<script>alert(1)</script>")
+	assert.Contains(t, w.Body.String(), `name="next" value="/api/v1/qr/page"`)
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/services"
@@ -66,6 +67,7 @@ func (h *qrHandler) GetConnectionStatus(c *gin.Context) {
 
 func (h *qrHandler) ShowQRPage(c *gin.Context) {
 	isConnected := h.whatsappService.IsConnected()
+	controls := operatorControls(EnsureCSRFToken(c), isConnected)
 
 	if isConnected {
 		c.Header("Content-Type", "text/html")
@@ -111,9 +113,10 @@ func (h *qrHandler) ShowQRPage(c *gin.Context) {
         <div class="success">✅ WhatsApp Bot Connected!</div>
         <div class="status">Your WhatsApp bot is connected and ready to receive messages.</div>
         <a href="/api/v1/qr/page" class="refresh-btn">Refresh Status</a>
+        %s
     </div>
 </body>
-</html>`)
+</html>`, controls)
 		return
 	}
 
@@ -165,9 +168,10 @@ func (h *qrHandler) ShowQRPage(c *gin.Context) {
         <div class="message">Please wait for the WhatsApp service to generate a QR code, or refresh this page.</div>
         <div class="message">Error: %s</div>
         <a href="/api/v1/qr/page" class="refresh-btn">Refresh Page</a>
+        %s
     </div>
 </body>
-</html>`, err.Error())
+</html>`, err.Error(), controls)
 		return
 	}
 
@@ -262,9 +266,29 @@ func (h *qrHandler) ShowQRPage(c *gin.Context) {
         <a href="/api/v1/qr/page" class="refresh-btn">Refresh QR Code</a>
         
         <div class="auto-refresh">This page will auto-refresh every 30 seconds</div>
+        %s
     </div>
 </body>
-</html>`)
+</html>`, controls)
+}
+
+// operatorControls renders the CSRF-protected forms of the QR page: unlinking
+// the device when connected, and signing the operator out
+func operatorControls(csrfToken string, connected bool) string {
+	controls := ""
+	if connected {
+		controls += fmt.Sprintf(`
+        <form method="POST" action="/api/v1/whatsapp/logout">
+            <input type="hidden" name="%s" value="%s" />
+            <button type="submit" class="refresh-btn">Unlink Device</button>
+        </form>`, CSRFFieldName, csrfToken)
+	}
+	controls += fmt.Sprintf(`
+        <form method="POST" action="/api/v1/operator/logout">
+            <input type="hidden" name="%s" value="%s" />
+            <button type="submit" class="refresh-btn">Sign Out</button>
+        </form>`, CSRFFieldName, csrfToken)
+	return controls
 }
 
 func (h *qrHandler) GetQRImage(c *gin.Context) {
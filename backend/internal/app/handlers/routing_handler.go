@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RoutingHandler manages where inbound WhatsApp messages go: the workflow
+// they are routed to and the access policy that decides who gets through
+type RoutingHandler interface {
+	GetWorkflow(c *gin.Context)
+	SetWorkflow(c *gin.Context)
+	GetAccessPolicy(c *gin.Context)
+	UpdateAccessPolicy(c *gin.Context)
+}
+
+type routingHandler struct {
+	workflowConfigService services.WorkflowConfigService
+	accessPolicyService   services.AccessPolicyService
+	auditService          services.AuditService
+}
+
+func NewRoutingHandler(workflowConfigService services.WorkflowConfigService, accessPolicyService services.AccessPolicyService, auditService services.AuditService) RoutingHandler {
+	return &routingHandler{
+		workflowConfigService: workflowConfigService,
+		accessPolicyService:   accessPolicyService,
+		auditService:          auditService,
+	}
+}
+
+func (h *routingHandler) GetWorkflow(c *gin.Context) {
+	config, err := h.workflowConfigService.GetConfig(c.Request.Context())
+	if err != nil {
+		respondRoutingError(c, err, "Failed to get workflow config")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Workflow config",
+		Data:    config,
+	})
+}
+
+func (h *routingHandler) SetWorkflow(c *gin.Context) {
+	var request models.SetWorkflowTypeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	config, err := h.workflowConfigService.SetWorkflowType(c.Request.Context(), request.WorkflowType)
+	if err != nil {
+		respondRoutingError(c, err, "Failed to set workflow type")
+		return
+	}
+
+	h.auditService.Record(c.Request.Context(), newAuditEntry(c, models.AuditActionWorkflowChanged, map[string]string{
+		"workflow_type": config.WorkflowType,
+	}))
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Workflow type updated",
+		Data:    config,
+	})
+}
+
+func (h *routingHandler) GetAccessPolicy(c *gin.Context) {
+	policy, err := h.accessPolicyService.GetActivePolicy(c.Request.Context())
+	if err != nil {
+		respondRoutingError(c, err, "Failed to get access policy")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Access policy",
+		Data:    policy,
+	})
+}
+
+func (h *routingHandler) UpdateAccessPolicy(c *gin.Context) {
+	var request models.UpdateAccessPolicyRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	policy, err := h.accessPolicyService.UpdatePolicy(c.Request.Context(), &request)
+	if err != nil {
+		respondRoutingError(c, err, "Failed to update access policy")
+		return
+	}
+
+	details := map[string]string{"mode": policy.Mode, "notify_unregistered": "false"}
+	if policy.NotifyUnregistered {
+		details["notify_unregistered"] = "true"
+	}
+	h.auditService.Record(c.Request.Context(), newAuditEntry(c, models.AuditActionAccessPolicyChanged, details))
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Access policy updated",
+		Data:    policy,
+	})
+}
+
+func respondRoutingError(c *gin.Context, err error, failure string) {
+	switch {
+	case errors.Is(err, services.ErrUnknownWorkflowType), errors.Is(err, services.ErrInvalidAccessMode):
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Error: err.Error()})
+	default:
+		log.Printf("[RoutingHandler] %s: %v", failure, err)
+		c.JSON(http.StatusInternalServerError, models.APIResponse{Success: false, Error: failure})
+	}
+}
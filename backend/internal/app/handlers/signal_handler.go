@@ -0,0 +1,332 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type SignalHandler interface {
+	GetBroadcast(c *gin.Context)
+	ListDeliveries(c *gin.Context)
+	ListSignals(c *gin.Context)
+	ListRejected(c *gin.Context)
+	GetStats(c *gin.Context)
+	ImportPrices(c *gin.Context)
+	ListTemplates(c *gin.Context)
+	SaveTemplate(c *gin.Context)
+	ResetTemplate(c *gin.Context)
+	PreviewTemplate(c *gin.Context)
+}
+
+// maxPriceImportSize bounds the uploaded price file
+const maxPriceImportSize = 10 << 20
+
+type signalHandler struct {
+	signalService   services.SignalService
+	outcomeService  services.SignalOutcomeService
+	templateService services.SignalTemplateService
+}
+
+func NewSignalHandler(signalService services.SignalService, outcomeService services.SignalOutcomeService, templateService services.SignalTemplateService) SignalHandler {
+	return &signalHandler{
+		signalService:   signalService,
+		outcomeService:  outcomeService,
+		templateService: templateService,
+	}
+}
+
+// GetBroadcast returns a broadcast with its delivery counts
+func (h *signalHandler) GetBroadcast(c *gin.Context) {
+	id, ok := parseBroadcastID(c)
+	if !ok {
+		return
+	}
+
+	broadcast, err := h.signalService.GetBroadcast(c.Request.Context(), id)
+	if err != nil {
+		respondSignalError(c, err, "Failed to get signal broadcast")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Signal broadcast",
+		Data:    broadcast,
+	})
+}
+
+// ListDeliveries returns the per-recipient status of a broadcast, optionally
+// filtered by ?status=pending|sent|failed
+func (h *signalHandler) ListDeliveries(c *gin.Context) {
+	id, ok := parseBroadcastID(c)
+	if !ok {
+		return
+	}
+
+	deliveries, err := h.signalService.ListDeliveries(c.Request.Context(), id, c.Query("status"))
+	if err != nil {
+		respondSignalError(c, err, "Failed to list signal deliveries")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Signal deliveries",
+		Data:    deliveries,
+	})
+}
+
+// ListSignals returns the most recent signals with their outcomes, optionally
+// filtered by ?ticker= and ?outcome=
+func (h *signalHandler) ListSignals(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	filter := &models.TrackedSignalFilter{
+		Ticker:  c.Query("ticker"),
+		Outcome: c.Query("outcome"),
+	}
+
+	signals, err := h.outcomeService.ListSignals(c.Request.Context(), filter, limit)
+	if err != nil {
+		respondSignalError(c, err, "Failed to list signals")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Signals",
+		Data:    signals,
+	})
+}
+
+// ListRejected returns the most recent signals that failed validation with
+// their violations, optionally filtered by ?ticker= and ?rule=
+func (h *signalHandler) ListRejected(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	filter := &models.RejectedSignalFilter{
+		Ticker: c.Query("ticker"),
+		Rule:   c.Query("rule"),
+	}
+
+	rejected, err := h.signalService.ListRejected(c.Request.Context(), filter, limit)
+	if err != nil {
+		respondSignalError(c, err, "Failed to list rejected signals")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Rejected signals",
+		Data:    rejected,
+	})
+}
+
+// GetStats returns realised win rates next to the claimed backtest win rates,
+// grouped by ?group_by=ticker (default) or confluence, in ?bucket_size= point buckets
+func (h *signalHandler) GetStats(c *gin.Context) {
+	bucketSize := 0.0
+	if value := c.Query("bucket_size"); value != "" {
+		var err error
+		if bucketSize, err = strconv.ParseFloat(value, 64); err != nil || bucketSize <= 0 {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   "bucket_size must be a positive number",
+			})
+			return
+		}
+	}
+
+	report, err := h.outcomeService.Stats(c.Request.Context(), c.DefaultQuery("group_by", models.SignalStatsByTicker), bucketSize)
+	if err != nil {
+		respondSignalError(c, err, "Failed to get signal stats")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Signal stats",
+		Data:    report,
+	})
+}
+
+// ImportPrices records daily price bars from a CSV file, sent either as the
+// request body or as the "file" field of a multipart form
+func (h *signalHandler) ImportPrices(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxPriceImportSize)
+
+	var reader io.Reader = c.Request.Body
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		header, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   "Invalid import file: missing file field",
+			})
+			return
+		}
+		file, err := header.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   "Invalid import file: " + err.Error(),
+			})
+			return
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	prices, err := services.ParseSignalPrices(reader)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid import file: " + err.Error(),
+		})
+		return
+	}
+
+	report, err := h.outcomeService.RecordPrices(c.Request.Context(), prices)
+	if err != nil {
+		respondSignalError(c, err, "Failed to import prices")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: fmt.Sprintf("Recorded %d prices, resolved %d signals", report.Prices, report.Resolved),
+		Data:    report,
+	})
+}
+
+// ListTemplates returns the current message template of every kind and language
+func (h *signalHandler) ListTemplates(c *gin.Context) {
+	templates, err := h.templateService.List(c.Request.Context())
+	if err != nil {
+		respondSignalError(c, err, "Failed to list signal templates")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Signal templates",
+		Data:    templates,
+	})
+}
+
+// SaveTemplate replaces the template of :kind in :language
+func (h *signalHandler) SaveTemplate(c *gin.Context) {
+	var req models.SaveSignalTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	var updatedBy *uuid.UUID
+	if principal := CurrentPrincipal(c); principal != nil {
+		updatedBy = principal.UserID
+	}
+
+	template, err := h.templateService.Save(c.Request.Context(), c.Param("kind"), c.Param("language"), req.Body, updatedBy)
+	if err != nil {
+		respondSignalError(c, err, "Failed to save signal template")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Signal template saved",
+		Data:    template,
+	})
+}
+
+// ResetTemplate restores the built-in template of :kind in :language
+func (h *signalHandler) ResetTemplate(c *gin.Context) {
+	template, err := h.templateService.Reset(c.Request.Context(), c.Param("kind"), c.Param("language"))
+	if err != nil {
+		respondSignalError(c, err, "Failed to reset signal template")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Signal template reset",
+		Data:    template,
+	})
+}
+
+// PreviewTemplate renders a template, or a draft body, for a sample or given signal
+func (h *signalHandler) PreviewTemplate(c *gin.Context) {
+	var req models.SignalTemplatePreviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	preview, err := h.templateService.Preview(c.Request.Context(), &req)
+	if err != nil {
+		respondSignalError(c, err, "Failed to preview signal template")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Signal template preview",
+		Data:    preview,
+	})
+}
+
+func parseBroadcastID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid broadcast ID",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// respondSignalError maps signal service errors to 400/404/409/422, and anything else to 500
+func respondSignalError(c *gin.Context, err error, failure string) {
+	var rejectedErr *services.SignalRejectedError
+	switch {
+	case errors.As(err, &rejectedErr):
+		// Every violation is listed; rejected_id points at the copy kept for review
+		data := gin.H{"violations": rejectedErr.Rejected.Violations}
+		if rejectedErr.Rejected.ID != uuid.Nil {
+			data["rejected_id"] = rejectedErr.Rejected.ID
+		}
+		c.JSON(http.StatusUnprocessableEntity, models.APIResponse{Success: false, Error: err.Error(), Data: data})
+	case errors.Is(err, services.ErrInvalidDeliveryStatus), errors.Is(err, services.ErrInvalidOutcome), errors.Is(err, services.ErrInvalidStatsGroup),
+		errors.Is(err, services.ErrInvalidSignalTemplate), errors.Is(err, services.ErrInvalidSignalRule):
+		c.JSON(http.StatusBadRequest, models.APIResponse{Success: false, Error: err.Error()})
+	case errors.Is(err, services.ErrUnknownSignalTemplate):
+		c.JSON(http.StatusNotFound, models.APIResponse{Success: false, Error: err.Error()})
+	case errors.Is(err, services.ErrSignalBroadcastNotFound):
+		c.JSON(http.StatusNotFound, models.APIResponse{Success: false, Error: "Signal broadcast not found"})
+	case errors.Is(err, services.ErrSignalNotFound):
+		c.JSON(http.StatusNotFound, models.APIResponse{Success: false, Error: "Signal not found"})
+	case errors.Is(err, services.ErrSignalClosed):
+		c.JSON(http.StatusConflict, models.APIResponse{Success: false, Error: err.Error()})
+	default:
+		log.Printf("[SignalHandler] %s: %v", failure, err)
+		c.JSON(http.StatusInternalServerError, models.APIResponse{Success: false, Error: failure})
+	}
+}
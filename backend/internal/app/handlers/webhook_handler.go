@@ -1,11 +1,14 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 
 	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
 	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/services"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/pkg/phonenumber"
 
 	"github.com/gin-gonic/gin"
 )
@@ -13,17 +16,23 @@ import (
 type WebhookHandler interface {
 	HandleN8NResponse(c *gin.Context)
 	HandleN8NSignal(c *gin.Context)
+	HandleN8NPrices(c *gin.Context)
+	HandleFlowiseResponse(c *gin.Context)
 }
 
 type webhookHandler struct {
-	n8nService    services.N8NService
-	signalService services.SignalService
+	n8nService     services.N8NService
+	flowiseService services.FlowiseService
+	signalService  services.SignalService
+	outcomeService services.SignalOutcomeService
 }
 
-func NewWebhookHandler(n8nService services.N8NService, signalService services.SignalService) WebhookHandler {
+func NewWebhookHandler(n8nService services.N8NService, flowiseService services.FlowiseService, signalService services.SignalService, outcomeService services.SignalOutcomeService) WebhookHandler {
 	return &webhookHandler{
-		n8nService:    n8nService,
-		signalService: signalService,
+		n8nService:     n8nService,
+		flowiseService: flowiseService,
+		signalService:  signalService,
+		outcomeService: outcomeService,
 	}
 }
 
@@ -40,18 +49,17 @@ func (h *webhookHandler) HandleN8NResponse(c *gin.Context) {
 		return
 	}
 
+	if !normalizeResponsePhone(c, &response.Phone) {
+		return
+	}
+
 	// Log the received response
 	log.Printf("[WebhookHandler] N8N Response - MessageID: %s, Phone: %s, Success: %t",
 		response.MessageID, response.Phone, response.Success)
 
 	// Handle the response using N8N service
-	err := h.n8nService.HandleWorkflowResponse(&response)
-	if err != nil {
-		log.Printf("[WebhookHandler] Failed to handle workflow response: %v", err)
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
-			Success: false,
-			Error:   "Failed to process response",
-		})
+	if err := h.n8nService.HandleWorkflowResponse(&response); err != nil {
+		respondWorkflowResponseError(c, err, "Failed to process response")
 		return
 	}
 
@@ -68,11 +76,50 @@ func (h *webhookHandler) HandleN8NResponse(c *gin.Context) {
 	log.Printf("[WebhookHandler] N8N response processed successfully")
 }
 
+func (h *webhookHandler) HandleFlowiseResponse(c *gin.Context) {
+	log.Printf("[WebhookHandler] Received Flowise response from %s", c.ClientIP())
+
+	var response models.FlowiseResponse
+	if err := c.ShouldBindJSON(&response); err != nil {
+		log.Printf("[WebhookHandler] Invalid JSON payload: %v", err)
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid JSON payload",
+		})
+		return
+	}
+
+	if !normalizeResponsePhone(c, &response.Phone) {
+		return
+	}
+
+	log.Printf("[WebhookHandler] Flowise Response - MessageID: %s, Phone: %s, Success: %t",
+		response.MessageID, response.Phone, response.Success)
+
+	if err := h.flowiseService.HandleWorkflowResponse(&response); err != nil {
+		respondWorkflowResponseError(c, err, "Failed to process response")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Response processed successfully",
+		Data: gin.H{
+			"message_id": response.MessageID,
+			"processed":  true,
+		},
+	})
+
+	log.Printf("[WebhookHandler] Flowise response processed successfully")
+}
+
 func (h *webhookHandler) HandleN8NSignal(c *gin.Context) {
 	log.Printf("[WebhookHandler] Received N8N signal from %s", c.ClientIP())
 
+	// The binding tags are checked by the signal service with the other rules,
+	// so that every violation is reported together
 	var signal models.Signal
-	if err := c.ShouldBindJSON(&signal); err != nil {
+	if err := json.NewDecoder(c.Request.Body).Decode(&signal); err != nil {
 		log.Printf("[WebhookHandler] Invalid signal JSON payload: %v", err)
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
@@ -85,20 +132,110 @@ func (h *webhookHandler) HandleN8NSignal(c *gin.Context) {
 
 	response, err := h.signalService.ProcessSignal(c.Request.Context(), &signal)
 	if err != nil {
-		log.Printf("[WebhookHandler] Failed to process signal: %v", err)
+		respondSignalError(c, err, "Failed to process signal")
+		return
+	}
+
+	// A repeated post changes nothing and points at the earlier broadcast
+	if response.Duplicate {
+		c.JSON(http.StatusOK, models.APIResponse{
+			Success: true,
+			Message: "Signal already received",
+			Data:    response,
+		})
+		log.Printf("[WebhookHandler] Duplicate %s signal for %s, broadcast %s", response.Operation, signal.Ticker, response.BroadcastID)
+		return
+	}
+
+	message := "Signal accepted for delivery"
+	switch response.Operation {
+	case models.SignalOperationUpdate:
+		message = "Signal update accepted for delivery"
+	case models.SignalOperationCancel:
+		message = "Signal cancellation accepted for delivery"
+	}
+
+	// Messages go out in the background; the broadcast ID tracks their delivery
+	c.JSON(http.StatusAccepted, models.APIResponse{
+		Success: true,
+		Message: message,
+		Data:    response,
+	})
+
+	log.Printf("[WebhookHandler] %s signal accepted for %s as broadcast %s to %d users",
+		response.Operation, signal.Ticker, response.BroadcastID, response.Recipients)
+}
+
+// HandleN8NPrices records daily price bars and resolves the open signals they decide
+func (h *webhookHandler) HandleN8NPrices(c *gin.Context) {
+	log.Printf("[WebhookHandler] Received N8N prices from %s", c.ClientIP())
+
+	var request models.SignalPriceRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Printf("[WebhookHandler] Invalid prices JSON payload: %v", err)
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid JSON payload",
+		})
+		return
+	}
+
+	report, err := h.outcomeService.RecordPrices(c.Request.Context(), request.Prices)
+	if err != nil {
+		log.Printf("[WebhookHandler] Failed to record prices: %v", err)
 		c.JSON(http.StatusInternalServerError, models.APIResponse{
 			Success: false,
-			Error:   "Failed to process signal",
+			Error:   "Failed to record prices",
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
-		Message: "Signal processed successfully",
-		Data:    response,
+		Message: "Prices recorded",
+		Data:    report,
 	})
 
-	log.Printf("[WebhookHandler] Signal processed successfully for %s, notified %d users",
-		signal.Ticker, response.UsersNotified)
+	log.Printf("[WebhookHandler] Recorded %d prices (%d rejected), resolved %d signals",
+		report.Prices, report.Rejected, report.Resolved)
+}
+
+// respondWorkflowResponseError rejects callbacks that do not claim a pending
+// request, so each reply is delivered once and only to the phone that asked
+func respondWorkflowResponseError(c *gin.Context, err error, failure string) {
+	switch {
+	case errors.Is(err, services.ErrUnknownRequest):
+		log.Printf("[WebhookHandler] Rejected workflow response: %v", err)
+		c.JSON(http.StatusNotFound, models.APIResponse{Success: false, Error: "Unknown message_id"})
+	case errors.Is(err, services.ErrDuplicateCallback):
+		log.Printf("[WebhookHandler] Rejected workflow response: %v", err)
+		c.JSON(http.StatusConflict, models.APIResponse{Success: false, Error: "Response already processed for message_id"})
+	case errors.Is(err, services.ErrExpiredRequest):
+		log.Printf("[WebhookHandler] Rejected workflow response: %v", err)
+		c.JSON(http.StatusGone, models.APIResponse{Success: false, Error: "Request for message_id already timed out"})
+	default:
+		log.Printf("[WebhookHandler] %s: %v", failure, err)
+		c.JSON(http.StatusInternalServerError, models.APIResponse{Success: false, Error: failure})
+	}
+}
+
+// normalizeResponsePhone rewrites a workflow response phone to the normalised
+// form used for pending requests and transcripts. An empty phone is left as is.
+func normalizeResponsePhone(c *gin.Context, phone *string) bool {
+	if *phone == "" {
+		return true
+	}
+
+	normalized, err := phonenumber.Normalize(*phone)
+	if err != nil {
+		log.Printf("[WebhookHandler] Invalid phone in workflow response: %q", *phone)
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid phone number",
+		})
+		return false
+	}
+
+	*phone = normalized
+	return true
 }
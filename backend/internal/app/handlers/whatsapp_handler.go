@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
 	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/services"
 
 	"github.com/gin-gonic/gin"
@@ -15,16 +16,28 @@ type WhatsAppHandler interface {
 
 type whatsappHandler struct {
 	whatsappService services.WhatsAppService
+	auditService    services.AuditService
 }
 
-func NewWhatsAppHandler(whatsappService services.WhatsAppService) WhatsAppHandler {
+func NewWhatsAppHandler(whatsappService services.WhatsAppService, auditService services.AuditService) WhatsAppHandler {
 	return &whatsappHandler{
 		whatsappService: whatsappService,
+		auditService:    auditService,
 	}
 }
 
+// Logout unlinks the bot's device. The QR page submits it as a form and is
+// redirected back; API clients get JSON.
 func (h *whatsappHandler) Logout(c *gin.Context) {
 	err := h.whatsappService.Logout()
+
+	details := map[string]string{"result": "success"}
+	if err != nil {
+		details["result"] = "failed"
+		details["error"] = err.Error()
+	}
+	h.auditService.Record(c.Request.Context(), newAuditEntry(c, models.AuditActionWhatsAppLogout, details))
+
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
@@ -34,6 +47,11 @@ func (h *whatsappHandler) Logout(c *gin.Context) {
 		return
 	}
 
+	if c.ContentType() == "application/x-www-form-urlencoded" {
+		c.Redirect(http.StatusSeeOther, operatorHomePath)
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"message": "Successfully logged out from WhatsApp. Device has been removed.",
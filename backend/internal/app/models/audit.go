@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audited actions
+const (
+	AuditActionWhatsAppPaired       = "whatsapp.paired"
+	AuditActionWhatsAppLogout       = "whatsapp.logout"
+	AuditActionWhatsAppLoggedOut    = "whatsapp.logged_out"
+	AuditActionWorkflowChanged      = "routing.workflow_changed"
+	AuditActionAccessPolicyChanged  = "routing.access_policy_changed"
+	AuditActionConversationTakeover = "conversation.takeover"
+	AuditActionConversationReleased = "conversation.released"
+)
+
+// AuditActorWhatsApp is the actor of entries caused by WhatsApp itself, such as
+// a scan of the QR code or a device removed from the phone
+const AuditActorWhatsApp = "whatsapp"
+
+// AuditEntry records who did what and when
+type AuditEntry struct {
+	ID          uuid.UUID         `json:"id" db:"id"`
+	Action      string            `json:"action" db:"action"`
+	Actor       string            `json:"actor" db:"actor"`
+	ActorUserID *uuid.UUID        `json:"actor_user_id,omitempty" db:"actor_user_id"`
+	RemoteAddr  string            `json:"remote_addr,omitempty" db:"remote_addr"`
+	Details     map[string]string `json:"details" db:"details"`
+	CreatedAt   time.Time         `json:"created_at" db:"created_at"`
+}
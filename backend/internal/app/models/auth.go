@@ -0,0 +1,96 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Built-in roles
+const (
+	RoleAdmin      = "admin"
+	RoleAgent      = "agent"
+	RoleSubscriber = "subscriber"
+)
+
+// Permissions granted through roles
+const (
+	PermissionUsersManage           = "users:manage"
+	PermissionRoutingManage         = "routing:manage"
+	PermissionWhatsAppManage        = "whatsapp:manage"
+	PermissionConversationsRead     = "conversations:read"
+	PermissionConversationsTakeover = "conversations:takeover"
+	PermissionSignalsReceive        = "signals:receive"
+	PermissionSignalsManage         = "signals:manage"
+	PermissionKnowledgeRead         = "knowledge:read"
+	PermissionKnowledgeManage       = "knowledge:manage"
+)
+
+// Authentication methods of a Principal
+const (
+	AuthMethodAPIKey = "api_key"
+	AuthMethodJWT    = "jwt"
+	// AuthMethodSession is a JWT held in the operator session cookie
+	AuthMethodSession = "session"
+)
+
+// Role is a named set of permissions
+type Role struct {
+	Name        string   `json:"name" db:"name"`
+	Description string   `json:"description" db:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+// SetUserRolesRequest replaces the roles of a user
+type SetUserRolesRequest struct {
+	Roles []string `json:"roles" binding:"required"`
+}
+
+// APIKey identifies a key without revealing it; only its SHA-256 is stored
+type APIKey struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	UserID     uuid.UUID  `json:"user_id" db:"user_id"`
+	Name       string     `json:"name" db:"name"`
+	Prefix     string     `json:"prefix" db:"prefix"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+// CreateAPIKeyRequest is the body of POST /api/v1/admin/users/:id/api-keys
+type CreateAPIKeyRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=100"`
+}
+
+// CreatedAPIKey is returned once, when the key is created; Key cannot be retrieved later
+type CreatedAPIKey struct {
+	*APIKey
+	Key string `json:"key"`
+}
+
+// AuthToken is a signed JWT for the caller
+type AuthToken struct {
+	Token     string    `json:"token"`
+	TokenType string    `json:"token_type"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Principal is the authenticated caller of an API request. UserID is nil for
+// the bootstrap ADMIN_API_KEY.
+type Principal struct {
+	UserID      *uuid.UUID `json:"user_id,omitempty"`
+	Name        string     `json:"name"`
+	Roles       []string   `json:"roles"`
+	Permissions []string   `json:"permissions"`
+	Method      string     `json:"method"`
+}
+
+// HasPermission reports whether the principal's roles grant permission
+func (p *Principal) HasPermission(permission string) bool {
+	for _, granted := range p.Permissions {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Message directions
+const (
+	MessageDirectionInbound  = "inbound"
+	MessageDirectionOutbound = "outbound"
+)
+
+// Message statuses
+const (
+	MessageStatusReceived = "received"
+	MessageStatusRejected = "rejected"
+	MessageStatusRouted   = "routed"
+	MessageStatusSent     = "sent"
+	MessageStatusFailed   = "failed"
+)
+
+// Message represents a stored inbound or outbound WhatsApp message
+type Message struct {
+	ID                uuid.UUID  `json:"id" db:"id"`
+	Phone             string     `json:"phone" db:"phone"`
+	Direction         string     `json:"direction" db:"direction"`
+	WhatsAppMessageID string     `json:"whatsapp_message_id,omitempty" db:"whatsapp_message_id"`
+	CorrelationID     string     `json:"correlation_id,omitempty" db:"correlation_id"`
+	SessionID         *uuid.UUID `json:"session_id,omitempty" db:"session_id"`
+	WorkflowType      string     `json:"workflow_type,omitempty" db:"workflow_type"`
+	Content           string     `json:"content" db:"content"`
+	Status            string     `json:"status" db:"status"`
+	Error             string     `json:"error,omitempty" db:"error"`
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// ConversationTakeover is a conversation answered by a support agent; inbound
+// messages are recorded but not routed to the workflow while it lasts
+type ConversationTakeover struct {
+	Phone       string     `json:"phone" db:"phone"`
+	AgentUserID *uuid.UUID `json:"agent_user_id,omitempty" db:"agent_user_id"`
+	AgentName   string     `json:"agent_name" db:"agent_name"`
+	StartedAt   time.Time  `json:"started_at" db:"started_at"`
+}
+
+// AgentMessageRequest is a message an agent sends in a conversation they took over
+type AgentMessageRequest struct {
+	Message string `json:"message" binding:"required"`
+}
+
+// MessagePage represents a paginated conversation transcript
+type MessagePage struct {
+	Phone    string     `json:"phone"`
+	Messages []*Message `json:"messages"`
+	Total    int        `json:"total"`
+	Page     int        `json:"page"`
+	PageSize int        `json:"page_size"`
+}
+
+// Pending request statuses
+const (
+	PendingStatusPending   = "pending"
+	PendingStatusCompleted = "completed"
+	PendingStatusFailed    = "failed"
+	PendingStatusTimedOut  = "timed_out"
+)
+
+// PendingRequest represents a workflow request awaiting its callback
+type PendingRequest struct {
+	MessageID      string     `json:"message_id" db:"message_id"`
+	Phone          string     `json:"phone" db:"phone"`
+	WorkflowType   string     `json:"workflow_type" db:"workflow_type"`
+	Status         string     `json:"status" db:"status"`
+	Error          string     `json:"error,omitempty" db:"error"`
+	SentAt         time.Time  `json:"sent_at" db:"sent_at"`
+	DeadlineAt     time.Time  `json:"deadline_at" db:"deadline_at"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	SoftNotifiedAt *time.Time `json:"soft_notified_at,omitempty" db:"soft_notified_at"`
+	TimedOutAt     *time.Time `json:"timed_out_at,omitempty" db:"timed_out_at"`
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultEmbeddingSpace is stored in simple_knowledge_vectors.embedding
+const DefaultEmbeddingSpace = "default"
+
+// Vector index types for embedding spaces
+const (
+	EmbeddingIndexHNSW    = "hnsw"
+	EmbeddingIndexIVFFlat = "ivfflat"
+	EmbeddingIndexNone    = "none"
+)
+
+// EmbeddingSpace represents a row of embedding_spaces
+type EmbeddingSpace struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	Model     string    `json:"model" db:"model"`
+	Dimension int       `json:"dimension" db:"dimension"`
+	IndexType string    `json:"index_type" db:"index_type"`
+	BaseURL   string    `json:"base_url,omitempty" db:"base_url"`
+	IsActive  bool      `json:"is_active" db:"is_active"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// IsDefault reports whether the space uses the legacy embedding column
+func (s *EmbeddingSpace) IsDefault() bool {
+	return s.Name == DefaultEmbeddingSpace
+}
+
+// CreateEmbeddingSpaceRequest is the body of POST /api/v1/knowledge/spaces
+type CreateEmbeddingSpaceRequest struct {
+	Name      string `json:"name" binding:"required"`
+	Model     string `json:"model" binding:"required"`
+	Dimension int    `json:"dimension" binding:"required"`
+	IndexType string `json:"index_type"`
+	BaseURL   string `json:"base_url"`
+}
+
+// Embedding job statuses
+const (
+	EmbeddingJobPending   = "pending"
+	EmbeddingJobRunning   = "running"
+	EmbeddingJobCompleted = "completed"
+	EmbeddingJobFailed    = "failed"
+	EmbeddingJobCancelled = "cancelled"
+)
+
+// EmbeddingJob represents a row of embedding_jobs, re-embedding knowledge
+// chunks into a target space. Without a source space every chunk is embedded.
+type EmbeddingJob struct {
+	ID               uuid.UUID  `json:"id" db:"id"`
+	SourceSpaceID    *uuid.UUID `json:"source_space_id,omitempty" db:"source_space_id"`
+	TargetSpaceID    uuid.UUID  `json:"target_space_id" db:"target_space_id"`
+	ActivateOnFinish bool       `json:"activate_on_finish" db:"activate_on_finish"`
+	Status           string     `json:"status" db:"status"`
+	Total            int        `json:"total" db:"total"`
+	Processed        int        `json:"processed" db:"processed"`
+	Error            string     `json:"error,omitempty" db:"error"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	StartedAt        *time.Time `json:"started_at,omitempty" db:"started_at"`
+	FinishedAt       *time.Time `json:"finished_at,omitempty" db:"finished_at"`
+}
+
+// Progress returns the completed fraction of the job between 0 and 1
+func (j *EmbeddingJob) Progress() float64 {
+	if j.Total == 0 {
+		if j.Status == EmbeddingJobCompleted {
+			return 1
+		}
+		return 0
+	}
+	return min(float64(j.Processed)/float64(j.Total), 1)
+}
+
+// ReembedRequest is the body of POST /api/v1/knowledge/spaces/:name/reembed
+type ReembedRequest struct {
+	SourceSpace string `json:"source_space"`
+	Activate    bool   `json:"activate"`
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// KnowledgeChunk represents a row of simple_knowledge_vectors returned by retrieval.
+// Score is the ranking score of the search that produced it: cosine similarity,
+// full-text rank, reciprocal rank fusion or reranker relevance. VectorScore and
+// KeywordScore keep the per-method scores of hybrid results.
+type KnowledgeChunk struct {
+	ID           uuid.UUID              `json:"id" db:"id"`
+	Content      string                 `json:"content" db:"content"`
+	Metadata     map[string]interface{} `json:"metadata" db:"metadata"`
+	Score        float64                `json:"score"`
+	VectorScore  float64                `json:"vector_score,omitempty"`
+	KeywordScore float64                `json:"keyword_score,omitempty"`
+	CreatedAt    time.Time              `json:"created_at" db:"created_at"`
+}
+
+// Knowledge search modes
+const (
+	KnowledgeSearchHybrid  = "hybrid"
+	KnowledgeSearchVector  = "vector"
+	KnowledgeSearchKeyword = "keyword"
+)
+
+// Metadata keys written by knowledge base ingestion
+const (
+	KnowledgeMetaSource      = "source"
+	KnowledgeMetaSection     = "section"
+	KnowledgeMetaChunkIndex  = "chunk_index"
+	KnowledgeMetaContentHash = "content_hash"
+)
+
+// IngestResult summarises the changes made (or planned, in a dry run) for one source
+type IngestResult struct {
+	Source    string `json:"source"`
+	Chunks    int    `json:"chunks"`
+	Inserted  int    `json:"inserted"`
+	Updated   int    `json:"updated"`
+	Unchanged int    `json:"unchanged"`
+	Deleted   int    `json:"deleted"`
+	DryRun    bool   `json:"dry_run"`
+}
+
+// KnowledgeSearchRequest is the body of POST /api/v1/knowledge/search.
+// Filters must all match the chunk metadata (JSONB containment). Threshold
+// applies to vector similarity only; keyword matches are not thresholded.
+type KnowledgeSearchRequest struct {
+	Query     string                 `json:"query" binding:"required"`
+	Mode      string                 `json:"mode"`
+	Space     string                 `json:"space"`
+	TopK      int                    `json:"top_k"`
+	Threshold *float64               `json:"threshold"`
+	Filters   map[string]interface{} `json:"filters"`
+}
+
+// KnowledgeSearchResult lists the ranked chunks for a query with the parameters applied
+type KnowledgeSearchResult struct {
+	Query     string            `json:"query"`
+	Mode      string            `json:"mode"`
+	Reranked  bool              `json:"reranked"`
+	TopK      int               `json:"top_k"`
+	Threshold float64           `json:"threshold"`
+	Results   []*KnowledgeChunk `json:"results"`
+}
+
+// KnowledgeDocument groups the chunks stored for one metadata source. UpdatedAt
+// is when the newest chunk was ingested.
+type KnowledgeDocument struct {
+	Source     string            `json:"source"`
+	ChunkCount int               `json:"chunk_count"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+	Chunks     []*KnowledgeChunk `json:"chunks,omitempty"`
+}
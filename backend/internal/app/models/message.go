@@ -14,11 +14,20 @@ type UserContext struct {
 	Email  string    `json:"email"`
 }
 
+// WorkflowRequest represents an inbound message routed to an AI workflow
+type WorkflowRequest struct {
+	MessageID   string
+	SessionID   string
+	UserContext *UserContext
+	Message     string
+}
+
 // N8NRequest represents the payload sent to N8N workflow
 type N8NRequest struct {
 	UserContext *UserContext `json:"user_context"`
 	Message     string       `json:"message"`
 	MessageID   string       `json:"message_id"`
+	SessionID   string       `json:"session_id"`
 	Timestamp   time.Time    `json:"timestamp"`
 }
 
@@ -39,8 +48,20 @@ type HealthStatus struct {
 	Server    string    `json:"server"`
 }
 
-// Signal represents a stock trading signal received from N8N
+// Signal operations. A signal is identified by its ticker, last date and
+// direction; posting a new signal again has no effect, while update and cancel
+// change the signal and notify everyone who received it.
+const (
+	SignalOperationNew    = "new"
+	SignalOperationUpdate = "update"
+	SignalOperationCancel = "cancel"
+)
+
+// Signal represents a stock trading signal received from N8N. Operation
+// defaults to new; Note explains an update or cancellation.
 type Signal struct {
+	Operation        string  `json:"operation" binding:"omitempty,oneof=new update cancel"`
+	Note             string  `json:"note" binding:"max=500"`
 	Ticker           string  `json:"ticker" binding:"required,min=1,max=10"`
 	LastDate         string  `json:"last_date" binding:"required"`
 	LastClose        int     `json:"last_close"`
@@ -59,12 +80,18 @@ type Signal struct {
 	AnalysisSummary  string  `json:"analysis_summary"`
 }
 
-// SignalResponse represents the response when processing a signal
+// SignalResponse represents the response when a signal is accepted. Delivery
+// happens in the background; follow it through the broadcast ID. A duplicate
+// post returns the broadcast of the earlier one.
 type SignalResponse struct {
-	Ticker           string    `json:"ticker"`
-	UsersNotified    int       `json:"users_notified"`
-	Timestamp        time.Time `json:"timestamp"`
-	ProcessingTimeMs int64     `json:"processing_time_ms"`
+	SignalID    uuid.UUID `json:"signal_id"`
+	BroadcastID uuid.UUID `json:"broadcast_id"`
+	Operation   string    `json:"operation"`
+	Duplicate   bool      `json:"duplicate"`
+	Ticker      string    `json:"ticker"`
+	Recipients  int       `json:"recipients"`
+	Status      string    `json:"status"`
+	Timestamp   time.Time `json:"timestamp"`
 }
 
 // WorkflowConfig represents global workflow routing configuration
@@ -75,6 +102,11 @@ type WorkflowConfig struct {
 	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
 }
 
+// SetWorkflowTypeRequest switches the workflow inbound messages are routed to
+type SetWorkflowTypeRequest struct {
+	WorkflowType string `json:"workflow_type" binding:"required"`
+}
+
 // FlowiseRequest represents the payload sent to Flowise prediction endpoint
 type FlowiseRequest struct {
 	Question       string                 `json:"question"`
@@ -104,3 +136,42 @@ type APIResponse struct {
 	Data    interface{} `json:"data,omitempty"`
 	Error   string      `json:"error,omitempty"`
 }
+
+// ChatMessage is a single message in an OpenAI-compatible chat completion
+type ChatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// ChatCompletionRequest represents the payload sent to /v1/chat/completions
+type ChatCompletionRequest struct {
+	Model       string        `json:"model"`
+	Messages    []ChatMessage `json:"messages"`
+	Temperature float64       `json:"temperature"`
+	MaxTokens   int           `json:"max_tokens,omitempty"`
+	TopP        float64       `json:"top_p,omitempty"`
+	User        string        `json:"user,omitempty"`
+}
+
+// ChatCompletionResponse represents the response received from /v1/chat/completions
+type ChatCompletionResponse struct {
+	ID      string `json:"id"`
+	Choices []struct {
+		Message      ChatMessage `json:"message"`
+		FinishReason string      `json:"finish_reason"`
+	} `json:"choices"`
+}
+
+// EmbeddingRequest represents the payload sent to /v1/embeddings
+type EmbeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+// EmbeddingResponse represents the response received from /v1/embeddings
+type EmbeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Signal validation rules
+const (
+	// SignalRuleFields checks the binding tags of Signal
+	SignalRuleFields = "fields"
+	// SignalRuleLevels checks that the stop and target sit on either side of
+	// the entry, in the direction of the sentiment
+	SignalRuleLevels = "levels"
+	// SignalRuleRiskReward checks risk_reward against the levels
+	SignalRuleRiskReward = "risk_reward"
+	// SignalRuleFreshness checks the age of last_date
+	SignalRuleFreshness = "freshness"
+	// SignalRuleTicker checks the ticker against the allowed codes
+	SignalRuleTicker = "ticker"
+	// SignalRuleThresholds checks the minimum risk/reward, confluence score,
+	// backtest win rate and trade count
+	SignalRuleThresholds = "thresholds"
+)
+
+// SignalViolation is one failed check of a signal; Field is its JSON name
+type SignalViolation struct {
+	Rule    string `json:"rule"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// RejectedSignal represents a row of rejected_signals, a signal that failed
+// validation, kept with its violations for review
+type RejectedSignal struct {
+	ID         uuid.UUID         `json:"id" db:"id"`
+	Ticker     string            `json:"ticker" db:"ticker"`
+	LastDate   string            `json:"last_date" db:"last_date"`
+	Operation  string            `json:"operation" db:"operation"`
+	Payload    *Signal           `json:"payload" db:"payload"`
+	Violations []SignalViolation `json:"violations" db:"violations"`
+	CreatedAt  time.Time         `json:"created_at" db:"created_at"`
+}
+
+// RejectedSignalFilter narrows a rejected signal listing; empty fields match everything
+type RejectedSignalFilter struct {
+	Ticker string
+	Rule   string
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ConversationSession represents a conversation with a single phone, used as memory key by workflows
+type ConversationSession struct {
+	ID             uuid.UUID  `json:"id" db:"id"`
+	Phone          string     `json:"phone" db:"phone"`
+	StartedAt      time.Time  `json:"started_at" db:"started_at"`
+	LastActivityAt time.Time  `json:"last_activity_at" db:"last_activity_at"`
+	EndedAt        *time.Time `json:"ended_at,omitempty" db:"ended_at"`
+}
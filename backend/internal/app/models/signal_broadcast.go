@@ -0,0 +1,81 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Signal broadcast statuses
+const (
+	SignalBroadcastQueued    = "queued"
+	SignalBroadcastSending   = "sending"
+	SignalBroadcastCompleted = "completed"
+)
+
+// Signal broadcast kinds. Updates and cancellations are follow-ups of the
+// original signal broadcast.
+const (
+	SignalBroadcastSignal = "signal"
+	SignalBroadcastUpdate = "update"
+	SignalBroadcastCancel = "cancel"
+)
+
+// Signal delivery statuses. A delivery is sending while a worker has claimed it.
+const (
+	SignalDeliveryPending   = "pending"
+	SignalDeliverySending   = "sending"
+	SignalDeliverySent      = "sent"
+	SignalDeliveryFailed    = "failed"
+	SignalDeliveryCancelled = "cancelled"
+)
+
+// SignalBroadcast represents a row of signal_broadcasts, one signal message
+// fanned out to its recipients. Message is rendered in the default language;
+// each delivery holds the message in its recipient's language. The counts are
+// derived from its deliveries.
+// A follow-up has the original broadcast as parent and quotes its message.
+// Revision is the update time of the signal an update broadcast carries; a
+// signal has one broadcast per kind and revision.
+type SignalBroadcast struct {
+	ID            uuid.UUID  `json:"id" db:"id"`
+	SignalID      *uuid.UUID `json:"signal_id,omitempty" db:"signal_id"`
+	Kind          string     `json:"kind" db:"kind"`
+	ParentID      *uuid.UUID `json:"parent_id,omitempty" db:"parent_id"`
+	Ticker        string     `json:"ticker" db:"ticker"`
+	Message       string     `json:"message" db:"message"`
+	QuotedMessage string     `json:"-"`
+	Revision      *time.Time `json:"-" db:"revision"`
+	Status        string     `json:"status" db:"status"`
+	Total         int        `json:"total"`
+	Pending       int        `json:"pending"`
+	Sent          int        `json:"sent"`
+	Failed        int        `json:"failed"`
+	Cancelled     int        `json:"cancelled"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	StartedAt     *time.Time `json:"started_at,omitempty" db:"started_at"`
+	FinishedAt    *time.Time `json:"finished_at,omitempty" db:"finished_at"`
+}
+
+// SignalDelivery represents a row of signal_deliveries, the send of a
+// broadcast to one recipient. UserID is nil once the user has been deleted.
+// Message is rendered in Language, the recipient's preferred language; it is
+// empty for deliveries stored before per-recipient messages, which use the
+// broadcast message.
+// ReplyToMessageID and QuotedMessage are the recipient's copy of the original
+// signal message that a follow-up quotes.
+type SignalDelivery struct {
+	ID                uuid.UUID  `json:"id" db:"id"`
+	BroadcastID       uuid.UUID  `json:"broadcast_id" db:"broadcast_id"`
+	UserID            *uuid.UUID `json:"user_id" db:"user_id"`
+	Phone             string     `json:"phone" db:"phone"`
+	Status            string     `json:"status" db:"status"`
+	WhatsAppMessageID string     `json:"whatsapp_message_id,omitempty" db:"whatsapp_message_id"`
+	Language          string     `json:"language,omitempty" db:"language"`
+	Message           string     `json:"message,omitempty" db:"message"`
+	ReplyToMessageID  string     `json:"reply_to_message_id,omitempty" db:"reply_to_message_id"`
+	QuotedMessage     string     `json:"-" db:"quoted_message"`
+	Error             string     `json:"error,omitempty" db:"error"`
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+	AttemptedAt       *time.Time `json:"attempted_at,omitempty" db:"attempted_at"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SignalTemplate is the text/template body of one kind of signal message
+// (SignalBroadcastSignal, SignalBroadcastUpdate or SignalBroadcastCancel) in
+// one language. IsDefault marks the built-in template, used until an admin
+// saves a replacement in signal_templates.
+type SignalTemplate struct {
+	Kind      string     `json:"kind" db:"kind"`
+	Language  string     `json:"language" db:"language"`
+	Body      string     `json:"body" db:"body"`
+	IsDefault bool       `json:"is_default"`
+	UpdatedBy *uuid.UUID `json:"updated_by,omitempty" db:"updated_by"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// SaveSignalTemplateRequest is the body of PUT /api/v1/signals/templates/:kind/:language
+type SaveSignalTemplateRequest struct {
+	Body string `json:"body" binding:"required,max=20000"`
+}
+
+// SignalTemplatePreviewRequest renders a template without sending it. Body
+// previews a draft instead of the current template, and Signal replaces the
+// built-in sample signal.
+type SignalTemplatePreviewRequest struct {
+	Kind     string  `json:"kind" binding:"required,oneof=signal update cancel"`
+	Language string  `json:"language" binding:"required,oneof=id en"`
+	Body     string  `json:"body" binding:"max=20000"`
+	Signal   *Signal `json:"signal" binding:"-"`
+}
+
+// SignalTemplatePreview is a rendered template
+type SignalTemplatePreview struct {
+	Kind     string `json:"kind"`
+	Language string `json:"language"`
+	Message  string `json:"message"`
+}
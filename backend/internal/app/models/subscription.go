@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SignalSubscription selects the signals a user receives. Nil filters match
+// every signal; a nil Ticker subscribes to all tickers but ExcludedTickers.
+type SignalSubscription struct {
+	ID                 uuid.UUID `json:"id" db:"id"`
+	UserID             uuid.UUID `json:"user_id" db:"user_id"`
+	Ticker             *string   `json:"ticker" db:"ticker"`
+	Sentiment          *string   `json:"sentiment" db:"sentiment"`
+	MinConfluenceScore *float64  `json:"min_confluence_score" db:"min_confluence_score"`
+	MinBacktestWinRate *float64  `json:"min_backtest_win_rate" db:"min_backtest_win_rate"`
+	ExcludedTickers    []string  `json:"excluded_tickers,omitempty" db:"excluded_tickers"`
+	CreatedAt          time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// SubscribeRequest creates or replaces the subscription of a user for Ticker.
+// A Ticker of "all" subscribes to every ticker.
+type SubscribeRequest struct {
+	Ticker             string   `json:"ticker" binding:"required,max=10"`
+	Sentiment          string   `json:"sentiment" binding:"omitempty,max=20"`
+	MinConfluenceScore *float64 `json:"min_confluence_score" binding:"omitempty,gte=0"`
+	MinBacktestWinRate *float64 `json:"min_backtest_win_rate" binding:"omitempty,gte=0,lte=100"`
+}
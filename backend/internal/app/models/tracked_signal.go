@@ -0,0 +1,109 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SignalDateLayout is the format of signal and price dates
+const SignalDateLayout = "2006-01-02"
+
+// Signal directions, derived from the target relative to the entry
+const (
+	SignalDirectionLong  = "long"
+	SignalDirectionShort = "short"
+)
+
+// Signal outcomes
+const (
+	SignalOutcomeOpen       = "open"
+	SignalOutcomeTargetHit  = "target_hit"
+	SignalOutcomeStoppedOut = "stopped_out"
+	SignalOutcomeExpired    = "expired"
+	SignalOutcomeCancelled  = "cancelled"
+)
+
+// Signal statistics groupings
+const (
+	SignalStatsByTicker     = "ticker"
+	SignalStatsByConfluence = "confluence"
+)
+
+// TrackedSignal represents a row of tracked_signals, a received signal and its
+// outcome. RMultiple is the result in units of the initial risk (entry to stop).
+// A cancelled signal is never resolved and is left out of the stats.
+type TrackedSignal struct {
+	ID               uuid.UUID  `json:"id" db:"id"`
+	Ticker           string     `json:"ticker" db:"ticker"`
+	LastDate         string     `json:"last_date" db:"last_date"`
+	Direction        string     `json:"direction" db:"direction"`
+	EntryPrice       float64    `json:"entry_price" db:"entry_price"`
+	Stop             float64    `json:"stop" db:"stop"`
+	Target           float64    `json:"target" db:"target"`
+	RiskReward       float64    `json:"risk_reward" db:"risk_reward"`
+	BacktestWinRate  float64    `json:"backtest_win_rate" db:"backtest_win_rate"`
+	TotalTrades      int        `json:"total_trades" db:"total_trades"`
+	ConfluenceScore  float64    `json:"confluence_score" db:"confluence_score"`
+	OverallSentiment string     `json:"overall_sentiment" db:"overall_sentiment"`
+	Outcome          string     `json:"outcome" db:"outcome"`
+	ExitPrice        *float64   `json:"exit_price,omitempty" db:"exit_price"`
+	RMultiple        *float64   `json:"r_multiple,omitempty" db:"r_multiple"`
+	ExpiresOn        string     `json:"expires_on" db:"expires_on"`
+	ResolvedOn       *string    `json:"resolved_on,omitempty" db:"resolved_on"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// TrackedSignalFilter narrows a tracked signal listing; empty fields match everything
+type TrackedSignalFilter struct {
+	Ticker  string
+	Outcome string
+}
+
+// SignalPrice is a daily price bar of a ticker. Without High and Low the
+// close is used for both.
+type SignalPrice struct {
+	Ticker string  `json:"ticker" binding:"required,max=10"`
+	Date   string  `json:"date" binding:"required"`
+	High   float64 `json:"high" binding:"gte=0"`
+	Low    float64 `json:"low" binding:"gte=0"`
+	Close  float64 `json:"close" binding:"required,gt=0"`
+}
+
+// SignalPriceRequest is the body of POST /api/v1/webhook/n8n/prices
+type SignalPriceRequest struct {
+	Prices []*SignalPrice `json:"prices" binding:"required,min=1,dive"`
+}
+
+// SignalPriceReport summarises a batch of price updates
+type SignalPriceReport struct {
+	Prices     int      `json:"prices"`
+	Rejected   int      `json:"rejected"`
+	Resolved   int      `json:"resolved"`
+	TargetHit  int      `json:"target_hit"`
+	StoppedOut int      `json:"stopped_out"`
+	Expired    int      `json:"expired"`
+	Errors     []string `json:"errors,omitempty"`
+}
+
+// SignalStats compares the realised results of a group of signals with the
+// backtest win rate they claimed. WinRate counts target hits among resolved signals.
+type SignalStats struct {
+	Group              string   `json:"group"`
+	Signals            int      `json:"signals"`
+	Open               int      `json:"open"`
+	TargetHit          int      `json:"target_hit"`
+	StoppedOut         int      `json:"stopped_out"`
+	Expired            int      `json:"expired"`
+	WinRate            *float64 `json:"win_rate"`
+	AvgRMultiple       *float64 `json:"avg_r_multiple"`
+	AvgBacktestWinRate float64  `json:"avg_backtest_win_rate"`
+}
+
+// SignalStatsReport is the overall result followed by one row per group
+type SignalStatsReport struct {
+	GroupBy string         `json:"group_by"`
+	Overall *SignalStats   `json:"overall"`
+	Groups  []*SignalStats `json:"groups"`
+}
@@ -12,18 +12,105 @@ type User struct {
 	Phone     string    `json:"phone" db:"phone"`
 	Email     string    `json:"email" db:"email"`
 	IsActive  bool      `json:"is_active" db:"is_active"`
+	Language  string    `json:"language" db:"language"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
 }
 
+// CreateUserRequest creates a user. Language is the preferred language of
+// signal messages (id or en) and defaults to id.
 type CreateUserRequest struct {
-	Name  string `json:"name" binding:"required,min=2,max=100"`
-	Phone string `json:"phone" binding:"required,min=10,max=20"`
-	Email string `json:"email" binding:"required,email,max=100"`
+	Name     string `json:"name" binding:"required,min=2,max=100"`
+	Phone    string `json:"phone" binding:"required,min=10,max=20"`
+	Email    string `json:"email" binding:"required,email,max=100"`
+	Language string `json:"language,omitempty" binding:"omitempty,oneof=id en"`
 }
 
 type UpdateUserRequest struct {
 	Name     string `json:"name,omitempty" binding:"omitempty,min=2,max=100"`
+	Phone    string `json:"phone,omitempty" binding:"omitempty,min=10,max=20"`
 	Email    string `json:"email,omitempty" binding:"omitempty,email,max=100"`
+	Language string `json:"language,omitempty" binding:"omitempty,oneof=id en"`
 	IsActive *bool  `json:"is_active,omitempty"`
-}
\ No newline at end of file
+}
+
+// UserFilter narrows the admin user list. Search matches name, phone or email.
+type UserFilter struct {
+	Search   string
+	IsActive *bool
+}
+
+// UserPage represents a paginated user list
+type UserPage struct {
+	Users    []*User `json:"users"`
+	Total    int     `json:"total"`
+	Page     int     `json:"page"`
+	PageSize int     `json:"page_size"`
+}
+
+// Per-row outcomes of a bulk user import
+const (
+	UserImportCreated = "created"
+	UserImportUpdated = "updated"
+	UserImportValid   = "valid" // dry run: the row passed validation
+	UserImportFailed  = "failed"
+)
+
+// UserImportRow reports the outcome of one imported row. Row is 1-based and
+// counts data rows only, so a CSV header is not row 1.
+type UserImportRow struct {
+	Row    int        `json:"row"`
+	Phone  string     `json:"phone"`
+	Email  string     `json:"email"`
+	Status string     `json:"status"`
+	UserID *uuid.UUID `json:"user_id,omitempty"`
+	Error  string     `json:"error,omitempty"`
+}
+
+// UserImportReport summarises a bulk user import
+type UserImportReport struct {
+	Total   int              `json:"total"`
+	Created int              `json:"created"`
+	Updated int              `json:"updated"`
+	Failed  int              `json:"failed"`
+	DryRun  bool             `json:"dry_run"`
+	Rows    []*UserImportRow `json:"rows"`
+}
+
+// Access policy modes for inbound WhatsApp messages
+const (
+	AccessModeOpen       = "open"
+	AccessModeRegistered = "registered"
+	AccessModeAllowlist  = "allowlist"
+)
+
+// AccessPolicy represents global access control for inbound messages
+type AccessPolicy struct {
+	ID                 int       `json:"id" db:"id"`
+	Mode               string    `json:"mode" db:"mode"`
+	NotifyUnregistered bool      `json:"notify_unregistered" db:"notify_unregistered"`
+	UpdatedAt          time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// UpdateAccessPolicyRequest changes the access policy; an omitted
+// notify_unregistered keeps the current setting
+type UpdateAccessPolicyRequest struct {
+	Mode               string `json:"mode" binding:"required"`
+	NotifyUnregistered *bool  `json:"notify_unregistered"`
+}
+
+// AllowlistEntry represents a phone that is auto-enrolled on first contact
+type AllowlistEntry struct {
+	Phone     string    `json:"phone" db:"phone"`
+	Name      string    `json:"name" db:"name"`
+	Email     string    `json:"email" db:"email"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// AccessDecision represents the outcome of an access policy check
+type AccessDecision struct {
+	Allowed            bool
+	User               *User
+	NotifyUnregistered bool
+	Reason             string
+}
@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/pkg/phonenumber"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AccessPolicyRepository interface {
+	GetActivePolicy(ctx context.Context) (*models.AccessPolicy, error)
+	GetAllowlistEntry(ctx context.Context, phone string) (*models.AllowlistEntry, error)
+	// UpdatePolicy sets the mode and, when notifyUnregistered is not nil, whether
+	// rejected senders are told they are not registered
+	UpdatePolicy(ctx context.Context, mode string, notifyUnregistered *bool) (*models.AccessPolicy, error)
+}
+
+type accessPolicyRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAccessPolicyRepository(db *pgxpool.Pool) AccessPolicyRepository {
+	return &accessPolicyRepository{db: db}
+}
+
+func (r *accessPolicyRepository) GetActivePolicy(ctx context.Context) (*models.AccessPolicy, error) {
+	query := `
+		SELECT id, mode, notify_unregistered, updated_at
+		FROM access_policy
+		WHERE id = 1
+	`
+
+	var policy models.AccessPolicy
+	err := r.db.QueryRow(ctx, query).Scan(
+		&policy.ID, &policy.Mode, &policy.NotifyUnregistered, &policy.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("no access policy found")
+		}
+		return nil, fmt.Errorf("failed to get access policy: %w", err)
+	}
+
+	return &policy, nil
+}
+
+func (r *accessPolicyRepository) UpdatePolicy(ctx context.Context, mode string, notifyUnregistered *bool) (*models.AccessPolicy, error) {
+	query := `
+		UPDATE access_policy
+		SET mode = $1, notify_unregistered = COALESCE($2, notify_unregistered), updated_at = CURRENT_TIMESTAMP
+		WHERE id = 1
+		RETURNING id, mode, notify_unregistered, updated_at
+	`
+
+	var policy models.AccessPolicy
+	err := r.db.QueryRow(ctx, query, mode, notifyUnregistered).Scan(
+		&policy.ID, &policy.Mode, &policy.NotifyUnregistered, &policy.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("no access policy found")
+		}
+		return nil, fmt.Errorf("failed to update access policy: %w", err)
+	}
+
+	return &policy, nil
+}
+
+// GetAllowlistEntry returns nil without error when the phone is not allowlisted
+func (r *accessPolicyRepository) GetAllowlistEntry(ctx context.Context, phone string) (*models.AllowlistEntry, error) {
+	phone, err := phonenumber.Normalize(phone)
+	if err != nil {
+		return nil, nil // An invalid phone is never allowlisted
+	}
+
+	query := `
+		SELECT phone, name, email, created_at
+		FROM phone_allowlist
+		WHERE phone = $1
+	`
+
+	var entry models.AllowlistEntry
+	err = r.db.QueryRow(ctx, query, phone).Scan(
+		&entry.Phone, &entry.Name, &entry.Email, &entry.CreatedAt,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil // Not allowlisted
+		}
+		return nil, fmt.Errorf("failed to get allowlist entry: %w", err)
+	}
+
+	return &entry, nil
+}
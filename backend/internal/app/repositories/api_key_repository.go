@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *models.APIKey, keyHash string) error
+	// GetActiveByHash returns the unrevoked key with keyHash whose user is active,
+	// or nil without error when there is none
+	GetActiveByHash(ctx context.Context, keyHash string) (*models.APIKey, *models.User, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.APIKey, error)
+	// Revoke returns false when no unrevoked key has id
+	Revoke(ctx context.Context, id uuid.UUID) (bool, error)
+	TouchLastUsed(ctx context.Context, id uuid.UUID) error
+}
+
+type apiKeyRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAPIKeyRepository(db *pgxpool.Pool) APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+const apiKeyColumns = `k.id, k.user_id, k.name, k.prefix, k.last_used_at, k.revoked_at, k.created_at`
+
+func scanAPIKey(row pgx.Row, extra ...interface{}) (*models.APIKey, error) {
+	var key models.APIKey
+	dest := append([]interface{}{
+		&key.ID, &key.UserID, &key.Name, &key.Prefix, &key.LastUsedAt, &key.RevokedAt, &key.CreatedAt,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *models.APIKey, keyHash string) error {
+	query := `
+		INSERT INTO api_keys (user_id, name, prefix, key_hash)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query, key.UserID, key.Name, key.Prefix, keyHash).Scan(&key.ID, &key.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	return nil
+}
+
+func (r *apiKeyRepository) GetActiveByHash(ctx context.Context, keyHash string) (*models.APIKey, *models.User, error) {
+	query := `
+		SELECT ` + apiKeyColumns + `,
+			u.id, u.name, u.phone, u.email, u.is_active, u.language, u.created_at, u.updated_at
+		FROM api_keys k
+		JOIN users u ON u.id = k.user_id
+		WHERE k.key_hash = $1 AND k.revoked_at IS NULL AND u.is_active = true
+	`
+
+	var user models.User
+	key, err := scanAPIKey(r.db.QueryRow(ctx, query, keyHash),
+		&user.ID, &user.Name, &user.Phone, &user.Email, &user.IsActive, &user.Language, &user.CreatedAt, &user.UpdatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	return key, &user, nil
+}
+
+func (r *apiKeyRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys k WHERE k.user_id = $1 ORDER BY k.created_at DESC`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []*models.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan API key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over API keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, id uuid.UUID) (bool, error) {
+	result, err := r.db.Exec(ctx, `UPDATE api_keys SET revoked_at = CURRENT_TIMESTAMP WHERE id = $1 AND revoked_at IS NULL`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.Exec(ctx, `UPDATE api_keys SET last_used_at = CURRENT_TIMESTAMP WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to record API key use: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AuditRepository interface {
+	Create(ctx context.Context, entry *models.AuditEntry) error
+	// List returns the most recent entries, optionally only those with action
+	List(ctx context.Context, action string, limit int) ([]*models.AuditEntry, error)
+}
+
+type auditRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAuditRepository(db *pgxpool.Pool) AuditRepository {
+	return &auditRepository{db: db}
+}
+
+func (r *auditRepository) Create(ctx context.Context, entry *models.AuditEntry) error {
+	query := `
+		INSERT INTO audit_log (action, actor, actor_user_id, remote_addr, details)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5)
+		RETURNING id, created_at
+	`
+
+	details := entry.Details
+	if details == nil {
+		details = map[string]string{}
+	}
+
+	err := r.db.QueryRow(ctx, query, entry.Action, entry.Actor, entry.ActorUserID, entry.RemoteAddr, details).
+		Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create audit entry: %w", err)
+	}
+
+	return nil
+}
+
+func (r *auditRepository) List(ctx context.Context, action string, limit int) ([]*models.AuditEntry, error) {
+	query := `
+		SELECT id, action, actor, actor_user_id, COALESCE(remote_addr, ''), details, created_at
+		FROM audit_log
+		WHERE $1 = '' OR action = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, action, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*models.AuditEntry{}
+	for rows.Next() {
+		var entry models.AuditEntry
+		err := rows.Scan(&entry.ID, &entry.Action, &entry.Actor, &entry.ActorUserID, &entry.RemoteAddr, &entry.Details, &entry.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over audit entries: %w", err)
+	}
+
+	return entries, nil
+}
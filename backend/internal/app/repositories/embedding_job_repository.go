@@ -0,0 +1,167 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type EmbeddingJobRepository interface {
+	Create(ctx context.Context, job *models.EmbeddingJob) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.EmbeddingJob, error)
+	List(ctx context.Context, limit int) ([]*models.EmbeddingJob, error)
+	HasUnfinished(ctx context.Context, targetSpaceID uuid.UUID) (bool, error)
+	Start(ctx context.Context, id uuid.UUID, total int) error
+	UpdateProgress(ctx context.Context, id uuid.UUID, processed int) error
+	Finish(ctx context.Context, id uuid.UUID, status, errMsg string) error
+	FailUnfinished(ctx context.Context, errMsg string) (int64, error)
+}
+
+type embeddingJobRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewEmbeddingJobRepository(db *pgxpool.Pool) EmbeddingJobRepository {
+	return &embeddingJobRepository{db: db}
+}
+
+const embeddingJobColumns = `id, source_space_id, target_space_id, activate_on_finish, status, total, processed, COALESCE(error, ''), created_at, started_at, finished_at`
+
+func scanEmbeddingJob(row pgx.Row) (*models.EmbeddingJob, error) {
+	var job models.EmbeddingJob
+	err := row.Scan(
+		&job.ID, &job.SourceSpaceID, &job.TargetSpaceID, &job.ActivateOnFinish, &job.Status,
+		&job.Total, &job.Processed, &job.Error, &job.CreatedAt, &job.StartedAt, &job.FinishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *embeddingJobRepository) Create(ctx context.Context, job *models.EmbeddingJob) error {
+	query := `
+		INSERT INTO embedding_jobs (source_space_id, target_space_id, activate_on_finish, status)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query, job.SourceSpaceID, job.TargetSpaceID, job.ActivateOnFinish, job.Status).
+		Scan(&job.ID, &job.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create embedding job: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID returns nil without error when the job does not exist
+func (r *embeddingJobRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.EmbeddingJob, error) {
+	job, err := scanEmbeddingJob(r.db.QueryRow(ctx, `SELECT `+embeddingJobColumns+` FROM embedding_jobs WHERE id = $1`, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get embedding job: %w", err)
+	}
+
+	return job, nil
+}
+
+// List returns the most recent jobs first
+func (r *embeddingJobRepository) List(ctx context.Context, limit int) ([]*models.EmbeddingJob, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+embeddingJobColumns+` FROM embedding_jobs ORDER BY created_at DESC LIMIT $1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list embedding jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []*models.EmbeddingJob{}
+	for rows.Next() {
+		job, err := scanEmbeddingJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan embedding job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over embedding jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// HasUnfinished reports whether a pending or running job targets the space
+func (r *embeddingJobRepository) HasUnfinished(ctx context.Context, targetSpaceID uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM embedding_jobs
+			WHERE target_space_id = $1 AND status IN ('pending', 'running')
+		)
+	`
+
+	var exists bool
+	if err := r.db.QueryRow(ctx, query, targetSpaceID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check embedding jobs: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *embeddingJobRepository) Start(ctx context.Context, id uuid.UUID, total int) error {
+	query := `
+		UPDATE embedding_jobs
+		SET status = 'running', total = $1, processed = 0, started_at = CURRENT_TIMESTAMP
+		WHERE id = $2
+	`
+
+	if _, err := r.db.Exec(ctx, query, total, id); err != nil {
+		return fmt.Errorf("failed to start embedding job: %w", err)
+	}
+
+	return nil
+}
+
+func (r *embeddingJobRepository) UpdateProgress(ctx context.Context, id uuid.UUID, processed int) error {
+	if _, err := r.db.Exec(ctx, `UPDATE embedding_jobs SET processed = $1 WHERE id = $2`, processed, id); err != nil {
+		return fmt.Errorf("failed to update embedding job progress: %w", err)
+	}
+
+	return nil
+}
+
+func (r *embeddingJobRepository) Finish(ctx context.Context, id uuid.UUID, status, errMsg string) error {
+	query := `
+		UPDATE embedding_jobs
+		SET status = $1, error = NULLIF($2, ''), finished_at = CURRENT_TIMESTAMP
+		WHERE id = $3
+	`
+
+	if _, err := r.db.Exec(ctx, query, status, errMsg, id); err != nil {
+		return fmt.Errorf("failed to finish embedding job: %w", err)
+	}
+
+	return nil
+}
+
+// FailUnfinished marks jobs left pending or running by a previous process as failed
+func (r *embeddingJobRepository) FailUnfinished(ctx context.Context, errMsg string) (int64, error) {
+	query := `
+		UPDATE embedding_jobs
+		SET status = 'failed', error = $1, finished_at = CURRENT_TIMESTAMP
+		WHERE status IN ('pending', 'running')
+	`
+
+	result, err := r.db.Exec(ctx, query, errMsg)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fail unfinished embedding jobs: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
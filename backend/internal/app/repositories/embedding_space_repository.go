@@ -0,0 +1,225 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type EmbeddingSpaceRepository interface {
+	List(ctx context.Context) ([]*models.EmbeddingSpace, error)
+	GetByName(ctx context.Context, name string) (*models.EmbeddingSpace, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.EmbeddingSpace, error)
+	GetActive(ctx context.Context) (*models.EmbeddingSpace, error)
+	Create(ctx context.Context, space *models.EmbeddingSpace) error
+	SetActive(ctx context.Context, id uuid.UUID) error
+	// BuildIndex (re)builds the space's IVFFlat index from the embeddings it holds now
+	BuildIndex(ctx context.Context, space *models.EmbeddingSpace) error
+}
+
+type embeddingSpaceRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewEmbeddingSpaceRepository(db *pgxpool.Pool) EmbeddingSpaceRepository {
+	return &embeddingSpaceRepository{db: db}
+}
+
+const embeddingSpaceColumns = `id, name, model, dimension, index_type, COALESCE(base_url, ''), is_active, created_at`
+
+func scanEmbeddingSpace(row pgx.Row) (*models.EmbeddingSpace, error) {
+	var space models.EmbeddingSpace
+	err := row.Scan(
+		&space.ID, &space.Name, &space.Model, &space.Dimension,
+		&space.IndexType, &space.BaseURL, &space.IsActive, &space.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &space, nil
+}
+
+func (r *embeddingSpaceRepository) List(ctx context.Context) ([]*models.EmbeddingSpace, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+embeddingSpaceColumns+` FROM embedding_spaces ORDER BY created_at, name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list embedding spaces: %w", err)
+	}
+	defer rows.Close()
+
+	spaces := []*models.EmbeddingSpace{}
+	for rows.Next() {
+		space, err := scanEmbeddingSpace(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan embedding space: %w", err)
+		}
+		spaces = append(spaces, space)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over embedding spaces: %w", err)
+	}
+
+	return spaces, nil
+}
+
+// GetByName returns nil without error when no space has that name
+func (r *embeddingSpaceRepository) GetByName(ctx context.Context, name string) (*models.EmbeddingSpace, error) {
+	return r.getOne(ctx, `SELECT `+embeddingSpaceColumns+` FROM embedding_spaces WHERE name = $1`, name)
+}
+
+// GetByID returns nil without error when the space does not exist
+func (r *embeddingSpaceRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.EmbeddingSpace, error) {
+	return r.getOne(ctx, `SELECT `+embeddingSpaceColumns+` FROM embedding_spaces WHERE id = $1`, id)
+}
+
+// GetActive returns nil without error when no space is active
+func (r *embeddingSpaceRepository) GetActive(ctx context.Context) (*models.EmbeddingSpace, error) {
+	return r.getOne(ctx, `SELECT `+embeddingSpaceColumns+` FROM embedding_spaces WHERE is_active`)
+}
+
+func (r *embeddingSpaceRepository) getOne(ctx context.Context, query string, args ...interface{}) (*models.EmbeddingSpace, error) {
+	space, err := scanEmbeddingSpace(r.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get embedding space: %w", err)
+	}
+
+	return space, nil
+}
+
+// Create inserts the space and builds its partial HNSW index on
+// knowledge_embeddings in the same transaction. IVFFlat indexes are left to
+// BuildIndex: their lists are trained on existing rows, so one built on an
+// empty space recalls poorly.
+func (r *embeddingSpaceRepository) Create(ctx context.Context, space *models.EmbeddingSpace) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO embedding_spaces (name, model, dimension, index_type, base_url)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+		RETURNING id, is_active, created_at
+	`
+
+	err = tx.QueryRow(ctx, query, space.Name, space.Model, space.Dimension, space.IndexType, space.BaseURL).
+		Scan(&space.ID, &space.IsActive, &space.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create embedding space: %w", err)
+	}
+
+	if space.IndexType == models.EmbeddingIndexHNSW {
+		if _, err := tx.Exec(ctx, embeddingIndexDDL(space, 0)); err != nil {
+			return fmt.Errorf("failed to create embedding space index: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit embedding space: %w", err)
+	}
+
+	return nil
+}
+
+// SetActive makes id the only active space
+func (r *embeddingSpaceRepository) SetActive(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `UPDATE embedding_spaces SET is_active = false WHERE is_active AND id <> $1`, id); err != nil {
+		return fmt.Errorf("failed to deactivate embedding spaces: %w", err)
+	}
+
+	result, err := tx.Exec(ctx, `UPDATE embedding_spaces SET is_active = true WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to activate embedding space: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("embedding space %s not found", id)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit embedding space activation: %w", err)
+	}
+
+	return nil
+}
+
+func (r *embeddingSpaceRepository) BuildIndex(ctx context.Context, space *models.EmbeddingSpace) error {
+	if space.IndexType != models.EmbeddingIndexIVFFlat {
+		return nil
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var rows int
+	err = tx.QueryRow(ctx, `SELECT COUNT(*) FROM knowledge_embeddings WHERE space_id = $1`, space.ID).Scan(&rows)
+	if err != nil {
+		return fmt.Errorf("failed to count embeddings: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, "DROP INDEX IF EXISTS "+embeddingIndexName(space)); err != nil {
+		return fmt.Errorf("failed to drop embedding space index: %w", err)
+	}
+	if _, err := tx.Exec(ctx, embeddingIndexDDL(space, ivfflatLists(rows))); err != nil {
+		return fmt.Errorf("failed to build embedding space index: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit embedding space index: %w", err)
+	}
+
+	return nil
+}
+
+// ivfflatLists follows the pgvector guidance: rows/1000 lists up to a million
+// rows and sqrt(rows) beyond
+func ivfflatLists(rows int) int {
+	if rows > 1000000 {
+		return int(math.Sqrt(float64(rows)))
+	}
+	return max(rows/1000, 1)
+}
+
+func embeddingIndexName(space *models.EmbeddingSpace) string {
+	return "idx_knowledge_embeddings_" + strings.ReplaceAll(space.ID.String(), "-", "")
+}
+
+// embeddingIndexDDL builds the partial expression index for a non-default space.
+// The cast to vector(dimension) must match the expression used by searches.
+// lists only applies to IVFFlat. Values are interpolated because DDL takes no
+// parameters; the ID is a UUID and the dimension and lists ints, so none can carry SQL.
+func embeddingIndexDDL(space *models.EmbeddingSpace, lists int) string {
+	method := "hnsw"
+	if space.IndexType == models.EmbeddingIndexIVFFlat {
+		method = "ivfflat"
+	}
+
+	ddl := fmt.Sprintf(
+		"CREATE INDEX %s ON knowledge_embeddings USING %s ((embedding::vector(%d)) vector_cosine_ops)",
+		embeddingIndexName(space), method, space.Dimension,
+	)
+	if method == "ivfflat" {
+		ddl += fmt.Sprintf(" WITH (lists = %d)", lists)
+	}
+
+	return ddl + fmt.Sprintf(" WHERE space_id = '%s'", space.ID)
+}
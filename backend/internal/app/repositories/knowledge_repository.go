@@ -0,0 +1,368 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type KnowledgeRepository interface {
+	SearchSimilar(ctx context.Context, space *models.EmbeddingSpace, embedding []float32, topK int, threshold float64, filters map[string]interface{}) ([]*models.KnowledgeChunk, error)
+	SearchKeyword(ctx context.Context, query string, topK int, filters map[string]interface{}) ([]*models.KnowledgeChunk, error)
+	ListSources(ctx context.Context) ([]*models.KnowledgeDocument, error)
+	ListBySource(ctx context.Context, source string) ([]*models.KnowledgeChunk, error)
+	Insert(ctx context.Context, chunk *models.KnowledgeChunk, space *models.EmbeddingSpace, embedding []float32) error
+	CountMissingEmbeddings(ctx context.Context, target, source *models.EmbeddingSpace) (int, error)
+	ListMissingEmbeddings(ctx context.Context, target, source *models.EmbeddingSpace, limit int) ([]*models.KnowledgeChunk, error)
+	SaveEmbedding(ctx context.Context, space *models.EmbeddingSpace, chunkID uuid.UUID, embedding []float32) error
+	UpdateMetadata(ctx context.Context, id uuid.UUID, metadata map[string]interface{}) error
+	DeleteByIDs(ctx context.Context, ids []uuid.UUID) (int64, error)
+	DeleteBySource(ctx context.Context, source string) (int64, error)
+}
+
+type knowledgeRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewKnowledgeRepository(db *pgxpool.Pool) KnowledgeRepository {
+	return &knowledgeRepository{db: db}
+}
+
+// SearchSimilar returns up to topK chunks ordered by cosine similarity in space,
+// keeping only those scoring at least threshold whose metadata contains filters
+func (r *knowledgeRepository) SearchSimilar(ctx context.Context, space *models.EmbeddingSpace, embedding []float32, topK int, threshold float64, filters map[string]interface{}) ([]*models.KnowledgeChunk, error) {
+	join, column := spaceEmbedding(space)
+	query := fmt.Sprintf(`
+		SELECT k.id, k.content, COALESCE(k.metadata, '{}'::jsonb), 1 - (%[2]s <=> $1::vector) AS score, k.created_at
+		FROM simple_knowledge_vectors k %[1]s
+		WHERE %[2]s IS NOT NULL
+			AND 1 - (%[2]s <=> $1::vector) >= $2
+			AND COALESCE(k.metadata, '{}'::jsonb) @> $4::jsonb
+		ORDER BY %[2]s <=> $1::vector
+		LIMIT $3
+	`, join, column)
+
+	filterJSON, err := marshalMetadataFilters(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, query, FormatVector(embedding), threshold, topK, filterJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search knowledge vectors: %w", err)
+	}
+
+	return collectKnowledgeChunks(rows)
+}
+
+// SearchKeyword returns up to topK chunks matching any term of query in the
+// content_tsv full-text column, ordered by ts_rank_cd. Terms are ORed so that a
+// single exact hit (a hostname, an asset tag) is enough to be a candidate.
+func (r *knowledgeRepository) SearchKeyword(ctx context.Context, query string, topK int, filters map[string]interface{}) ([]*models.KnowledgeChunk, error) {
+	sql := `
+		SELECT id, content, COALESCE(metadata, '{}'::jsonb), ts_rank_cd(content_tsv, terms)::float8 AS score, created_at
+		FROM simple_knowledge_vectors,
+			NULLIF(replace(plainto_tsquery('simple', $1)::text, '&', '|'), '')::tsquery AS terms
+		WHERE content_tsv @@ terms
+			AND COALESCE(metadata, '{}'::jsonb) @> $3::jsonb
+		ORDER BY score DESC, created_at
+		LIMIT $2
+	`
+
+	filterJSON, err := marshalMetadataFilters(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, sql, query, topK, filterJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search knowledge keywords: %w", err)
+	}
+
+	return collectKnowledgeChunks(rows)
+}
+
+// ListSources groups stored chunks by metadata source; chunks without a source are omitted
+func (r *knowledgeRepository) ListSources(ctx context.Context) ([]*models.KnowledgeDocument, error) {
+	query := `
+		SELECT metadata->>'source', COUNT(*), MIN(created_at), MAX(created_at)
+		FROM simple_knowledge_vectors
+		WHERE metadata->>'source' IS NOT NULL
+		GROUP BY metadata->>'source'
+		ORDER BY metadata->>'source'
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list knowledge sources: %w", err)
+	}
+	defer rows.Close()
+
+	documents := []*models.KnowledgeDocument{}
+	for rows.Next() {
+		var document models.KnowledgeDocument
+		err := rows.Scan(&document.Source, &document.ChunkCount, &document.CreatedAt, &document.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan knowledge source: %w", err)
+		}
+		documents = append(documents, &document)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over knowledge sources: %w", err)
+	}
+
+	return documents, nil
+}
+
+// ListBySource returns the chunks ingested from source in chunk order, without scores
+func (r *knowledgeRepository) ListBySource(ctx context.Context, source string) ([]*models.KnowledgeChunk, error) {
+	query := `
+		SELECT id, content, COALESCE(metadata, '{}'::jsonb), 0::float8, created_at
+		FROM simple_knowledge_vectors
+		WHERE metadata->>'source' = $1
+		ORDER BY (metadata->>'chunk_index')::int NULLS LAST, created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list knowledge chunks: %w", err)
+	}
+
+	return collectKnowledgeChunks(rows)
+}
+
+// Insert stores a chunk with its embedding in space. Chunks embedded into a
+// non-default space have no legacy embedding until re-embedded into default.
+func (r *knowledgeRepository) Insert(ctx context.Context, chunk *models.KnowledgeChunk, space *models.EmbeddingSpace, embedding []float32) error {
+	metadata, err := json.Marshal(chunk.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal knowledge chunk metadata: %w", err)
+	}
+
+	if space.IsDefault() {
+		query := `
+			INSERT INTO simple_knowledge_vectors (content, embedding, metadata)
+			VALUES ($1, $2::vector, $3::jsonb)
+			RETURNING id, created_at
+		`
+
+		err = r.db.QueryRow(ctx, query, chunk.Content, FormatVector(embedding), string(metadata)).Scan(&chunk.ID, &chunk.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to insert knowledge chunk: %w", err)
+		}
+		return nil
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO simple_knowledge_vectors (content, metadata)
+		VALUES ($1, $2::jsonb)
+		RETURNING id, created_at
+	`
+
+	err = tx.QueryRow(ctx, query, chunk.Content, string(metadata)).Scan(&chunk.ID, &chunk.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert knowledge chunk: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `INSERT INTO knowledge_embeddings (chunk_id, space_id, embedding) VALUES ($1, $2, $3::vector)`,
+		chunk.ID, space.ID, FormatVector(embedding))
+	if err != nil {
+		return fmt.Errorf("failed to insert knowledge embedding: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit knowledge chunk: %w", err)
+	}
+
+	return nil
+}
+
+// CountMissingEmbeddings counts chunks with no embedding in target; with a
+// source space only chunks embedded in source are counted
+func (r *knowledgeRepository) CountMissingEmbeddings(ctx context.Context, target, source *models.EmbeddingSpace) (int, error) {
+	query := `SELECT COUNT(*) FROM simple_knowledge_vectors k WHERE ` + missingEmbeddingCondition(target, source)
+
+	var count int
+	if err := r.db.QueryRow(ctx, query).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count missing embeddings: %w", err)
+	}
+
+	return count, nil
+}
+
+// ListMissingEmbeddings returns up to limit chunks that CountMissingEmbeddings counts.
+// Saving their embeddings removes them from the next page.
+func (r *knowledgeRepository) ListMissingEmbeddings(ctx context.Context, target, source *models.EmbeddingSpace, limit int) ([]*models.KnowledgeChunk, error) {
+	query := `
+		SELECT k.id, k.content, COALESCE(k.metadata, '{}'::jsonb), 0::float8, k.created_at
+		FROM simple_knowledge_vectors k
+		WHERE ` + missingEmbeddingCondition(target, source) + `
+		ORDER BY k.id
+		LIMIT $1
+	`
+
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list missing embeddings: %w", err)
+	}
+
+	return collectKnowledgeChunks(rows)
+}
+
+// SaveEmbedding stores or replaces the embedding of a chunk in space
+func (r *knowledgeRepository) SaveEmbedding(ctx context.Context, space *models.EmbeddingSpace, chunkID uuid.UUID, embedding []float32) error {
+	var err error
+	if space.IsDefault() {
+		_, err = r.db.Exec(ctx, `UPDATE simple_knowledge_vectors SET embedding = $1::vector, updated_at = CURRENT_TIMESTAMP WHERE id = $2`,
+			FormatVector(embedding), chunkID)
+	} else {
+		_, err = r.db.Exec(ctx, `
+			INSERT INTO knowledge_embeddings (chunk_id, space_id, embedding)
+			VALUES ($1, $2, $3::vector)
+			ON CONFLICT (space_id, chunk_id) DO UPDATE SET embedding = EXCLUDED.embedding, created_at = CURRENT_TIMESTAMP
+		`, chunkID, space.ID, FormatVector(embedding))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save knowledge embedding: %w", err)
+	}
+
+	return nil
+}
+
+func (r *knowledgeRepository) UpdateMetadata(ctx context.Context, id uuid.UUID, metadata map[string]interface{}) error {
+	query := `
+		UPDATE simple_knowledge_vectors
+		SET metadata = $1::jsonb, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2
+	`
+
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal knowledge chunk metadata: %w", err)
+	}
+
+	_, err = r.db.Exec(ctx, query, string(data), id)
+	if err != nil {
+		return fmt.Errorf("failed to update knowledge chunk metadata: %w", err)
+	}
+
+	return nil
+}
+
+func (r *knowledgeRepository) DeleteByIDs(ctx context.Context, ids []uuid.UUID) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = id.String()
+	}
+
+	result, err := r.db.Exec(ctx, `DELETE FROM simple_knowledge_vectors WHERE id = ANY($1::uuid[])`, values)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete knowledge chunks: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func (r *knowledgeRepository) DeleteBySource(ctx context.Context, source string) (int64, error) {
+	result, err := r.db.Exec(ctx, `DELETE FROM simple_knowledge_vectors WHERE metadata->>'source' = $1`, source)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete knowledge source: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func collectKnowledgeChunks(rows pgx.Rows) ([]*models.KnowledgeChunk, error) {
+	defer rows.Close()
+
+	chunks := []*models.KnowledgeChunk{}
+	for rows.Next() {
+		var chunk models.KnowledgeChunk
+		err := rows.Scan(&chunk.ID, &chunk.Content, &chunk.Metadata, &chunk.Score, &chunk.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan knowledge chunk: %w", err)
+		}
+		chunks = append(chunks, &chunk)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over knowledge chunks: %w", err)
+	}
+
+	return chunks, nil
+}
+
+// spaceEmbedding returns the join and vector expression that read the embeddings
+// of space for simple_knowledge_vectors k. The cast for non-default spaces must
+// match the expression of the index built by embeddingIndexDDL.
+func spaceEmbedding(space *models.EmbeddingSpace) (join, column string) {
+	if space.IsDefault() {
+		return "", "k.embedding"
+	}
+
+	join = fmt.Sprintf("JOIN knowledge_embeddings e ON e.chunk_id = k.id AND e.space_id = '%s'", space.ID)
+	return join, fmt.Sprintf("e.embedding::vector(%d)", space.Dimension)
+}
+
+// hasEmbeddingCondition matches chunks of k that have an embedding in space
+func hasEmbeddingCondition(space *models.EmbeddingSpace) string {
+	if space.IsDefault() {
+		return "k.embedding IS NOT NULL"
+	}
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM knowledge_embeddings e WHERE e.chunk_id = k.id AND e.space_id = '%s')", space.ID)
+}
+
+func missingEmbeddingCondition(target, source *models.EmbeddingSpace) string {
+	condition := "NOT " + hasEmbeddingCondition(target)
+	if source != nil {
+		condition += " AND " + hasEmbeddingCondition(source)
+	}
+	return condition
+}
+
+// marshalMetadataFilters renders filters as a JSONB containment operand; no filters match everything
+func marshalMetadataFilters(filters map[string]interface{}) (string, error) {
+	if filters == nil {
+		filters = map[string]interface{}{}
+	}
+
+	filterJSON, err := json.Marshal(filters)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal metadata filters: %w", err)
+	}
+
+	return string(filterJSON), nil
+}
+
+// FormatVector renders an embedding as a pgvector literal such as [0.1,0.2]
+func FormatVector(embedding []float32) string {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, value := range embedding {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(float64(value), 'f', -1, 32))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
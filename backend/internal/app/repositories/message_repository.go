@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type MessageRepository interface {
+	Create(ctx context.Context, message *models.Message) error
+	UpdateStatus(ctx context.Context, id uuid.UUID, status, workflowType, errMsg string) error
+	ListByPhone(ctx context.Context, phone string, limit, offset int) ([]*models.Message, int, error)
+}
+
+type messageRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewMessageRepository(db *pgxpool.Pool) MessageRepository {
+	return &messageRepository{db: db}
+}
+
+func (r *messageRepository) Create(ctx context.Context, message *models.Message) error {
+	query := `
+		INSERT INTO messages (phone, direction, whatsapp_message_id, correlation_id, session_id, workflow_type, content, status, error)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), $5, NULLIF($6, ''), $7, $8, NULLIF($9, ''))
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		message.Phone, message.Direction, message.WhatsAppMessageID, message.CorrelationID,
+		message.SessionID, message.WorkflowType, message.Content, message.Status, message.Error,
+	).Scan(&message.ID, &message.CreatedAt, &message.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create message: %w", err)
+	}
+
+	return nil
+}
+
+func (r *messageRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status, workflowType, errMsg string) error {
+	query := `
+		UPDATE messages
+		SET status = $1,
+			workflow_type = COALESCE(NULLIF($2, ''), workflow_type),
+			error = NULLIF($3, ''),
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $4
+	`
+
+	result, err := r.db.Exec(ctx, query, status, workflowType, errMsg, id)
+	if err != nil {
+		return fmt.Errorf("failed to update message status: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("message not found")
+	}
+
+	return nil
+}
+
+func (r *messageRepository) ListByPhone(ctx context.Context, phone string, limit, offset int) ([]*models.Message, int, error) {
+	var total int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM messages WHERE phone = $1`, phone).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count messages: %w", err)
+	}
+
+	query := `
+		SELECT id, phone, direction, COALESCE(whatsapp_message_id, ''), COALESCE(correlation_id, ''),
+			session_id, COALESCE(workflow_type, ''), content, status, COALESCE(error, ''),
+			created_at, updated_at
+		FROM messages
+		WHERE phone = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(ctx, query, phone, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list messages: %w", err)
+	}
+	defer rows.Close()
+
+	messages := []*models.Message{}
+	for rows.Next() {
+		var message models.Message
+		err := rows.Scan(
+			&message.ID, &message.Phone, &message.Direction, &message.WhatsAppMessageID, &message.CorrelationID,
+			&message.SessionID, &message.WorkflowType, &message.Content, &message.Status, &message.Error,
+			&message.CreatedAt, &message.UpdatedAt,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan message: %w", err)
+		}
+		messages = append(messages, &message)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating over messages: %w", err)
+	}
+
+	return messages, total, nil
+}
@@ -0,0 +1,182 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PendingRequestRepository interface {
+	Create(ctx context.Context, request *models.PendingRequest) error
+	GetByMessageID(ctx context.Context, messageID string) (*models.PendingRequest, error)
+	Complete(ctx context.Context, messageID string) (*models.PendingRequest, error)
+	MarkFailed(ctx context.Context, messageID, errMsg string) error
+	ListAwaitingSoftNotice(ctx context.Context, sentBefore time.Time) ([]*models.PendingRequest, error)
+	MarkSoftNotified(ctx context.Context, messageID string) (bool, error)
+	ExpireOverdue(ctx context.Context, now time.Time, errMsg string) ([]*models.PendingRequest, error)
+}
+
+type pendingRequestRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPendingRequestRepository(db *pgxpool.Pool) PendingRequestRepository {
+	return &pendingRequestRepository{db: db}
+}
+
+const pendingRequestColumns = `message_id, phone, workflow_type, status, COALESCE(error, ''), sent_at, deadline_at, completed_at, soft_notified_at, timed_out_at`
+
+func scanPendingRequest(row pgx.Row) (*models.PendingRequest, error) {
+	var request models.PendingRequest
+	err := row.Scan(
+		&request.MessageID, &request.Phone, &request.WorkflowType, &request.Status,
+		&request.Error, &request.SentAt, &request.DeadlineAt, &request.CompletedAt,
+		&request.SoftNotifiedAt, &request.TimedOutAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *pendingRequestRepository) Create(ctx context.Context, request *models.PendingRequest) error {
+	query := `
+		INSERT INTO pending_requests (message_id, phone, workflow_type, status, sent_at, deadline_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		request.MessageID, request.Phone, request.WorkflowType, request.Status, request.SentAt, request.DeadlineAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create pending request: %w", err)
+	}
+
+	return nil
+}
+
+// GetByMessageID returns nil without error when the message ID was never issued
+func (r *pendingRequestRepository) GetByMessageID(ctx context.Context, messageID string) (*models.PendingRequest, error) {
+	query := `SELECT ` + pendingRequestColumns + ` FROM pending_requests WHERE message_id = $1`
+
+	request, err := scanPendingRequest(r.db.QueryRow(ctx, query, messageID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil // Unknown message ID
+		}
+		return nil, fmt.Errorf("failed to get pending request: %w", err)
+	}
+
+	return request, nil
+}
+
+// Complete atomically moves a pending request to completed. It returns nil
+// without error when the request is unknown or no longer pending.
+func (r *pendingRequestRepository) Complete(ctx context.Context, messageID string) (*models.PendingRequest, error) {
+	query := `
+		UPDATE pending_requests
+		SET status = 'completed', completed_at = CURRENT_TIMESTAMP
+		WHERE message_id = $1 AND status = 'pending'
+		RETURNING ` + pendingRequestColumns
+
+	request, err := scanPendingRequest(r.db.QueryRow(ctx, query, messageID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil // Unknown or already handled
+		}
+		return nil, fmt.Errorf("failed to complete pending request: %w", err)
+	}
+
+	return request, nil
+}
+
+func (r *pendingRequestRepository) MarkFailed(ctx context.Context, messageID, errMsg string) error {
+	query := `
+		UPDATE pending_requests
+		SET status = 'failed', error = NULLIF($2, ''), completed_at = CURRENT_TIMESTAMP
+		WHERE message_id = $1
+	`
+
+	_, err := r.db.Exec(ctx, query, messageID, errMsg)
+	if err != nil {
+		return fmt.Errorf("failed to mark pending request failed: %w", err)
+	}
+
+	return nil
+}
+
+// ListAwaitingSoftNotice returns pending requests sent before sentBefore that
+// have not yet been sent a "still working" notice
+func (r *pendingRequestRepository) ListAwaitingSoftNotice(ctx context.Context, sentBefore time.Time) ([]*models.PendingRequest, error) {
+	query := `
+		SELECT ` + pendingRequestColumns + `
+		FROM pending_requests
+		WHERE status = 'pending' AND soft_notified_at IS NULL AND sent_at <= $1
+		ORDER BY sent_at ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, sentBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending requests: %w", err)
+	}
+
+	return collectPendingRequests(rows)
+}
+
+// MarkSoftNotified reports false when the request was already notified or is no longer pending
+func (r *pendingRequestRepository) MarkSoftNotified(ctx context.Context, messageID string) (bool, error) {
+	query := `
+		UPDATE pending_requests
+		SET soft_notified_at = CURRENT_TIMESTAMP
+		WHERE message_id = $1 AND status = 'pending' AND soft_notified_at IS NULL
+	`
+
+	result, err := r.db.Exec(ctx, query, messageID)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark pending request notified: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// ExpireOverdue atomically moves pending requests past their deadline to timed_out
+// and returns them, so a late callback can no longer claim them
+func (r *pendingRequestRepository) ExpireOverdue(ctx context.Context, now time.Time, errMsg string) ([]*models.PendingRequest, error) {
+	query := `
+		UPDATE pending_requests
+		SET status = 'timed_out', error = NULLIF($2, ''), timed_out_at = CURRENT_TIMESTAMP
+		WHERE status = 'pending' AND deadline_at <= $1
+		RETURNING ` + pendingRequestColumns
+
+	rows, err := r.db.Query(ctx, query, now, errMsg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expire pending requests: %w", err)
+	}
+
+	return collectPendingRequests(rows)
+}
+
+func collectPendingRequests(rows pgx.Rows) ([]*models.PendingRequest, error) {
+	defer rows.Close()
+
+	requests := []*models.PendingRequest{}
+	for rows.Next() {
+		request, err := scanPendingRequest(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pending request: %w", err)
+		}
+		requests = append(requests, request)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over pending requests: %w", err)
+	}
+
+	return requests, nil
+}
@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type RejectedSignalRepository interface {
+	// Create stores a rejected signal with its payload and violations
+	Create(ctx context.Context, rejected *models.RejectedSignal) error
+	// List returns the most recent rejected signals matching filter
+	List(ctx context.Context, filter *models.RejectedSignalFilter, limit int) ([]*models.RejectedSignal, error)
+}
+
+type rejectedSignalRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewRejectedSignalRepository(db *pgxpool.Pool) RejectedSignalRepository {
+	return &rejectedSignalRepository{db: db}
+}
+
+const rejectedSignalColumns = `id, ticker, last_date, operation, payload, violations, created_at`
+
+func scanRejectedSignal(row pgx.Row) (*models.RejectedSignal, error) {
+	var rejected models.RejectedSignal
+	err := row.Scan(&rejected.ID, &rejected.Ticker, &rejected.LastDate, &rejected.Operation, &rejected.Payload, &rejected.Violations, &rejected.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &rejected, nil
+}
+
+func (r *rejectedSignalRepository) Create(ctx context.Context, rejected *models.RejectedSignal) error {
+	query := `
+		INSERT INTO rejected_signals (ticker, last_date, operation, payload, violations)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query, rejected.Ticker, rejected.LastDate, rejected.Operation, rejected.Payload, rejected.Violations).
+		Scan(&rejected.ID, &rejected.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create rejected signal: %w", err)
+	}
+
+	return nil
+}
+
+func (r *rejectedSignalRepository) List(ctx context.Context, filter *models.RejectedSignalFilter, limit int) ([]*models.RejectedSignal, error) {
+	query := `SELECT ` + rejectedSignalColumns + ` FROM rejected_signals
+		WHERE ($1 = '' OR ticker = $1) AND ($2 = '' OR violations @> jsonb_build_array(jsonb_build_object('rule', $2::text)))
+		ORDER BY created_at DESC
+		LIMIT $3`
+
+	rows, err := r.db.Query(ctx, query, filter.Ticker, filter.Rule, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rejected signals: %w", err)
+	}
+	defer rows.Close()
+
+	signals := []*models.RejectedSignal{}
+	for rows.Next() {
+		rejected, err := scanRejectedSignal(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rejected signal: %w", err)
+		}
+		signals = append(signals, rejected)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rejected signals: %w", err)
+	}
+
+	return signals, nil
+}
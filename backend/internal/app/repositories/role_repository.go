@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type RoleRepository interface {
+	// List returns every role with its permissions
+	List(ctx context.Context) ([]*models.Role, error)
+	GetUserRoles(ctx context.Context, userID uuid.UUID) ([]string, error)
+	// SetUserRoles replaces the roles of a user
+	SetUserRoles(ctx context.Context, userID uuid.UUID, roles []string) error
+	// GetPermissions returns the union of the permissions granted by roles
+	GetPermissions(ctx context.Context, roles []string) ([]string, error)
+}
+
+type roleRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewRoleRepository(db *pgxpool.Pool) RoleRepository {
+	return &roleRepository{db: db}
+}
+
+func (r *roleRepository) List(ctx context.Context) ([]*models.Role, error) {
+	query := `
+		SELECT r.name, r.description, COALESCE(array_agg(p.permission ORDER BY p.permission) FILTER (WHERE p.permission IS NOT NULL), '{}')
+		FROM roles r
+		LEFT JOIN role_permissions p ON p.role = r.name
+		GROUP BY r.name, r.description
+		ORDER BY r.name
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	defer rows.Close()
+
+	var roles []*models.Role
+	for rows.Next() {
+		var role models.Role
+		if err := rows.Scan(&role.Name, &role.Description, &role.Permissions); err != nil {
+			return nil, fmt.Errorf("failed to scan role: %w", err)
+		}
+		roles = append(roles, &role)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over roles: %w", err)
+	}
+
+	return roles, nil
+}
+
+func (r *roleRepository) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	rows, err := r.db.Query(ctx, `SELECT role FROM user_roles WHERE user_id = $1 ORDER BY role`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user roles: %w", err)
+	}
+
+	roles, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user roles: %w", err)
+	}
+
+	return roles, nil
+}
+
+func (r *roleRepository) SetUserRoles(ctx context.Context, userID uuid.UUID, roles []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM user_roles WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to clear user roles: %w", err)
+	}
+
+	for _, role := range roles {
+		_, err := tx.Exec(ctx, `INSERT INTO user_roles (user_id, role) VALUES ($1, $2) ON CONFLICT DO NOTHING`, userID, role)
+		if err != nil {
+			return fmt.Errorf("failed to assign role %s: %w", role, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit user roles: %w", err)
+	}
+
+	return nil
+}
+
+func (r *roleRepository) GetPermissions(ctx context.Context, roles []string) ([]string, error) {
+	if len(roles) == 0 {
+		return []string{}, nil
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT DISTINCT permission FROM role_permissions WHERE role = ANY($1) ORDER BY permission`, roles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get permissions: %w", err)
+	}
+
+	permissions, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to get permissions: %w", err)
+	}
+
+	return permissions, nil
+}
@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SessionRepository interface {
+	GetActiveByPhone(ctx context.Context, phone string) (*models.ConversationSession, error)
+	Create(ctx context.Context, phone string) (*models.ConversationSession, error)
+	Touch(ctx context.Context, id uuid.UUID) error
+	End(ctx context.Context, id uuid.UUID) error
+	EndActiveByPhone(ctx context.Context, phone string) (bool, error)
+}
+
+type sessionRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSessionRepository(db *pgxpool.Pool) SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+// GetActiveByPhone returns nil without error when the phone has no open session
+func (r *sessionRepository) GetActiveByPhone(ctx context.Context, phone string) (*models.ConversationSession, error) {
+	query := `
+		SELECT id, phone, started_at, last_activity_at, ended_at
+		FROM conversation_sessions
+		WHERE phone = $1 AND ended_at IS NULL
+	`
+
+	var session models.ConversationSession
+	err := r.db.QueryRow(ctx, query, phone).Scan(
+		&session.ID, &session.Phone, &session.StartedAt,
+		&session.LastActivityAt, &session.EndedAt,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil // No open session
+		}
+		return nil, fmt.Errorf("failed to get active session: %w", err)
+	}
+
+	return &session, nil
+}
+
+// Create opens a new session, or returns the open one if another message won the race
+func (r *sessionRepository) Create(ctx context.Context, phone string) (*models.ConversationSession, error) {
+	query := `
+		INSERT INTO conversation_sessions (phone)
+		VALUES ($1)
+		ON CONFLICT (phone) WHERE ended_at IS NULL
+		DO UPDATE SET last_activity_at = CURRENT_TIMESTAMP
+		RETURNING id, phone, started_at, last_activity_at, ended_at
+	`
+
+	var session models.ConversationSession
+	err := r.db.QueryRow(ctx, query, phone).Scan(
+		&session.ID, &session.Phone, &session.StartedAt,
+		&session.LastActivityAt, &session.EndedAt,
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return &session, nil
+}
+
+func (r *sessionRepository) Touch(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE conversation_sessions SET last_activity_at = CURRENT_TIMESTAMP WHERE id = $1`
+
+	_, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to touch session: %w", err)
+	}
+
+	return nil
+}
+
+func (r *sessionRepository) End(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE conversation_sessions SET ended_at = CURRENT_TIMESTAMP WHERE id = $1 AND ended_at IS NULL`
+
+	_, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to end session: %w", err)
+	}
+
+	return nil
+}
+
+func (r *sessionRepository) EndActiveByPhone(ctx context.Context, phone string) (bool, error) {
+	query := `UPDATE conversation_sessions SET ended_at = CURRENT_TIMESTAMP WHERE phone = $1 AND ended_at IS NULL`
+
+	result, err := r.db.Exec(ctx, query, phone)
+	if err != nil {
+		return false, fmt.Errorf("failed to end active session: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
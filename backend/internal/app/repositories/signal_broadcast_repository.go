@@ -0,0 +1,338 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SignalBroadcastRepository interface {
+	// Create stores the broadcast with a pending delivery per recipient, filling
+	// in the generated IDs. It returns false without storing anything when the
+	// signal already has a broadcast of the kind and revision; the signal is
+	// locked meanwhile, so concurrent posts of a signal store one broadcast.
+	Create(ctx context.Context, broadcast *models.SignalBroadcast, deliveries []*models.SignalDelivery) (bool, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.SignalBroadcast, error)
+	// GetLatest returns the most recent broadcast of kind for a signal, or nil
+	GetLatest(ctx context.Context, signalID uuid.UUID, kind string) (*models.SignalBroadcast, error)
+	// ListUnfinished returns queued and sending broadcasts, oldest first
+	ListUnfinished(ctx context.Context) ([]*models.SignalBroadcast, error)
+	// ListDeliveries returns the deliveries of a broadcast, all of them when status is empty
+	ListDeliveries(ctx context.Context, broadcastID uuid.UUID, status string) ([]*models.SignalDelivery, error)
+	// ClaimDelivery marks a pending delivery as sending and returns it as
+	// stored, or nil when it is no longer pending
+	ClaimDelivery(ctx context.Context, id uuid.UUID) (*models.SignalDelivery, error)
+	// FailInterrupted marks the deliveries left sending by a previous process as
+	// failed with errMsg and returns how many there were
+	FailInterrupted(ctx context.Context, errMsg string) (int, error)
+	// CancelPending marks the pending deliveries of every broadcast of a signal
+	// as cancelled and returns how many there were
+	CancelPending(ctx context.Context, signalID uuid.UUID) (int, error)
+	// RewritePending replaces the message of each of deliveries that is still
+	// pending and returns how many were replaced
+	RewritePending(ctx context.Context, deliveries []*models.SignalDelivery) (int, error)
+	Start(ctx context.Context, id uuid.UUID) error
+	// RecordDelivery stores the outcome of sending one delivery; it only
+	// changes a delivery that is sending
+	RecordDelivery(ctx context.Context, id uuid.UUID, status, whatsAppMessageID, errMsg string) error
+	Finish(ctx context.Context, id uuid.UUID) error
+}
+
+type signalBroadcastRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSignalBroadcastRepository(db *pgxpool.Pool) SignalBroadcastRepository {
+	return &signalBroadcastRepository{db: db}
+}
+
+const signalBroadcastColumns = `b.id, b.signal_id, b.kind, b.parent_id, b.ticker, b.message, COALESCE(p.message, ''), b.status,
+	COUNT(d.id)::int,
+	COUNT(d.id) FILTER (WHERE d.status IN ('pending', 'sending'))::int,
+	COUNT(d.id) FILTER (WHERE d.status = 'sent')::int,
+	COUNT(d.id) FILTER (WHERE d.status = 'failed')::int,
+	COUNT(d.id) FILTER (WHERE d.status = 'cancelled')::int,
+	b.created_at, b.started_at, b.finished_at`
+
+const signalBroadcastFrom = `FROM signal_broadcasts b
+	LEFT JOIN signal_broadcasts p ON p.id = b.parent_id
+	LEFT JOIN signal_deliveries d ON d.broadcast_id = b.id`
+
+func scanSignalBroadcast(row pgx.Row) (*models.SignalBroadcast, error) {
+	var broadcast models.SignalBroadcast
+	err := row.Scan(
+		&broadcast.ID, &broadcast.SignalID, &broadcast.Kind, &broadcast.ParentID, &broadcast.Ticker, &broadcast.Message, &broadcast.QuotedMessage, &broadcast.Status,
+		&broadcast.Total, &broadcast.Pending, &broadcast.Sent, &broadcast.Failed, &broadcast.Cancelled,
+		&broadcast.CreatedAt, &broadcast.StartedAt, &broadcast.FinishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &broadcast, nil
+}
+
+const signalDeliveryColumns = `id, broadcast_id, user_id, phone, status, COALESCE(language, ''), COALESCE(message, ''),
+	COALESCE(whatsapp_message_id, ''), COALESCE(reply_to_message_id, ''), COALESCE(quoted_message, ''),
+	COALESCE(error, ''), created_at, attempted_at`
+
+func scanSignalDelivery(row pgx.Row) (*models.SignalDelivery, error) {
+	var delivery models.SignalDelivery
+	err := row.Scan(
+		&delivery.ID, &delivery.BroadcastID, &delivery.UserID, &delivery.Phone, &delivery.Status, &delivery.Language, &delivery.Message,
+		&delivery.WhatsAppMessageID, &delivery.ReplyToMessageID, &delivery.QuotedMessage,
+		&delivery.Error, &delivery.CreatedAt, &delivery.AttemptedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *signalBroadcastRepository) Create(ctx context.Context, broadcast *models.SignalBroadcast, deliveries []*models.SignalDelivery) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if broadcast.SignalID != nil {
+		if _, err := tx.Exec(ctx, `SELECT 1 FROM tracked_signals WHERE id = $1 FOR UPDATE`, broadcast.SignalID); err != nil {
+			return false, fmt.Errorf("failed to lock tracked signal: %w", err)
+		}
+
+		var exists bool
+		err := tx.QueryRow(ctx, `
+			SELECT EXISTS (
+				SELECT 1 FROM signal_broadcasts
+				WHERE signal_id = $1 AND kind = $2 AND revision IS NOT DISTINCT FROM $3::timestamp
+			)
+		`, broadcast.SignalID, broadcast.Kind, broadcast.Revision).Scan(&exists)
+		if err != nil {
+			return false, fmt.Errorf("failed to check signal broadcast: %w", err)
+		}
+		if exists {
+			return false, nil
+		}
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO signal_broadcasts (signal_id, kind, parent_id, ticker, message, status, finished_at, revision)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at
+	`, broadcast.SignalID, broadcast.Kind, broadcast.ParentID, broadcast.Ticker, broadcast.Message, broadcast.Status, broadcast.FinishedAt, broadcast.Revision).Scan(&broadcast.ID, &broadcast.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to create signal broadcast: %w", err)
+	}
+
+	for _, delivery := range deliveries {
+		delivery.BroadcastID = broadcast.ID
+		delivery.Status = models.SignalDeliveryPending
+		err := tx.QueryRow(ctx, `
+			INSERT INTO signal_deliveries (broadcast_id, user_id, phone, status, language, message, reply_to_message_id, quoted_message)
+			VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''), NULLIF($8, ''))
+			RETURNING id, created_at
+		`, delivery.BroadcastID, delivery.UserID, delivery.Phone, delivery.Status, delivery.Language, delivery.Message,
+			delivery.ReplyToMessageID, delivery.QuotedMessage).Scan(&delivery.ID, &delivery.CreatedAt)
+		if err != nil {
+			return false, fmt.Errorf("failed to create signal delivery: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit signal broadcast: %w", err)
+	}
+
+	broadcast.Total = len(deliveries)
+	broadcast.Pending = len(deliveries)
+	return true, nil
+}
+
+// GetByID returns nil without error when the broadcast does not exist
+func (r *signalBroadcastRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.SignalBroadcast, error) {
+	query := `SELECT ` + signalBroadcastColumns + ` ` + signalBroadcastFrom + ` WHERE b.id = $1 GROUP BY b.id, p.id`
+
+	broadcast, err := scanSignalBroadcast(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get signal broadcast: %w", err)
+	}
+
+	return broadcast, nil
+}
+
+func (r *signalBroadcastRepository) GetLatest(ctx context.Context, signalID uuid.UUID, kind string) (*models.SignalBroadcast, error) {
+	query := `SELECT ` + signalBroadcastColumns + ` ` + signalBroadcastFrom + `
+		WHERE b.signal_id = $1 AND b.kind = $2
+		GROUP BY b.id, p.id
+		ORDER BY b.created_at DESC
+		LIMIT 1`
+
+	broadcast, err := scanSignalBroadcast(r.db.QueryRow(ctx, query, signalID, kind))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get latest signal broadcast: %w", err)
+	}
+
+	return broadcast, nil
+}
+
+func (r *signalBroadcastRepository) ListUnfinished(ctx context.Context) ([]*models.SignalBroadcast, error) {
+	query := `SELECT ` + signalBroadcastColumns + ` ` + signalBroadcastFrom + `
+		WHERE b.status IN ($1, $2)
+		GROUP BY b.id, p.id
+		ORDER BY b.created_at`
+
+	rows, err := r.db.Query(ctx, query, models.SignalBroadcastQueued, models.SignalBroadcastSending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unfinished signal broadcasts: %w", err)
+	}
+	defer rows.Close()
+
+	var broadcasts []*models.SignalBroadcast
+	for rows.Next() {
+		broadcast, err := scanSignalBroadcast(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan signal broadcast: %w", err)
+		}
+		broadcasts = append(broadcasts, broadcast)
+	}
+
+	return broadcasts, rows.Err()
+}
+
+func (r *signalBroadcastRepository) ListDeliveries(ctx context.Context, broadcastID uuid.UUID, status string) ([]*models.SignalDelivery, error) {
+	query := `SELECT ` + signalDeliveryColumns + ` FROM signal_deliveries
+		WHERE broadcast_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at, phone`
+
+	rows, err := r.db.Query(ctx, query, broadcastID, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signal deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []*models.SignalDelivery{}
+	for rows.Next() {
+		delivery, err := scanSignalDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan signal delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func (r *signalBroadcastRepository) ClaimDelivery(ctx context.Context, id uuid.UUID) (*models.SignalDelivery, error) {
+	query := `UPDATE signal_deliveries SET status = $2 WHERE id = $1 AND status = $3 RETURNING ` + signalDeliveryColumns
+
+	delivery, err := scanSignalDelivery(r.db.QueryRow(ctx, query, id, models.SignalDeliverySending, models.SignalDeliveryPending))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim signal delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+func (r *signalBroadcastRepository) FailInterrupted(ctx context.Context, errMsg string) (int, error) {
+	query := `
+		UPDATE signal_deliveries
+		SET status = $1, error = $2, attempted_at = CURRENT_TIMESTAMP
+		WHERE status = $3
+	`
+
+	result, err := r.db.Exec(ctx, query, models.SignalDeliveryFailed, errMsg, models.SignalDeliverySending)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fail interrupted signal deliveries: %w", err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
+
+func (r *signalBroadcastRepository) CancelPending(ctx context.Context, signalID uuid.UUID) (int, error) {
+	query := `
+		UPDATE signal_deliveries d
+		SET status = $2
+		FROM signal_broadcasts b
+		WHERE b.id = d.broadcast_id AND b.signal_id = $1 AND d.status = $3
+	`
+
+	result, err := r.db.Exec(ctx, query, signalID, models.SignalDeliveryCancelled, models.SignalDeliveryPending)
+	if err != nil {
+		return 0, fmt.Errorf("failed to cancel pending signal deliveries: %w", err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
+
+func (r *signalBroadcastRepository) RewritePending(ctx context.Context, deliveries []*models.SignalDelivery) (int, error) {
+	ids := make([]uuid.UUID, len(deliveries))
+	messages := make([]string, len(deliveries))
+	for i, delivery := range deliveries {
+		ids[i], messages[i] = delivery.ID, delivery.Message
+	}
+
+	query := `
+		UPDATE signal_deliveries d
+		SET message = m.message
+		FROM unnest($1::uuid[], $2::text[]) AS m(id, message)
+		WHERE d.id = m.id AND d.status = $3
+	`
+
+	result, err := r.db.Exec(ctx, query, ids, messages, models.SignalDeliveryPending)
+	if err != nil {
+		return 0, fmt.Errorf("failed to rewrite pending signal deliveries: %w", err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
+
+func (r *signalBroadcastRepository) Start(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE signal_broadcasts
+		SET status = $2, started_at = COALESCE(started_at, CURRENT_TIMESTAMP)
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, id, models.SignalBroadcastSending); err != nil {
+		return fmt.Errorf("failed to start signal broadcast: %w", err)
+	}
+
+	return nil
+}
+
+func (r *signalBroadcastRepository) RecordDelivery(ctx context.Context, id uuid.UUID, status, whatsAppMessageID, errMsg string) error {
+	query := `
+		UPDATE signal_deliveries
+		SET status = $2, whatsapp_message_id = NULLIF($3, ''), error = NULLIF($4, ''), attempted_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = $5
+	`
+
+	if _, err := r.db.Exec(ctx, query, id, status, whatsAppMessageID, errMsg, models.SignalDeliverySending); err != nil {
+		return fmt.Errorf("failed to record signal delivery: %w", err)
+	}
+
+	return nil
+}
+
+func (r *signalBroadcastRepository) Finish(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE signal_broadcasts SET status = $2, finished_at = CURRENT_TIMESTAMP WHERE id = $1`
+
+	if _, err := r.db.Exec(ctx, query, id, models.SignalBroadcastCompleted); err != nil {
+		return fmt.Errorf("failed to finish signal broadcast: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SignalTemplateRepository stores the signal templates edited by admins. Only
+// replaced templates have a row; the built-in ones live in the service.
+type SignalTemplateRepository interface {
+	List(ctx context.Context) ([]*models.SignalTemplate, error)
+	// Get returns the template of kind and language, or nil
+	Get(ctx context.Context, kind, language string) (*models.SignalTemplate, error)
+	// Save stores the template, replacing the one of the same kind and language
+	Save(ctx context.Context, template *models.SignalTemplate) error
+	// Delete removes the template of kind and language, reporting whether it existed
+	Delete(ctx context.Context, kind, language string) (bool, error)
+}
+
+type signalTemplateRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSignalTemplateRepository(db *pgxpool.Pool) SignalTemplateRepository {
+	return &signalTemplateRepository{db: db}
+}
+
+const signalTemplateColumns = `kind, language, body, updated_by, updated_at`
+
+func scanSignalTemplate(row pgx.Row) (*models.SignalTemplate, error) {
+	var template models.SignalTemplate
+	if err := row.Scan(&template.Kind, &template.Language, &template.Body, &template.UpdatedBy, &template.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *signalTemplateRepository) List(ctx context.Context) ([]*models.SignalTemplate, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+signalTemplateColumns+` FROM signal_templates ORDER BY kind, language`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signal templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := []*models.SignalTemplate{}
+	for rows.Next() {
+		template, err := scanSignalTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan signal template: %w", err)
+		}
+		templates = append(templates, template)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over signal templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+func (r *signalTemplateRepository) Get(ctx context.Context, kind, language string) (*models.SignalTemplate, error) {
+	query := `SELECT ` + signalTemplateColumns + ` FROM signal_templates WHERE kind = $1 AND language = $2`
+
+	template, err := scanSignalTemplate(r.db.QueryRow(ctx, query, kind, language))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get signal template: %w", err)
+	}
+
+	return template, nil
+}
+
+func (r *signalTemplateRepository) Save(ctx context.Context, template *models.SignalTemplate) error {
+	query := `
+		INSERT INTO signal_templates (kind, language, body, updated_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (kind, language) DO UPDATE
+		SET body = EXCLUDED.body, updated_by = EXCLUDED.updated_by, updated_at = CURRENT_TIMESTAMP
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query, template.Kind, template.Language, template.Body, template.UpdatedBy).Scan(&template.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save signal template: %w", err)
+	}
+
+	return nil
+}
+
+func (r *signalTemplateRepository) Delete(ctx context.Context, kind, language string) (bool, error) {
+	result, err := r.db.Exec(ctx, `DELETE FROM signal_templates WHERE kind = $1 AND language = $2`, kind, language)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete signal template: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
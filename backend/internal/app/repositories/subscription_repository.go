@@ -0,0 +1,183 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SubscriptionRepository interface {
+	// Upsert creates the subscription, or replaces the filters of the user's
+	// subscription for the same ticker and clears its exclusions
+	Upsert(ctx context.Context, subscription *models.SignalSubscription) (*models.SignalSubscription, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.SignalSubscription, error)
+	// Delete returns false when there is no subscription with id
+	Delete(ctx context.Context, id uuid.UUID) (bool, error)
+	// DeleteByUser removes the user's subscription for ticker, or every
+	// subscription when ticker is nil, and returns how many were removed
+	DeleteByUser(ctx context.Context, userID uuid.UUID, ticker *string) (int, error)
+	// ExcludeTicker leaves ticker out of the user's all-tickers subscription. It
+	// returns false when the user has none or already excludes ticker.
+	ExcludeTicker(ctx context.Context, userID uuid.UUID, ticker string) (bool, error)
+	// FindRecipients returns the active users allowed to receive signals who
+	// have a subscription matching signal
+	FindRecipients(ctx context.Context, signal *models.Signal) ([]*models.User, error)
+}
+
+type subscriptionRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSubscriptionRepository(db *pgxpool.Pool) SubscriptionRepository {
+	return &subscriptionRepository{db: db}
+}
+
+const subscriptionColumns = `id, user_id, ticker, sentiment, min_confluence_score::float8, min_backtest_win_rate::float8, excluded_tickers, created_at, updated_at`
+
+func scanSubscription(row pgx.Row) (*models.SignalSubscription, error) {
+	var subscription models.SignalSubscription
+	err := row.Scan(
+		&subscription.ID, &subscription.UserID, &subscription.Ticker, &subscription.Sentiment,
+		&subscription.MinConfluenceScore, &subscription.MinBacktestWinRate, &subscription.ExcludedTickers,
+		&subscription.CreatedAt, &subscription.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *subscriptionRepository) Upsert(ctx context.Context, subscription *models.SignalSubscription) (*models.SignalSubscription, error) {
+	query := `
+		INSERT INTO signal_subscriptions (user_id, ticker, sentiment, min_confluence_score, min_backtest_win_rate)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, (COALESCE(ticker, ''))) DO UPDATE
+		SET sentiment = EXCLUDED.sentiment,
+			min_confluence_score = EXCLUDED.min_confluence_score,
+			min_backtest_win_rate = EXCLUDED.min_backtest_win_rate,
+			excluded_tickers = EXCLUDED.excluded_tickers,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING ` + subscriptionColumns
+
+	saved, err := scanSubscription(r.db.QueryRow(ctx, query,
+		subscription.UserID, subscription.Ticker, subscription.Sentiment,
+		subscription.MinConfluenceScore, subscription.MinBacktestWinRate,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to save subscription: %w", err)
+	}
+
+	return saved, nil
+}
+
+func (r *subscriptionRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.SignalSubscription, error) {
+	query := `SELECT ` + subscriptionColumns + ` FROM signal_subscriptions WHERE user_id = $1 ORDER BY ticker NULLS FIRST`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subscriptions := []*models.SignalSubscription{}
+	for rows.Next() {
+		subscription, err := scanSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan subscription: %w", err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+func (r *subscriptionRepository) Delete(ctx context.Context, id uuid.UUID) (bool, error) {
+	result, err := r.db.Exec(ctx, `DELETE FROM signal_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete subscription: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+func (r *subscriptionRepository) DeleteByUser(ctx context.Context, userID uuid.UUID, ticker *string) (int, error) {
+	query := `DELETE FROM signal_subscriptions WHERE user_id = $1 AND ($2::varchar IS NULL OR ticker = $2)`
+
+	result, err := r.db.Exec(ctx, query, userID, ticker)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete subscriptions: %w", err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
+
+func (r *subscriptionRepository) ExcludeTicker(ctx context.Context, userID uuid.UUID, ticker string) (bool, error) {
+	query := `
+		UPDATE signal_subscriptions
+		SET excluded_tickers = array_append(excluded_tickers, $2::varchar), updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND ticker IS NULL AND NOT ($2::varchar = ANY(excluded_tickers))
+	`
+
+	result, err := r.db.Exec(ctx, query, userID, ticker)
+	if err != nil {
+		return false, fmt.Errorf("failed to exclude ticker from subscription: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+func (r *subscriptionRepository) FindRecipients(ctx context.Context, signal *models.Signal) ([]*models.User, error) {
+	query := `
+		SELECT u.id, u.name, u.phone, u.email, u.is_active, u.language, u.created_at, u.updated_at
+		FROM users u
+		WHERE u.is_active = true
+		AND EXISTS (
+			SELECT 1 FROM user_roles ur
+			JOIN role_permissions rp ON rp.role = ur.role
+			WHERE ur.user_id = u.id AND rp.permission = $5
+		)
+		AND EXISTS (
+			SELECT 1 FROM signal_subscriptions s
+			WHERE s.user_id = u.id
+			AND (s.ticker = UPPER($1) OR (s.ticker IS NULL AND NOT (UPPER($1) = ANY(s.excluded_tickers))))
+			AND (s.sentiment IS NULL OR s.sentiment = LOWER($2))
+			AND (s.min_confluence_score IS NULL OR s.min_confluence_score <= $3)
+			AND (s.min_backtest_win_rate IS NULL OR s.min_backtest_win_rate <= $4)
+		)
+		ORDER BY u.created_at
+	`
+
+	rows, err := r.db.Query(ctx, query,
+		signal.Ticker, signal.OverallSentiment, signal.ConfluenceScore, signal.BacktestWinRate,
+		models.PermissionSignalsReceive,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find signal recipients: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*models.User
+	for rows.Next() {
+		var user models.User
+		err := rows.Scan(&user.ID, &user.Name, &user.Phone, &user.Email, &user.IsActive, &user.Language, &user.CreatedAt, &user.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan signal recipient: %w", err)
+		}
+		users = append(users, &user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over signal recipients: %w", err)
+	}
+
+	return users, nil
+}
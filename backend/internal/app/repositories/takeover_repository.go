@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TakeoverRepository interface {
+	// Get returns nil without error when the conversation is not taken over
+	Get(ctx context.Context, phone string) (*models.ConversationTakeover, error)
+	List(ctx context.Context) ([]*models.ConversationTakeover, error)
+	// Create stores takeover and reports false when the conversation was
+	// already taken over
+	Create(ctx context.Context, takeover *models.ConversationTakeover) (bool, error)
+	// Delete reports false when the conversation was not taken over
+	Delete(ctx context.Context, phone string) (bool, error)
+}
+
+type takeoverRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewTakeoverRepository(db *pgxpool.Pool) TakeoverRepository {
+	return &takeoverRepository{db: db}
+}
+
+func (r *takeoverRepository) Get(ctx context.Context, phone string) (*models.ConversationTakeover, error) {
+	query := `
+		SELECT phone, agent_user_id, agent_name, started_at
+		FROM conversation_takeovers
+		WHERE phone = $1
+	`
+
+	var takeover models.ConversationTakeover
+	err := r.db.QueryRow(ctx, query, phone).Scan(
+		&takeover.Phone, &takeover.AgentUserID, &takeover.AgentName, &takeover.StartedAt,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get takeover: %w", err)
+	}
+
+	return &takeover, nil
+}
+
+func (r *takeoverRepository) List(ctx context.Context) ([]*models.ConversationTakeover, error) {
+	query := `
+		SELECT phone, agent_user_id, agent_name, started_at
+		FROM conversation_takeovers
+		ORDER BY started_at
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list takeovers: %w", err)
+	}
+	defer rows.Close()
+
+	takeovers := []*models.ConversationTakeover{}
+	for rows.Next() {
+		var takeover models.ConversationTakeover
+		if err := rows.Scan(&takeover.Phone, &takeover.AgentUserID, &takeover.AgentName, &takeover.StartedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan takeover: %w", err)
+		}
+		takeovers = append(takeovers, &takeover)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over takeovers: %w", err)
+	}
+
+	return takeovers, nil
+}
+
+func (r *takeoverRepository) Create(ctx context.Context, takeover *models.ConversationTakeover) (bool, error) {
+	query := `
+		INSERT INTO conversation_takeovers (phone, agent_user_id, agent_name)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (phone) DO NOTHING
+		RETURNING started_at
+	`
+
+	err := r.db.QueryRow(ctx, query, takeover.Phone, takeover.AgentUserID, takeover.AgentName).Scan(&takeover.StartedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to create takeover: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *takeoverRepository) Delete(ctx context.Context, phone string) (bool, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM conversation_takeovers WHERE phone = $1`, phone)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete takeover: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,302 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TrackedSignalRepository interface {
+	// Create stores the tracked signal with signal as its original payload. It
+	// returns false, storing nothing, when a signal with the same ticker, last
+	// date and direction exists.
+	Create(ctx context.Context, tracked *models.TrackedSignal, signal *models.Signal) (bool, error)
+	// GetByIdentity returns the signal of ticker, lastDate and direction, or nil
+	GetByIdentity(ctx context.Context, ticker, lastDate, direction string) (*models.TrackedSignal, error)
+	// Amend replaces the levels and payload of an open signal. It returns false
+	// when the signal is not open or signal equals the stored payload.
+	Amend(ctx context.Context, tracked *models.TrackedSignal, signal *models.Signal) (bool, error)
+	// Cancel marks an open signal as cancelled; it returns false when the
+	// signal is not open
+	Cancel(ctx context.Context, id uuid.UUID) (bool, error)
+	// List returns the most recent signals matching filter
+	List(ctx context.Context, filter *models.TrackedSignalFilter, limit int) ([]*models.TrackedSignal, error)
+	// ListOpen returns the open signals of ticker dated before date, oldest first
+	ListOpen(ctx context.Context, ticker, date string) ([]*models.TrackedSignal, error)
+	// Resolve records the outcome of an open signal; it returns false when the
+	// signal was already resolved
+	Resolve(ctx context.Context, tracked *models.TrackedSignal) (bool, error)
+	// SavePrice stores a price bar, replacing the bar of the same ticker and date
+	SavePrice(ctx context.Context, price *models.SignalPrice) error
+	// ListPrices returns the bars of ticker dated after date, oldest first
+	ListPrices(ctx context.Context, ticker, date string) ([]*models.SignalPrice, error)
+	// Stats aggregates outcomes by models.SignalStatsByTicker or
+	// models.SignalStatsByConfluence (in buckets of bucketSize points), or over
+	// every signal as a single "all" group when groupBy is empty
+	Stats(ctx context.Context, groupBy string, bucketSize float64) ([]*models.SignalStats, error)
+}
+
+type trackedSignalRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewTrackedSignalRepository(db *pgxpool.Pool) TrackedSignalRepository {
+	return &trackedSignalRepository{db: db}
+}
+
+const trackedSignalColumns = `id, ticker, last_date::text, direction, entry_price::float8, stop::float8, target::float8,
+	risk_reward::float8, backtest_win_rate::float8, total_trades, confluence_score::float8, COALESCE(overall_sentiment, ''),
+	outcome, exit_price::float8, r_multiple::float8, expires_on::text, resolved_on::text, created_at, updated_at`
+
+func scanTrackedSignal(row pgx.Row) (*models.TrackedSignal, error) {
+	var tracked models.TrackedSignal
+	err := row.Scan(
+		&tracked.ID, &tracked.Ticker, &tracked.LastDate, &tracked.Direction, &tracked.EntryPrice, &tracked.Stop, &tracked.Target,
+		&tracked.RiskReward, &tracked.BacktestWinRate, &tracked.TotalTrades, &tracked.ConfluenceScore, &tracked.OverallSentiment,
+		&tracked.Outcome, &tracked.ExitPrice, &tracked.RMultiple, &tracked.ExpiresOn, &tracked.ResolvedOn, &tracked.CreatedAt, &tracked.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &tracked, nil
+}
+
+func (r *trackedSignalRepository) Create(ctx context.Context, tracked *models.TrackedSignal, signal *models.Signal) (bool, error) {
+	query := `
+		INSERT INTO tracked_signals (ticker, last_date, direction, entry_price, stop, target, risk_reward,
+			backtest_win_rate, total_trades, confluence_score, overall_sentiment, payload, outcome, expires_on)
+		VALUES ($1, $2::date, $3, $4, $5, $6, $7, $8, $9, $10, NULLIF($11, ''), $12, $13, $14::date)
+		ON CONFLICT (ticker, last_date, direction) DO NOTHING
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		tracked.Ticker, tracked.LastDate, tracked.Direction, tracked.EntryPrice, tracked.Stop, tracked.Target, tracked.RiskReward,
+		tracked.BacktestWinRate, tracked.TotalTrades, tracked.ConfluenceScore, tracked.OverallSentiment, signal, tracked.Outcome, tracked.ExpiresOn,
+	).Scan(&tracked.ID, &tracked.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to create tracked signal: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *trackedSignalRepository) GetByIdentity(ctx context.Context, ticker, lastDate, direction string) (*models.TrackedSignal, error) {
+	query := `SELECT ` + trackedSignalColumns + ` FROM tracked_signals
+		WHERE ticker = $1 AND last_date = $2::date AND direction = $3`
+
+	tracked, err := scanTrackedSignal(r.db.QueryRow(ctx, query, ticker, lastDate, direction))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get tracked signal: %w", err)
+	}
+
+	return tracked, nil
+}
+
+func (r *trackedSignalRepository) Amend(ctx context.Context, tracked *models.TrackedSignal, signal *models.Signal) (bool, error) {
+	query := `
+		UPDATE tracked_signals
+		SET entry_price = $2, stop = $3, target = $4, risk_reward = $5, backtest_win_rate = $6, total_trades = $7,
+			confluence_score = $8, overall_sentiment = NULLIF($9, ''), payload = $10, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND outcome = $11 AND payload IS DISTINCT FROM $10::jsonb
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		tracked.ID, tracked.EntryPrice, tracked.Stop, tracked.Target, tracked.RiskReward, tracked.BacktestWinRate, tracked.TotalTrades,
+		tracked.ConfluenceScore, tracked.OverallSentiment, signal, models.SignalOutcomeOpen,
+	).Scan(&tracked.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to amend tracked signal: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *trackedSignalRepository) Cancel(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `
+		UPDATE tracked_signals
+		SET outcome = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND outcome = $3
+	`
+
+	result, err := r.db.Exec(ctx, query, id, models.SignalOutcomeCancelled, models.SignalOutcomeOpen)
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel tracked signal: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+func (r *trackedSignalRepository) List(ctx context.Context, filter *models.TrackedSignalFilter, limit int) ([]*models.TrackedSignal, error) {
+	query := `SELECT ` + trackedSignalColumns + ` FROM tracked_signals
+		WHERE ($1 = '' OR ticker = $1) AND ($2 = '' OR outcome = $2)
+		ORDER BY created_at DESC
+		LIMIT $3`
+
+	return r.query(ctx, query, filter.Ticker, filter.Outcome, limit)
+}
+
+func (r *trackedSignalRepository) ListOpen(ctx context.Context, ticker, date string) ([]*models.TrackedSignal, error) {
+	query := `SELECT ` + trackedSignalColumns + ` FROM tracked_signals
+		WHERE ticker = $1 AND outcome = $2 AND last_date < $3::date
+		ORDER BY created_at`
+
+	return r.query(ctx, query, ticker, models.SignalOutcomeOpen, date)
+}
+
+func (r *trackedSignalRepository) query(ctx context.Context, query string, args ...any) ([]*models.TrackedSignal, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tracked signals: %w", err)
+	}
+	defer rows.Close()
+
+	signals := []*models.TrackedSignal{}
+	for rows.Next() {
+		tracked, err := scanTrackedSignal(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tracked signal: %w", err)
+		}
+		signals = append(signals, tracked)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over tracked signals: %w", err)
+	}
+
+	return signals, nil
+}
+
+func (r *trackedSignalRepository) Resolve(ctx context.Context, tracked *models.TrackedSignal) (bool, error) {
+	query := `
+		UPDATE tracked_signals
+		SET outcome = $2, exit_price = $3, r_multiple = $4, resolved_on = $5::date
+		WHERE id = $1 AND outcome = $6
+	`
+
+	result, err := r.db.Exec(ctx, query, tracked.ID, tracked.Outcome, tracked.ExitPrice, tracked.RMultiple, tracked.ResolvedOn, models.SignalOutcomeOpen)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve tracked signal: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+func (r *trackedSignalRepository) SavePrice(ctx context.Context, price *models.SignalPrice) error {
+	query := `
+		INSERT INTO signal_prices (ticker, price_date, high, low, close)
+		VALUES ($1, $2::date, $3, $4, $5)
+		ON CONFLICT (ticker, price_date) DO UPDATE
+		SET high = EXCLUDED.high, low = EXCLUDED.low, close = EXCLUDED.close, created_at = CURRENT_TIMESTAMP
+	`
+
+	if _, err := r.db.Exec(ctx, query, price.Ticker, price.Date, price.High, price.Low, price.Close); err != nil {
+		return fmt.Errorf("failed to save signal price: %w", err)
+	}
+
+	return nil
+}
+
+func (r *trackedSignalRepository) ListPrices(ctx context.Context, ticker, date string) ([]*models.SignalPrice, error) {
+	query := `
+		SELECT ticker, price_date::text, high::float8, low::float8, close::float8
+		FROM signal_prices
+		WHERE ticker = $1 AND price_date > $2::date
+		ORDER BY price_date
+	`
+
+	rows, err := r.db.Query(ctx, query, ticker, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signal prices: %w", err)
+	}
+	defer rows.Close()
+
+	var prices []*models.SignalPrice
+	for rows.Next() {
+		var price models.SignalPrice
+		if err := rows.Scan(&price.Ticker, &price.Date, &price.High, &price.Low, &price.Close); err != nil {
+			return nil, fmt.Errorf("failed to scan signal price: %w", err)
+		}
+		prices = append(prices, &price)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over signal prices: %w", err)
+	}
+
+	return prices, nil
+}
+
+func (r *trackedSignalRepository) Stats(ctx context.Context, groupBy string, bucketSize float64) ([]*models.SignalStats, error) {
+	group := `'all'::text`
+	args := []any{}
+	switch groupBy {
+	case models.SignalStatsByTicker:
+		group = `ticker`
+	case models.SignalStatsByConfluence:
+		group = `(FLOOR(confluence_score / $1) * $1)::float8`
+		args = append(args, bucketSize)
+	}
+
+	query := `
+		SELECT ` + group + ` AS grp,
+			COUNT(*)::int,
+			COUNT(*) FILTER (WHERE outcome = 'open')::int,
+			COUNT(*) FILTER (WHERE outcome = 'target_hit')::int,
+			COUNT(*) FILTER (WHERE outcome = 'stopped_out')::int,
+			COUNT(*) FILTER (WHERE outcome = 'expired')::int,
+			AVG(r_multiple) FILTER (WHERE outcome <> 'open')::float8,
+			AVG(backtest_win_rate)::float8
+		FROM tracked_signals
+		WHERE outcome <> 'cancelled'
+		GROUP BY grp
+		ORDER BY grp
+	`
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signal stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := []*models.SignalStats{}
+	for rows.Next() {
+		var row models.SignalStats
+		var bucket float64
+		var group any = &row.Group
+		if groupBy == models.SignalStatsByConfluence {
+			group = &bucket
+		}
+
+		err := rows.Scan(group, &row.Signals, &row.Open, &row.TargetHit, &row.StoppedOut, &row.Expired, &row.AvgRMultiple, &row.AvgBacktestWinRate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan signal stats: %w", err)
+		}
+		if groupBy == models.SignalStatsByConfluence {
+			row.Group = strconv.FormatFloat(bucket, 'f', -1, 64) + "-" + strconv.FormatFloat(bucket+bucketSize, 'f', -1, 64)
+		}
+		stats = append(stats, &row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over signal stats: %w", err)
+	}
+
+	return stats, nil
+}
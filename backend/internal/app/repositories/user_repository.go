@@ -2,23 +2,43 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/pkg/phonenumber"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// uniqueViolation is the PostgreSQL error code for a unique constraint violation
+const uniqueViolation = "23505"
+
+var (
+	ErrUserNotFound  = errors.New("user not found")
+	ErrDuplicateUser = errors.New("a user with this phone or email already exists")
+)
+
+// UserRepository stores phones normalised by the phonenumber package and
+// normalises the phones it is given, so "0812...", "+62812..." and "62812..."
+// are the same user. An invalid phone matches no user.
 type UserRepository interface {
 	GetByPhone(ctx context.Context, phone string) (*models.User, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
 	Create(ctx context.Context, user *models.CreateUserRequest) (*models.User, error)
 	Update(ctx context.Context, id uuid.UUID, user *models.UpdateUserRequest) (*models.User, error)
+	// Upsert creates the user, or updates the user that already has its phone or
+	// email. It reports whether a new user was created.
+	Upsert(ctx context.Context, user *models.CreateUserRequest) (*models.User, bool, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	IsEligible(ctx context.Context, phone string) (bool, error)
 	GetEligibleUsers(ctx context.Context) ([]*models.User, error)
+	// List returns one page of users matching filter, newest first, with the total match count
+	List(ctx context.Context, filter *models.UserFilter, limit, offset int) ([]*models.User, int, error)
 }
 
 type userRepository struct {
@@ -30,21 +50,26 @@ func NewUserRepository(db *pgxpool.Pool) UserRepository {
 }
 
 func (r *userRepository) GetByPhone(ctx context.Context, phone string) (*models.User, error) {
+	phone, err := phonenumber.Normalize(phone)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
 	query := `
-		SELECT id, name, phone, email, is_active, created_at, updated_at 
+		SELECT id, name, phone, email, is_active, language, created_at, updated_at 
 		FROM users 
 		WHERE phone = $1
 	`
 
 	var user models.User
-	err := r.db.QueryRow(ctx, query, phone).Scan(
+	err = r.db.QueryRow(ctx, query, phone).Scan(
 		&user.ID, &user.Name, &user.Phone, &user.Email,
-		&user.IsActive, &user.CreatedAt, &user.UpdatedAt,
+		&user.IsActive, &user.Language, &user.CreatedAt, &user.UpdatedAt,
 	)
 
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("user not found")
+			return nil, ErrUserNotFound
 		}
 		return nil, fmt.Errorf("failed to get user by phone: %w", err)
 	}
@@ -54,7 +79,7 @@ func (r *userRepository) GetByPhone(ctx context.Context, phone string) (*models.
 
 func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
 	query := `
-		SELECT id, name, phone, email, is_active, created_at, updated_at 
+		SELECT id, name, phone, email, is_active, language, created_at, updated_at 
 		FROM users 
 		WHERE id = $1
 	`
@@ -62,12 +87,12 @@ func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Use
 	var user models.User
 	err := r.db.QueryRow(ctx, query, id).Scan(
 		&user.ID, &user.Name, &user.Phone, &user.Email,
-		&user.IsActive, &user.CreatedAt, &user.UpdatedAt,
+		&user.IsActive, &user.Language, &user.CreatedAt, &user.UpdatedAt,
 	)
 
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("user not found")
+			return nil, ErrUserNotFound
 		}
 		return nil, fmt.Errorf("failed to get user by id: %w", err)
 	}
@@ -75,26 +100,102 @@ func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Use
 	return &user, nil
 }
 
+// insertUserQuery creates a user with the default subscriber role, subscribed
+// to signals of every ticker
+const insertUserQuery = `
+	WITH inserted AS (
+		INSERT INTO users (name, phone, email, language)
+		VALUES ($1, $2, $3, COALESCE(NULLIF($4, ''), 'id'))
+		RETURNING id, name, phone, email, is_active, language, created_at, updated_at
+	), default_role AS (
+		INSERT INTO user_roles (user_id, role)
+		SELECT id, 'subscriber' FROM inserted
+	), default_subscription AS (
+		INSERT INTO signal_subscriptions (user_id)
+		SELECT id FROM inserted
+	)
+	SELECT id, name, phone, email, is_active, language, created_at, updated_at FROM inserted
+`
+
 func (r *userRepository) Create(ctx context.Context, req *models.CreateUserRequest) (*models.User, error) {
-	query := `
-		INSERT INTO users (name, phone, email) 
-		VALUES ($1, $2, $3) 
-		RETURNING id, name, phone, email, is_active, created_at, updated_at
-	`
+	phone, err := phonenumber.Normalize(req.Phone)
+	if err != nil {
+		return nil, err
+	}
 
 	var user models.User
-	err := r.db.QueryRow(ctx, query, req.Name, req.Phone, req.Email).Scan(
+	err = r.db.QueryRow(ctx, insertUserQuery, req.Name, phone, req.Email, req.Language).Scan(
 		&user.ID, &user.Name, &user.Phone, &user.Email,
-		&user.IsActive, &user.CreatedAt, &user.UpdatedAt,
+		&user.IsActive, &user.Language, &user.CreatedAt, &user.UpdatedAt,
 	)
 
 	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrDuplicateUser
+		}
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
 	return &user, nil
 }
 
+func (r *userRepository) Upsert(ctx context.Context, req *models.CreateUserRequest) (*models.User, bool, error) {
+	phone, err := phonenumber.Normalize(req.Phone)
+	if err != nil {
+		return nil, false, err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `SELECT id FROM users WHERE phone = $1 OR email = $2 FOR UPDATE`, phone, req.Email)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to find existing user: %w", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to find existing user: %w", err)
+	}
+
+	var query string
+	args := []interface{}{req.Name, phone, req.Email, req.Language}
+	switch len(ids) {
+	case 0:
+		query = insertUserQuery
+	case 1:
+		query = `
+			UPDATE users
+			SET name = $1, phone = $2, email = $3, language = COALESCE(NULLIF($4, ''), language), updated_at = CURRENT_TIMESTAMP
+			WHERE id = $5
+			RETURNING id, name, phone, email, is_active, language, created_at, updated_at
+		`
+		args = append(args, ids[0])
+	default:
+		return nil, false, fmt.Errorf("%w: phone and email belong to different users", ErrDuplicateUser)
+	}
+
+	var user models.User
+	err = tx.QueryRow(ctx, query, args...).Scan(
+		&user.ID, &user.Name, &user.Phone, &user.Email,
+		&user.IsActive, &user.Language, &user.CreatedAt, &user.UpdatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, false, ErrDuplicateUser
+		}
+		return nil, false, fmt.Errorf("failed to upsert user: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, false, fmt.Errorf("failed to commit user upsert: %w", err)
+	}
+
+	return &user, len(ids) == 0, nil
+}
+
 func (r *userRepository) Update(ctx context.Context, id uuid.UUID, req *models.UpdateUserRequest) (*models.User, error) {
 	// Build dynamic query based on provided fields
 	setParts := []string{}
@@ -107,12 +208,28 @@ func (r *userRepository) Update(ctx context.Context, id uuid.UUID, req *models.U
 		argIndex++
 	}
 
+	if req.Phone != "" {
+		phone, err := phonenumber.Normalize(req.Phone)
+		if err != nil {
+			return nil, err
+		}
+		setParts = append(setParts, fmt.Sprintf("phone = $%d", argIndex))
+		args = append(args, phone)
+		argIndex++
+	}
+
 	if req.Email != "" {
 		setParts = append(setParts, fmt.Sprintf("email = $%d", argIndex))
 		args = append(args, req.Email)
 		argIndex++
 	}
 
+	if req.Language != "" {
+		setParts = append(setParts, fmt.Sprintf("language = $%d", argIndex))
+		args = append(args, req.Language)
+		argIndex++
+	}
+
 	if req.IsActive != nil {
 		setParts = append(setParts, fmt.Sprintf("is_active = $%d", argIndex))
 		args = append(args, *req.IsActive)
@@ -130,20 +247,23 @@ func (r *userRepository) Update(ctx context.Context, id uuid.UUID, req *models.U
 		UPDATE users 
 		SET %s
 		WHERE id = $%d 
-		RETURNING id, name, phone, email, is_active, created_at, updated_at
-	`, fmt.Sprintf("%s", setParts), argIndex)
+		RETURNING id, name, phone, email, is_active, language, created_at, updated_at
+	`, strings.Join(setParts, ", "), argIndex)
 
 	args = append(args, id)
 
 	var user models.User
 	err := r.db.QueryRow(ctx, query, args...).Scan(
 		&user.ID, &user.Name, &user.Phone, &user.Email,
-		&user.IsActive, &user.CreatedAt, &user.UpdatedAt,
+		&user.IsActive, &user.Language, &user.CreatedAt, &user.UpdatedAt,
 	)
 
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("user not found")
+			return nil, ErrUserNotFound
+		}
+		if isUniqueViolation(err) {
+			return nil, ErrDuplicateUser
 		}
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
@@ -160,17 +280,22 @@ func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("user not found")
+		return ErrUserNotFound
 	}
 
 	return nil
 }
 
 func (r *userRepository) IsEligible(ctx context.Context, phone string) (bool, error) {
+	phone, err := phonenumber.Normalize(phone)
+	if err != nil {
+		return false, nil // An invalid phone belongs to no user
+	}
+
 	query := `SELECT is_active FROM users WHERE phone = $1`
 
 	var isActive bool
-	err := r.db.QueryRow(ctx, query, phone).Scan(&isActive)
+	err = r.db.QueryRow(ctx, query, phone).Scan(&isActive)
 
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -184,7 +309,7 @@ func (r *userRepository) IsEligible(ctx context.Context, phone string) (bool, er
 
 func (r *userRepository) GetEligibleUsers(ctx context.Context) ([]*models.User, error) {
 	query := `
-		SELECT id, name, phone, email, is_active, created_at, updated_at 
+		SELECT id, name, phone, email, is_active, language, created_at, updated_at 
 		FROM users 
 		WHERE is_active = true
 		ORDER BY name
@@ -201,7 +326,7 @@ func (r *userRepository) GetEligibleUsers(ctx context.Context) ([]*models.User,
 		var user models.User
 		err := rows.Scan(
 			&user.ID, &user.Name, &user.Phone, &user.Email,
-			&user.IsActive, &user.CreatedAt, &user.UpdatedAt,
+			&user.IsActive, &user.Language, &user.CreatedAt, &user.UpdatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
@@ -215,3 +340,79 @@ func (r *userRepository) GetEligibleUsers(ctx context.Context) ([]*models.User,
 
 	return users, nil
 }
+
+func (r *userRepository) List(ctx context.Context, filter *models.UserFilter, limit, offset int) ([]*models.User, int, error) {
+	conditions := []string{}
+	args := []interface{}{}
+
+	if filter.Search != "" {
+		args = append(args, "%"+escapeLike(filter.Search)+"%")
+		condition := fmt.Sprintf("name ILIKE $%[1]d OR phone ILIKE $%[1]d OR email ILIKE $%[1]d", len(args))
+
+		// A full phone number in any notation also finds its normalised form
+		if phone, err := phonenumber.Normalize(filter.Search); err == nil {
+			args = append(args, phone)
+			condition += fmt.Sprintf(" OR phone = $%d", len(args))
+		}
+		conditions = append(conditions, "("+condition+")")
+	}
+
+	if filter.IsActive != nil {
+		args = append(args, *filter.IsActive)
+		conditions = append(conditions, fmt.Sprintf("is_active = $%d", len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM users "+where, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, name, phone, email, is_active, language, created_at, updated_at
+		FROM users
+		%s
+		ORDER BY created_at DESC, id
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)+1, len(args)+2)
+
+	rows, err := r.db.Query(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+	}
+	defer rows.Close()
+
+	users := []*models.User{}
+	for rows.Next() {
+		var user models.User
+		err := rows.Scan(
+			&user.ID, &user.Name, &user.Phone, &user.Email,
+			&user.IsActive, &user.Language, &user.CreatedAt, &user.UpdatedAt,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, &user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating over users: %w", err)
+	}
+
+	return users, total, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
+
+// escapeLike escapes the LIKE wildcards in s so it matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
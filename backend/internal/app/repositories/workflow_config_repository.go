@@ -4,12 +4,17 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type WorkflowConfigRepository interface {
 	GetActiveWorkflowType(ctx context.Context) (string, error)
+	GetConfig(ctx context.Context) (*models.WorkflowConfig, error)
+	// SetWorkflowType routes inbound messages to workflowType and activates the config
+	SetWorkflowType(ctx context.Context, workflowType string) (*models.WorkflowConfig, error)
 }
 
 type workflowConfigRepository struct {
@@ -39,3 +44,41 @@ func (r *workflowConfigRepository) GetActiveWorkflowType(ctx context.Context) (s
 
 	return workflowType, nil
 }
+
+func (r *workflowConfigRepository) GetConfig(ctx context.Context) (*models.WorkflowConfig, error) {
+	query := `
+		SELECT id, workflow_type, is_active, updated_at
+		FROM workflow_config
+		WHERE id = 1
+	`
+
+	var config models.WorkflowConfig
+	err := r.db.QueryRow(ctx, query).Scan(&config.ID, &config.WorkflowType, &config.IsActive, &config.UpdatedAt)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("no workflow configuration found")
+		}
+		return nil, fmt.Errorf("failed to get workflow configuration: %w", err)
+	}
+
+	return &config, nil
+}
+
+func (r *workflowConfigRepository) SetWorkflowType(ctx context.Context, workflowType string) (*models.WorkflowConfig, error) {
+	query := `
+		INSERT INTO workflow_config (id, workflow_type, is_active, updated_at)
+		VALUES (1, $1, true, CURRENT_TIMESTAMP)
+		ON CONFLICT (id) DO UPDATE
+		SET workflow_type = EXCLUDED.workflow_type, is_active = true, updated_at = CURRENT_TIMESTAMP
+		RETURNING id, workflow_type, is_active, updated_at
+	`
+
+	var config models.WorkflowConfig
+	err := r.db.QueryRow(ctx, query, workflowType).Scan(&config.ID, &config.WorkflowType, &config.IsActive, &config.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set workflow type: %w", err)
+	}
+
+	return &config, nil
+}
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/handlers"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader carries an API key; "Authorization: Bearer <key or JWT>" is also accepted
+const APIKeyHeader = "X-API-Key"
+
+// AuthMiddleware authenticates the request with an API key, a JWT or an operator
+// session cookie and stores the principal for RequirePermission and the
+// handlers. Unlike webhook verification it fails closed: a request without valid
+// credentials is refused, and a browser asking for a page is sent to the
+// operator login. Requests authenticated by the session cookie must also carry
+// the CSRF token unless they are safe (GET, HEAD, OPTIONS).
+func AuthMiddleware(authService services.AuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		credential, fromSession := requestCredential(c)
+
+		principal, err := authService.Authenticate(c.Request.Context(), credential)
+		if err != nil {
+			if !errors.Is(err, services.ErrInvalidCredentials) {
+				log.Printf("[Server] Failed to authenticate request %s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+				c.JSON(http.StatusInternalServerError, models.APIResponse{
+					Success: false,
+					Error:   "Failed to authenticate",
+				})
+				c.Abort()
+				return
+			}
+
+			log.Printf("[Server] Rejected request %s %s from %s: invalid credentials", c.Request.Method, c.Request.URL.Path, c.ClientIP())
+			if fromSession {
+				handlers.ClearSessionCookie(c)
+			}
+			if wantsPage(c) {
+				c.Redirect(http.StatusSeeOther, handlers.OperatorLoginPath+"?next="+url.QueryEscape(c.Request.URL.Path))
+			} else {
+				c.JSON(http.StatusUnauthorized, models.APIResponse{
+					Success: false,
+					Error:   "Invalid or missing credentials",
+				})
+			}
+			c.Abort()
+			return
+		}
+
+		if fromSession {
+			principal.Method = models.AuthMethodSession
+			if !isSafeMethod(c.Request.Method) && !handlers.ValidCSRFToken(c) {
+				log.Printf("[Server] Refused %s %s to %s: invalid CSRF token", c.Request.Method, c.Request.URL.Path, principal.Name)
+				c.JSON(http.StatusForbidden, models.APIResponse{
+					Success: false,
+					Error:   "Invalid or missing CSRF token",
+				})
+				c.Abort()
+				return
+			}
+		}
+
+		c.Set(handlers.PrincipalKey, principal)
+		c.Next()
+	}
+}
+
+// requestCredential returns the API key or token of the request, and whether it
+// came from the operator session cookie rather than a header
+func requestCredential(c *gin.Context) (string, bool) {
+	if key := c.GetHeader(APIKeyHeader); key != "" {
+		return key, false
+	}
+	if token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer "); token != "" {
+		return token, false
+	}
+	if session, err := c.Cookie(handlers.SessionCookieName); err == nil && session != "" {
+		return session, true
+	}
+	return "", false
+}
+
+// wantsPage reports whether a browser is navigating to an HTML page
+func wantsPage(c *gin.Context) bool {
+	return c.Request.Method == http.MethodGet && strings.Contains(c.GetHeader("Accept"), "text/html")
+}
+
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// RequirePermission refuses principals whose roles do not grant permission.
+// It must run after AuthMiddleware.
+func RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := handlers.CurrentPrincipal(c)
+		if principal == nil {
+			c.JSON(http.StatusUnauthorized, models.APIResponse{
+				Success: false,
+				Error:   "Invalid or missing credentials",
+			})
+			c.Abort()
+			return
+		}
+
+		if !principal.HasPermission(permission) {
+			log.Printf("[Server] Refused %s %s to %s: missing permission %s", c.Request.Method, c.Request.URL.Path, principal.Name, permission)
+			c.JSON(http.StatusForbidden, models.APIResponse{
+				Success: false,
+				Error:   "Permission denied: requires " + permission,
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
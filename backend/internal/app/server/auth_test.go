@@ -0,0 +1,213 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/handlers"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/services"
+	"github.com/fajarAnd/workshop-brin/wa-service/testutils/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestAuthMiddleware
+// Summary: Test API key and token authentication with role permissions
+// Purpose: Validate both credential headers, rejection of bad credentials and refusal of principals without the permission
+func TestAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	admin := &models.Principal{Name: "ADMIN_API_KEY", Permissions: []string{models.PermissionUsersManage}}
+	agent := &models.Principal{Name: "Siti", Permissions: []string{models.PermissionConversationsRead}}
+
+	tests := []struct {
+		name               string
+		headers            map[string]string
+		expectedCredential string
+		principal          *models.Principal
+		authErr            error
+		expectedStatus     int
+	}{
+		{
+			name:               "Valid X-API-Key",
+			headers:            map[string]string{APIKeyHeader: "admin-key"},
+			expectedCredential: "admin-key",
+			principal:          admin,
+			expectedStatus:     http.StatusOK,
+		},
+		{
+			name:               "Valid bearer token",
+			headers:            map[string]string{"Authorization": "Bearer a.b.c"},
+			expectedCredential: "a.b.c",
+			principal:          admin,
+			expectedStatus:     http.StatusOK,
+		},
+		{
+			name:               "Missing permission",
+			headers:            map[string]string{APIKeyHeader: "agent-key"},
+			expectedCredential: "agent-key",
+			principal:          agent,
+			expectedStatus:     http.StatusForbidden,
+		},
+		{
+			name:               "Wrong key",
+			headers:            map[string]string{APIKeyHeader: "guess"},
+			expectedCredential: "guess",
+			authErr:            services.ErrInvalidCredentials,
+			expectedStatus:     http.StatusUnauthorized,
+		},
+		{
+			name:               "Missing key",
+			expectedCredential: "",
+			authErr:            services.ErrInvalidCredentials,
+			expectedStatus:     http.StatusUnauthorized,
+		},
+		{
+			name:               "Lookup failure",
+			headers:            map[string]string{APIKeyHeader: "wak_key"},
+			expectedCredential: "wak_key",
+			authErr:            errors.New("connection refused"),
+			expectedStatus:     http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuth := mocks.NewMockAuthService(t)
+			mockAuth.EXPECT().Authenticate(mock.Anything, tt.expectedCredential).Return(tt.principal, tt.authErr)
+
+			router := gin.New()
+			router.Use(AuthMiddleware(mockAuth), RequirePermission(models.PermissionUsersManage))
+			router.GET("/api/v1/admin/users", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users", nil)
+			for header, value := range tt.headers {
+				req.Header.Set(header, value)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+// TestAuthMiddleware_Session
+// Summary: Test operator session cookie authentication
+// Purpose: Validate CSRF checks on unsafe requests, the login redirect for browsers and clearing of stale sessions
+func TestAuthMiddleware_Session(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	operator := &models.Principal{Name: "Siti", Permissions: []string{models.PermissionWhatsAppManage}, Method: models.AuthMethodJWT}
+	csrfToken := strings.Repeat("a", 64)
+
+	tests := []struct {
+		name             string
+		method           string
+		accept           string
+		session          string
+		csrfCookie       string
+		csrfHeader       string
+		principal        *models.Principal
+		authErr          error
+		expectedStatus   int
+		expectedLocation string
+	}{
+		{
+			name:           "Page with session",
+			method:         http.MethodGet,
+			session:        "a.b.c",
+			principal:      operator,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Unsafe request with CSRF token",
+			method:         http.MethodPost,
+			session:        "a.b.c",
+			csrfCookie:     csrfToken,
+			csrfHeader:     csrfToken,
+			principal:      operator,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Unsafe request without CSRF token",
+			method:         http.MethodPost,
+			session:        "a.b.c",
+			csrfCookie:     csrfToken,
+			principal:      operator,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Unsafe request with mismatched CSRF token",
+			method:         http.MethodPost,
+			session:        "a.b.c",
+			csrfCookie:     csrfToken,
+			csrfHeader:     strings.Repeat("b", 64),
+			principal:      operator,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:             "Browser without session is sent to login",
+			method:           http.MethodGet,
+			accept:           "text/html,application/xhtml+xml",
+			authErr:          services.ErrInvalidCredentials,
+			expectedStatus:   http.StatusSeeOther,
+			expectedLocation: "/api/v1/operator/login?next=%2Fapi%2Fv1%2Fwhatsapp%2Flogout",
+		},
+		{
+			name:           "Expired session",
+			method:         http.MethodPost,
+			session:        "expired.b.c",
+			authErr:        services.ErrInvalidCredentials,
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuth := mocks.NewMockAuthService(t)
+			mockAuth.EXPECT().Authenticate(mock.Anything, tt.session).Return(tt.principal, tt.authErr)
+
+			var method string
+			router := gin.New()
+			router.Use(AuthMiddleware(mockAuth), RequirePermission(models.PermissionWhatsAppManage))
+			router.Handle(tt.method, "/api/v1/whatsapp/logout", func(c *gin.Context) {
+				method = handlers.CurrentPrincipal(c).Method
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(tt.method, "/api/v1/whatsapp/logout", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			if tt.session != "" {
+				req.AddCookie(&http.Cookie{Name: handlers.SessionCookieName, Value: tt.session})
+			}
+			if tt.csrfCookie != "" {
+				req.AddCookie(&http.Cookie{Name: handlers.CSRFCookieName, Value: tt.csrfCookie})
+			}
+			if tt.csrfHeader != "" {
+				req.Header.Set(handlers.CSRFHeader, tt.csrfHeader)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, models.AuthMethodSession, method)
+			}
+			if tt.expectedLocation != "" {
+				assert.Equal(t, tt.expectedLocation, w.Header().Get("Location"))
+			}
+			if tt.authErr != nil && tt.session != "" {
+				assert.Contains(t, w.Header().Get("Set-Cookie"), handlers.SessionCookieName+"=;")
+			}
+		})
+	}
+}
@@ -70,6 +70,26 @@ func (w responseWriter) Write(b []byte) (int, error) {
 	return w.ResponseWriter.Write(b)
 }
 
+// redactedHeaders carry credentials and are never logged
+var redactedHeaders = []string{"Authorization", "X-Api-Key", "Cookie", "X-Csrf-Token"}
+
+// redactedBodyRoutes carry credentials in the request or response body
+var redactedBodyRoutes = map[string]bool{
+	"/api/v1/operator/login":           true,
+	"/api/v1/auth/token":               true,
+	"/api/v1/admin/users/:id/api-keys": true,
+}
+
+func loggableHeaders(header http.Header) http.Header {
+	loggable := header.Clone()
+	for _, name := range redactedHeaders {
+		if loggable.Get(name) != "" {
+			loggable.Set(name, "[REDACTED]")
+		}
+	}
+	return loggable
+}
+
 func RequestResponseLoggingMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		startTime := time.Now()
@@ -86,16 +106,26 @@ func RequestResponseLoggingMiddleware() gin.HandlerFunc {
 		}
 		c.Writer = w
 
+		redactBody := redactedBodyRoutes[c.FullPath()]
+		loggedRequestBody := string(requestBody)
+		if redactBody {
+			loggedRequestBody = "[REDACTED]"
+		}
+
 		log.Printf("[Server] Request | %s %s | IP: %s | Headers: %v | Body: %s",
 			c.Request.Method, c.Request.URL.Path, c.ClientIP(),
-			c.Request.Header, string(requestBody))
+			loggableHeaders(c.Request.Header), loggedRequestBody)
 
 		c.Next()
 
 		duration := time.Since(startTime)
+		loggedResponseBody := w.body.String()
+		if redactBody {
+			loggedResponseBody = "[REDACTED]"
+		}
 		log.Printf("[Server] Response | %s %s | Status: %d | Duration: %v | Body: %s",
 			c.Request.Method, c.Request.URL.Path, c.Writer.Status(),
-			duration, w.body.String())
+			duration, loggedResponseBody)
 	}
 }
 
@@ -1,12 +1,17 @@
 package server
 
 import (
+	"github.com/fajarAnd/workshop-brin/wa-service/configs"
 	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/handlers"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/services"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRoutes(r *gin.Engine, handlers *handlers.Handlers) {
+func SetupRoutes(r *gin.Engine, handlers *handlers.Handlers, webhookConfig configs.WebhookConfig, authService services.AuthService) {
+	authenticate := AuthMiddleware(authService)
+
 	// API version group
 	api := r.Group("/api/v1")
 
@@ -18,15 +23,36 @@ func SetupRoutes(r *gin.Engine, handlers *handlers.Handlers) {
 		health.GET("/status", handlers.Health.Status)
 	}
 
-	// Webhook endpoints
+	// Webhook endpoints, signed with a per-source shared secret
 	webhook := api.Group("/webhook")
+	webhook.Use(WebhookSignatureMiddleware(webhookConfig))
 	{
 		webhook.POST("/n8n/response", handlers.Webhook.HandleN8NResponse)
 		webhook.POST("/n8n/signal", handlers.Webhook.HandleN8NSignal)
+		webhook.POST("/n8n/prices", handlers.Webhook.HandleN8NPrices)
+		webhook.POST("/flowise/response", handlers.Webhook.HandleFlowiseResponse)
+	}
+
+	// Token exchange and identity for any authenticated caller
+	auth := api.Group("/auth")
+	auth.Use(authenticate)
+	{
+		auth.POST("/token", handlers.Auth.IssueToken)
+		auth.GET("/me", handlers.Auth.Me)
+	}
+
+	// Operator login for the QR page; the session cookie it sets authenticates
+	// the qr and whatsapp groups in a browser
+	operator := api.Group("/operator")
+	{
+		operator.GET("/login", handlers.Operator.ShowLogin)
+		operator.POST("/login", handlers.Operator.Login)
+		operator.POST("/logout", handlers.Operator.Logout)
 	}
 
 	// QR Code endpoints for WhatsApp bot setup
 	qr := api.Group("/qr")
+	qr.Use(authenticate, RequirePermission(models.PermissionWhatsAppManage))
 	{
 		qr.GET("/", handlers.QR.GetQRCode)                 // JSON response
 		qr.GET("/status", handlers.QR.GetConnectionStatus) // JSON response
@@ -36,11 +62,101 @@ func SetupRoutes(r *gin.Engine, handlers *handlers.Handlers) {
 
 	// WhatsApp management endpoints
 	whatsapp := api.Group("/whatsapp")
+	whatsapp.Use(authenticate, RequirePermission(models.PermissionWhatsAppManage))
 	{
 		whatsapp.POST("/logout", handlers.WhatsApp.Logout)
 		whatsapp.GET("/status", handlers.WhatsApp.GetConnectionStatus)
 	}
 
+	// Background signal broadcasts and their per-recipient delivery status,
+	// and the tracked outcomes of past signals
+	signals := api.Group("/signals")
+	signals.Use(authenticate, RequirePermission(models.PermissionSignalsManage))
+	{
+		signals.GET("/history", handlers.Signal.ListSignals)
+		signals.GET("/rejected", handlers.Signal.ListRejected)
+		signals.GET("/stats", handlers.Signal.GetStats)
+		signals.POST("/prices/import", handlers.Signal.ImportPrices)
+		signals.GET("/templates", handlers.Signal.ListTemplates)
+		signals.POST("/templates/preview", handlers.Signal.PreviewTemplate)
+		signals.PUT("/templates/:kind/:language", handlers.Signal.SaveTemplate)
+		signals.DELETE("/templates/:kind/:language", handlers.Signal.ResetTemplate)
+		signals.GET("/:id", handlers.Signal.GetBroadcast)
+		signals.GET("/:id/deliveries", handlers.Signal.ListDeliveries)
+	}
+
+	// Workflow routing and the access policy for inbound messages
+	routing := api.Group("/routing")
+	routing.Use(authenticate, RequirePermission(models.PermissionRoutingManage))
+	{
+		routing.GET("/workflow", handlers.Routing.GetWorkflow)
+		routing.PUT("/workflow", handlers.Routing.SetWorkflow)
+		routing.GET("/access-policy", handlers.Routing.GetAccessPolicy)
+		routing.PUT("/access-policy", handlers.Routing.UpdateAccessPolicy)
+	}
+
+	// Conversation transcripts for support staff, and takeovers in which an
+	// agent answers instead of the workflow
+	conversations := api.Group("/conversations")
+	conversations.Use(authenticate, RequirePermission(models.PermissionConversationsRead))
+	{
+		conversations.GET("/takeovers", handlers.Conversation.ListTakeovers)
+		conversations.GET("/:phone/messages", handlers.Conversation.GetMessages)
+		conversations.POST("/:phone/messages", RequirePermission(models.PermissionConversationsTakeover), handlers.Conversation.SendMessage)
+		conversations.POST("/:phone/takeover", RequirePermission(models.PermissionConversationsTakeover), handlers.Conversation.StartTakeover)
+		conversations.DELETE("/:phone/takeover", RequirePermission(models.PermissionConversationsTakeover), handlers.Conversation.ReleaseTakeover)
+	}
+
+	// Knowledge base search and document management; changes need knowledge:manage
+	knowledge := api.Group("/knowledge")
+	knowledge.Use(authenticate, RequirePermission(models.PermissionKnowledgeRead))
+	{
+		knowledge.POST("/search", handlers.Knowledge.Search)
+		knowledge.GET("/documents", handlers.Knowledge.ListDocuments)
+		knowledge.GET("/documents/*source", handlers.Knowledge.GetDocument)
+		knowledge.DELETE("/documents/*source", RequirePermission(models.PermissionKnowledgeManage), handlers.Knowledge.DeleteDocument)
+
+		// Embedding spaces and background re-embedding
+		knowledge.GET("/spaces", handlers.Embedding.ListSpaces)
+		knowledge.POST("/spaces", RequirePermission(models.PermissionKnowledgeManage), handlers.Embedding.CreateSpace)
+		knowledge.POST("/spaces/:name/activate", RequirePermission(models.PermissionKnowledgeManage), handlers.Embedding.ActivateSpace)
+		knowledge.POST("/spaces/:name/reembed", RequirePermission(models.PermissionKnowledgeManage), handlers.Embedding.Reembed)
+		knowledge.GET("/embedding-jobs", RequirePermission(models.PermissionKnowledgeManage), handlers.Embedding.ListJobs)
+		knowledge.GET("/embedding-jobs/:id", RequirePermission(models.PermissionKnowledgeManage), handlers.Embedding.GetJob)
+	}
+
+	// Admin API for user management
+	admin := api.Group("/admin")
+	admin.Use(authenticate, RequirePermission(models.PermissionUsersManage))
+	{
+		admin.GET("/users", handlers.AdminUser.ListUsers)
+		admin.POST("/users", handlers.AdminUser.CreateUser)
+		admin.POST("/users/import", handlers.AdminUser.ImportUsers)
+		admin.GET("/users/export", handlers.AdminUser.ExportUsers)
+		admin.GET("/users/:id", handlers.AdminUser.GetUser)
+		admin.PATCH("/users/:id", handlers.AdminUser.UpdateUser)
+		admin.DELETE("/users/:id", handlers.AdminUser.DeleteUser)
+		admin.POST("/users/:id/activate", handlers.AdminUser.ActivateUser)
+		admin.POST("/users/:id/deactivate", handlers.AdminUser.DeactivateUser)
+
+		// Roles and API keys
+		admin.GET("/roles", handlers.AdminAccess.ListRoles)
+		admin.GET("/users/:id/roles", handlers.AdminAccess.GetUserRoles)
+		admin.PUT("/users/:id/roles", handlers.AdminAccess.SetUserRoles)
+		admin.GET("/users/:id/api-keys", handlers.AdminAccess.ListAPIKeys)
+		admin.POST("/users/:id/api-keys", handlers.AdminAccess.CreateAPIKey)
+		admin.DELETE("/api-keys/:id", handlers.AdminAccess.RevokeAPIKey)
+
+		// Signal subscriptions
+		admin.GET("/users/:id/subscriptions", handlers.Subscription.ListSubscriptions)
+		admin.POST("/users/:id/subscriptions", handlers.Subscription.Subscribe)
+		admin.DELETE("/users/:id/subscriptions", handlers.Subscription.Unsubscribe)
+		admin.DELETE("/subscriptions/:id", handlers.Subscription.DeleteSubscription)
+
+		// Audit log of WhatsApp pairing and logout
+		admin.GET("/audit", handlers.Audit.ListEntries)
+	}
+
 	// Root health check (for load balancers)
 	r.GET("/health", handlers.Health.HealthCheck)
 	r.HEAD("/health", handlers.Health.HealthCheck)
@@ -8,6 +8,7 @@ import (
 
 	"github.com/fajarAnd/workshop-brin/wa-service/configs"
 	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/handlers"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/services"
 
 	"github.com/gin-gonic/gin"
 )
@@ -17,9 +18,10 @@ type Server struct {
 	config   *configs.Config
 	server   *http.Server
 	handlers *handlers.Handlers
+	auth     services.AuthService
 }
 
-func NewServer(config *configs.Config, handlers *handlers.Handlers) *Server {
+func NewServer(config *configs.Config, handlers *handlers.Handlers, authService services.AuthService) *Server {
 	// Set gin mode based on environment
 	if config.IsProduction() {
 		gin.SetMode(gin.ReleaseMode)
@@ -30,6 +32,7 @@ func NewServer(config *configs.Config, handlers *handlers.Handlers) *Server {
 	return &Server{
 		config:   config,
 		handlers: handlers,
+		auth:     authService,
 	}
 }
 
@@ -45,7 +48,7 @@ func (s *Server) setupRoutes() {
 	s.gin.Use(RequestResponseLoggingMiddleware())
 
 	// Setup routes
-	SetupRoutes(s.gin, s.handlers)
+	SetupRoutes(s.gin, s.handlers, s.config.Webhook, s.auth)
 }
 
 func (s *Server) Start() error {
@@ -0,0 +1,180 @@
+package server
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/configs"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// WebhookTimestampHeader carries the Unix time (seconds) the webhook was signed at
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	// WebhookSignatureHeader carries "sha256=" followed by the hex HMAC of "<timestamp>.<body>"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+
+	webhookSignaturePrefix = "sha256="
+
+	// maxWebhookBodySize bounds the body read before the signature is checked;
+	// price batches are the largest webhooks
+	maxWebhookBodySize = 10 << 20
+)
+
+// SignWebhookPayload returns the signature header value for body signed at timestamp
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return webhookSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// replayCache remembers signatures until their timestamp leaves the tolerance
+// window, after which the timestamp check refuses them anyway
+type replayCache struct {
+	mu        sync.Mutex
+	expiresAt map[string]time.Time
+	tolerance time.Duration
+}
+
+func newReplayCache(tolerance time.Duration) *replayCache {
+	return &replayCache{
+		expiresAt: make(map[string]time.Time),
+		tolerance: tolerance,
+	}
+}
+
+// markSeen records a signature made at signedAt and reports false if it was
+// already used. A signature dated ahead of now is kept until signedAt plus the
+// tolerance, not just a tolerance after it was seen.
+func (c *replayCache) markSeen(signature string, signedAt, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for sig, expiresAt := range c.expiresAt {
+		if now.After(expiresAt) {
+			delete(c.expiresAt, sig)
+		}
+	}
+
+	if _, exists := c.expiresAt[signature]; exists {
+		return false
+	}
+
+	c.expiresAt[signature] = signedAt.Add(c.tolerance)
+	return true
+}
+
+// WebhookSignatureMiddleware verifies the HMAC signature of inbound webhooks.
+// The secret is chosen per route: N8N responses, Flowise responses and signals
+// each have their own; price updates share the signal secret. Requests outside the timestamp tolerance or reusing a
+// signature already seen are rejected. Webhooks from sources without a
+// configured secret are refused with 503 unless SignatureDisabled is set.
+func WebhookSignatureMiddleware(config configs.WebhookConfig) gin.HandlerFunc {
+	secrets := map[string]string{
+		"/api/v1/webhook/n8n/response":     config.N8NSecret,
+		"/api/v1/webhook/n8n/signal":       config.SignalSecret,
+		"/api/v1/webhook/n8n/prices":       config.SignalSecret,
+		"/api/v1/webhook/flowise/response": config.FlowiseSecret,
+	}
+
+	for path, secret := range secrets {
+		if secret == "" && config.SignatureDisabled {
+			log.Printf("[Server] Warning: webhook signature verification disabled for %s (no secret configured)", path)
+		} else if secret == "" {
+			log.Printf("[Server] Warning: webhooks to %s will be refused (no secret configured)", path)
+		}
+	}
+
+	replays := newReplayCache(config.SignatureTolerance)
+
+	return func(c *gin.Context) {
+		secret := secrets[c.FullPath()]
+		if secret == "" {
+			if config.SignatureDisabled {
+				c.Next()
+				return
+			}
+			log.Printf("[Server] Rejected webhook %s from %s: no secret configured", c.Request.URL.Path, c.ClientIP())
+			c.JSON(http.StatusServiceUnavailable, models.APIResponse{
+				Success: false,
+				Error:   "Webhook signature verification is not configured",
+			})
+			c.Abort()
+			return
+		}
+
+		timestamp, err := strconv.ParseInt(c.GetHeader(WebhookTimestampHeader), 10, 64)
+		if err != nil {
+			abortUnauthorized(c, "Missing or invalid webhook timestamp")
+			return
+		}
+
+		now := time.Now()
+		signedAt := time.Unix(timestamp, 0)
+		skew := now.Sub(signedAt)
+		if skew < 0 {
+			skew = -skew
+		}
+		if skew > config.SignatureTolerance {
+			abortUnauthorized(c, "Webhook timestamp outside tolerance")
+			return
+		}
+
+		var body []byte
+		if c.Request.Body != nil {
+			body, err = io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxWebhookBodySize))
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				log.Printf("[Server] Rejected webhook %s from %s: body larger than %d bytes", c.Request.URL.Path, c.ClientIP(), tooLarge.Limit)
+				c.JSON(http.StatusRequestEntityTooLarge, models.APIResponse{
+					Success: false,
+					Error:   "Webhook body too large",
+				})
+				c.Abort()
+				return
+			}
+			if err != nil {
+				abortUnauthorized(c, "Failed to read webhook body")
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewBuffer(body))
+		}
+
+		signature := strings.ToLower(c.GetHeader(WebhookSignatureHeader))
+		expected := SignWebhookPayload(secret, timestamp, body)
+		if !hmac.Equal([]byte(signature), []byte(expected)) {
+			abortUnauthorized(c, "Invalid webhook signature")
+			return
+		}
+
+		if !replays.markSeen(signature, signedAt, now) {
+			abortUnauthorized(c, "Webhook already received")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func abortUnauthorized(c *gin.Context, reason string) {
+	log.Printf("[Server] Rejected webhook %s from %s: %s", c.Request.URL.Path, c.ClientIP(), reason)
+	c.JSON(http.StatusUnauthorized, models.APIResponse{
+		Success: false,
+		Error:   reason,
+	})
+	c.Abort()
+}
@@ -0,0 +1,181 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/configs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWebhookSignatureMiddleware
+// Summary: Test HMAC verification of inbound webhooks
+// Purpose: Validate per-source secrets, timestamp tolerance, replay protection, the body size limit and that sources without a secret are refused unless verification is disabled
+func TestWebhookSignatureMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	config := configs.WebhookConfig{
+		N8NSecret:          "n8n-secret",
+		SignalSecret:       "signal-secret",
+		SignatureTolerance: 5 * time.Minute,
+	}
+
+	router := gin.New()
+	webhook := router.Group("/api/v1/webhook")
+	webhook.Use(WebhookSignatureMiddleware(config))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	webhook.POST("/n8n/response", ok)
+	webhook.POST("/n8n/signal", ok)
+	webhook.POST("/flowise/response", ok)
+
+	body := `{"message_id":"msg-123","success":true}`
+	now := time.Now().Unix()
+
+	send := func(path, timestamp, signature string) int {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if timestamp != "" {
+			req.Header.Set(WebhookTimestampHeader, timestamp)
+		}
+		if signature != "" {
+			req.Header.Set(WebhookSignatureHeader, signature)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		timestamp      int64
+		secret         string
+		expectedStatus int
+	}{
+		{
+			name:           "Valid N8N signature",
+			path:           "/api/v1/webhook/n8n/response",
+			timestamp:      now,
+			secret:         "n8n-secret",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Signal signed with N8N secret",
+			path:           "/api/v1/webhook/n8n/signal",
+			timestamp:      now,
+			secret:         "n8n-secret",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Stale timestamp",
+			path:           "/api/v1/webhook/n8n/signal",
+			timestamp:      now - 600,
+			secret:         "signal-secret",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Source without secret is refused",
+			path:           "/api/v1/webhook/flowise/response",
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var timestamp, signature string
+			if tt.secret != "" {
+				timestamp = strconv.FormatInt(tt.timestamp, 10)
+				signature = SignWebhookPayload(tt.secret, tt.timestamp, []byte(body))
+			}
+
+			assert.Equal(t, tt.expectedStatus, send(tt.path, timestamp, signature))
+		})
+	}
+
+	t.Run("Missing signature headers", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, send("/api/v1/webhook/n8n/response", "", ""))
+	})
+
+	t.Run("Replayed signature", func(t *testing.T) {
+		timestamp := now - 1
+		signature := SignWebhookPayload("signal-secret", timestamp, []byte(body))
+
+		assert.Equal(t, http.StatusOK, send("/api/v1/webhook/n8n/signal", strconv.FormatInt(timestamp, 10), signature))
+		assert.Equal(t, http.StatusUnauthorized, send("/api/v1/webhook/n8n/signal", strconv.FormatInt(timestamp, 10), signature))
+	})
+
+	t.Run("Body too large", func(t *testing.T) {
+		large := strings.Repeat("x", maxWebhookBodySize+1)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/webhook/n8n/response", strings.NewReader(large))
+		req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(now, 10))
+		req.Header.Set(WebhookSignatureHeader, SignWebhookPayload("n8n-secret", now, []byte(large)))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+
+	t.Run("Source without secret when verification is disabled", func(t *testing.T) {
+		disabled := config
+		disabled.SignatureDisabled = true
+
+		router := gin.New()
+		router.Group("/api/v1/webhook").Use(WebhookSignatureMiddleware(disabled)).POST("/flowise/response", ok)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/webhook/flowise/response", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+// TestReplayCache_MarkSeen
+// Summary: Test how long signatures are remembered
+// Purpose: Validate that a signature dated ahead of its arrival stays refused until its timestamp leaves the tolerance window, not just a tolerance after it was seen
+func TestReplayCache_MarkSeen(t *testing.T) {
+	tolerance := 5 * time.Minute
+	seenAt := time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)
+	signedAt := seenAt.Add(tolerance)
+
+	tests := []struct {
+		name     string
+		replayAt time.Time
+		expected bool
+	}{
+		{
+			name:     "Replayed right away",
+			replayAt: seenAt.Add(time.Second),
+			expected: false,
+		},
+		{
+			name:     "Replayed a tolerance after it was seen",
+			replayAt: seenAt.Add(tolerance + time.Second),
+			expected: false,
+		},
+		{
+			name:     "Replayed just before its timestamp leaves the window",
+			replayAt: signedAt.Add(tolerance),
+			expected: false,
+		},
+		{
+			name:     "Seen again once its timestamp left the window",
+			replayAt: signedAt.Add(tolerance + time.Second),
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := newReplayCache(tolerance)
+			assert.True(t, cache.markSeen("sha256=abc", signedAt, seenAt))
+			assert.Equal(t, tt.expected, cache.markSeen("sha256=abc", signedAt, tt.replayAt))
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/repositories"
+)
+
+var ErrInvalidAccessMode = errors.New("access mode must be open, registered or allowlist")
+
+type AccessPolicyService interface {
+	GetActivePolicy(ctx context.Context) (*models.AccessPolicy, error)
+	UpdatePolicy(ctx context.Context, request *models.UpdateAccessPolicyRequest) (*models.AccessPolicy, error)
+	Authorize(ctx context.Context, phone, displayName string) (*models.AccessDecision, error)
+}
+
+type accessPolicyService struct {
+	accessPolicyRepo repositories.AccessPolicyRepository
+	userService      UserService
+}
+
+func NewAccessPolicyService(accessPolicyRepo repositories.AccessPolicyRepository, userService UserService) AccessPolicyService {
+	return &accessPolicyService{
+		accessPolicyRepo: accessPolicyRepo,
+		userService:      userService,
+	}
+}
+
+func (s *accessPolicyService) GetActivePolicy(ctx context.Context) (*models.AccessPolicy, error) {
+	policy, err := s.accessPolicyRepo.GetActivePolicy(ctx)
+	if err != nil {
+		log.Printf("[AccessPolicyService] Failed to get access policy: %v", err)
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (s *accessPolicyService) UpdatePolicy(ctx context.Context, request *models.UpdateAccessPolicyRequest) (*models.AccessPolicy, error) {
+	mode := strings.ToLower(strings.TrimSpace(request.Mode))
+	switch mode {
+	case models.AccessModeOpen, models.AccessModeRegistered, models.AccessModeAllowlist:
+	default:
+		return nil, ErrInvalidAccessMode
+	}
+
+	policy, err := s.accessPolicyRepo.UpdatePolicy(ctx, mode, request.NotifyUnregistered)
+	if err != nil {
+		log.Printf("[AccessPolicyService] Failed to update access policy: %v", err)
+		return nil, err
+	}
+
+	log.Printf("[AccessPolicyService] Access policy set to %s (notify unregistered: %t)", policy.Mode, policy.NotifyUnregistered)
+	return policy, nil
+}
+
+// Authorize decides whether a phone may talk to the bot under the active policy.
+// The policy is read on every call so it can be switched at runtime. When it
+// cannot be read, or names an unknown mode, only registered users are allowed.
+func (s *accessPolicyService) Authorize(ctx context.Context, phone, displayName string) (*models.AccessDecision, error) {
+	policy, err := s.GetActivePolicy(ctx)
+	if err != nil {
+		log.Printf("[AccessPolicyService] Falling back to registered-only access: %v", err)
+		policy = &models.AccessPolicy{Mode: models.AccessModeRegistered}
+	}
+
+	log.Printf("[AccessPolicyService] Authorizing %s with policy: %s", phone, policy.Mode)
+
+	decision := &models.AccessDecision{
+		NotifyUnregistered: policy.NotifyUnregistered,
+	}
+
+	eligible, err := s.userService.IsUserEligible(ctx, phone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check user eligibility: %w", err)
+	}
+
+	if eligible {
+		user, err := s.userService.GetUserByPhone(ctx, phone)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		decision.Allowed = true
+		decision.User = user
+		return decision, nil
+	}
+
+	switch policy.Mode {
+	case models.AccessModeOpen:
+		// Open mode lets unregistered users through without an identity
+		decision.Allowed = true
+	case models.AccessModeAllowlist:
+		return s.enrolFromAllowlist(ctx, phone, decision)
+	default:
+		if policy.Mode != models.AccessModeRegistered {
+			log.Printf("[AccessPolicyService] Unknown access mode %s, defaulting to registered", policy.Mode)
+		}
+		decision.Reason = "not_registered"
+	}
+
+	log.Printf("[AccessPolicyService] Phone %s (%s) allowed: %t", phone, displayName, decision.Allowed)
+	return decision, nil
+}
+
+func (s *accessPolicyService) enrolFromAllowlist(ctx context.Context, phone string, decision *models.AccessDecision) (*models.AccessDecision, error) {
+	entry, err := s.accessPolicyRepo.GetAllowlistEntry(ctx, phone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check allowlist: %w", err)
+	}
+
+	if entry == nil {
+		decision.Reason = "not_allowlisted"
+		return decision, nil
+	}
+
+	// A registered but inactive user has been deactivated on purpose
+	if existing, err := s.userService.GetUserByPhone(ctx, phone); err == nil && existing != nil {
+		decision.Reason = "inactive"
+		return decision, nil
+	}
+
+	user, err := s.userService.CreateUser(ctx, &models.CreateUserRequest{
+		Name:  entry.Name,
+		Phone: phone,
+		Email: entry.Email,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to enrol allowlisted user: %w", err)
+	}
+
+	log.Printf("[AccessPolicyService] Auto-enrolled allowlisted phone %s as user %s", phone, user.ID.String())
+	decision.Allowed = true
+	decision.User = user
+	return decision, nil
+}
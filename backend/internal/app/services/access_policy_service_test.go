@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/testutils/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestAccessPolicyService_Authorize
+// Summary: Test access decisions for each policy mode
+// Purpose: Validate open, registered and allowlist modes including auto-enrolment, and that an unreadable or unknown policy fails closed
+func TestAccessPolicyService_Authorize(t *testing.T) {
+	phone := "6281234567890"
+	registeredUser := &models.User{
+		ID:       uuid.New(),
+		Name:     "Budi Santoso",
+		Phone:    phone,
+		Email:    "budi@example.com",
+		IsActive: true,
+	}
+
+	tests := []struct {
+		name           string
+		policy         *models.AccessPolicy
+		policyError    error
+		eligible       bool
+		setupMocks     func(repo *mocks.MockAccessPolicyRepository, users *mocks.MockUserService)
+		expectAllowed  bool
+		expectUser     bool
+		expectReason   string
+		expectNotify   bool
+		expectErrorMsg string
+	}{
+		{
+			name:     "Registered user is allowed in registered mode",
+			policy:   &models.AccessPolicy{Mode: models.AccessModeRegistered},
+			eligible: true,
+			setupMocks: func(repo *mocks.MockAccessPolicyRepository, users *mocks.MockUserService) {
+				users.EXPECT().GetUserByPhone(mock.Anything, phone).Return(registeredUser, nil)
+			},
+			expectAllowed: true,
+			expectUser:    true,
+		},
+		{
+			name:          "Unregistered user is rejected in registered mode",
+			policy:        &models.AccessPolicy{Mode: models.AccessModeRegistered, NotifyUnregistered: true},
+			eligible:      false,
+			expectAllowed: false,
+			expectReason:  "not_registered",
+			expectNotify:  true,
+		},
+		{
+			name:          "Unregistered user is anonymous in open mode",
+			policy:        &models.AccessPolicy{Mode: models.AccessModeOpen},
+			eligible:      false,
+			expectAllowed: true,
+			expectUser:    false,
+		},
+		{
+			name:          "Policy lookup failure falls back to registered-only",
+			policyError:   fmt.Errorf("no access policy found"),
+			eligible:      false,
+			expectAllowed: false,
+			expectReason:  "not_registered",
+		},
+		{
+			name:        "Registered user is allowed when the policy lookup fails",
+			policyError: fmt.Errorf("connection refused"),
+			eligible:    true,
+			setupMocks: func(repo *mocks.MockAccessPolicyRepository, users *mocks.MockUserService) {
+				users.EXPECT().GetUserByPhone(mock.Anything, phone).Return(registeredUser, nil)
+			},
+			expectAllowed: true,
+			expectUser:    true,
+		},
+		{
+			name:          "Unknown mode falls back to registered-only",
+			policy:        &models.AccessPolicy{Mode: "everyone", NotifyUnregistered: true},
+			eligible:      false,
+			expectAllowed: false,
+			expectReason:  "not_registered",
+			expectNotify:  true,
+		},
+		{
+			name:     "Allowlisted phone is auto-enrolled",
+			policy:   &models.AccessPolicy{Mode: models.AccessModeAllowlist},
+			eligible: false,
+			setupMocks: func(repo *mocks.MockAccessPolicyRepository, users *mocks.MockUserService) {
+				repo.EXPECT().GetAllowlistEntry(mock.Anything, phone).Return(&models.AllowlistEntry{
+					Phone: phone,
+					Name:  "Budi Santoso",
+					Email: "budi@example.com",
+				}, nil)
+				users.EXPECT().GetUserByPhone(mock.Anything, phone).Return(nil, fmt.Errorf("user not found"))
+				users.EXPECT().CreateUser(mock.Anything, &models.CreateUserRequest{
+					Name:  "Budi Santoso",
+					Phone: phone,
+					Email: "budi@example.com",
+				}).Return(registeredUser, nil)
+			},
+			expectAllowed: true,
+			expectUser:    true,
+		},
+		{
+			name:     "Phone not on allowlist is rejected",
+			policy:   &models.AccessPolicy{Mode: models.AccessModeAllowlist},
+			eligible: false,
+			setupMocks: func(repo *mocks.MockAccessPolicyRepository, users *mocks.MockUserService) {
+				repo.EXPECT().GetAllowlistEntry(mock.Anything, phone).Return(nil, nil)
+			},
+			expectAllowed: false,
+			expectReason:  "not_allowlisted",
+		},
+		{
+			name:     "Deactivated allowlisted user is not re-enrolled",
+			policy:   &models.AccessPolicy{Mode: models.AccessModeAllowlist},
+			eligible: false,
+			setupMocks: func(repo *mocks.MockAccessPolicyRepository, users *mocks.MockUserService) {
+				repo.EXPECT().GetAllowlistEntry(mock.Anything, phone).Return(&models.AllowlistEntry{Phone: phone}, nil)
+				users.EXPECT().GetUserByPhone(mock.Anything, phone).Return(&models.User{Phone: phone, IsActive: false}, nil)
+			},
+			expectAllowed: false,
+			expectReason:  "inactive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockAccessPolicyRepository(t)
+			mockUsers := mocks.NewMockUserService(t)
+
+			mockRepo.EXPECT().GetActivePolicy(mock.Anything).Return(tt.policy, tt.policyError)
+			mockUsers.EXPECT().IsUserEligible(mock.Anything, phone).Return(tt.eligible, nil)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockRepo, mockUsers)
+			}
+
+			service := NewAccessPolicyService(mockRepo, mockUsers)
+			decision, err := service.Authorize(context.Background(), phone, "Budi WA")
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectAllowed, decision.Allowed)
+			assert.Equal(t, tt.expectUser, decision.User != nil)
+			assert.Equal(t, tt.expectReason, decision.Reason)
+			assert.Equal(t, tt.expectNotify, decision.NotifyUnregistered)
+		})
+	}
+}
+
+// TestAccessPolicyService_Authorize_EligibilityError
+// Summary: Test authorization when the user lookup fails
+// Purpose: Validate that database errors are surfaced instead of silently allowing the message
+func TestAccessPolicyService_Authorize_EligibilityError(t *testing.T) {
+	mockRepo := mocks.NewMockAccessPolicyRepository(t)
+	mockUsers := mocks.NewMockUserService(t)
+
+	mockRepo.EXPECT().GetActivePolicy(mock.Anything).Return(&models.AccessPolicy{Mode: models.AccessModeRegistered}, nil)
+	mockUsers.EXPECT().IsUserEligible(mock.Anything, "6281234567890").Return(false, fmt.Errorf("database connection failed"))
+
+	service := NewAccessPolicyService(mockRepo, mockUsers)
+	decision, err := service.Authorize(context.Background(), "6281234567890", "Budi")
+
+	assert.Error(t, err)
+	assert.Nil(t, decision)
+}
+
+// TestAccessPolicyService_UpdatePolicy
+// Summary: Test changing the access policy
+// Purpose: Validate that known modes are stored, an omitted notify setting is kept and unknown modes are refused
+func TestAccessPolicyService_UpdatePolicy(t *testing.T) {
+	notify := true
+
+	tests := []struct {
+		name         string
+		request      *models.UpdateAccessPolicyRequest
+		expectedMode string
+		expectedErr  error
+	}{
+		{
+			name:         "Allowlist with notification",
+			request:      &models.UpdateAccessPolicyRequest{Mode: "allowlist", NotifyUnregistered: &notify},
+			expectedMode: models.AccessModeAllowlist,
+		},
+		{
+			name:         "Mode in another case keeps the notify setting",
+			request:      &models.UpdateAccessPolicyRequest{Mode: " Registered "},
+			expectedMode: models.AccessModeRegistered,
+		},
+		{
+			name:        "Unknown mode",
+			request:     &models.UpdateAccessPolicyRequest{Mode: "closed"},
+			expectedErr: ErrInvalidAccessMode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockAccessPolicyRepository(t)
+			if tt.expectedErr == nil {
+				mockRepo.EXPECT().UpdatePolicy(mock.Anything, tt.expectedMode, tt.request.NotifyUnregistered).
+					Return(&models.AccessPolicy{ID: 1, Mode: tt.expectedMode}, nil)
+			}
+
+			service := NewAccessPolicyService(mockRepo, mocks.NewMockUserService(t))
+			policy, err := service.UpdatePolicy(context.Background(), tt.request)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, policy)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedMode, policy.Mode)
+		})
+	}
+}
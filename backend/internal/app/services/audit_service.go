@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/repositories"
+)
+
+const (
+	defaultAuditLimit = 50
+	maxAuditLimit     = 500
+)
+
+type AuditService interface {
+	// Record stores entry. A failure is logged rather than returned so that
+	// auditing never blocks the audited action.
+	Record(ctx context.Context, entry *models.AuditEntry)
+	List(ctx context.Context, action string, limit int) ([]*models.AuditEntry, error)
+}
+
+type auditService struct {
+	auditRepo repositories.AuditRepository
+}
+
+func NewAuditService(auditRepo repositories.AuditRepository) AuditService {
+	return &auditService{
+		auditRepo: auditRepo,
+	}
+}
+
+func (s *auditService) Record(ctx context.Context, entry *models.AuditEntry) {
+	log.Printf("[AuditService] %s by %s %v", entry.Action, entry.Actor, entry.Details)
+
+	if err := s.auditRepo.Create(ctx, entry); err != nil {
+		log.Printf("[AuditService] Failed to record %s by %s: %v", entry.Action, entry.Actor, err)
+	}
+}
+
+func (s *auditService) List(ctx context.Context, action string, limit int) ([]*models.AuditEntry, error) {
+	if limit < 1 {
+		limit = defaultAuditLimit
+	}
+	if limit > maxAuditLimit {
+		limit = maxAuditLimit
+	}
+
+	entries, err := s.auditRepo.List(ctx, action, limit)
+	if err != nil {
+		log.Printf("[AuditService] Failed to list audit entries: %v", err)
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/repositories"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/pkg/jwt"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid or missing credentials")
+	ErrTokensDisabled     = errors.New("token issuing is disabled; set JWT_SECRET")
+	ErrAPIKeyNotFound     = errors.New("API key not found")
+	ErrWeakJWTSecret      = errors.New("JWT_SECRET is a published example or shorter than 32 characters")
+)
+
+// minJWTSecretLength is the HS256 key size in bytes
+const minJWTSecretLength = 32
+
+// weakJWTSecrets are values published in this repository or commonly used as
+// placeholders, which anyone could use to forge tokens
+var weakJWTSecrets = []string{
+	"workshop2025",
+	"dev-secret-key-change-in-production",
+	"secret",
+	"changeme",
+	"change_me",
+	"jwt-secret",
+	"your_jwt_secret_here",
+}
+
+const (
+	// apiKeyPrefix marks keys issued by the service; the stored prefix includes
+	// a few random characters so a key can be recognised without revealing it
+	apiKeyPrefix       = "wak_"
+	apiKeyPrefixLength = 12
+
+	// bootstrapSubject is the JWT subject of tokens issued to ADMIN_API_KEY
+	bootstrapSubject = "bootstrap"
+	bootstrapName    = "ADMIN_API_KEY"
+)
+
+// AuthConfig configures API authentication. APIKey is a bootstrap key with the
+// admin role; tokens cannot be issued or verified while JWTSecret is empty.
+type AuthConfig struct {
+	APIKey    string
+	JWTSecret string
+	JWTExpiry time.Duration
+}
+
+// ValidateJWTSecret refuses a secret that is a known example value or too short
+// to sign HS256 tokens safely. An empty secret is valid: it disables tokens.
+func ValidateJWTSecret(secret string) error {
+	if secret == "" {
+		return nil
+	}
+	for _, weak := range weakJWTSecrets {
+		if strings.EqualFold(secret, weak) {
+			return ErrWeakJWTSecret
+		}
+	}
+	if len(secret) < minJWTSecretLength {
+		return ErrWeakJWTSecret
+	}
+	return nil
+}
+
+type AuthService interface {
+	// Authenticate resolves an API key or a JWT to the principal it identifies
+	Authenticate(ctx context.Context, credential string) (*models.Principal, error)
+	// IssueToken signs a JWT for principal that is valid for JWTExpiry
+	IssueToken(ctx context.Context, principal *models.Principal) (*models.AuthToken, error)
+	CreateAPIKey(ctx context.Context, userID uuid.UUID, name string) (*models.CreatedAPIKey, error)
+	ListAPIKeys(ctx context.Context, userID uuid.UUID) ([]*models.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) error
+}
+
+type authService struct {
+	config     *AuthConfig
+	apiKeyRepo repositories.APIKeyRepository
+	userRepo   repositories.UserRepository
+	roleRepo   repositories.RoleRepository
+	now        func() time.Time
+}
+
+func NewAuthService(config *AuthConfig, apiKeyRepo repositories.APIKeyRepository, userRepo repositories.UserRepository, roleRepo repositories.RoleRepository) AuthService {
+	if config.APIKey == "" {
+		log.Printf("[AuthService] Warning: ADMIN_API_KEY not set; only user API keys and tokens are accepted")
+	}
+	if config.JWTSecret == "" {
+		log.Printf("[AuthService] Warning: JWT_SECRET not set; token authentication disabled")
+	}
+
+	return &authService{
+		config:     config,
+		apiKeyRepo: apiKeyRepo,
+		userRepo:   userRepo,
+		roleRepo:   roleRepo,
+		now:        time.Now,
+	}
+}
+
+func (s *authService) Authenticate(ctx context.Context, credential string) (*models.Principal, error) {
+	if credential == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	if s.config.APIKey != "" && subtle.ConstantTimeCompare([]byte(credential), []byte(s.config.APIKey)) == 1 {
+		return s.bootstrapPrincipal(ctx, models.AuthMethodAPIKey)
+	}
+
+	if strings.Count(credential, ".") == 2 {
+		return s.authenticateToken(ctx, credential)
+	}
+	return s.authenticateAPIKey(ctx, credential)
+}
+
+func (s *authService) authenticateAPIKey(ctx context.Context, key string) (*models.Principal, error) {
+	apiKey, user, err := s.apiKeyRepo.GetActiveByHash(ctx, hashAPIKey(key))
+	if err != nil {
+		log.Printf("[AuthService] Failed to look up API key: %v", err)
+		return nil, err
+	}
+	if apiKey == nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	if err := s.apiKeyRepo.TouchLastUsed(ctx, apiKey.ID); err != nil {
+		log.Printf("[AuthService] Failed to record use of API key %s: %v", apiKey.Prefix, err)
+	}
+
+	return s.userPrincipal(ctx, user, models.AuthMethodAPIKey)
+}
+
+func (s *authService) authenticateToken(ctx context.Context, token string) (*models.Principal, error) {
+	if s.config.JWTSecret == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	claims, err := jwt.Parse(token, []byte(s.config.JWTSecret), s.now())
+	if err != nil {
+		log.Printf("[AuthService] Rejected token: %v", err)
+		return nil, ErrInvalidCredentials
+	}
+
+	// Tokens of the bootstrap key stop working once the key is removed
+	if claims.Subject == bootstrapSubject {
+		if s.config.APIKey == "" {
+			return nil, ErrInvalidCredentials
+		}
+		return s.bootstrapPrincipal(ctx, models.AuthMethodJWT)
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	// Roles come from the database rather than the token, so a role change or
+	// deactivation applies to tokens already issued
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, ErrInvalidCredentials
+	}
+
+	return s.userPrincipal(ctx, user, models.AuthMethodJWT)
+}
+
+func (s *authService) bootstrapPrincipal(ctx context.Context, method string) (*models.Principal, error) {
+	roles := []string{models.RoleAdmin}
+	permissions, err := s.roleRepo.GetPermissions(ctx, roles)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Principal{
+		Name:        bootstrapName,
+		Roles:       roles,
+		Permissions: permissions,
+		Method:      method,
+	}, nil
+}
+
+func (s *authService) userPrincipal(ctx context.Context, user *models.User, method string) (*models.Principal, error) {
+	roles, err := s.roleRepo.GetUserRoles(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := s.roleRepo.GetPermissions(ctx, roles)
+	if err != nil {
+		return nil, err
+	}
+
+	userID := user.ID
+	return &models.Principal{
+		UserID:      &userID,
+		Name:        user.Name,
+		Roles:       roles,
+		Permissions: permissions,
+		Method:      method,
+	}, nil
+}
+
+func (s *authService) IssueToken(ctx context.Context, principal *models.Principal) (*models.AuthToken, error) {
+	if s.config.JWTSecret == "" {
+		return nil, ErrTokensDisabled
+	}
+
+	subject := bootstrapSubject
+	if principal.UserID != nil {
+		subject = principal.UserID.String()
+	}
+
+	now := s.now()
+	expiresAt := now.Add(s.config.JWTExpiry)
+	token, err := jwt.Sign(&jwt.Claims{
+		Subject:   subject,
+		Roles:     principal.Roles,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	}, []byte(s.config.JWTSecret))
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[AuthService] Issued token for %s, expires %s", principal.Name, expiresAt.Format(time.RFC3339))
+	return &models.AuthToken{
+		Token:     token,
+		TokenType: "Bearer",
+		ExpiresAt: time.Unix(expiresAt.Unix(), 0).UTC(),
+	}, nil
+}
+
+func (s *authService) CreateAPIKey(ctx context.Context, userID uuid.UUID, name string) (*models.CreatedAPIKey, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	key := apiKeyPrefix + hex.EncodeToString(secret)
+
+	apiKey := &models.APIKey{
+		UserID: userID,
+		Name:   name,
+		Prefix: key[:apiKeyPrefixLength],
+	}
+	if err := s.apiKeyRepo.Create(ctx, apiKey, hashAPIKey(key)); err != nil {
+		log.Printf("[AuthService] Failed to create API key for user %s: %v", userID, err)
+		return nil, err
+	}
+
+	log.Printf("[AuthService] Created API key %s for user %s", apiKey.Prefix, userID)
+	return &models.CreatedAPIKey{APIKey: apiKey, Key: key}, nil
+}
+
+func (s *authService) ListAPIKeys(ctx context.Context, userID uuid.UUID) ([]*models.APIKey, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	return s.apiKeyRepo.ListByUser(ctx, userID)
+}
+
+func (s *authService) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	revoked, err := s.apiKeyRepo.Revoke(ctx, id)
+	if err != nil {
+		log.Printf("[AuthService] Failed to revoke API key %s: %v", id, err)
+		return err
+	}
+	if !revoked {
+		return ErrAPIKeyNotFound
+	}
+
+	log.Printf("[AuthService] Revoked API key %s", id)
+	return nil
+}
+
+// hashAPIKey returns the hex SHA-256 stored for key. Keys are random, so an
+// unsalted fast hash is enough.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
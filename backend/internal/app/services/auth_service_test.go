@@ -0,0 +1,293 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/pkg/jwt"
+	"github.com/fajarAnd/workshop-brin/wa-service/testutils/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestAuthService_Authenticate
+// Summary: Test resolving API keys and tokens to principals
+// Purpose: Validate the bootstrap key, user API keys, user and bootstrap tokens, and rejection of bad credentials
+func TestAuthService_Authenticate(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	secret := "jwt-secret"
+	userID := uuid.New()
+	user := &models.User{ID: userID, Name: "Siti", IsActive: true}
+	agentPermissions := []string{models.PermissionConversationsRead, models.PermissionConversationsTakeover}
+	adminPermissions := []string{models.PermissionUsersManage, models.PermissionWhatsAppManage}
+
+	sign := func(subject string, expiresAt time.Time, key string) string {
+		token, err := jwt.Sign(&jwt.Claims{Subject: subject, IssuedAt: now.Unix(), ExpiresAt: expiresAt.Unix()}, []byte(key))
+		assert.NoError(t, err)
+		return token
+	}
+
+	tests := []struct {
+		name         string
+		credential   string
+		setup        func(apiKeys *mocks.MockAPIKeyRepository, users *mocks.MockUserRepository, roles *mocks.MockRoleRepository)
+		expectedName string
+		expectedPerm []string
+		expectedVia  string
+		expectedErr  error
+	}{
+		{
+			name:       "Bootstrap API key",
+			credential: "admin-key",
+			setup: func(apiKeys *mocks.MockAPIKeyRepository, users *mocks.MockUserRepository, roles *mocks.MockRoleRepository) {
+				roles.EXPECT().GetPermissions(mock.Anything, []string{models.RoleAdmin}).Return(adminPermissions, nil)
+			},
+			expectedName: bootstrapName,
+			expectedPerm: adminPermissions,
+			expectedVia:  models.AuthMethodAPIKey,
+		},
+		{
+			name:       "User API key",
+			credential: "wak_0123456789abcdef",
+			setup: func(apiKeys *mocks.MockAPIKeyRepository, users *mocks.MockUserRepository, roles *mocks.MockRoleRepository) {
+				keyID := uuid.New()
+				apiKeys.EXPECT().GetActiveByHash(mock.Anything, hashAPIKey("wak_0123456789abcdef")).Return(&models.APIKey{ID: keyID, UserID: userID}, user, nil)
+				apiKeys.EXPECT().TouchLastUsed(mock.Anything, keyID).Return(nil)
+				roles.EXPECT().GetUserRoles(mock.Anything, userID).Return([]string{models.RoleAgent}, nil)
+				roles.EXPECT().GetPermissions(mock.Anything, []string{models.RoleAgent}).Return(agentPermissions, nil)
+			},
+			expectedName: "Siti",
+			expectedPerm: agentPermissions,
+			expectedVia:  models.AuthMethodAPIKey,
+		},
+		{
+			name:       "Unknown or revoked API key",
+			credential: "wak_revoked",
+			setup: func(apiKeys *mocks.MockAPIKeyRepository, users *mocks.MockUserRepository, roles *mocks.MockRoleRepository) {
+				apiKeys.EXPECT().GetActiveByHash(mock.Anything, hashAPIKey("wak_revoked")).Return(nil, nil, nil)
+			},
+			expectedErr: ErrInvalidCredentials,
+		},
+		{
+			name:       "User token",
+			credential: sign(userID.String(), now.Add(time.Hour), secret),
+			setup: func(apiKeys *mocks.MockAPIKeyRepository, users *mocks.MockUserRepository, roles *mocks.MockRoleRepository) {
+				users.EXPECT().GetByID(mock.Anything, userID).Return(user, nil)
+				roles.EXPECT().GetUserRoles(mock.Anything, userID).Return([]string{models.RoleAgent}, nil)
+				roles.EXPECT().GetPermissions(mock.Anything, []string{models.RoleAgent}).Return(agentPermissions, nil)
+			},
+			expectedName: "Siti",
+			expectedPerm: agentPermissions,
+			expectedVia:  models.AuthMethodJWT,
+		},
+		{
+			name:       "Token of a deactivated user",
+			credential: sign(userID.String(), now.Add(time.Hour), secret),
+			setup: func(apiKeys *mocks.MockAPIKeyRepository, users *mocks.MockUserRepository, roles *mocks.MockRoleRepository) {
+				users.EXPECT().GetByID(mock.Anything, userID).Return(&models.User{ID: userID, IsActive: false}, nil)
+			},
+			expectedErr: ErrInvalidCredentials,
+		},
+		{
+			name:       "Bootstrap token",
+			credential: sign(bootstrapSubject, now.Add(time.Hour), secret),
+			setup: func(apiKeys *mocks.MockAPIKeyRepository, users *mocks.MockUserRepository, roles *mocks.MockRoleRepository) {
+				roles.EXPECT().GetPermissions(mock.Anything, []string{models.RoleAdmin}).Return(adminPermissions, nil)
+			},
+			expectedName: bootstrapName,
+			expectedPerm: adminPermissions,
+			expectedVia:  models.AuthMethodJWT,
+		},
+		{
+			name:        "Expired token",
+			credential:  sign(userID.String(), now.Add(-time.Minute), secret),
+			expectedErr: ErrInvalidCredentials,
+		},
+		{
+			name:        "Token signed with another secret",
+			credential:  sign(userID.String(), now.Add(time.Hour), "other-secret"),
+			expectedErr: ErrInvalidCredentials,
+		},
+		{
+			name:        "Missing credential",
+			credential:  "",
+			expectedErr: ErrInvalidCredentials,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPIKeyRepo := mocks.NewMockAPIKeyRepository(t)
+			mockUserRepo := mocks.NewMockUserRepository(t)
+			mockRoleRepo := mocks.NewMockRoleRepository(t)
+			if tt.setup != nil {
+				tt.setup(mockAPIKeyRepo, mockUserRepo, mockRoleRepo)
+			}
+
+			service := &authService{
+				config:     &AuthConfig{APIKey: "admin-key", JWTSecret: secret, JWTExpiry: time.Hour},
+				apiKeyRepo: mockAPIKeyRepo,
+				userRepo:   mockUserRepo,
+				roleRepo:   mockRoleRepo,
+				now:        func() time.Time { return now },
+			}
+			principal, err := service.Authenticate(context.Background(), tt.credential)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, principal)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedName, principal.Name)
+			assert.Equal(t, tt.expectedPerm, principal.Permissions)
+			assert.Equal(t, tt.expectedVia, principal.Method)
+		})
+	}
+}
+
+// TestValidateJWTSecret
+// Summary: Test the JWT secret check at startup
+// Purpose: Validate that published example values and short secrets are refused and that an empty secret is allowed
+func TestValidateJWTSecret(t *testing.T) {
+	tests := []struct {
+		name        string
+		secret      string
+		expectError bool
+	}{
+		{name: "Not set", secret: ""},
+		{name: "Random secret", secret: "3f9c2a7e1b8d4f6a0c5e9b2d7a1f4c8e"},
+		{name: "Docker compose default", secret: "workshop2025", expectError: true},
+		{name: "Example value in another case", secret: "Dev-Secret-Key-Change-In-Production", expectError: true},
+		{name: "Too short", secret: "k8s-prod-2025", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateJWTSecret(tt.secret)
+			if tt.expectError {
+				assert.ErrorIs(t, err, ErrWeakJWTSecret)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// TestAuthService_IssueToken
+// Summary: Test issuing tokens to authenticated principals
+// Purpose: Validate the token subject and expiry, and that issuing is refused without JWT_SECRET
+func TestAuthService_IssueToken(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+
+	tests := []struct {
+		name            string
+		secret          string
+		principal       *models.Principal
+		expectedSubject string
+		expectedErr     error
+	}{
+		{
+			name:            "User principal",
+			secret:          "jwt-secret",
+			principal:       &models.Principal{UserID: &userID, Name: "Siti", Roles: []string{models.RoleAgent}},
+			expectedSubject: userID.String(),
+		},
+		{
+			name:            "Bootstrap principal",
+			secret:          "jwt-secret",
+			principal:       &models.Principal{Name: bootstrapName, Roles: []string{models.RoleAdmin}},
+			expectedSubject: bootstrapSubject,
+		},
+		{
+			name:        "JWT_SECRET not set",
+			principal:   &models.Principal{UserID: &userID},
+			expectedErr: ErrTokensDisabled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &authService{
+				config: &AuthConfig{JWTSecret: tt.secret, JWTExpiry: 24 * time.Hour},
+				now:    func() time.Time { return now },
+			}
+			token, err := service.IssueToken(context.Background(), tt.principal)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "Bearer", token.TokenType)
+			assert.Equal(t, now.Add(24*time.Hour), token.ExpiresAt)
+
+			claims, err := jwt.Parse(token.Token, []byte(tt.secret), now)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedSubject, claims.Subject)
+			assert.Equal(t, tt.principal.Roles, claims.Roles)
+		})
+	}
+}
+
+// TestAuthService_CreateAPIKey
+// Summary: Test issuing API keys to users
+// Purpose: Validate that only the hash and prefix of the key are stored
+func TestAuthService_CreateAPIKey(t *testing.T) {
+	userID := uuid.New()
+	var storedHash string
+
+	mockUserRepo := mocks.NewMockUserRepository(t)
+	mockUserRepo.EXPECT().GetByID(mock.Anything, userID).Return(&models.User{ID: userID}, nil)
+	mockAPIKeyRepo := mocks.NewMockAPIKeyRepository(t)
+	mockAPIKeyRepo.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, _ *models.APIKey, keyHash string) { storedHash = keyHash }).
+		Return(nil)
+
+	service := NewAuthService(&AuthConfig{}, mockAPIKeyRepo, mockUserRepo, mocks.NewMockRoleRepository(t))
+	created, err := service.CreateAPIKey(context.Background(), userID, "support laptop")
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(created.Key, apiKeyPrefix))
+	assert.Equal(t, created.Key[:apiKeyPrefixLength], created.Prefix)
+	assert.Equal(t, userID, created.UserID)
+	assert.Equal(t, hashAPIKey(created.Key), storedHash)
+	assert.NotContains(t, storedHash, created.Key)
+}
+
+// TestAuthService_RevokeAPIKey
+// Summary: Test revoking API keys
+// Purpose: Validate that a missing or already revoked key returns ErrAPIKeyNotFound
+func TestAuthService_RevokeAPIKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		revoked     bool
+		expectedErr error
+	}{
+		{name: "Revoke active key", revoked: true},
+		{name: "Missing or revoked key", revoked: false, expectedErr: ErrAPIKeyNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := uuid.New()
+			mockAPIKeyRepo := mocks.NewMockAPIKeyRepository(t)
+			mockAPIKeyRepo.EXPECT().Revoke(mock.Anything, id).Return(tt.revoked, nil)
+
+			service := NewAuthService(&AuthConfig{}, mockAPIKeyRepo, mocks.NewMockUserRepository(t), mocks.NewMockRoleRepository(t))
+			err := service.RevokeAPIKey(context.Background(), id)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/repositories"
+
+	"github.com/google/uuid"
+)
+
+// pgvector indexes support up to 2000 dimensions; unindexed vectors up to 16000
+const (
+	maxIndexedDimension = 2000
+	maxVectorDimension  = 16000
+)
+
+var embeddingSpaceNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,49}$`)
+
+var (
+	ErrEmbeddingSpaceNotFound     = errors.New("embedding space not found")
+	ErrEmbeddingSpaceExists       = errors.New("embedding space already exists")
+	ErrInvalidEmbeddingSpace      = errors.New("invalid embedding space")
+	ErrNoActiveEmbeddingSpace     = errors.New("no active embedding space")
+	ErrEmbeddingDimensionMismatch = errors.New("embedding dimension does not match space")
+)
+
+// EmbeddingSpaceService manages named embedding spaces and embeds text with the
+// model of a given space
+type EmbeddingSpaceService interface {
+	List(ctx context.Context) ([]*models.EmbeddingSpace, error)
+	Get(ctx context.Context, name string) (*models.EmbeddingSpace, error)
+	// Resolve returns the named space, or the active space when name is empty
+	Resolve(ctx context.Context, name string) (*models.EmbeddingSpace, error)
+	Create(ctx context.Context, request *models.CreateEmbeddingSpaceRequest) (*models.EmbeddingSpace, error)
+	Activate(ctx context.Context, name string) (*models.EmbeddingSpace, error)
+	Embed(ctx context.Context, space *models.EmbeddingSpace, input []string) ([][]float32, error)
+	// BuildIndex builds an IVFFlat space's index once it holds embeddings;
+	// HNSW indexes are built on creation
+	BuildIndex(ctx context.Context, space *models.EmbeddingSpace) error
+}
+
+type embeddingSpaceService struct {
+	spaceRepo    repositories.EmbeddingSpaceRepository
+	clientConfig OpenAIClientConfig
+	newEmbedder  func(space *models.EmbeddingSpace) OpenAIClient
+
+	mu        sync.Mutex
+	embedders map[uuid.UUID]OpenAIClient
+}
+
+// NewEmbeddingSpaceService creates the service. clientConfig is the template for
+// each space's client: the space supplies the model and, optionally, the base URL.
+func NewEmbeddingSpaceService(spaceRepo repositories.EmbeddingSpaceRepository, clientConfig *OpenAIClientConfig) EmbeddingSpaceService {
+	s := &embeddingSpaceService{
+		spaceRepo:    spaceRepo,
+		clientConfig: *clientConfig,
+		embedders:    make(map[uuid.UUID]OpenAIClient),
+	}
+	s.newEmbedder = s.newOpenAIEmbedder
+	return s
+}
+
+func (s *embeddingSpaceService) List(ctx context.Context) ([]*models.EmbeddingSpace, error) {
+	spaces, err := s.spaceRepo.List(ctx)
+	if err != nil {
+		log.Printf("[EmbeddingSpaceService] Failed to list embedding spaces: %v", err)
+		return nil, err
+	}
+
+	return spaces, nil
+}
+
+func (s *embeddingSpaceService) Get(ctx context.Context, name string) (*models.EmbeddingSpace, error) {
+	space, err := s.spaceRepo.GetByName(ctx, name)
+	if err != nil {
+		log.Printf("[EmbeddingSpaceService] Failed to get embedding space %s: %v", name, err)
+		return nil, err
+	}
+
+	if space == nil {
+		return nil, ErrEmbeddingSpaceNotFound
+	}
+
+	return space, nil
+}
+
+func (s *embeddingSpaceService) Resolve(ctx context.Context, name string) (*models.EmbeddingSpace, error) {
+	if name != "" {
+		return s.Get(ctx, name)
+	}
+
+	space, err := s.spaceRepo.GetActive(ctx)
+	if err != nil {
+		log.Printf("[EmbeddingSpaceService] Failed to get active embedding space: %v", err)
+		return nil, err
+	}
+
+	if space == nil {
+		return nil, ErrNoActiveEmbeddingSpace
+	}
+
+	return space, nil
+}
+
+func (s *embeddingSpaceService) Create(ctx context.Context, request *models.CreateEmbeddingSpaceRequest) (*models.EmbeddingSpace, error) {
+	space := &models.EmbeddingSpace{
+		Name:      strings.TrimSpace(request.Name),
+		Model:     strings.TrimSpace(request.Model),
+		Dimension: request.Dimension,
+		IndexType: request.IndexType,
+		BaseURL:   strings.TrimSpace(request.BaseURL),
+	}
+	if space.IndexType == "" {
+		space.IndexType = models.EmbeddingIndexHNSW
+	}
+
+	if err := validateEmbeddingSpace(space); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.spaceRepo.GetByName(ctx, space.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrEmbeddingSpaceExists
+	}
+
+	if err := s.spaceRepo.Create(ctx, space); err != nil {
+		log.Printf("[EmbeddingSpaceService] Failed to create embedding space %s: %v", space.Name, err)
+		return nil, err
+	}
+
+	log.Printf("[EmbeddingSpaceService] Created embedding space %s (%s, %d dimensions, %s index)",
+		space.Name, space.Model, space.Dimension, space.IndexType)
+	return space, nil
+}
+
+// Activate switches retrieval and ingestion to the named space. Chunks without
+// an embedding in that space are not found by vector search until re-embedded.
+func (s *embeddingSpaceService) Activate(ctx context.Context, name string) (*models.EmbeddingSpace, error) {
+	space, err := s.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.spaceRepo.SetActive(ctx, space.ID); err != nil {
+		log.Printf("[EmbeddingSpaceService] Failed to activate embedding space %s: %v", name, err)
+		return nil, err
+	}
+
+	space.IsActive = true
+	log.Printf("[EmbeddingSpaceService] Activated embedding space %s", name)
+	return space, nil
+}
+
+func (s *embeddingSpaceService) BuildIndex(ctx context.Context, space *models.EmbeddingSpace) error {
+	if space.IndexType != models.EmbeddingIndexIVFFlat {
+		return nil
+	}
+
+	if err := s.spaceRepo.BuildIndex(ctx, space); err != nil {
+		log.Printf("[EmbeddingSpaceService] Failed to build index for embedding space %s: %v", space.Name, err)
+		return err
+	}
+
+	log.Printf("[EmbeddingSpaceService] Built %s index for embedding space %s", space.IndexType, space.Name)
+	return nil
+}
+
+// Embed returns one embedding per input from the model of space, checking that
+// the model produces vectors of the space's dimension
+func (s *embeddingSpaceService) Embed(ctx context.Context, space *models.EmbeddingSpace, input []string) ([][]float32, error) {
+	embeddings, err := s.embedder(space).CreateEmbeddings(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, embedding := range embeddings {
+		if len(embedding) != space.Dimension {
+			return nil, fmt.Errorf("%w: model %s returned %d dimensions, space %s expects %d",
+				ErrEmbeddingDimensionMismatch, space.Model, len(embedding), space.Name, space.Dimension)
+		}
+	}
+
+	return embeddings, nil
+}
+
+func (s *embeddingSpaceService) embedder(space *models.EmbeddingSpace) OpenAIClient {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if embedder, exists := s.embedders[space.ID]; exists {
+		return embedder
+	}
+
+	embedder := s.newEmbedder(space)
+	s.embedders[space.ID] = embedder
+	return embedder
+}
+
+func (s *embeddingSpaceService) newOpenAIEmbedder(space *models.EmbeddingSpace) OpenAIClient {
+	config := s.clientConfig
+	config.EmbeddingModel = space.Model
+	if space.BaseURL != "" {
+		config.BaseURL = space.BaseURL
+	}
+	return NewOpenAIClient(&config)
+}
+
+func validateEmbeddingSpace(space *models.EmbeddingSpace) error {
+	if !embeddingSpaceNamePattern.MatchString(space.Name) {
+		return fmt.Errorf("%w: name must be 1-50 lowercase letters, digits, '-' or '_'", ErrInvalidEmbeddingSpace)
+	}
+
+	if space.Model == "" {
+		return fmt.Errorf("%w: model is required", ErrInvalidEmbeddingSpace)
+	}
+
+	switch space.IndexType {
+	case models.EmbeddingIndexHNSW, models.EmbeddingIndexIVFFlat:
+		if space.Dimension < 1 || space.Dimension > maxIndexedDimension {
+			return fmt.Errorf("%w: indexed spaces support 1-%d dimensions", ErrInvalidEmbeddingSpace, maxIndexedDimension)
+		}
+	case models.EmbeddingIndexNone:
+		if space.Dimension < 1 || space.Dimension > maxVectorDimension {
+			return fmt.Errorf("%w: dimension must be 1-%d", ErrInvalidEmbeddingSpace, maxVectorDimension)
+		}
+	default:
+		return fmt.Errorf("%w: index_type must be hnsw, ivfflat or none", ErrInvalidEmbeddingSpace)
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/testutils/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestEmbeddingSpaceService_Create
+// Summary: Test creating named embedding spaces
+// Purpose: Validate name, model, dimension and index type rules, the hnsw default and duplicate names
+func TestEmbeddingSpaceService_Create(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       *models.CreateEmbeddingSpaceRequest
+		existing      *models.EmbeddingSpace
+		expectCreate  bool
+		expectedIndex string
+		expectedErr   error
+	}{
+		{
+			name:          "Index type defaults to hnsw",
+			request:       &models.CreateEmbeddingSpaceRequest{Name: "e5-small", Model: "intfloat/multilingual-e5-small", Dimension: 384},
+			expectCreate:  true,
+			expectedIndex: models.EmbeddingIndexHNSW,
+		},
+		{
+			name:          "Unindexed space may exceed the index dimension limit",
+			request:       &models.CreateEmbeddingSpaceRequest{Name: "large", Model: "text-embedding-3-large", Dimension: 3072, IndexType: models.EmbeddingIndexNone},
+			expectCreate:  true,
+			expectedIndex: models.EmbeddingIndexNone,
+		},
+		{
+			name:        "Indexed space over 2000 dimensions",
+			request:     &models.CreateEmbeddingSpaceRequest{Name: "large", Model: "text-embedding-3-large", Dimension: 3072},
+			expectedErr: ErrInvalidEmbeddingSpace,
+		},
+		{
+			name:        "Invalid name",
+			request:     &models.CreateEmbeddingSpaceRequest{Name: "E5 Small", Model: "e5", Dimension: 384},
+			expectedErr: ErrInvalidEmbeddingSpace,
+		},
+		{
+			name:        "Unknown index type",
+			request:     &models.CreateEmbeddingSpaceRequest{Name: "e5-small", Model: "e5", Dimension: 384, IndexType: "btree"},
+			expectedErr: ErrInvalidEmbeddingSpace,
+		},
+		{
+			name:        "Duplicate name",
+			request:     &models.CreateEmbeddingSpaceRequest{Name: "default", Model: "text-embedding-3-small", Dimension: 1536},
+			existing:    &models.EmbeddingSpace{Name: "default"},
+			expectedErr: ErrEmbeddingSpaceExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockEmbeddingSpaceRepository(t)
+
+			if tt.expectCreate || tt.existing != nil {
+				mockRepo.EXPECT().GetByName(mock.Anything, tt.request.Name).Return(tt.existing, nil)
+			}
+			if tt.expectCreate {
+				mockRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(space *models.EmbeddingSpace) bool {
+					return space.IndexType == tt.expectedIndex && space.Dimension == tt.request.Dimension
+				})).Return(nil)
+			}
+
+			service := NewEmbeddingSpaceService(mockRepo, &OpenAIClientConfig{})
+			space, err := service.Create(context.Background(), tt.request)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, space)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.request.Name, space.Name)
+		})
+	}
+}
+
+// TestEmbeddingSpaceService_BuildIndex
+// Summary: Test building the vector index of a filled embedding space
+// Purpose: Validate that only IVFFlat spaces are indexed here and build failures are returned
+func TestEmbeddingSpaceService_BuildIndex(t *testing.T) {
+	tests := []struct {
+		name        string
+		indexType   string
+		expectBuild bool
+		buildErr    error
+	}{
+		{
+			name:        "IVFFlat index is built",
+			indexType:   models.EmbeddingIndexIVFFlat,
+			expectBuild: true,
+		},
+		{
+			name:        "IVFFlat build failure is returned",
+			indexType:   models.EmbeddingIndexIVFFlat,
+			expectBuild: true,
+			buildErr:    errors.New("out of memory"),
+		},
+		{
+			name:      "HNSW index was built on creation",
+			indexType: models.EmbeddingIndexHNSW,
+		},
+		{
+			name:      "Space without index",
+			indexType: models.EmbeddingIndexNone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			space := &models.EmbeddingSpace{ID: uuid.New(), Name: "e5-small", Dimension: 384, IndexType: tt.indexType}
+
+			mockRepo := mocks.NewMockEmbeddingSpaceRepository(t)
+			if tt.expectBuild {
+				mockRepo.EXPECT().BuildIndex(mock.Anything, space).Return(tt.buildErr)
+			}
+
+			service := NewEmbeddingSpaceService(mockRepo, &OpenAIClientConfig{})
+			err := service.BuildIndex(context.Background(), space)
+
+			assert.Equal(t, tt.buildErr, err)
+		})
+	}
+}
+
+// TestEmbeddingSpaceService_Embed
+// Summary: Test embedding text with the model of a space
+// Purpose: Validate that each space gets its own client and vectors of the wrong dimension are rejected
+func TestEmbeddingSpaceService_Embed(t *testing.T) {
+	space := &models.EmbeddingSpace{ID: uuid.New(), Name: "e5-small", Model: "e5", Dimension: 2}
+
+	mockClient := mocks.NewMockOpenAIClient(t)
+	mockClient.EXPECT().CreateEmbeddings(mock.Anything, []string{"halo"}).Return([][]float32{{0.1, 0.2}}, nil).Once()
+	mockClient.EXPECT().CreateEmbeddings(mock.Anything, []string{"halo"}).Return([][]float32{{0.1, 0.2, 0.3}}, nil).Once()
+
+	service := NewEmbeddingSpaceService(mocks.NewMockEmbeddingSpaceRepository(t), &OpenAIClientConfig{}).(*embeddingSpaceService)
+	created := 0
+	service.newEmbedder = func(s *models.EmbeddingSpace) OpenAIClient {
+		created++
+		assert.Equal(t, "e5", s.Model)
+		return mockClient
+	}
+
+	embeddings, err := service.Embed(context.Background(), space, []string{"halo"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float32{{0.1, 0.2}}, embeddings)
+
+	_, err = service.Embed(context.Background(), space, []string{"halo"})
+	assert.ErrorIs(t, err, ErrEmbeddingDimensionMismatch)
+	assert.Equal(t, 1, created, "client is reused for the same space")
+}
@@ -10,32 +10,33 @@ import (
 	"time"
 
 	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
-
-	"github.com/google/uuid"
 )
 
 type FlowiseService interface {
-	SendMessageToWorkflow(ctx context.Context, userContext *models.UserContext, message string) error
+	WorkflowBackend
 	HandleWorkflowResponse(response *models.FlowiseResponse) error
-	SetWhatsAppService(whatsappSvc WhatsAppService)
 }
 
 type flowiseService struct {
-	httpClient  *http.Client
-	baseURL     string
-	flowID      string
-	apiKey      string
-	whatsappSvc WhatsAppService
+	httpClient *http.Client
+	baseURL    string
+	flowID     string
+	apiKey     string
+	pendingSvc PendingRequestService
+	sender     MessageSender
+	retry      RetryPolicy
 }
 
 type FlowiseConfig struct {
-	BaseURL string
-	FlowID  string
-	APIKey  string
-	Timeout time.Duration
+	BaseURL       string
+	FlowID        string
+	APIKey        string
+	Timeout       time.Duration
+	RetryAttempts int
+	RetryDelay    time.Duration
 }
 
-func NewFlowiseService(config *FlowiseConfig) FlowiseService {
+func NewFlowiseService(config *FlowiseConfig, pendingSvc PendingRequestService, sender MessageSender) FlowiseService {
 	httpClient := &http.Client{
 		Timeout: config.Timeout,
 	}
@@ -45,22 +46,27 @@ func NewFlowiseService(config *FlowiseConfig) FlowiseService {
 		baseURL:    config.BaseURL,
 		flowID:     config.FlowID,
 		apiKey:     config.APIKey,
+		pendingSvc: pendingSvc,
+		sender:     sender,
+		retry: RetryPolicy{
+			Retries:   config.RetryAttempts,
+			BaseDelay: config.RetryDelay,
+		},
 	}
 }
 
-func (s *flowiseService) SetWhatsAppService(whatsappSvc WhatsAppService) {
-	s.whatsappSvc = whatsappSvc
+func (s *flowiseService) Type() string {
+	return WorkflowTypeFlowise
 }
 
-func (s *flowiseService) SendMessageToWorkflow(ctx context.Context, userContext *models.UserContext, message string) error {
-	log.Printf("[FlowiseService] Sending message to workflow for user %s: %s", userContext.Name, message)
-
-	messageID := uuid.New().String()
+func (s *flowiseService) SendMessageToWorkflow(ctx context.Context, request *models.WorkflowRequest) error {
+	log.Printf("[FlowiseService] Sending message to workflow for user %s: %s", request.UserContext.Name, request.Message)
 
-	request := &models.FlowiseRequest{
-		Question: message,
+	userContext := request.UserContext
+	payload := &models.FlowiseRequest{
+		Question: request.Message,
 		OverrideConfig: &models.FlowiseOverrideConfig{
-			SessionID: messageID,
+			SessionID: request.SessionID,
 			Vars: map[string]interface{}{
 				"userContext": map[string]interface{}{
 					"user_id": userContext.UserID,
@@ -68,25 +74,59 @@ func (s *flowiseService) SendMessageToWorkflow(ctx context.Context, userContext
 					"phone":   userContext.Phone,
 					"email":   userContext.Email,
 				},
+				"messageId": request.MessageID,
 				"timestamp": time.Now(),
 			},
 		},
 	}
 
-	jsonData, err := json.Marshal(request)
+	jsonData, err := json.Marshal(payload)
 	if err != nil {
 		log.Printf("[FlowiseService] Failed to marshal request: %v", err)
 		return fmt.Errorf("failed to marshal request: %w", err)
 	}
 
+	// Register before sending so a callback from an asynchronous flow always
+	// finds its request
+	if err := s.pendingSvc.Register(ctx, request.MessageID, userContext.Phone, WorkflowTypeFlowise); err != nil {
+		return fmt.Errorf("failed to register pending request: %w", err)
+	}
+
+	var prediction *models.FlowiseResponse
+	err = withRetry(ctx, s.retry, "[FlowiseService]", func() error {
+		var postErr error
+		prediction, postErr = s.postPrediction(ctx, request.MessageID, jsonData)
+		return postErr
+	})
+	if err != nil {
+		s.pendingSvc.MarkFailed(ctx, request.MessageID, err.Error())
+		return err
+	}
+
+	log.Printf("[FlowiseService] Message sent to Flowise workflow successfully (MessageID: %s)", request.MessageID)
+
+	if prediction.Text == "" {
+		// Flows that reply asynchronously call /webhook/flowise/response instead
+		log.Printf("[FlowiseService] Prediction returned no text, awaiting callback (MessageID: %s)", request.MessageID)
+		return nil
+	}
+
+	prediction.MessageID = request.MessageID
+	prediction.Success = true
+
+	return s.HandleWorkflowResponse(prediction)
+}
+
+func (s *flowiseService) postPrediction(ctx context.Context, messageID string, jsonData []byte) (*models.FlowiseResponse, error) {
 	url := fmt.Sprintf("%s/api/v1/prediction/%s", s.baseURL, s.flowID)
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
 		log.Printf("[FlowiseService] Failed to create HTTP request: %v", err)
-		return fmt.Errorf("failed to create HTTP request: %w", err)
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set(IdempotencyKeyHeader, messageID)
 	if s.apiKey != "" {
 		httpReq.Header.Set("Authorization", "Bearer "+s.apiKey)
 	}
@@ -94,44 +134,70 @@ func (s *flowiseService) SendMessageToWorkflow(ctx context.Context, userContext
 	resp, err := s.httpClient.Do(httpReq)
 	if err != nil {
 		log.Printf("[FlowiseService] Failed to send HTTP request: %v", err)
-		return fmt.Errorf("failed to send HTTP request: %w", err)
+		return nil, fmt.Errorf("failed to send HTTP request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		log.Printf("[FlowiseService] Flowise API returned error status: %d", resp.StatusCode)
-		return fmt.Errorf("Flowise API returned error status: %d", resp.StatusCode)
+		return nil, &HTTPStatusError{Source: "Flowise API", StatusCode: resp.StatusCode}
 	}
 
-	log.Printf("[FlowiseService] Message sent to Flowise workflow successfully (MessageID: %s)", messageID)
-	return nil
+	// Decode prediction result so it can be delivered synchronously
+	var prediction models.FlowiseResponse
+	if err := json.NewDecoder(resp.Body).Decode(&prediction); err != nil {
+		log.Printf("[FlowiseService] Failed to decode prediction response: %v", err)
+		return nil, fmt.Errorf("failed to decode prediction response: %v", err)
+	}
+
+	return &prediction, nil
 }
 
+// HandleWorkflowResponse delivers a prediction or callback for a request issued by
+// SendMessageToWorkflow. The reply goes to the phone recorded for the request,
+// not the one in the payload.
 func (s *flowiseService) HandleWorkflowResponse(response *models.FlowiseResponse) error {
 	log.Printf("[FlowiseService] Handling workflow response for phone %s (MessageID: %s)", response.Phone, response.MessageID)
 
+	ctx := WithMessageMeta(context.Background(), MessageMeta{
+		CorrelationID: response.MessageID,
+		WorkflowType:  WorkflowTypeFlowise,
+	})
+
+	pending, err := s.pendingSvc.Claim(ctx, response.MessageID)
+	if err != nil {
+		return err
+	}
+
+	if response.Phone != "" && response.Phone != pending.Phone {
+		log.Printf("[FlowiseService] Callback phone %s does not match recorded phone %s (MessageID: %s), using recorded phone",
+			response.Phone, pending.Phone, response.MessageID)
+	}
+
 	if !response.Success {
 		log.Printf("[FlowiseService] Flowise workflow returned error: %s", response.Error)
+		s.pendingSvc.MarkFailed(ctx, response.MessageID, response.Error)
 		return fmt.Errorf("Flowise workflow error: %s", response.Error)
 	}
 
 	if response.Text == "" {
 		log.Printf("[FlowiseService] Empty response from Flowise workflow (MessageID: %s)", response.MessageID)
+		s.pendingSvc.MarkFailed(ctx, response.MessageID, "empty response")
 		return fmt.Errorf("empty response from Flowise workflow")
 	}
 
-	if s.whatsappSvc == nil {
-		log.Printf("[FlowiseService] WhatsApp service not set, cannot send response")
+	if s.sender == nil {
+		log.Printf("[FlowiseService] Message sender not set, cannot send response")
 		return fmt.Errorf("WhatsApp service not available")
 	}
 
-	ctx := context.Background()
-	err := s.whatsappSvc.SendMessage(ctx, response.Phone, response.Text)
+	err = s.sender.SendMessage(ctx, pending.Phone, response.Text)
 	if err != nil {
-		log.Printf("[FlowiseService] Failed to send response to WhatsApp user %s: %v", response.Phone, err)
+		log.Printf("[FlowiseService] Failed to send response to WhatsApp user %s: %v", pending.Phone, err)
+		s.pendingSvc.MarkFailed(ctx, response.MessageID, err.Error())
 		return fmt.Errorf("failed to send response to WhatsApp: %w", err)
 	}
 
-	log.Printf("[FlowiseService] Response sent to WhatsApp user %s successfully (MessageID: %s)", response.Phone, response.MessageID)
+	log.Printf("[FlowiseService] Response sent to WhatsApp user %s successfully (MessageID: %s)", pending.Phone, response.MessageID)
 	return nil
 }
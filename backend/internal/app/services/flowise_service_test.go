@@ -2,13 +2,19 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/testutils/mocks"
 
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 // Simple mock WhatsApp service for testing (avoiding import cycle)
@@ -27,6 +33,12 @@ func (m *mockWhatsAppService) SendMessage(ctx context.Context, phone, message st
 	}
 	return nil
 }
+func (m *mockWhatsAppService) SendMessageWithID(ctx context.Context, phone, message string) (string, error) {
+	return "", m.SendMessage(ctx, phone, message)
+}
+func (m *mockWhatsAppService) SendReplyWithID(ctx context.Context, phone, message, quotedID, quotedMessage string) (string, error) {
+	return "", m.SendMessage(ctx, phone, message)
+}
 
 // TestFlowiseService_SendMessageToWorkflow
 // Summary: Test sending messages to Flowise workflow API
@@ -71,11 +83,20 @@ func TestFlowiseService_SendMessageToWorkflow(t *testing.T) {
 				APIKey:  "test-api-key",
 				Timeout: 30 * time.Second,
 			}
-			service := NewFlowiseService(config)
+			mockPending := mocks.NewMockPendingRequestService(t)
+			mockPending.EXPECT().Register(mock.Anything, "msg-123", tt.userContext.Phone, WorkflowTypeFlowise).Return(nil)
+			mockPending.EXPECT().MarkFailed(mock.Anything, "msg-123", mock.Anything).Return(nil).Maybe()
+			mockPending.EXPECT().Claim(mock.Anything, "msg-123").Return(&models.PendingRequest{MessageID: "msg-123", Phone: tt.userContext.Phone}, nil).Maybe()
+			service := NewFlowiseService(config, mockPending, nil)
 
 			// Execute test
 			ctx := context.Background()
-			err := service.SendMessageToWorkflow(ctx, tt.userContext, tt.message)
+			err := service.SendMessageToWorkflow(ctx, &models.WorkflowRequest{
+				MessageID:   "msg-123",
+				SessionID:   "session-123",
+				UserContext: tt.userContext,
+				Message:     tt.message,
+			})
 
 			// Validate results based on expectation
 			if tt.expectError && err == nil {
@@ -89,108 +110,238 @@ func TestFlowiseService_SendMessageToWorkflow(t *testing.T) {
 	}
 }
 
+// TestFlowiseService_SendMessageToWorkflow_DeliversPrediction
+// Summary: Test synchronous delivery of Flowise prediction results
+// Purpose: Validate that prediction text is sent to the recorded phone and empty text leaves the request pending for a callback
+func TestFlowiseService_SendMessageToWorkflow_DeliversPrediction(t *testing.T) {
+	tests := []struct {
+		name            string
+		statusCode      int
+		responseBody    string
+		expectError     bool
+		expectFailed    bool
+		expectedMessage string
+	}{
+		{
+			name:            "Prediction text is delivered",
+			statusCode:      http.StatusOK,
+			responseBody:    `{"text":"Silakan reset password melalui portal.","chatId":"chat123"}`,
+			expectError:     false,
+			expectedMessage: "Silakan reset password melalui portal.",
+		},
+		{
+			name:         "Empty prediction waits for callback",
+			statusCode:   http.StatusOK,
+			responseBody: `{"text":""}`,
+			expectError:  false,
+		},
+		{
+			name:         "Malformed prediction body",
+			statusCode:   http.StatusOK,
+			responseBody: `not-json`,
+			expectError:  true,
+			expectFailed: true,
+		},
+		{
+			name:         "Flowise error status",
+			statusCode:   http.StatusInternalServerError,
+			responseBody: `{}`,
+			expectError:  true,
+			expectFailed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v1/prediction/test-flow-id" {
+					t.Errorf("Unexpected path %s", r.URL.Path)
+				}
+
+				var request models.FlowiseRequest
+				if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+					t.Errorf("Failed to decode request: %v", err)
+				}
+				if request.OverrideConfig == nil || request.OverrideConfig.SessionID != "session-123" {
+					t.Errorf("Expected session ID session-123 in override config")
+				}
+
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.responseBody))
+			}))
+			defer server.Close()
+
+			mockPending := mocks.NewMockPendingRequestService(t)
+			mockPending.EXPECT().Register(mock.Anything, "msg-123", "6281234567890", WorkflowTypeFlowise).Return(nil)
+			if tt.expectFailed {
+				mockPending.EXPECT().MarkFailed(mock.Anything, "msg-123", mock.Anything).Return(nil)
+			}
+			if tt.expectedMessage != "" {
+				mockPending.EXPECT().Claim(mock.Anything, "msg-123").Return(&models.PendingRequest{MessageID: "msg-123", Phone: "6281234567890"}, nil)
+			}
+
+			var sentPhone, sentMessage string
+			service := NewFlowiseService(&FlowiseConfig{
+				BaseURL: server.URL,
+				FlowID:  "test-flow-id",
+				Timeout: 5 * time.Second,
+			}, mockPending, &mockWhatsAppService{
+				sendMessageFunc: func(ctx context.Context, phone, message string) error {
+					sentPhone = phone
+					sentMessage = message
+					return nil
+				},
+			})
+
+			userContext := &models.UserContext{
+				UserID: uuid.New(),
+				Name:   "John Doe",
+				Phone:  "6281234567890",
+			}
+			err := service.SendMessageToWorkflow(context.Background(), &models.WorkflowRequest{
+				MessageID:   "msg-123",
+				SessionID:   "session-123",
+				UserContext: userContext,
+				Message:     "Bagaimana cara reset password?",
+			})
+
+			if tt.expectError && err == nil {
+				t.Errorf("Expected error but got none")
+			}
+
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+			}
+
+			if sentMessage != tt.expectedMessage {
+				t.Errorf("Expected message %q, got %q", tt.expectedMessage, sentMessage)
+			}
+
+			if tt.expectedMessage != "" && sentPhone != userContext.Phone {
+				t.Errorf("Expected phone %s, got %s", userContext.Phone, sentPhone)
+			}
+		})
+	}
+}
+
 // TestFlowiseService_HandleWorkflowResponse
-// Summary: Test handling webhook responses from Flowise
-// Purpose: Validate response processing and WhatsApp message forwarding
+// Summary: Test handling callbacks from Flowise
+// Purpose: Validate that replies go to the recorded phone and unknown or repeated callbacks are rejected
 func TestFlowiseService_HandleWorkflowResponse(t *testing.T) {
 	tests := []struct {
-		name             string
-		response         *models.FlowiseResponse
-		whatsappError    error
-		expectError      bool
-		expectedWhatsApp bool
+		name          string
+		response      *models.FlowiseResponse
+		claimed       *models.PendingRequest
+		claimError    error
+		whatsappError error
+		expectFailed  bool
+		expectSend    bool
+		expectedError error
+		expectError   bool
 	}{
 		{
-			name: "Successful response handling",
+			name: "Reply goes to recorded phone",
 			response: &models.FlowiseResponse{
 				Text:      "AI analysis response",
 				ChatID:    "chat123",
 				MessageID: "msg456",
-				Phone:     "1234567890",
+				Phone:     "6289999999999",
+				Success:   true,
+			},
+			claimed:    &models.PendingRequest{MessageID: "msg456", Phone: "6281234567890"},
+			expectSend: true,
+		},
+		{
+			name: "Unknown message ID is rejected",
+			response: &models.FlowiseResponse{
+				Text:      "AI analysis response",
+				MessageID: "msg-unknown",
+				Phone:     "6281234567890",
+				Success:   true,
+			},
+			claimError:    fmt.Errorf("%w: msg-unknown", ErrUnknownRequest),
+			expectedError: ErrUnknownRequest,
+			expectError:   true,
+		},
+		{
+			name: "Duplicate callback is rejected",
+			response: &models.FlowiseResponse{
+				Text:      "AI analysis response",
+				MessageID: "msg456",
+				Phone:     "6281234567890",
 				Success:   true,
 			},
-			whatsappError:    nil,
-			expectError:      false,
-			expectedWhatsApp: true,
+			claimError:    fmt.Errorf("%w: msg456", ErrDuplicateCallback),
+			expectedError: ErrDuplicateCallback,
+			expectError:   true,
 		},
 		{
-			name: "Empty response text",
+			name: "Empty response text marks request failed",
 			response: &models.FlowiseResponse{
 				Text:      "",
 				ChatID:    "chat123",
 				MessageID: "msg456",
-				Phone:     "1234567890",
 				Success:   true,
 			},
-			whatsappError:    nil,
-			expectError:      true,
-			expectedWhatsApp: false,
+			claimed:      &models.PendingRequest{MessageID: "msg456", Phone: "6281234567890"},
+			expectFailed: true,
+			expectError:  true,
 		},
 		{
-			name: "Workflow error response",
+			name: "Workflow error marks request failed",
 			response: &models.FlowiseResponse{
 				Text:      "",
 				ChatID:    "chat123",
 				MessageID: "msg456",
-				Phone:     "1234567890",
 				Success:   false,
 				Error:     "Workflow processing failed",
 			},
-			whatsappError:    nil,
-			expectError:      true,
-			expectedWhatsApp: false,
+			claimed:      &models.PendingRequest{MessageID: "msg456", Phone: "6281234567890"},
+			expectFailed: true,
+			expectError:  true,
 		},
 		{
-			name: "WhatsApp send error",
+			name: "WhatsApp send error marks request failed",
 			response: &models.FlowiseResponse{
 				Text:      "AI response",
 				ChatID:    "chat123",
 				MessageID: "msg456",
-				Phone:     "1234567890",
 				Success:   true,
 			},
-			whatsappError:    fmt.Errorf("WhatsApp send failed"),
-			expectError:      true,
-			expectedWhatsApp: true,
+			claimed:       &models.PendingRequest{MessageID: "msg456", Phone: "6281234567890"},
+			whatsappError: fmt.Errorf("WhatsApp send failed"),
+			expectFailed:  true,
+			expectSend:    true,
+			expectError:   true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Create simple mock WhatsApp service
-			var mockWhatsApp *mockWhatsAppService
-			if tt.expectedWhatsApp {
-				mockWhatsApp = &mockWhatsAppService{
-					sendMessageFunc: func(ctx context.Context, phone, message string) error {
-						if phone != tt.response.Phone {
-							t.Errorf("Expected phone %s, got %s", tt.response.Phone, phone)
-						}
-						if message != tt.response.Text {
-							t.Errorf("Expected message %s, got %s", tt.response.Text, message)
-						}
-						return tt.whatsappError
-					},
-				}
-			} else {
-				mockWhatsApp = &mockWhatsAppService{}
+			mockPending := mocks.NewMockPendingRequestService(t)
+			mockPending.EXPECT().Claim(mock.Anything, tt.response.MessageID).Return(tt.claimed, tt.claimError)
+			if tt.expectFailed {
+				mockPending.EXPECT().MarkFailed(mock.Anything, tt.response.MessageID, mock.Anything).Return(nil)
 			}
 
-			// Create service and set WhatsApp service
-			service := &flowiseService{
-				whatsappSvc: mockWhatsApp,
-			}
+			sent := false
+			service := NewFlowiseService(&FlowiseConfig{Timeout: time.Second}, mockPending, &mockWhatsAppService{
+				sendMessageFunc: func(ctx context.Context, phone, message string) error {
+					sent = true
+					assert.Equal(t, tt.claimed.Phone, phone)
+					assert.Equal(t, tt.response.Text, message)
+					assert.Equal(t, tt.response.MessageID, MessageMetaFromContext(ctx).CorrelationID)
+					return tt.whatsappError
+				},
+			})
 
-			// Execute test
 			err := service.HandleWorkflowResponse(tt.response)
 
-			// Validate results
-			if tt.expectError && err == nil {
-				t.Errorf("Expected error but got none")
-			}
-
-			if !tt.expectError && err != nil {
-				t.Errorf("Expected no error but got: %v", err)
+			assert.Equal(t, tt.expectError, err != nil, "unexpected error result: %v", err)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
 			}
+			assert.Equal(t, tt.expectSend, sent)
 		})
 	}
 }
@@ -207,8 +358,11 @@ func TestFlowiseService_HandleWorkflowResponse_NoWhatsAppService(t *testing.T) {
 		Success:   true,
 	}
 
+	mockPending := mocks.NewMockPendingRequestService(t)
+	mockPending.EXPECT().Claim(mock.Anything, "msg456").Return(&models.PendingRequest{MessageID: "msg456", Phone: "1234567890"}, nil)
+
 	// Create service without WhatsApp service
-	service := &flowiseService{}
+	service := &flowiseService{pendingSvc: mockPending}
 
 	// Execute test
 	err := service.HandleWorkflowResponse(response)
@@ -230,7 +384,8 @@ func TestNewFlowiseService(t *testing.T) {
 		Timeout: 30 * time.Second,
 	}
 
-	service := NewFlowiseService(config)
+	mockWhatsApp := &mockWhatsAppService{}
+	service := NewFlowiseService(config, mocks.NewMockPendingRequestService(t), mockWhatsApp)
 
 	// Validate service was created
 	if service == nil {
@@ -254,34 +409,16 @@ func TestNewFlowiseService(t *testing.T) {
 	if flowiseService.apiKey != config.APIKey {
 		t.Errorf("Expected apiKey %s, got %s", config.APIKey, flowiseService.apiKey)
 	}
-}
 
-// TestFlowiseService_SetWhatsAppService
-// Summary: Test setting WhatsApp service dependency
-// Purpose: Validate dependency injection works correctly
-func TestFlowiseService_SetWhatsAppService(t *testing.T) {
-	// Create service
-	config := &FlowiseConfig{
-		BaseURL: "http://test-flowise.com",
-		FlowID:  "test-flow-id",
-		APIKey:  "test-api-key",
-		Timeout: 30 * time.Second,
+	if flowiseService.sender == nil {
+		t.Errorf("Expected message sender to be set")
 	}
-	service := NewFlowiseService(config)
-
-	// Create simple mock WhatsApp service
-	mockWhatsApp := &mockWhatsAppService{}
-
-	// Set WhatsApp service
-	service.SetWhatsAppService(mockWhatsApp)
 
-	// Validate WhatsApp service was set
-	flowiseService, ok := service.(*flowiseService)
-	if !ok {
-		t.Errorf("Expected flowiseService type")
+	if flowiseService.pendingSvc == nil {
+		t.Errorf("Expected pending request service to be set")
 	}
 
-	if flowiseService.whatsappSvc == nil {
-		t.Errorf("Expected WhatsApp service to be set")
+	if service.Type() != WorkflowTypeFlowise {
+		t.Errorf("Expected type %s, got %s", WorkflowTypeFlowise, service.Type())
 	}
 }
@@ -0,0 +1,277 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/repositories"
+
+	"github.com/google/uuid"
+)
+
+const defaultEmbeddingBatchSize = 32
+
+// KnowledgeIngestionService loads documents into simple_knowledge_vectors.
+// Chunks are keyed by content hash within a source, so re-ingesting a file only
+// embeds new chunks and removes the ones that disappeared.
+type KnowledgeIngestionService interface {
+	Ingest(ctx context.Context, source, text string, dryRun bool) (*models.IngestResult, error)
+	DeleteSource(ctx context.Context, source string, dryRun bool) (int, error)
+}
+
+// IngestionConfig configures chunking and embedding. Space names the embedding
+// space new chunks are embedded into; empty means the active space.
+type IngestionConfig struct {
+	ChunkSize    int
+	ChunkOverlap int
+	BatchSize    int
+	Space        string
+}
+
+type knowledgeIngestionService struct {
+	knowledgeRepo repositories.KnowledgeRepository
+	spaces        EmbeddingSpaceService
+	space         string
+	splitter      *TextSplitter
+	batchSize     int
+}
+
+func NewKnowledgeIngestionService(config *IngestionConfig, knowledgeRepo repositories.KnowledgeRepository, spaces EmbeddingSpaceService) KnowledgeIngestionService {
+	batchSize := config.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultEmbeddingBatchSize
+	}
+
+	return &knowledgeIngestionService{
+		knowledgeRepo: knowledgeRepo,
+		spaces:        spaces,
+		space:         config.Space,
+		splitter:      NewRecursiveTextSplitter(config.ChunkSize, config.ChunkOverlap),
+		batchSize:     batchSize,
+	}
+}
+
+func (s *knowledgeIngestionService) Ingest(ctx context.Context, source, text string, dryRun bool) (*models.IngestResult, error) {
+	chunks := s.chunkDocument(source, text)
+	result := &models.IngestResult{Source: source, Chunks: len(chunks), DryRun: dryRun}
+
+	existing, err := s.knowledgeRepo.ListBySource(ctx, source)
+	if err != nil {
+		log.Printf("[KnowledgeIngestionService] Failed to list existing chunks for %s: %v", source, err)
+		return nil, err
+	}
+
+	existingByHash := make(map[string]*models.KnowledgeChunk, len(existing))
+	var stale []uuid.UUID
+	for _, chunk := range existing {
+		hash, _ := chunk.Metadata[models.KnowledgeMetaContentHash].(string)
+		if hash == "" || existingByHash[hash] != nil {
+			stale = append(stale, chunk.ID)
+			continue
+		}
+		existingByHash[hash] = chunk
+	}
+
+	var toInsert []*models.KnowledgeChunk
+	var toUpdate []*models.KnowledgeChunk
+	for _, chunk := range chunks {
+		hash := chunk.Metadata[models.KnowledgeMetaContentHash].(string)
+		current, exists := existingByHash[hash]
+		if !exists {
+			toInsert = append(toInsert, chunk)
+			continue
+		}
+
+		delete(existingByHash, hash)
+		if sameChunkPosition(current.Metadata, chunk.Metadata) {
+			result.Unchanged++
+			continue
+		}
+		chunk.ID = current.ID
+		toUpdate = append(toUpdate, chunk)
+	}
+	for _, chunk := range existingByHash {
+		stale = append(stale, chunk.ID)
+	}
+
+	result.Inserted = len(toInsert)
+	result.Updated = len(toUpdate)
+	result.Deleted = len(stale)
+
+	if dryRun {
+		return result, nil
+	}
+
+	if err := s.insertChunks(ctx, toInsert); err != nil {
+		return nil, err
+	}
+
+	for _, chunk := range toUpdate {
+		if err := s.knowledgeRepo.UpdateMetadata(ctx, chunk.ID, chunk.Metadata); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := s.knowledgeRepo.DeleteByIDs(ctx, stale); err != nil {
+		return nil, err
+	}
+
+	log.Printf("[KnowledgeIngestionService] Ingested %s: %d chunks (%d inserted, %d updated, %d unchanged, %d deleted)",
+		source, result.Chunks, result.Inserted, result.Updated, result.Unchanged, result.Deleted)
+	return result, nil
+}
+
+func (s *knowledgeIngestionService) DeleteSource(ctx context.Context, source string, dryRun bool) (int, error) {
+	if dryRun {
+		existing, err := s.knowledgeRepo.ListBySource(ctx, source)
+		if err != nil {
+			return 0, err
+		}
+		return len(existing), nil
+	}
+
+	deleted, err := s.knowledgeRepo.DeleteBySource(ctx, source)
+	if err != nil {
+		log.Printf("[KnowledgeIngestionService] Failed to delete source %s: %v", source, err)
+		return 0, err
+	}
+
+	log.Printf("[KnowledgeIngestionService] Deleted %d chunks for %s", deleted, source)
+	return int(deleted), nil
+}
+
+// insertChunks embeds chunks in batches and stores them in the ingestion space
+func (s *knowledgeIngestionService) insertChunks(ctx context.Context, chunks []*models.KnowledgeChunk) error {
+	if len(chunks) == 0 {
+		return nil
+	}
+
+	space, err := s.spaces.Resolve(ctx, s.space)
+	if err != nil {
+		log.Printf("[KnowledgeIngestionService] Failed to resolve embedding space %q: %v", s.space, err)
+		return err
+	}
+
+	for start := 0; start < len(chunks); start += s.batchSize {
+		end := min(start+s.batchSize, len(chunks))
+		batch := chunks[start:end]
+
+		input := make([]string, len(batch))
+		for i, chunk := range batch {
+			input[i] = chunk.Content
+		}
+
+		embeddings, err := s.spaces.Embed(ctx, space, input)
+		if err != nil {
+			log.Printf("[KnowledgeIngestionService] Failed to embed chunks %d-%d: %v", start, end-1, err)
+			return fmt.Errorf("failed to embed chunks: %w", err)
+		}
+
+		for i, chunk := range batch {
+			if err := s.knowledgeRepo.Insert(ctx, chunk, space, embeddings[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// chunkDocument splits text section by section so each chunk carries its heading.
+// Identical chunks within a document are stored once.
+func (s *knowledgeIngestionService) chunkDocument(source, text string) []*models.KnowledgeChunk {
+	var chunks []*models.KnowledgeChunk
+	seen := make(map[string]bool)
+
+	for _, section := range splitSections(text) {
+		for _, content := range s.splitter.Split(section.body) {
+			hash := contentHash(content)
+			if seen[hash] {
+				continue
+			}
+			seen[hash] = true
+
+			chunks = append(chunks, &models.KnowledgeChunk{
+				Content: content,
+				Metadata: map[string]interface{}{
+					models.KnowledgeMetaSource:      source,
+					models.KnowledgeMetaSection:     section.heading,
+					models.KnowledgeMetaChunkIndex:  len(chunks),
+					models.KnowledgeMetaContentHash: hash,
+				},
+			})
+		}
+	}
+
+	return chunks
+}
+
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// sameChunkPosition compares the metadata that can change without the content changing
+func sameChunkPosition(current, next map[string]interface{}) bool {
+	for _, key := range []string{models.KnowledgeMetaSection, models.KnowledgeMetaChunkIndex} {
+		if fmt.Sprint(current[key]) != fmt.Sprint(next[key]) {
+			return false
+		}
+	}
+	return true
+}
+
+type documentSection struct {
+	heading string
+	body    string
+}
+
+// splitSections splits a knowledge base document on headings framed by rule
+// lines, as used in knowledge-base/*.txt:
+//
+//	=====================
+//	A. SECTION HEADING
+//	=====================
+//
+// Text before the first heading belongs to a section with an empty heading.
+func splitSections(text string) []documentSection {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	var sections []documentSection
+	current := documentSection{}
+	var body []string
+
+	flush := func() {
+		current.body = strings.TrimSpace(strings.Join(body, "\n"))
+		if current.body != "" {
+			sections = append(sections, current)
+		}
+		body = nil
+	}
+
+	for i := 0; i < len(lines); i++ {
+		if isRuleLine(lines[i]) && i+2 < len(lines) && !isRuleLine(lines[i+1]) && isRuleLine(lines[i+2]) {
+			flush()
+			current = documentSection{heading: strings.TrimSpace(lines[i+1])}
+			i += 2
+			continue
+		}
+		if isRuleLine(lines[i]) {
+			continue
+		}
+		body = append(body, lines[i])
+	}
+	flush()
+
+	return sections
+}
+
+func isRuleLine(line string) bool {
+	line = strings.TrimSpace(line)
+	return len(line) >= 10 && (strings.Trim(line, "=") == "" || strings.Trim(line, "-") == "")
+}
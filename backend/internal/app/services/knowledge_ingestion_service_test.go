@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/testutils/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestKnowledgeIngestionService_Ingest
+// Summary: Test upserting a document into the knowledge base by content hash
+// Purpose: Validate that only new chunks are embedded, moved chunks are updated and removed chunks deleted
+func TestKnowledgeIngestionService_Ingest(t *testing.T) {
+	document := "==========\nOVERVIEW\n==========\nPanduan reset password.\n\nHubungi IT support di ext 1234."
+
+	unchangedID := uuid.New()
+	movedID := uuid.New()
+	staleID := uuid.New()
+	legacyID := uuid.New()
+	existing := []*models.KnowledgeChunk{
+		{
+			ID: unchangedID,
+			Metadata: map[string]interface{}{
+				"source": "faq.txt", "section": "OVERVIEW", "chunk_index": float64(0),
+				"content_hash": contentHash("Panduan reset password."),
+			},
+		},
+		{
+			ID: movedID,
+			Metadata: map[string]interface{}{
+				"source": "faq.txt", "section": "LAMA", "chunk_index": float64(5),
+				"content_hash": contentHash("Hubungi IT support di ext 1234."),
+			},
+		},
+		{
+			ID:       staleID,
+			Metadata: map[string]interface{}{"source": "faq.txt", "content_hash": contentHash("Konten lama")},
+		},
+		{
+			ID:       legacyID,
+			Metadata: map[string]interface{}{"source": "faq.txt"},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		dryRun        bool
+		existing      []*models.KnowledgeChunk
+		expectedStale []uuid.UUID
+		expected      *models.IngestResult
+	}{
+		{
+			name:     "New document is embedded and inserted",
+			existing: []*models.KnowledgeChunk{},
+			expected: &models.IngestResult{Source: "faq.txt", Chunks: 2, Inserted: 2},
+		},
+		{
+			name:          "Re-ingest keeps unchanged chunks",
+			existing:      existing,
+			expectedStale: []uuid.UUID{staleID, legacyID},
+			expected:      &models.IngestResult{Source: "faq.txt", Chunks: 2, Unchanged: 1, Updated: 1, Deleted: 2},
+		},
+		{
+			name:     "Dry run only reports",
+			dryRun:   true,
+			existing: existing,
+			expected: &models.IngestResult{Source: "faq.txt", Chunks: 2, Unchanged: 1, Updated: 1, Deleted: 2, DryRun: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockKnowledgeRepository(t)
+			mockSpaces := mocks.NewMockEmbeddingSpaceService(t)
+			space := &models.EmbeddingSpace{ID: uuid.New(), Name: "e5-small", Dimension: 1}
+
+			mockRepo.EXPECT().ListBySource(mock.Anything, "faq.txt").Return(tt.existing, nil)
+			if !tt.dryRun && tt.expected.Inserted > 0 {
+				mockSpaces.EXPECT().Resolve(mock.Anything, "e5-small").Return(space, nil)
+				mockSpaces.EXPECT().Embed(mock.Anything, space, []string{"Panduan reset password.", "Hubungi IT support di ext 1234."}).
+					Return([][]float32{{0.1}, {0.2}}, nil)
+				mockRepo.EXPECT().Insert(mock.Anything, mock.Anything, space, mock.Anything).Return(nil).Times(tt.expected.Inserted)
+			}
+			if !tt.dryRun && tt.expected.Updated > 0 {
+				mockRepo.EXPECT().UpdateMetadata(mock.Anything, movedID, mock.MatchedBy(func(metadata map[string]interface{}) bool {
+					return metadata["section"] == "OVERVIEW" && metadata["chunk_index"] == 1
+				})).Return(nil)
+			}
+			if !tt.dryRun {
+				mockRepo.EXPECT().DeleteByIDs(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, ids []uuid.UUID) (int64, error) {
+					assert.ElementsMatch(t, tt.expectedStale, ids)
+					return int64(len(ids)), nil
+				})
+			}
+
+			service := NewKnowledgeIngestionService(&IngestionConfig{ChunkSize: 40, ChunkOverlap: 0, Space: "e5-small"}, mockRepo, mockSpaces)
+			result, err := service.Ingest(context.Background(), "faq.txt", document, tt.dryRun)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
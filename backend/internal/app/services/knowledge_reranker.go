@@ -0,0 +1,161 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+
+	"github.com/google/uuid"
+)
+
+// defaultRRFConstant is the k in 1/(k + rank); 60 is the value from the original RRF paper
+const defaultRRFConstant = 60
+
+// Reranker reorders retrieval candidates by relevance to query. Implementations
+// return the chunks to keep, best first, and may replace their Score.
+type Reranker interface {
+	Rerank(ctx context.Context, query string, chunks []*models.KnowledgeChunk) ([]*models.KnowledgeChunk, error)
+}
+
+// fuseReciprocalRank merges ranked lists with reciprocal rank fusion: each chunk
+// scores the sum of 1/(k + rank) over the lists it appears in. Ties keep the
+// order in which chunks were first seen.
+func fuseReciprocalRank(k int, rankings ...[]*models.KnowledgeChunk) []*models.KnowledgeChunk {
+	if k <= 0 {
+		k = defaultRRFConstant
+	}
+
+	var fused []*models.KnowledgeChunk
+	byID := make(map[uuid.UUID]*models.KnowledgeChunk)
+	for _, ranking := range rankings {
+		for i, chunk := range ranking {
+			score := 1 / float64(k+i+1)
+
+			existing, seen := byID[chunk.ID]
+			if !seen {
+				merged := *chunk
+				merged.Score = score
+				byID[chunk.ID] = &merged
+				fused = append(fused, &merged)
+				continue
+			}
+
+			existing.Score += score
+			existing.VectorScore = max(existing.VectorScore, chunk.VectorScore)
+			existing.KeywordScore = max(existing.KeywordScore, chunk.KeywordScore)
+		}
+	}
+
+	sort.SliceStable(fused, func(i, j int) bool {
+		return fused[i].Score > fused[j].Score
+	})
+
+	return fused
+}
+
+type httpReranker struct {
+	httpClient *http.Client
+	baseURL    string
+	apiKey     string
+	model      string
+}
+
+type RerankerConfig struct {
+	BaseURL string
+	APIKey  string
+	Model   string
+	Timeout time.Duration
+}
+
+// NewHTTPReranker creates a Reranker for the /v1/rerank API shared by Cohere,
+// Jina and self-hosted rerankers such as text-embeddings-inference
+func NewHTTPReranker(config *RerankerConfig) Reranker {
+	return &httpReranker{
+		httpClient: &http.Client{
+			Timeout: config.Timeout,
+		},
+		baseURL: strings.TrimSuffix(config.BaseURL, "/"),
+		apiKey:  config.APIKey,
+		model:   config.Model,
+	}
+}
+
+type rerankRequest struct {
+	Model     string   `json:"model,omitempty"`
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+}
+
+type rerankResponse struct {
+	Results []struct {
+		Index          int     `json:"index"`
+		RelevanceScore float64 `json:"relevance_score"`
+	} `json:"results"`
+}
+
+func (r *httpReranker) Rerank(ctx context.Context, query string, chunks []*models.KnowledgeChunk) ([]*models.KnowledgeChunk, error) {
+	if len(chunks) == 0 {
+		return chunks, nil
+	}
+
+	request := rerankRequest{Model: r.model, Query: query, Documents: make([]string, len(chunks))}
+	for i, chunk := range chunks {
+		request.Documents[i] = chunk.Content
+	}
+
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal rerank request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", r.baseURL+"/v1/rerank", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	if r.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+r.apiKey)
+	}
+
+	resp, err := r.httpClient.Do(httpReq)
+	if err != nil {
+		log.Printf("[Reranker] Failed to send HTTP request: %v", err)
+		return nil, fmt.Errorf("failed to send HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("[Reranker] Rerank API returned error status: %d", resp.StatusCode)
+		return nil, &HTTPStatusError{Source: "Rerank API", StatusCode: resp.StatusCode}
+	}
+
+	var response rerankResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode rerank response: %w", err)
+	}
+
+	reranked := make([]*models.KnowledgeChunk, 0, len(response.Results))
+	for _, result := range response.Results {
+		if result.Index < 0 || result.Index >= len(chunks) {
+			return nil, fmt.Errorf("rerank index %d out of range", result.Index)
+		}
+		chunk := *chunks[result.Index]
+		chunk.Score = result.RelevanceScore
+		reranked = append(reranked, &chunk)
+	}
+
+	sort.SliceStable(reranked, func(i, j int) bool {
+		return reranked[i].Score > reranked[j].Score
+	})
+
+	return reranked, nil
+}
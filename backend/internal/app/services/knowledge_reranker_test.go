@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFuseReciprocalRank
+// Summary: Test merging ranked lists with reciprocal rank fusion
+// Purpose: Validate that chunks found by both searches rise to the top and keep both method scores
+func TestFuseReciprocalRank(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name     string
+		rankings [][]*models.KnowledgeChunk
+		expected []uuid.UUID
+	}{
+		{
+			name: "Chunk in both lists outranks single-list leaders",
+			rankings: [][]*models.KnowledgeChunk{
+				{{ID: a, VectorScore: 0.9}, {ID: b, VectorScore: 0.8}},
+				{{ID: c, KeywordScore: 0.5}, {ID: b, KeywordScore: 0.4}},
+			},
+			expected: []uuid.UUID{b, a, c},
+		},
+		{
+			name: "Equal scores keep first-seen order",
+			rankings: [][]*models.KnowledgeChunk{
+				{{ID: a}},
+				{{ID: c}},
+			},
+			expected: []uuid.UUID{a, c},
+		},
+		{
+			name:     "Empty lists",
+			rankings: [][]*models.KnowledgeChunk{{}, {}},
+			expected: []uuid.UUID{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fused := fuseReciprocalRank(60, tt.rankings...)
+
+			ids := []uuid.UUID{}
+			for _, chunk := range fused {
+				ids = append(ids, chunk.ID)
+			}
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+
+	fused := fuseReciprocalRank(0, [][]*models.KnowledgeChunk{
+		{{ID: a, VectorScore: 0.9}},
+		{{ID: a, KeywordScore: 0.4}},
+	}...)
+	assert.InDelta(t, 2.0/61, fused[0].Score, 1e-9)
+	assert.Equal(t, 0.9, fused[0].VectorScore)
+	assert.Equal(t, 0.4, fused[0].KeywordScore)
+}
+
+// TestHTTPReranker_Rerank
+// Summary: Test reranking candidates through a /v1/rerank API
+// Purpose: Validate the request payload, reordering by relevance score and error handling
+func TestHTTPReranker_Rerank(t *testing.T) {
+	chunks := []*models.KnowledgeChunk{
+		{ID: uuid.New(), Content: "VLAN 20 untuk staf", Score: 0.03},
+		{ID: uuid.New(), Content: "Switch core-sw-01 di ruang server", Score: 0.02},
+	}
+
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		expected    []string
+		expectError bool
+	}{
+		{
+			name:     "Results are ordered by relevance",
+			status:   http.StatusOK,
+			body:     `{"results":[{"index":0,"relevance_score":0.2},{"index":1,"relevance_score":0.95}]}`,
+			expected: []string{"Switch core-sw-01 di ruang server", "VLAN 20 untuk staf"},
+		},
+		{
+			name:        "Index out of range",
+			status:      http.StatusOK,
+			body:        `{"results":[{"index":5,"relevance_score":0.9}]}`,
+			expectError: true,
+		},
+		{
+			name:        "Server error",
+			status:      http.StatusInternalServerError,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/v1/rerank", r.URL.Path)
+				assert.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+
+				var request rerankRequest
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+				assert.Equal(t, "core-sw-01", request.Query)
+				assert.Equal(t, "test-rerank", request.Model)
+				assert.Len(t, request.Documents, 2)
+
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			reranker := NewHTTPReranker(&RerankerConfig{
+				BaseURL: server.URL,
+				APIKey:  "test-key",
+				Model:   "test-rerank",
+				Timeout: 5 * time.Second,
+			})
+			reranked, err := reranker.Rerank(context.Background(), "core-sw-01", chunks)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			contents := []string{}
+			for _, chunk := range reranked {
+				contents = append(contents, chunk.Content)
+			}
+			assert.Equal(t, tt.expected, contents)
+			assert.Equal(t, 0.03, chunks[0].Score, "input chunks are not modified")
+		})
+	}
+}
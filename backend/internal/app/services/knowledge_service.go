@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/repositories"
+)
+
+// Upper bound for top_k on API searches, so one request can't pull the whole table
+const maxSearchTopK = 50
+
+// Hybrid search and reranking look at more candidates than they return
+const (
+	candidateMultiplier = 4
+	minCandidates       = 20
+	maxCandidates       = 100
+)
+
+var (
+	ErrEmptyQuery        = errors.New("query is required")
+	ErrInvalidThreshold  = errors.New("threshold must be between 0 and 1")
+	ErrInvalidSearchMode = errors.New("mode must be hybrid, vector or keyword")
+	ErrDocumentNotFound  = errors.New("knowledge document not found")
+)
+
+type KnowledgeService interface {
+	Retrieve(ctx context.Context, question string, topK int, threshold float64) ([]*models.KnowledgeChunk, error)
+	Search(ctx context.Context, request *models.KnowledgeSearchRequest) (*models.KnowledgeSearchResult, error)
+	ListDocuments(ctx context.Context) ([]*models.KnowledgeDocument, error)
+	GetDocument(ctx context.Context, source string) (*models.KnowledgeDocument, error)
+	DeleteDocument(ctx context.Context, source string) (int, error)
+}
+
+// KnowledgeSearchConfig holds the defaults applied to searches that omit them.
+// With Hybrid set, retrieval fuses full-text and vector results; RRFConstant is
+// the k of reciprocal rank fusion.
+type KnowledgeSearchConfig struct {
+	TopK        int
+	Threshold   float64
+	Hybrid      bool
+	RRFConstant int
+}
+
+type knowledgeService struct {
+	knowledgeRepo repositories.KnowledgeRepository
+	spaces        EmbeddingSpaceService
+	searchConfig  KnowledgeSearchConfig
+	reranker      Reranker
+}
+
+// NewKnowledgeService creates the retrieval service; reranker may be nil
+func NewKnowledgeService(knowledgeRepo repositories.KnowledgeRepository, spaces EmbeddingSpaceService, searchConfig KnowledgeSearchConfig, reranker Reranker) KnowledgeService {
+	return &knowledgeService{
+		knowledgeRepo: knowledgeRepo,
+		spaces:        spaces,
+		searchConfig:  searchConfig,
+		reranker:      reranker,
+	}
+}
+
+// Retrieve returns the knowledge base chunks most relevant to question using
+// the configured search mode and the active embedding space
+func (s *knowledgeService) Retrieve(ctx context.Context, question string, topK int, threshold float64) ([]*models.KnowledgeChunk, error) {
+	chunks, _, err := s.search(ctx, s.defaultMode(), "", question, topK, threshold, nil)
+	return chunks, err
+}
+
+// Search runs a similarity search for the API, filling in top_k and threshold
+// from the configured defaults when they are not given
+func (s *knowledgeService) Search(ctx context.Context, request *models.KnowledgeSearchRequest) (*models.KnowledgeSearchResult, error) {
+	query := strings.TrimSpace(request.Query)
+	if query == "" {
+		return nil, ErrEmptyQuery
+	}
+
+	topK := request.TopK
+	if topK <= 0 {
+		topK = s.searchConfig.TopK
+	}
+	topK = min(topK, maxSearchTopK)
+
+	threshold := s.searchConfig.Threshold
+	if request.Threshold != nil {
+		threshold = *request.Threshold
+	}
+	if threshold < 0 || threshold > 1 {
+		return nil, ErrInvalidThreshold
+	}
+
+	mode := request.Mode
+	if mode == "" {
+		mode = s.defaultMode()
+	}
+	if mode != models.KnowledgeSearchHybrid && mode != models.KnowledgeSearchVector && mode != models.KnowledgeSearchKeyword {
+		return nil, ErrInvalidSearchMode
+	}
+
+	chunks, reranked, err := s.search(ctx, mode, request.Space, query, topK, threshold, request.Filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.KnowledgeSearchResult{
+		Query:     query,
+		Mode:      mode,
+		Reranked:  reranked,
+		TopK:      topK,
+		Threshold: threshold,
+		Results:   chunks,
+	}, nil
+}
+
+func (s *knowledgeService) defaultMode() string {
+	if s.searchConfig.Hybrid {
+		return models.KnowledgeSearchHybrid
+	}
+	return models.KnowledgeSearchVector
+}
+
+// search collects candidates for mode, fuses them when both methods ran and
+// lets the reranker pick the final topK. It reports whether reranking applied.
+// Vector search runs in the named embedding space, or the active one.
+func (s *knowledgeService) search(ctx context.Context, mode, spaceName, query string, topK int, threshold float64, filters map[string]interface{}) ([]*models.KnowledgeChunk, bool, error) {
+	candidates := topK
+	if mode == models.KnowledgeSearchHybrid || s.reranker != nil {
+		candidates = min(max(topK*candidateMultiplier, minCandidates), maxCandidates)
+	}
+
+	var rankings [][]*models.KnowledgeChunk
+
+	if mode != models.KnowledgeSearchKeyword {
+		space, err := s.spaces.Resolve(ctx, spaceName)
+		if err != nil {
+			return nil, false, err
+		}
+
+		embeddings, err := s.spaces.Embed(ctx, space, []string{query})
+		if err != nil {
+			log.Printf("[KnowledgeService] Failed to embed question in space %s: %v", space.Name, err)
+			return nil, false, fmt.Errorf("failed to embed question: %w", err)
+		}
+
+		chunks, err := s.knowledgeRepo.SearchSimilar(ctx, space, embeddings[0], candidates, threshold, filters)
+		if err != nil {
+			log.Printf("[KnowledgeService] Failed to search knowledge base: %v", err)
+			return nil, false, err
+		}
+		for _, chunk := range chunks {
+			chunk.VectorScore = chunk.Score
+		}
+		rankings = append(rankings, chunks)
+	}
+
+	if mode != models.KnowledgeSearchVector {
+		chunks, err := s.knowledgeRepo.SearchKeyword(ctx, query, candidates, filters)
+		if err != nil {
+			log.Printf("[KnowledgeService] Failed to search knowledge base keywords: %v", err)
+			return nil, false, err
+		}
+		for _, chunk := range chunks {
+			chunk.KeywordScore = chunk.Score
+		}
+		rankings = append(rankings, chunks)
+	}
+
+	results := rankings[0]
+	if len(rankings) > 1 {
+		results = fuseReciprocalRank(s.searchConfig.RRFConstant, rankings...)
+	}
+
+	reranked := false
+	if s.reranker != nil && len(results) > 0 {
+		rerankedResults, err := s.reranker.Rerank(ctx, query, results)
+		if err != nil {
+			log.Printf("[KnowledgeService] Reranker failed, keeping %s order: %v", mode, err)
+		} else {
+			results = rerankedResults
+			reranked = true
+		}
+	}
+
+	if len(results) > topK {
+		results = results[:topK]
+	}
+
+	log.Printf("[KnowledgeService] Retrieved %d chunks (mode %s, top_k %d, threshold %.2f, reranked %t)", len(results), mode, topK, threshold, reranked)
+	return results, reranked, nil
+}
+
+func (s *knowledgeService) ListDocuments(ctx context.Context) ([]*models.KnowledgeDocument, error) {
+	documents, err := s.knowledgeRepo.ListSources(ctx)
+	if err != nil {
+		log.Printf("[KnowledgeService] Failed to list documents: %v", err)
+		return nil, err
+	}
+
+	return documents, nil
+}
+
+// GetDocument returns a source with all of its chunks in document order
+func (s *knowledgeService) GetDocument(ctx context.Context, source string) (*models.KnowledgeDocument, error) {
+	chunks, err := s.knowledgeRepo.ListBySource(ctx, source)
+	if err != nil {
+		log.Printf("[KnowledgeService] Failed to get document %s: %v", source, err)
+		return nil, err
+	}
+
+	if len(chunks) == 0 {
+		return nil, ErrDocumentNotFound
+	}
+
+	document := &models.KnowledgeDocument{
+		Source:     source,
+		ChunkCount: len(chunks),
+		CreatedAt:  chunks[0].CreatedAt,
+		UpdatedAt:  chunks[0].CreatedAt,
+		Chunks:     chunks,
+	}
+	for _, chunk := range chunks {
+		if chunk.CreatedAt.Before(document.CreatedAt) {
+			document.CreatedAt = chunk.CreatedAt
+		}
+		if chunk.CreatedAt.After(document.UpdatedAt) {
+			document.UpdatedAt = chunk.CreatedAt
+		}
+	}
+
+	return document, nil
+}
+
+// DeleteDocument removes every chunk of source and returns how many were deleted
+func (s *knowledgeService) DeleteDocument(ctx context.Context, source string) (int, error) {
+	deleted, err := s.knowledgeRepo.DeleteBySource(ctx, source)
+	if err != nil {
+		log.Printf("[KnowledgeService] Failed to delete document %s: %v", source, err)
+		return 0, err
+	}
+
+	if deleted == 0 {
+		return 0, ErrDocumentNotFound
+	}
+
+	log.Printf("[KnowledgeService] Deleted %d chunks for %s", deleted, source)
+	return int(deleted), nil
+}
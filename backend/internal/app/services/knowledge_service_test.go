@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/testutils/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestKnowledgeService_Search
+// Summary: Test knowledge base search through the API parameters
+// Purpose: Validate defaults for top_k and threshold, the top_k cap, filters and input validation
+func TestKnowledgeService_Search(t *testing.T) {
+	zero := 0.0
+	tooHigh := 1.5
+	embedding := []float32{0.1, 0.2}
+
+	tests := []struct {
+		name              string
+		request           *models.KnowledgeSearchRequest
+		expectedTopK      int
+		expectedThreshold float64
+		expectedFilters   map[string]interface{}
+		expectedErr       error
+	}{
+		{
+			name:              "Defaults fill missing top_k and threshold",
+			request:           &models.KnowledgeSearchRequest{Query: "reset password"},
+			expectedTopK:      3,
+			expectedThreshold: 0.7,
+		},
+		{
+			name: "Explicit zero threshold and filters are passed through",
+			request: &models.KnowledgeSearchRequest{
+				Query:     "reset password",
+				TopK:      5,
+				Threshold: &zero,
+				Filters:   map[string]interface{}{"source": "faq.txt"},
+			},
+			expectedTopK:      5,
+			expectedThreshold: 0,
+			expectedFilters:   map[string]interface{}{"source": "faq.txt"},
+		},
+		{
+			name:              "Named embedding space",
+			request:           &models.KnowledgeSearchRequest{Query: "reset password", Space: "e5-small"},
+			expectedTopK:      3,
+			expectedThreshold: 0.7,
+		},
+		{
+			name:              "top_k is capped",
+			request:           &models.KnowledgeSearchRequest{Query: "reset password", TopK: 500},
+			expectedTopK:      maxSearchTopK,
+			expectedThreshold: 0.7,
+		},
+		{
+			name:        "Blank query is rejected",
+			request:     &models.KnowledgeSearchRequest{Query: "   "},
+			expectedErr: ErrEmptyQuery,
+		},
+		{
+			name:        "Threshold outside 0..1 is rejected",
+			request:     &models.KnowledgeSearchRequest{Query: "reset password", Threshold: &tooHigh},
+			expectedErr: ErrInvalidThreshold,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockKnowledgeRepository(t)
+			mockSpaces := mocks.NewMockEmbeddingSpaceService(t)
+			space := &models.EmbeddingSpace{Name: tt.request.Space, Dimension: 2}
+
+			chunks := []*models.KnowledgeChunk{{Content: "Buka menu akun", Score: 0.9}}
+			if tt.expectedErr == nil {
+				mockSpaces.EXPECT().Resolve(mock.Anything, tt.request.Space).Return(space, nil)
+				mockSpaces.EXPECT().Embed(mock.Anything, space, []string{"reset password"}).Return([][]float32{embedding}, nil)
+				mockRepo.EXPECT().SearchSimilar(mock.Anything, space, embedding, tt.expectedTopK, tt.expectedThreshold, tt.expectedFilters).Return(chunks, nil)
+			}
+
+			service := NewKnowledgeService(mockRepo, mockSpaces, KnowledgeSearchConfig{TopK: 3, Threshold: 0.7}, nil)
+			result, err := service.Search(context.Background(), tt.request)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedTopK, result.TopK)
+			assert.Equal(t, tt.expectedThreshold, result.Threshold)
+			assert.Equal(t, chunks, result.Results)
+		})
+	}
+}
+
+type stubReranker struct {
+	rerankFunc func(ctx context.Context, query string, chunks []*models.KnowledgeChunk) ([]*models.KnowledgeChunk, error)
+}
+
+func (r *stubReranker) Rerank(ctx context.Context, query string, chunks []*models.KnowledgeChunk) ([]*models.KnowledgeChunk, error) {
+	return r.rerankFunc(ctx, query, chunks)
+}
+
+// TestKnowledgeService_HybridSearch
+// Summary: Test hybrid retrieval combining vector and keyword search
+// Purpose: Validate candidate widening, rank fusion, the keyword-only mode and the reranker hook with its fallback
+func TestKnowledgeService_HybridSearch(t *testing.T) {
+	embedding := []float32{0.1, 0.2}
+	vectorOnly := &models.KnowledgeChunk{ID: uuid.New(), Content: "Panduan jaringan kantor", Score: 0.82}
+	both := &models.KnowledgeChunk{ID: uuid.New(), Content: "core-sw-01 menghubungkan VLAN 20", Score: 0.75}
+	keywordOnly := &models.KnowledgeChunk{ID: uuid.New(), Content: "Restart core-sw-01 saat maintenance", Score: 0.4}
+
+	tests := []struct {
+		name             string
+		mode             string
+		reranker         Reranker
+		expectVector     bool
+		candidates       int
+		expectedContents []string
+		expectedReranked bool
+	}{
+		{
+			name:             "Hybrid fuses both searches",
+			mode:             models.KnowledgeSearchHybrid,
+			expectVector:     true,
+			candidates:       minCandidates,
+			expectedContents: []string{both.Content, vectorOnly.Content},
+		},
+		{
+			name:             "Keyword mode skips embeddings",
+			mode:             models.KnowledgeSearchKeyword,
+			candidates:       2,
+			expectedContents: []string{keywordOnly.Content, both.Content},
+		},
+		{
+			name: "Reranker decides the final order",
+			mode: models.KnowledgeSearchHybrid,
+			reranker: &stubReranker{rerankFunc: func(ctx context.Context, query string, chunks []*models.KnowledgeChunk) ([]*models.KnowledgeChunk, error) {
+				assert.Len(t, chunks, 3)
+				return []*models.KnowledgeChunk{chunks[2], chunks[0]}, nil
+			}},
+			expectVector:     true,
+			candidates:       minCandidates,
+			expectedContents: []string{keywordOnly.Content, both.Content},
+			expectedReranked: true,
+		},
+		{
+			name: "Reranker failure keeps fused order",
+			mode: models.KnowledgeSearchHybrid,
+			reranker: &stubReranker{rerankFunc: func(ctx context.Context, query string, chunks []*models.KnowledgeChunk) ([]*models.KnowledgeChunk, error) {
+				return nil, errors.New("rerank unavailable")
+			}},
+			expectVector:     true,
+			candidates:       minCandidates,
+			expectedContents: []string{both.Content, vectorOnly.Content},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockKnowledgeRepository(t)
+			mockSpaces := mocks.NewMockEmbeddingSpaceService(t)
+			space := &models.EmbeddingSpace{Name: models.DefaultEmbeddingSpace, Dimension: 2}
+
+			if tt.expectVector {
+				mockSpaces.EXPECT().Resolve(mock.Anything, "").Return(space, nil)
+				mockSpaces.EXPECT().Embed(mock.Anything, space, []string{"core-sw-01"}).Return([][]float32{embedding}, nil)
+				mockRepo.EXPECT().SearchSimilar(mock.Anything, space, embedding, tt.candidates, 0.7, map[string]interface{}(nil)).
+					Return([]*models.KnowledgeChunk{copyChunk(vectorOnly), copyChunk(both)}, nil)
+			}
+			mockRepo.EXPECT().SearchKeyword(mock.Anything, "core-sw-01", tt.candidates, map[string]interface{}(nil)).
+				Return([]*models.KnowledgeChunk{copyChunk(keywordOnly), copyChunk(both)}, nil)
+
+			service := NewKnowledgeService(mockRepo, mockSpaces, KnowledgeSearchConfig{TopK: 2, Threshold: 0.7, Hybrid: true}, tt.reranker)
+			result, err := service.Search(context.Background(), &models.KnowledgeSearchRequest{Query: "core-sw-01", Mode: tt.mode})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.mode, result.Mode)
+			assert.Equal(t, tt.expectedReranked, result.Reranked)
+
+			contents := []string{}
+			for _, chunk := range result.Results {
+				contents = append(contents, chunk.Content)
+			}
+			assert.Equal(t, tt.expectedContents, contents)
+		})
+	}
+
+	t.Run("Unknown mode is rejected", func(t *testing.T) {
+		service := NewKnowledgeService(mocks.NewMockKnowledgeRepository(t), mocks.NewMockEmbeddingSpaceService(t), KnowledgeSearchConfig{TopK: 2}, nil)
+		_, err := service.Search(context.Background(), &models.KnowledgeSearchRequest{Query: "core-sw-01", Mode: "fuzzy"})
+		assert.ErrorIs(t, err, ErrInvalidSearchMode)
+	})
+}
+
+func copyChunk(chunk *models.KnowledgeChunk) *models.KnowledgeChunk {
+	copied := *chunk
+	return &copied
+}
+
+// TestKnowledgeService_Documents
+// Summary: Test fetching and deleting knowledge documents by source
+// Purpose: Validate that unknown sources report ErrDocumentNotFound and found ones are summarised
+func TestKnowledgeService_Documents(t *testing.T) {
+	first := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+	last := first.Add(time.Hour)
+
+	t.Run("GetDocument summarises chunks", func(t *testing.T) {
+		mockRepo := mocks.NewMockKnowledgeRepository(t)
+		chunks := []*models.KnowledgeChunk{{Content: "a", CreatedAt: last}, {Content: "b", CreatedAt: first}}
+		mockRepo.EXPECT().ListBySource(mock.Anything, "faq.txt").Return(chunks, nil)
+
+		document, err := NewKnowledgeService(mockRepo, nil, KnowledgeSearchConfig{}, nil).GetDocument(context.Background(), "faq.txt")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, document.ChunkCount)
+		assert.Equal(t, first, document.CreatedAt)
+		assert.Equal(t, last, document.UpdatedAt)
+		assert.Equal(t, chunks, document.Chunks)
+	})
+
+	t.Run("GetDocument unknown source", func(t *testing.T) {
+		mockRepo := mocks.NewMockKnowledgeRepository(t)
+		mockRepo.EXPECT().ListBySource(mock.Anything, "missing.txt").Return([]*models.KnowledgeChunk{}, nil)
+
+		document, err := NewKnowledgeService(mockRepo, nil, KnowledgeSearchConfig{}, nil).GetDocument(context.Background(), "missing.txt")
+
+		assert.ErrorIs(t, err, ErrDocumentNotFound)
+		assert.Nil(t, document)
+	})
+
+	t.Run("DeleteDocument unknown source", func(t *testing.T) {
+		mockRepo := mocks.NewMockKnowledgeRepository(t)
+		mockRepo.EXPECT().DeleteBySource(mock.Anything, "missing.txt").Return(int64(0), nil)
+
+		deleted, err := NewKnowledgeService(mockRepo, nil, KnowledgeSearchConfig{}, nil).DeleteDocument(context.Background(), "missing.txt")
+
+		assert.ErrorIs(t, err, ErrDocumentNotFound)
+		assert.Equal(t, 0, deleted)
+	})
+}
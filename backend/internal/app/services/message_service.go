@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/repositories"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultMessagePageSize = 50
+	maxMessagePageSize     = 200
+)
+
+type MessageService interface {
+	Record(ctx context.Context, message *models.Message) error
+	UpdateStatus(ctx context.Context, id uuid.UUID, status, workflowType, errMsg string) error
+	GetConversation(ctx context.Context, phone string, page, pageSize int) (*models.MessagePage, error)
+}
+
+type messageService struct {
+	messageRepo repositories.MessageRepository
+}
+
+func NewMessageService(messageRepo repositories.MessageRepository) MessageService {
+	return &messageService{
+		messageRepo: messageRepo,
+	}
+}
+
+func (s *messageService) Record(ctx context.Context, message *models.Message) error {
+	err := s.messageRepo.Create(ctx, message)
+	if err != nil {
+		log.Printf("[MessageService] Failed to record %s message for %s: %v", message.Direction, message.Phone, err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *messageService) UpdateStatus(ctx context.Context, id uuid.UUID, status, workflowType, errMsg string) error {
+	err := s.messageRepo.UpdateStatus(ctx, id, status, workflowType, errMsg)
+	if err != nil {
+		log.Printf("[MessageService] Failed to update message %s to %s: %v", id.String(), status, err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *messageService) GetConversation(ctx context.Context, phone string, page, pageSize int) (*models.MessagePage, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultMessagePageSize
+	}
+	if pageSize > maxMessagePageSize {
+		pageSize = maxMessagePageSize
+	}
+
+	log.Printf("[MessageService] Getting conversation for %s (page %d, size %d)", phone, page, pageSize)
+
+	messages, total, err := s.messageRepo.ListByPhone(ctx, phone, pageSize, (page-1)*pageSize)
+	if err != nil {
+		log.Printf("[MessageService] Failed to get conversation for %s: %v", phone, err)
+		return nil, err
+	}
+
+	return &models.MessagePage{
+		Phone:    phone,
+		Messages: messages,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+type messageMetaKey struct{}
+
+// MessageMeta carries correlation data for outbound messages through SendMessage
+type MessageMeta struct {
+	CorrelationID string
+	WorkflowType  string
+}
+
+// WithMessageMeta attaches correlation data that SendMessage stores with the outbound message
+func WithMessageMeta(ctx context.Context, meta MessageMeta) context.Context {
+	return context.WithValue(ctx, messageMetaKey{}, meta)
+}
+
+// MessageMetaFromContext returns the correlation data attached to ctx, if any
+func MessageMetaFromContext(ctx context.Context) MessageMeta {
+	meta, _ := ctx.Value(messageMetaKey{}).(MessageMeta)
+	return meta
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/testutils/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestMessageService_GetConversation
+// Summary: Test paginated transcript retrieval
+// Purpose: Validate page defaults, page size limits and offset calculation
+func TestMessageService_GetConversation(t *testing.T) {
+	phone := "6281234567890"
+
+	tests := []struct {
+		name             string
+		page             int
+		pageSize         int
+		expectedLimit    int
+		expectedOffset   int
+		expectedPage     int
+		expectedPageSize int
+	}{
+		{
+			name:             "Defaults for missing pagination",
+			page:             0,
+			pageSize:         0,
+			expectedLimit:    50,
+			expectedOffset:   0,
+			expectedPage:     1,
+			expectedPageSize: 50,
+		},
+		{
+			name:             "Third page of twenty",
+			page:             3,
+			pageSize:         20,
+			expectedLimit:    20,
+			expectedOffset:   40,
+			expectedPage:     3,
+			expectedPageSize: 20,
+		},
+		{
+			name:             "Page size is capped",
+			page:             1,
+			pageSize:         1000,
+			expectedLimit:    200,
+			expectedOffset:   0,
+			expectedPage:     1,
+			expectedPageSize: 200,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockMessageRepository(t)
+			messages := []*models.Message{{Phone: phone, Direction: models.MessageDirectionInbound, Content: "Halo"}}
+			mockRepo.EXPECT().ListByPhone(mock.Anything, phone, tt.expectedLimit, tt.expectedOffset).Return(messages, 41, nil)
+
+			service := NewMessageService(mockRepo)
+			result, err := service.GetConversation(context.Background(), phone, tt.page, tt.pageSize)
+
+			assert.NoError(t, err)
+			assert.Equal(t, phone, result.Phone)
+			assert.Equal(t, 41, result.Total)
+			assert.Equal(t, tt.expectedPage, result.Page)
+			assert.Equal(t, tt.expectedPageSize, result.PageSize)
+			assert.Len(t, result.Messages, 1)
+		})
+	}
+}
+
+// TestMessageService_GetConversation_Error
+// Summary: Test transcript retrieval when the repository fails
+// Purpose: Validate that repository errors are returned to the caller
+func TestMessageService_GetConversation_Error(t *testing.T) {
+	mockRepo := mocks.NewMockMessageRepository(t)
+	mockRepo.EXPECT().ListByPhone(mock.Anything, "6281234567890", 50, 0).Return(nil, 0, fmt.Errorf("database connection failed"))
+
+	service := NewMessageService(mockRepo)
+	result, err := service.GetConversation(context.Background(), "6281234567890", 1, 50)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+// TestMessageMetaContext
+// Summary: Test correlation data carried through context
+// Purpose: Validate that outbound messages can be tied back to the workflow request
+func TestMessageMetaContext(t *testing.T) {
+	empty := MessageMetaFromContext(context.Background())
+	assert.Equal(t, MessageMeta{}, empty)
+
+	ctx := WithMessageMeta(context.Background(), MessageMeta{CorrelationID: "msg-123", WorkflowType: "n8n"})
+	meta := MessageMetaFromContext(ctx)
+	assert.Equal(t, "msg-123", meta.CorrelationID)
+	assert.Equal(t, "n8n", meta.WorkflowType)
+}
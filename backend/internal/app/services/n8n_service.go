@@ -10,30 +10,31 @@ import (
 	"time"
 
 	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
-
-	"github.com/google/uuid"
 )
 
 type N8NService interface {
-	SendMessageToWorkflow(ctx context.Context, userContext *models.UserContext, message string) error
+	WorkflowBackend
 	HandleWorkflowResponse(response *models.N8NResponse) error
-	SetWhatsAppService(whatsappSvc WhatsAppService)
 }
 
 type n8nService struct {
 	httpClient  *http.Client
 	workflowURL string
 	apiKey      string
-	whatsappSvc WhatsAppService
+	sender      MessageSender
+	pendingSvc  PendingRequestService
+	retry       RetryPolicy
 }
 
 type N8NConfig struct {
-	WorkflowURL string
-	APIKey      string
-	Timeout     time.Duration
+	WorkflowURL   string
+	APIKey        string
+	Timeout       time.Duration
+	RetryAttempts int
+	RetryDelay    time.Duration
 }
 
-func NewN8NService(config *N8NConfig) N8NService {
+func NewN8NService(config *N8NConfig, pendingSvc PendingRequestService, sender MessageSender) N8NService {
 	httpClient := &http.Client{
 		Timeout: config.Timeout,
 	}
@@ -42,29 +43,51 @@ func NewN8NService(config *N8NConfig) N8NService {
 		httpClient:  httpClient,
 		workflowURL: config.WorkflowURL,
 		apiKey:      config.APIKey,
+		pendingSvc:  pendingSvc,
+		sender:      sender,
+		retry: RetryPolicy{
+			Retries:   config.RetryAttempts,
+			BaseDelay: config.RetryDelay,
+		},
 	}
 }
 
-func (s *n8nService) SetWhatsAppService(whatsappSvc WhatsAppService) {
-	s.whatsappSvc = whatsappSvc
+func (s *n8nService) Type() string {
+	return WorkflowTypeN8N
 }
 
-func (s *n8nService) SendMessageToWorkflow(ctx context.Context, userContext *models.UserContext, message string) error {
-	log.Printf("[N8NService] Sending message to workflow for user %s: %s", userContext.Name, message)
-
-	// Generate message ID for correlation
-	messageID := uuid.New().String()
+func (s *n8nService) SendMessageToWorkflow(ctx context.Context, request *models.WorkflowRequest) error {
+	log.Printf("[N8NService] Sending message to workflow for user %s: %s", request.UserContext.Name, request.Message)
 
 	// Create N8N request payload
-	request := &models.N8NRequest{
-		UserContext: userContext,
-		Message:     message,
-		MessageID:   messageID,
+	payload := &models.N8NRequest{
+		UserContext: request.UserContext,
+		Message:     request.Message,
+		MessageID:   request.MessageID,
+		SessionID:   request.SessionID,
 		Timestamp:   time.Now(),
 	}
 
+	// Register before sending so a fast callback always finds its request
+	if err := s.pendingSvc.Register(ctx, request.MessageID, request.UserContext.Phone, WorkflowTypeN8N); err != nil {
+		return fmt.Errorf("failed to register pending request: %w", err)
+	}
+
+	err := withRetry(ctx, s.retry, "[N8NService]", func() error {
+		return s.postToWorkflow(ctx, payload)
+	})
+	if err != nil {
+		s.pendingSvc.MarkFailed(ctx, request.MessageID, err.Error())
+		return err
+	}
+
+	log.Printf("[N8NService] Message sent to N8N workflow successfully (MessageID: %s, SessionID: %s)", request.MessageID, request.SessionID)
+	return nil
+}
+
+func (s *n8nService) postToWorkflow(ctx context.Context, payload *models.N8NRequest) error {
 	// Marshal request to JSON
-	jsonData, err := json.Marshal(request)
+	jsonData, err := json.Marshal(payload)
 	if err != nil {
 		log.Printf("[N8NService] Failed to marshal request: %v", err)
 		return fmt.Errorf("failed to marshal request: %w", err)
@@ -79,6 +102,7 @@ func (s *n8nService) SendMessageToWorkflow(ctx context.Context, userContext *mod
 
 	// Set headers
 	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set(IdempotencyKeyHeader, payload.MessageID)
 	if s.apiKey != "" {
 		httpReq.Header.Set("Authorization", "Bearer "+s.apiKey)
 	}
@@ -94,39 +118,57 @@ func (s *n8nService) SendMessageToWorkflow(ctx context.Context, userContext *mod
 	// Check response status
 	if resp.StatusCode != http.StatusOK {
 		log.Printf("[N8NService] N8N workflow returned error status: %d", resp.StatusCode)
-		return fmt.Errorf("N8N workflow returned error status: %d", resp.StatusCode)
+		return &HTTPStatusError{Source: "N8N workflow", StatusCode: resp.StatusCode}
 	}
 
-	log.Printf("[N8NService] Message sent to N8N workflow successfully (MessageID: %s)", messageID)
 	return nil
 }
 
+// HandleWorkflowResponse delivers a callback for a request issued by SendMessageToWorkflow.
+// The reply goes to the phone recorded for the request, not the one in the payload.
 func (s *n8nService) HandleWorkflowResponse(response *models.N8NResponse) error {
 	log.Printf("[N8NService] Handling workflow response for phone %s (MessageID: %s)", response.Phone, response.MessageID)
 
+	ctx := WithMessageMeta(context.Background(), MessageMeta{
+		CorrelationID: response.MessageID,
+		WorkflowType:  WorkflowTypeN8N,
+	})
+
+	pending, err := s.pendingSvc.Claim(ctx, response.MessageID)
+	if err != nil {
+		return err
+	}
+
+	if response.Phone != "" && response.Phone != pending.Phone {
+		log.Printf("[N8NService] Callback phone %s does not match recorded phone %s (MessageID: %s), using recorded phone",
+			response.Phone, pending.Phone, response.MessageID)
+	}
+
 	if !response.Success {
 		log.Printf("[N8NService] N8N workflow returned error: %s", response.Error)
+		s.pendingSvc.MarkFailed(ctx, response.MessageID, response.Error)
 		return fmt.Errorf("N8N workflow error: %s", response.Error)
 	}
 
 	if response.Response == "" {
 		log.Printf("[N8NService] Empty response from N8N workflow (MessageID: %s)", response.MessageID)
+		s.pendingSvc.MarkFailed(ctx, response.MessageID, "empty response")
 		return fmt.Errorf("empty response from N8N workflow")
 	}
 
 	// Send response back to WhatsApp user
-	if s.whatsappSvc == nil {
-		log.Printf("[N8NService] WhatsApp service not set, cannot send response")
+	if s.sender == nil {
+		log.Printf("[N8NService] Message sender not set, cannot send response")
 		return fmt.Errorf("WhatsApp service not available")
 	}
 
-	ctx := context.Background()
-	err := s.whatsappSvc.SendMessage(ctx, response.Phone, response.Response)
+	err = s.sender.SendMessage(ctx, pending.Phone, response.Response)
 	if err != nil {
-		log.Printf("[N8NService] Failed to send response to WhatsApp user %s: %v", response.Phone, err)
+		log.Printf("[N8NService] Failed to send response to WhatsApp user %s: %v", pending.Phone, err)
+		s.pendingSvc.MarkFailed(ctx, response.MessageID, err.Error())
 		return fmt.Errorf("failed to send response to WhatsApp: %w", err)
 	}
 
-	log.Printf("[N8NService] Response sent to WhatsApp user %s successfully (MessageID: %s)", response.Phone, response.MessageID)
+	log.Printf("[N8NService] Response sent to WhatsApp user %s successfully (MessageID: %s)", pending.Phone, response.MessageID)
 	return nil
 }
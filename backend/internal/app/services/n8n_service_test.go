@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/testutils/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestN8NService_HandleWorkflowResponse
+// Summary: Test handling callbacks from N8N
+// Purpose: Validate that replies go to the recorded phone and unknown callbacks are rejected
+func TestN8NService_HandleWorkflowResponse(t *testing.T) {
+	tests := []struct {
+		name          string
+		response      *models.N8NResponse
+		claimed       *models.PendingRequest
+		claimError    error
+		expectFailed  bool
+		expectSend    bool
+		expectedPhone string
+		expectError   bool
+	}{
+		{
+			name: "Reply goes to recorded phone",
+			response: &models.N8NResponse{
+				MessageID: "msg-123",
+				Phone:     "6289999999999",
+				Response:  "Silakan restart router Anda.",
+				Success:   true,
+			},
+			claimed:       &models.PendingRequest{MessageID: "msg-123", Phone: "6281234567890"},
+			expectSend:    true,
+			expectedPhone: "6281234567890",
+		},
+		{
+			name: "Unknown message ID is rejected",
+			response: &models.N8NResponse{
+				MessageID: "msg-unknown",
+				Phone:     "6281234567890",
+				Response:  "Hello",
+				Success:   true,
+			},
+			claimError:  fmt.Errorf("%w: msg-unknown", ErrUnknownRequest),
+			expectError: true,
+		},
+		{
+			name: "Workflow error marks request failed",
+			response: &models.N8NResponse{
+				MessageID: "msg-123",
+				Success:   false,
+				Error:     "Processing timeout",
+			},
+			claimed:      &models.PendingRequest{MessageID: "msg-123", Phone: "6281234567890"},
+			expectFailed: true,
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPending := mocks.NewMockPendingRequestService(t)
+			mockPending.EXPECT().Claim(mock.Anything, tt.response.MessageID).Return(tt.claimed, tt.claimError)
+			if tt.expectFailed {
+				mockPending.EXPECT().MarkFailed(mock.Anything, tt.response.MessageID, mock.Anything).Return(nil)
+			}
+
+			sent := false
+			service := NewN8NService(&N8NConfig{Timeout: time.Second}, mockPending, &mockWhatsAppService{
+				sendMessageFunc: func(ctx context.Context, phone, message string) error {
+					sent = true
+					assert.Equal(t, tt.expectedPhone, phone)
+					assert.Equal(t, tt.response.MessageID, MessageMetaFromContext(ctx).CorrelationID)
+					return nil
+				},
+			})
+
+			err := service.HandleWorkflowResponse(tt.response)
+
+			assert.Equal(t, tt.expectError, err != nil, "unexpected error result: %v", err)
+			assert.Equal(t, tt.expectSend, sent)
+		})
+	}
+}
+
+// TestN8NService_SendMessageToWorkflow
+// Summary: Test sending messages to the N8N webhook
+// Purpose: Validate that requests are registered before sending and marked failed on error
+func TestN8NService_SendMessageToWorkflow(t *testing.T) {
+	tests := []struct {
+		name         string
+		statusCode   int
+		expectFailed bool
+		expectError  bool
+	}{
+		{
+			name:       "Successful send",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:         "Workflow error status",
+			statusCode:   http.StatusBadGateway,
+			expectFailed: true,
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			mockPending := mocks.NewMockPendingRequestService(t)
+			mockPending.EXPECT().Register(mock.Anything, "msg-123", "6281234567890", "n8n").Return(nil)
+			if tt.expectFailed {
+				mockPending.EXPECT().MarkFailed(mock.Anything, "msg-123", mock.Anything).Return(nil)
+			}
+
+			service := NewN8NService(&N8NConfig{WorkflowURL: server.URL, Timeout: time.Second}, mockPending, nil)
+			err := service.SendMessageToWorkflow(context.Background(), &models.WorkflowRequest{
+				MessageID:   "msg-123",
+				SessionID:   "session-123",
+				UserContext: &models.UserContext{Name: "Budi", Phone: "6281234567890"},
+				Message:     "Internet kantor lambat",
+			})
+
+			assert.Equal(t, tt.expectError, err != nil, "unexpected error result: %v", err)
+		})
+	}
+}
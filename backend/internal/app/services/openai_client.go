@@ -0,0 +1,138 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+)
+
+// OpenAIClient calls an OpenAI-compatible API for chat completions and embeddings
+type OpenAIClient interface {
+	CreateChatCompletion(ctx context.Context, request *models.ChatCompletionRequest) (string, error)
+	CreateEmbeddings(ctx context.Context, input []string) ([][]float32, error)
+}
+
+type openAIClient struct {
+	httpClient     *http.Client
+	baseURL        string
+	apiKey         string
+	embeddingModel string
+	retry          RetryPolicy
+}
+
+type OpenAIClientConfig struct {
+	BaseURL        string
+	APIKey         string
+	EmbeddingModel string
+	Timeout        time.Duration
+	RetryAttempts  int
+	RetryDelay     time.Duration
+}
+
+// NewOpenAIClient creates a client for BaseURL, which is the API root without the
+// /v1 suffix (e.g. https://api.openai.com or http://localhost:11434)
+func NewOpenAIClient(config *OpenAIClientConfig) OpenAIClient {
+	return &openAIClient{
+		httpClient: &http.Client{
+			Timeout: config.Timeout,
+		},
+		baseURL:        strings.TrimSuffix(config.BaseURL, "/"),
+		apiKey:         config.APIKey,
+		embeddingModel: config.EmbeddingModel,
+		retry: RetryPolicy{
+			Retries:   config.RetryAttempts,
+			BaseDelay: config.RetryDelay,
+		},
+	}
+}
+
+func (c *openAIClient) CreateChatCompletion(ctx context.Context, request *models.ChatCompletionRequest) (string, error) {
+	var response models.ChatCompletionResponse
+	err := withRetry(ctx, c.retry, "[OpenAIClient]", func() error {
+		return c.post(ctx, "/v1/chat/completions", request, &response)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(response.Choices) == 0 {
+		return "", fmt.Errorf("chat completion returned no choices")
+	}
+
+	return strings.TrimSpace(response.Choices[0].Message.Content), nil
+}
+
+// CreateEmbeddings returns one embedding per input, in input order
+func (c *openAIClient) CreateEmbeddings(ctx context.Context, input []string) ([][]float32, error) {
+	request := &models.EmbeddingRequest{
+		Model: c.embeddingModel,
+		Input: input,
+	}
+
+	var response models.EmbeddingResponse
+	err := withRetry(ctx, c.retry, "[OpenAIClient]", func() error {
+		return c.post(ctx, "/v1/embeddings", request, &response)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response.Data) != len(input) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(input), len(response.Data))
+	}
+
+	embeddings := make([][]float32, len(input))
+	for _, item := range response.Data {
+		if item.Index < 0 || item.Index >= len(input) {
+			return nil, fmt.Errorf("embedding index %d out of range", item.Index)
+		}
+		embeddings[item.Index] = item.Embedding
+	}
+
+	return embeddings, nil
+}
+
+func (c *openAIClient) post(ctx context.Context, path string, payload, result interface{}) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("[OpenAIClient] Failed to marshal request: %v", err)
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+path, bytes.NewBuffer(jsonData))
+	if err != nil {
+		log.Printf("[OpenAIClient] Failed to create HTTP request: %v", err)
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		log.Printf("[OpenAIClient] Failed to send HTTP request: %v", err)
+		return fmt.Errorf("failed to send HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("[OpenAIClient] %s returned error status: %d", path, resp.StatusCode)
+		return &HTTPStatusError{Source: "OpenAI API " + path, StatusCode: resp.StatusCode}
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		log.Printf("[OpenAIClient] Failed to decode %s response: %v", path, err)
+		return fmt.Errorf("failed to decode response: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+)
+
+// Placeholders filled in by the RAG prompt template
+const (
+	promptRetrievedDocuments = "{{retrieved_documents}}"
+	promptUserQuestion       = "{{user_question}}"
+)
+
+type OpenAIConfig struct {
+	ChatModel      string
+	Temperature    float64
+	MaxTokens      int
+	TopP           float64
+	TopK           int
+	Threshold      float64
+	PromptTemplate string
+}
+
+type openAIService struct {
+	config       *OpenAIConfig
+	openAIClient OpenAIClient
+	knowledgeSvc KnowledgeService
+	sender       MessageSender
+}
+
+// NewOpenAIService creates a workflow backend that retrieves knowledge base chunks,
+// asks the chat model and replies synchronously
+func NewOpenAIService(config *OpenAIConfig, openAIClient OpenAIClient, knowledgeSvc KnowledgeService, sender MessageSender) WorkflowBackend {
+	return &openAIService{
+		config:       config,
+		openAIClient: openAIClient,
+		knowledgeSvc: knowledgeSvc,
+		sender:       sender,
+	}
+}
+
+// LoadPromptTemplate reads a prompt template and checks it has both RAG placeholders
+func LoadPromptTemplate(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read prompt template: %w", err)
+	}
+
+	template := string(content)
+	for _, placeholder := range []string{promptRetrievedDocuments, promptUserQuestion} {
+		if !strings.Contains(template, placeholder) {
+			return "", fmt.Errorf("prompt template %s is missing %s", path, placeholder)
+		}
+	}
+
+	return template, nil
+}
+
+func (s *openAIService) Type() string {
+	return WorkflowTypeOpenAI
+}
+
+func (s *openAIService) SendMessageToWorkflow(ctx context.Context, request *models.WorkflowRequest) error {
+	log.Printf("[OpenAIService] Answering message for user %s: %s", request.UserContext.Name, request.Message)
+
+	chunks, err := s.knowledgeSvc.Retrieve(ctx, request.Message, s.config.TopK, s.config.Threshold)
+	if err != nil {
+		return err
+	}
+
+	answer, err := s.openAIClient.CreateChatCompletion(ctx, &models.ChatCompletionRequest{
+		Model: s.config.ChatModel,
+		Messages: []models.ChatMessage{
+			{Role: "user", Content: s.buildPrompt(request.Message, chunks)},
+		},
+		Temperature: s.config.Temperature,
+		MaxTokens:   s.config.MaxTokens,
+		TopP:        s.config.TopP,
+	})
+	if err != nil {
+		log.Printf("[OpenAIService] Chat completion failed (MessageID: %s): %v", request.MessageID, err)
+		return err
+	}
+
+	if answer == "" {
+		return fmt.Errorf("empty response from chat completion")
+	}
+
+	ctx = WithMessageMeta(ctx, MessageMeta{
+		CorrelationID: request.MessageID,
+		WorkflowType:  WorkflowTypeOpenAI,
+	})
+	err = s.sender.SendMessage(ctx, request.UserContext.Phone, answer)
+	if err != nil {
+		log.Printf("[OpenAIService] Failed to send response to WhatsApp user %s: %v", request.UserContext.Phone, err)
+		return fmt.Errorf("failed to send response to WhatsApp: %w", err)
+	}
+
+	log.Printf("[OpenAIService] Response sent to WhatsApp user %s successfully (MessageID: %s, chunks: %d)",
+		request.UserContext.Phone, request.MessageID, len(chunks))
+	return nil
+}
+
+func (s *openAIService) buildPrompt(question string, chunks []*models.KnowledgeChunk) string {
+	documents := make([]string, 0, len(chunks))
+	for i, chunk := range chunks {
+		header := fmt.Sprintf("[%d]", i+1)
+		if source, ok := chunk.Metadata["source"].(string); ok && source != "" {
+			header += " Sumber: " + source
+		}
+		documents = append(documents, header+"\n"+strings.TrimSpace(chunk.Content))
+	}
+
+	return strings.NewReplacer(
+		promptRetrievedDocuments, strings.Join(documents, "\n\n"),
+		promptUserQuestion, question,
+	).Replace(s.config.PromptTemplate)
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/testutils/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestOpenAIService_SendMessageToWorkflow
+// Summary: Test answering a message through an OpenAI-compatible API with RAG
+// Purpose: Validate embedding, retrieval, prompt rendering and reply delivery against a mock server
+func TestOpenAIService_SendMessageToWorkflow(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+
+		switch r.URL.Path {
+		case "/v1/embeddings":
+			var request models.EmbeddingRequest
+			json.NewDecoder(r.Body).Decode(&request)
+			assert.Equal(t, "test-embedding", request.Model)
+			assert.Equal(t, []string{"Bagaimana cara reset password?"}, request.Input)
+			w.Write([]byte(`{"data":[{"index":0,"embedding":[0.1,0.2,0.3]}]}`))
+		case "/v1/chat/completions":
+			var request models.ChatCompletionRequest
+			json.NewDecoder(r.Body).Decode(&request)
+			assert.Equal(t, "test-chat", request.Model)
+			assert.Equal(t, 0.2, request.Temperature)
+			assert.Len(t, request.Messages, 1)
+			prompt := request.Messages[0].Content
+			assert.Contains(t, prompt, "Context:\n[1] Sumber: work-instruction-password-reset.txt\nBuka portal.company.com")
+			assert.Contains(t, prompt, "Question: Bagaimana cara reset password?")
+			assert.NotContains(t, prompt, "{{")
+			w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":" Silakan buka portal.company.com "}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	space := &models.EmbeddingSpace{ID: uuid.New(), Name: models.DefaultEmbeddingSpace, Model: "test-embedding", Dimension: 3, IsActive: true}
+	mockSpaceRepo := mocks.NewMockEmbeddingSpaceRepository(t)
+	mockSpaceRepo.EXPECT().GetActive(mock.Anything).Return(space, nil)
+
+	mockRepo := mocks.NewMockKnowledgeRepository(t)
+	mockRepo.EXPECT().SearchSimilar(mock.Anything, space, []float32{0.1, 0.2, 0.3}, 3, 0.7, map[string]interface{}(nil)).Return([]*models.KnowledgeChunk{
+		{
+			ID:       uuid.New(),
+			Content:  "Buka portal.company.com lalu pilih Reset Password.",
+			Metadata: map[string]interface{}{"source": "work-instruction-password-reset.txt"},
+			Score:    0.91,
+		},
+	}, nil)
+
+	clientConfig := &OpenAIClientConfig{
+		BaseURL: server.URL + "/",
+		APIKey:  "test-key",
+		Timeout: 5 * time.Second,
+	}
+	client := NewOpenAIClient(clientConfig)
+	spaces := NewEmbeddingSpaceService(mockSpaceRepo, clientConfig)
+
+	var sentPhone, sentMessage string
+	service := NewOpenAIService(&OpenAIConfig{
+		ChatModel:      "test-chat",
+		Temperature:    0.2,
+		MaxTokens:      300,
+		TopK:           3,
+		Threshold:      0.7,
+		PromptTemplate: "Context:\n{{retrieved_documents}}\n\nUser Question: {{user_question}}",
+	}, client, NewKnowledgeService(mockRepo, spaces, KnowledgeSearchConfig{}, nil), &mockWhatsAppService{
+		sendMessageFunc: func(ctx context.Context, phone, message string) error {
+			sentPhone = phone
+			sentMessage = message
+			assert.Equal(t, MessageMeta{CorrelationID: "msg-123", WorkflowType: WorkflowTypeOpenAI}, MessageMetaFromContext(ctx))
+			return nil
+		},
+	})
+
+	err := service.SendMessageToWorkflow(context.Background(), &models.WorkflowRequest{
+		MessageID:   "msg-123",
+		UserContext: &models.UserContext{Name: "Budi", Phone: "6281234567890"},
+		Message:     "Bagaimana cara reset password?",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, WorkflowTypeOpenAI, service.Type())
+	assert.Equal(t, "6281234567890", sentPhone)
+	assert.Equal(t, "Silakan buka portal.company.com", sentMessage)
+}
+
+// TestLoadPromptTemplate
+// Summary: Test loading RAG prompt templates
+// Purpose: Validate that the shipped template loads and templates without placeholders are rejected
+func TestLoadPromptTemplate(t *testing.T) {
+	template, err := LoadPromptTemplate("../../../../prompt-templates/rag-knowledge-query.txt")
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(template, "{{retrieved_documents}}"))
+
+	_, err = LoadPromptTemplate("../../../../prompt-templates/faq-handler.txt")
+	assert.Error(t, err)
+
+	_, err = LoadPromptTemplate("does-not-exist.txt")
+	assert.Error(t, err)
+}
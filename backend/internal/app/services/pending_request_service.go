@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/repositories"
+)
+
+var (
+	// ErrUnknownRequest is returned for callbacks whose message ID was never issued
+	ErrUnknownRequest = errors.New("unknown request")
+	// ErrDuplicateCallback is returned for callbacks on requests that were already handled
+	ErrDuplicateCallback = errors.New("duplicate callback")
+	// ErrExpiredRequest is returned for callbacks arriving after the watchdog timed the request out
+	ErrExpiredRequest = errors.New("request timed out")
+)
+
+const pendingTimeoutReason = "no workflow response before deadline"
+
+type PendingRequestService interface {
+	Register(ctx context.Context, messageID, phone, workflowType string) error
+	Claim(ctx context.Context, messageID string) (*models.PendingRequest, error)
+	MarkFailed(ctx context.Context, messageID, reason string) error
+	ListAwaitingSoftNotice(ctx context.Context, age time.Duration) ([]*models.PendingRequest, error)
+	MarkSoftNotified(ctx context.Context, messageID string) (bool, error)
+	ExpireOverdue(ctx context.Context) ([]*models.PendingRequest, error)
+}
+
+type pendingRequestService struct {
+	pendingRepo repositories.PendingRequestRepository
+	deadline    time.Duration
+	now         func() time.Time
+}
+
+func NewPendingRequestService(pendingRepo repositories.PendingRequestRepository, deadline time.Duration) PendingRequestService {
+	return &pendingRequestService{
+		pendingRepo: pendingRepo,
+		deadline:    deadline,
+		now:         time.Now,
+	}
+}
+
+func (s *pendingRequestService) Register(ctx context.Context, messageID, phone, workflowType string) error {
+	now := s.now()
+	request := &models.PendingRequest{
+		MessageID:    messageID,
+		Phone:        phone,
+		WorkflowType: workflowType,
+		Status:       models.PendingStatusPending,
+		SentAt:       now,
+		DeadlineAt:   now.Add(s.deadline),
+	}
+
+	err := s.pendingRepo.Create(ctx, request)
+	if err != nil {
+		log.Printf("[PendingRequestService] Failed to register request %s: %v", messageID, err)
+		return err
+	}
+
+	log.Printf("[PendingRequestService] Registered %s request %s for %s", workflowType, messageID, phone)
+	return nil
+}
+
+// Claim marks a pending request as completed so each callback is processed at most once
+func (s *pendingRequestService) Claim(ctx context.Context, messageID string) (*models.PendingRequest, error) {
+	request, err := s.pendingRepo.Complete(ctx, messageID)
+	if err != nil {
+		log.Printf("[PendingRequestService] Failed to claim request %s: %v", messageID, err)
+		return nil, err
+	}
+
+	if request != nil {
+		log.Printf("[PendingRequestService] Claimed request %s for %s", messageID, request.Phone)
+		return request, nil
+	}
+
+	existing, err := s.pendingRepo.GetByMessageID(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing == nil {
+		log.Printf("[PendingRequestService] Rejected callback for unknown request %s", messageID)
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRequest, messageID)
+	}
+
+	if existing.Status == models.PendingStatusTimedOut {
+		log.Printf("[PendingRequestService] Rejected late callback for timed out request %s", messageID)
+		return nil, fmt.Errorf("%w: %s", ErrExpiredRequest, messageID)
+	}
+
+	log.Printf("[PendingRequestService] Rejected duplicate callback for request %s (status: %s)", messageID, existing.Status)
+	return nil, fmt.Errorf("%w: %s", ErrDuplicateCallback, messageID)
+}
+
+func (s *pendingRequestService) MarkFailed(ctx context.Context, messageID, reason string) error {
+	err := s.pendingRepo.MarkFailed(ctx, messageID, reason)
+	if err != nil {
+		log.Printf("[PendingRequestService] Failed to mark request %s failed: %v", messageID, err)
+		return err
+	}
+
+	return nil
+}
+
+// ListAwaitingSoftNotice returns pending requests older than age that have not been sent a progress notice
+func (s *pendingRequestService) ListAwaitingSoftNotice(ctx context.Context, age time.Duration) ([]*models.PendingRequest, error) {
+	requests, err := s.pendingRepo.ListAwaitingSoftNotice(ctx, s.now().Add(-age))
+	if err != nil {
+		log.Printf("[PendingRequestService] Failed to list requests awaiting notice: %v", err)
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+// MarkSoftNotified reports false when another notice already went out or the request completed
+func (s *pendingRequestService) MarkSoftNotified(ctx context.Context, messageID string) (bool, error) {
+	marked, err := s.pendingRepo.MarkSoftNotified(ctx, messageID)
+	if err != nil {
+		log.Printf("[PendingRequestService] Failed to mark request %s notified: %v", messageID, err)
+		return false, err
+	}
+
+	return marked, nil
+}
+
+// ExpireOverdue times out every pending request past its deadline and returns them
+func (s *pendingRequestService) ExpireOverdue(ctx context.Context) ([]*models.PendingRequest, error) {
+	requests, err := s.pendingRepo.ExpireOverdue(ctx, s.now(), pendingTimeoutReason)
+	if err != nil {
+		log.Printf("[PendingRequestService] Failed to expire overdue requests: %v", err)
+		return nil, err
+	}
+
+	for _, request := range requests {
+		log.Printf("[PendingRequestService] Request %s for %s timed out (%s, sent %s)",
+			request.MessageID, request.Phone, request.WorkflowType, request.SentAt.Format(time.RFC3339))
+	}
+
+	return requests, nil
+}
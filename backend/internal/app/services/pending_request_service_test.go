@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/testutils/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestPendingRequestService_Register
+// Summary: Test registering an outstanding workflow request
+// Purpose: Validate that requests are stored as pending with a deadline
+func TestPendingRequestService_Register(t *testing.T) {
+	mockRepo := mocks.NewMockPendingRequestRepository(t)
+	mockRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(request *models.PendingRequest) bool {
+		return request.MessageID == "msg-123" &&
+			request.Phone == "6281234567890" &&
+			request.WorkflowType == "n8n" &&
+			request.Status == models.PendingStatusPending &&
+			time.Until(request.DeadlineAt) > 4*time.Minute
+	})).Return(nil)
+
+	service := NewPendingRequestService(mockRepo, 5*time.Minute)
+	err := service.Register(context.Background(), "msg-123", "6281234567890", "n8n")
+
+	assert.NoError(t, err)
+}
+
+// TestPendingRequestService_Claim
+// Summary: Test claiming callbacks for issued requests
+// Purpose: Validate that unknown and duplicate callbacks are rejected with typed errors
+func TestPendingRequestService_Claim(t *testing.T) {
+	pending := &models.PendingRequest{MessageID: "msg-123", Phone: "6281234567890", Status: models.PendingStatusCompleted}
+
+	tests := []struct {
+		name          string
+		completed     *models.PendingRequest
+		existing      *models.PendingRequest
+		expectLookup  bool
+		expectedError error
+	}{
+		{
+			name:      "Pending request is claimed",
+			completed: pending,
+		},
+		{
+			name:          "Unknown message ID",
+			completed:     nil,
+			existing:      nil,
+			expectLookup:  true,
+			expectedError: ErrUnknownRequest,
+		},
+		{
+			name:          "Duplicate callback",
+			completed:     nil,
+			existing:      pending,
+			expectLookup:  true,
+			expectedError: ErrDuplicateCallback,
+		},
+		{
+			name:          "Late callback after timeout",
+			completed:     nil,
+			existing:      &models.PendingRequest{MessageID: "msg-123", Status: models.PendingStatusTimedOut},
+			expectLookup:  true,
+			expectedError: ErrExpiredRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockPendingRequestRepository(t)
+			mockRepo.EXPECT().Complete(mock.Anything, "msg-123").Return(tt.completed, nil)
+			if tt.expectLookup {
+				mockRepo.EXPECT().GetByMessageID(mock.Anything, "msg-123").Return(tt.existing, nil)
+			}
+
+			service := NewPendingRequestService(mockRepo, 5*time.Minute)
+			request, err := service.Claim(context.Background(), "msg-123")
+
+			if tt.expectedError != nil {
+				assert.True(t, errors.Is(err, tt.expectedError), "expected %v, got %v", tt.expectedError, err)
+				assert.Nil(t, request)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "6281234567890", request.Phone)
+		})
+	}
+}
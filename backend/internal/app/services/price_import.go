@@ -0,0 +1,77 @@
+package services
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+)
+
+// ParseSignalPrices reads daily price bars from a CSV file whose header names
+// the ticker, date and close columns (case-insensitive). High and low columns
+// are optional; other columns are ignored.
+func ParseSignalPrices(r io.Reader) ([]*models.SignalPrice, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("empty CSV file")
+		}
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, required := range []string{"ticker", "date", "close"} {
+		if _, exists := columns[required]; !exists {
+			return nil, fmt.Errorf("CSV header is missing the %s column", required)
+		}
+	}
+
+	field := func(record []string, column string) string {
+		if i, exists := columns[column]; exists && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var prices []*models.SignalPrice
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+
+		price := &models.SignalPrice{
+			Ticker: field(record, "ticker"),
+			Date:   field(record, "date"),
+		}
+		numbers := []struct {
+			column string
+			value  *float64
+		}{{"high", &price.High}, {"low", &price.Low}, {"close", &price.Close}}
+		for _, number := range numbers {
+			text := field(record, number.column)
+			if text == "" {
+				continue
+			}
+			if *number.value, err = strconv.ParseFloat(text, 64); err != nil {
+				return nil, fmt.Errorf("row %d: %s is not a number: %q", row, number.column, text)
+			}
+		}
+		prices = append(prices, price)
+	}
+
+	return prices, nil
+}
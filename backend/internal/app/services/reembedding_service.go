@@ -0,0 +1,279 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/repositories"
+
+	"github.com/google/uuid"
+)
+
+const recentEmbeddingJobs = 50
+
+var (
+	ErrEmbeddingJobNotFound = errors.New("embedding job not found")
+	ErrEmbeddingJobRunning  = errors.New("an embedding job for this space is already running")
+	ErrSameEmbeddingSpace   = errors.New("source and target embedding spaces must differ")
+)
+
+// ReembeddingService embeds knowledge chunks into another embedding space in the
+// background. A job only embeds chunks missing from the target, so starting a
+// new job after a failure or restart resumes where the previous one stopped.
+type ReembeddingService interface {
+	Start(ctx context.Context, targetName string, request *models.ReembedRequest) (*models.EmbeddingJob, error)
+	Get(ctx context.Context, id uuid.UUID) (*models.EmbeddingJob, error)
+	List(ctx context.Context) ([]*models.EmbeddingJob, error)
+	// FailInterrupted marks jobs left unfinished by a previous process as failed
+	FailInterrupted(ctx context.Context) error
+	Stop()
+}
+
+type ReembeddingConfig struct {
+	BatchSize  int
+	BatchDelay time.Duration
+}
+
+type reembeddingService struct {
+	batchSize     int
+	batchDelay    time.Duration
+	jobRepo       repositories.EmbeddingJobRepository
+	knowledgeRepo repositories.KnowledgeRepository
+	spaces        EmbeddingSpaceService
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewReembeddingService(config *ReembeddingConfig, jobRepo repositories.EmbeddingJobRepository, knowledgeRepo repositories.KnowledgeRepository, spaces EmbeddingSpaceService) ReembeddingService {
+	batchSize := config.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultEmbeddingBatchSize
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &reembeddingService{
+		batchSize:     batchSize,
+		batchDelay:    config.BatchDelay,
+		jobRepo:       jobRepo,
+		knowledgeRepo: knowledgeRepo,
+		spaces:        spaces,
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+}
+
+func (s *reembeddingService) Start(ctx context.Context, targetName string, request *models.ReembedRequest) (*models.EmbeddingJob, error) {
+	target, err := s.spaces.Get(ctx, targetName)
+	if err != nil {
+		return nil, err
+	}
+
+	var source *models.EmbeddingSpace
+	if request.SourceSpace != "" {
+		if request.SourceSpace == targetName {
+			return nil, ErrSameEmbeddingSpace
+		}
+		if source, err = s.spaces.Get(ctx, request.SourceSpace); err != nil {
+			return nil, err
+		}
+	}
+
+	unfinished, err := s.jobRepo.HasUnfinished(ctx, target.ID)
+	if err != nil {
+		return nil, err
+	}
+	if unfinished {
+		return nil, ErrEmbeddingJobRunning
+	}
+
+	job := &models.EmbeddingJob{
+		TargetSpaceID:    target.ID,
+		ActivateOnFinish: request.Activate,
+		Status:           models.EmbeddingJobPending,
+	}
+	if source != nil {
+		job.SourceSpaceID = &source.ID
+	}
+
+	if err := s.jobRepo.Create(ctx, job); err != nil {
+		log.Printf("[ReembeddingService] Failed to create job for %s: %v", targetName, err)
+		return nil, err
+	}
+
+	// The worker tracks progress on its own copy; the caller gets the job as created
+	running := *job
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(s.ctx, &running, target, source)
+	}()
+
+	log.Printf("[ReembeddingService] Started job %s into space %s", job.ID, target.Name)
+	return job, nil
+}
+
+func (s *reembeddingService) Get(ctx context.Context, id uuid.UUID) (*models.EmbeddingJob, error) {
+	job, err := s.jobRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("[ReembeddingService] Failed to get job %s: %v", id, err)
+		return nil, err
+	}
+
+	if job == nil {
+		return nil, ErrEmbeddingJobNotFound
+	}
+
+	return job, nil
+}
+
+func (s *reembeddingService) List(ctx context.Context) ([]*models.EmbeddingJob, error) {
+	jobs, err := s.jobRepo.List(ctx, recentEmbeddingJobs)
+	if err != nil {
+		log.Printf("[ReembeddingService] Failed to list jobs: %v", err)
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (s *reembeddingService) FailInterrupted(ctx context.Context) error {
+	failed, err := s.jobRepo.FailUnfinished(ctx, "interrupted by restart; start a new job to resume")
+	if err != nil {
+		log.Printf("[ReembeddingService] Failed to mark interrupted jobs: %v", err)
+		return err
+	}
+
+	if failed > 0 {
+		log.Printf("[ReembeddingService] Marked %d interrupted jobs as failed", failed)
+	}
+	return nil
+}
+
+// Stop cancels running jobs and waits for them to record their final status
+func (s *reembeddingService) Stop() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+// run embeds missing chunks batch by batch, recording progress after each batch
+func (s *reembeddingService) run(ctx context.Context, job *models.EmbeddingJob, target, source *models.EmbeddingSpace) {
+	total, err := s.knowledgeRepo.CountMissingEmbeddings(ctx, target, source)
+	if err != nil {
+		s.finish(job, models.EmbeddingJobFailed, err)
+		return
+	}
+
+	if err := s.jobRepo.Start(ctx, job.ID, total); err != nil {
+		s.finish(job, models.EmbeddingJobFailed, err)
+		return
+	}
+	job.Status = models.EmbeddingJobRunning
+	job.Total = total
+
+	for {
+		if ctx.Err() != nil {
+			s.finish(job, models.EmbeddingJobCancelled, ctx.Err())
+			return
+		}
+
+		chunks, err := s.knowledgeRepo.ListMissingEmbeddings(ctx, target, source, s.batchSize)
+		if err != nil {
+			s.finish(job, jobStatusFor(ctx), err)
+			return
+		}
+		if len(chunks) == 0 {
+			break
+		}
+
+		if err := s.embedBatch(ctx, target, chunks); err != nil {
+			s.finish(job, jobStatusFor(ctx), err)
+			return
+		}
+
+		job.Processed += len(chunks)
+		if err := s.jobRepo.UpdateProgress(ctx, job.ID, job.Processed); err != nil {
+			log.Printf("[ReembeddingService] Failed to record progress for job %s: %v", job.ID, err)
+		}
+		log.Printf("[ReembeddingService] Job %s: %d/%d chunks (%.0f%%)", job.ID, job.Processed, job.Total, job.Progress()*100)
+
+		if s.batchDelay > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(s.batchDelay):
+			}
+		}
+	}
+
+	// IVFFlat lists are trained on the rows present, so the index is built
+	// once the space is filled
+	if err := s.spaces.BuildIndex(ctx, target); err != nil {
+		s.finish(job, jobStatusFor(ctx), err)
+		return
+	}
+
+	if job.ActivateOnFinish {
+		if _, err := s.spaces.Activate(ctx, target.Name); err != nil {
+			s.finish(job, models.EmbeddingJobFailed, err)
+			return
+		}
+	}
+
+	s.finish(job, models.EmbeddingJobCompleted, nil)
+}
+
+func (s *reembeddingService) embedBatch(ctx context.Context, target *models.EmbeddingSpace, chunks []*models.KnowledgeChunk) error {
+	input := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		input[i] = chunk.Content
+	}
+
+	embeddings, err := s.spaces.Embed(ctx, target, input)
+	if err != nil {
+		return err
+	}
+
+	for i, chunk := range chunks {
+		if err := s.knowledgeRepo.SaveEmbedding(ctx, target, chunk.ID, embeddings[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// finish records the final status; it uses a fresh context so that a job
+// cancelled on shutdown can still be marked as such
+func (s *reembeddingService) finish(job *models.EmbeddingJob, status string, cause error) {
+	errMsg := ""
+	if cause != nil {
+		errMsg = cause.Error()
+	}
+	job.Status = status
+	job.Error = errMsg
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.jobRepo.Finish(ctx, job.ID, status, errMsg); err != nil {
+		log.Printf("[ReembeddingService] Failed to record %s for job %s: %v", status, job.ID, err)
+	}
+
+	if cause != nil {
+		log.Printf("[ReembeddingService] Job %s %s after %d/%d chunks: %v", job.ID, status, job.Processed, job.Total, cause)
+		return
+	}
+	log.Printf("[ReembeddingService] Job %s %s: %d chunks embedded", job.ID, status, job.Processed)
+}
+
+func jobStatusFor(ctx context.Context) string {
+	if ctx.Err() != nil {
+		return models.EmbeddingJobCancelled
+	}
+	return models.EmbeddingJobFailed
+}
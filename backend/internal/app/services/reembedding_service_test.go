@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/testutils/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestReembeddingService_Run
+// Summary: Test the background loop that embeds chunks into a target space
+// Purpose: Validate batching, progress updates, index builds, activation on finish and failure reporting
+func TestReembeddingService_Run(t *testing.T) {
+	target := &models.EmbeddingSpace{ID: uuid.New(), Name: "e5-small", Dimension: 1}
+	source := &models.EmbeddingSpace{ID: uuid.New(), Name: models.DefaultEmbeddingSpace, Dimension: 1536}
+	chunks := []*models.KnowledgeChunk{
+		{ID: uuid.New(), Content: "satu"},
+		{ID: uuid.New(), Content: "dua"},
+		{ID: uuid.New(), Content: "tiga"},
+	}
+
+	tests := []struct {
+		name           string
+		activate       bool
+		embedErr       error
+		buildErr       error
+		expectedStatus string
+		expectedError  string
+	}{
+		{
+			name:           "All chunks embedded and space activated",
+			activate:       true,
+			expectedStatus: models.EmbeddingJobCompleted,
+		},
+		{
+			name:           "Embedding failure fails the job",
+			embedErr:       errors.New("rate limited"),
+			expectedStatus: models.EmbeddingJobFailed,
+			expectedError:  "rate limited",
+		},
+		{
+			name:           "Index build failure fails the job before activation",
+			activate:       true,
+			buildErr:       errors.New("out of memory"),
+			expectedStatus: models.EmbeddingJobFailed,
+			expectedError:  "out of memory",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockJobRepo := mocks.NewMockEmbeddingJobRepository(t)
+			mockKnowledgeRepo := mocks.NewMockKnowledgeRepository(t)
+			mockSpaces := mocks.NewMockEmbeddingSpaceService(t)
+			job := &models.EmbeddingJob{ID: uuid.New(), TargetSpaceID: target.ID, ActivateOnFinish: tt.activate}
+
+			mockKnowledgeRepo.EXPECT().CountMissingEmbeddings(mock.Anything, target, source).Return(3, nil)
+			mockJobRepo.EXPECT().Start(mock.Anything, job.ID, 3).Return(nil)
+			mockKnowledgeRepo.EXPECT().ListMissingEmbeddings(mock.Anything, target, source, 2).Return(chunks[:2], nil).Once()
+
+			if tt.embedErr != nil {
+				mockSpaces.EXPECT().Embed(mock.Anything, target, []string{"satu", "dua"}).Return(nil, tt.embedErr)
+			} else {
+				mockKnowledgeRepo.EXPECT().ListMissingEmbeddings(mock.Anything, target, source, 2).Return(chunks[2:], nil).Once()
+				mockKnowledgeRepo.EXPECT().ListMissingEmbeddings(mock.Anything, target, source, 2).Return([]*models.KnowledgeChunk{}, nil).Once()
+				mockSpaces.EXPECT().Embed(mock.Anything, target, []string{"satu", "dua"}).Return([][]float32{{0.1}, {0.2}}, nil)
+				mockSpaces.EXPECT().Embed(mock.Anything, target, []string{"tiga"}).Return([][]float32{{0.3}}, nil)
+				mockKnowledgeRepo.EXPECT().SaveEmbedding(mock.Anything, target, mock.Anything, mock.Anything).Return(nil).Times(3)
+				mockJobRepo.EXPECT().UpdateProgress(mock.Anything, job.ID, 2).Return(nil)
+				mockJobRepo.EXPECT().UpdateProgress(mock.Anything, job.ID, 3).Return(nil)
+				mockSpaces.EXPECT().BuildIndex(mock.Anything, target).Return(tt.buildErr)
+				if tt.buildErr == nil {
+					mockSpaces.EXPECT().Activate(mock.Anything, "e5-small").Return(target, nil)
+				}
+			}
+			mockJobRepo.EXPECT().Finish(mock.Anything, job.ID, tt.expectedStatus, tt.expectedError).Return(nil)
+
+			service := NewReembeddingService(&ReembeddingConfig{BatchSize: 2}, mockJobRepo, mockKnowledgeRepo, mockSpaces).(*reembeddingService)
+			service.run(context.Background(), job, target, source)
+
+			assert.Equal(t, tt.expectedStatus, job.Status)
+			if tt.embedErr == nil {
+				assert.Equal(t, 3, job.Processed)
+				assert.Equal(t, 1.0, job.Progress())
+			}
+		})
+	}
+}
+
+// TestReembeddingService_Start
+// Summary: Test starting a re-embedding job
+// Purpose: Validate that unknown spaces, identical source and target, and concurrent jobs are rejected
+func TestReembeddingService_Start(t *testing.T) {
+	target := &models.EmbeddingSpace{ID: uuid.New(), Name: "e5-small"}
+
+	tests := []struct {
+		name        string
+		request     *models.ReembedRequest
+		setupMocks  func(spaces *mocks.MockEmbeddingSpaceService, jobs *mocks.MockEmbeddingJobRepository)
+		expectedErr error
+	}{
+		{
+			name:    "Unknown target space",
+			request: &models.ReembedRequest{},
+			setupMocks: func(spaces *mocks.MockEmbeddingSpaceService, jobs *mocks.MockEmbeddingJobRepository) {
+				spaces.EXPECT().Get(mock.Anything, "e5-small").Return(nil, ErrEmbeddingSpaceNotFound)
+			},
+			expectedErr: ErrEmbeddingSpaceNotFound,
+		},
+		{
+			name:    "Source equals target",
+			request: &models.ReembedRequest{SourceSpace: "e5-small"},
+			setupMocks: func(spaces *mocks.MockEmbeddingSpaceService, jobs *mocks.MockEmbeddingJobRepository) {
+				spaces.EXPECT().Get(mock.Anything, "e5-small").Return(target, nil)
+			},
+			expectedErr: ErrSameEmbeddingSpace,
+		},
+		{
+			name:    "Job already running",
+			request: &models.ReembedRequest{},
+			setupMocks: func(spaces *mocks.MockEmbeddingSpaceService, jobs *mocks.MockEmbeddingJobRepository) {
+				spaces.EXPECT().Get(mock.Anything, "e5-small").Return(target, nil)
+				jobs.EXPECT().HasUnfinished(mock.Anything, target.ID).Return(true, nil)
+			},
+			expectedErr: ErrEmbeddingJobRunning,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSpaces := mocks.NewMockEmbeddingSpaceService(t)
+			mockJobRepo := mocks.NewMockEmbeddingJobRepository(t)
+			tt.setupMocks(mockSpaces, mockJobRepo)
+
+			service := NewReembeddingService(&ReembeddingConfig{}, mockJobRepo, mocks.NewMockKnowledgeRepository(t), mockSpaces)
+			job, err := service.Start(context.Background(), "e5-small", tt.request)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Nil(t, job)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// IdempotencyKeyHeader lets workflow engines drop duplicate deliveries of a retried request
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// maxRetryDelay caps the exponential backoff between attempts
+const maxRetryDelay = 30 * time.Second
+
+// RetryPolicy controls how outbound workflow calls are retried on transient errors
+type RetryPolicy struct {
+	// Retries is the number of attempts after the first one
+	Retries   int
+	BaseDelay time.Duration
+}
+
+// HTTPStatusError is returned when a workflow engine answers with a non-200 status
+type HTTPStatusError struct {
+	Source     string
+	StatusCode int
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("%s returned error status: %d", e.Source, e.StatusCode)
+}
+
+// isRetryable reports whether err is transient: a 5xx status, a timeout or a dropped connection.
+// 4xx statuses are never retried, and neither is an EOF outside the HTTP transport
+// (such as a truncated response body), since the request was already accepted.
+func isRetryable(err error) bool {
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= 500
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) &&
+		(errors.Is(urlErr, io.EOF) || errors.Is(urlErr, io.ErrUnexpectedEOF))
+}
+
+// backoffDelay returns the wait before retry n (starting at 1): exponential growth
+// from BaseDelay with jitter over the upper half of the window
+func (p RetryPolicy) backoffDelay(n int) time.Duration {
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := p.BaseDelay << (n - 1)
+	if delay <= 0 || delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+// withRetry runs fn until it succeeds, fails with a non-transient error, runs out
+// of retries or ctx is done. The last error is returned.
+func withRetry(ctx context.Context, policy RetryPolicy, logPrefix string, fn func() error) error {
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = fn()
+		if err == nil || !isRetryable(err) || attempt >= policy.Retries {
+			return err
+		}
+
+		delay := policy.backoffDelay(attempt + 1)
+		log.Printf("%s Transient error (attempt %d/%d), retrying in %s: %v",
+			logPrefix, attempt+1, policy.Retries+1, delay, err)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
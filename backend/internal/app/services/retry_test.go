@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/testutils/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestIsRetryable
+// Summary: Test classification of workflow call errors
+// Purpose: Validate that only transient failures are retried and 4xx never is
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "Server error", err: &HTTPStatusError{Source: "N8N workflow", StatusCode: 503}, expected: true},
+		{name: "Bad request", err: &HTTPStatusError{Source: "N8N workflow", StatusCode: 400}, expected: false},
+		{name: "Too many requests", err: &HTTPStatusError{Source: "Flowise API", StatusCode: 429}, expected: false},
+		{name: "Connection refused", err: fmt.Errorf("failed to send HTTP request: %w", syscall.ECONNREFUSED), expected: true},
+		{name: "Connection closed before the response", err: fmt.Errorf("failed to send HTTP request: %w", &url.Error{Op: "Post", URL: "http://flowise", Err: io.EOF}), expected: true},
+		{name: "Truncated response body", err: fmt.Errorf("failed to decode prediction response: %v", io.ErrUnexpectedEOF), expected: false},
+		{name: "Bare EOF", err: io.EOF, expected: false},
+		{name: "Other error", err: errors.New("failed to marshal request"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isRetryable(tt.err))
+		})
+	}
+}
+
+// TestRetryPolicy_BackoffDelay
+// Summary: Test exponential backoff with jitter
+// Purpose: Validate that delays double per retry, stay within the jitter window and are capped
+func TestRetryPolicy_BackoffDelay(t *testing.T) {
+	policy := RetryPolicy{Retries: 10, BaseDelay: time.Second}
+
+	for n, window := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 10: maxRetryDelay} {
+		delay := policy.backoffDelay(n)
+		assert.GreaterOrEqual(t, delay, window/2, "retry %d", n)
+		assert.LessOrEqual(t, delay, window, "retry %d", n)
+	}
+}
+
+// TestN8NService_SendMessageToWorkflow_Retries
+// Summary: Test retrying N8N calls on transient errors
+// Purpose: Validate retry counts, 4xx short-circuit and a stable idempotency key across attempts
+func TestN8NService_SendMessageToWorkflow_Retries(t *testing.T) {
+	tests := []struct {
+		name             string
+		statuses         []int
+		expectedAttempts int32
+		expectError      bool
+	}{
+		{
+			name:             "Recovers after server errors",
+			statuses:         []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			expectedAttempts: 3,
+		},
+		{
+			name:             "Client error is not retried",
+			statuses:         []int{http.StatusBadRequest},
+			expectedAttempts: 1,
+			expectError:      true,
+		},
+		{
+			name:             "Gives up after configured retries",
+			statuses:         []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError},
+			expectedAttempts: 3,
+			expectError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&attempts, 1)
+				assert.Equal(t, "msg-123", r.Header.Get(IdempotencyKeyHeader))
+				w.WriteHeader(tt.statuses[n-1])
+			}))
+			defer server.Close()
+
+			mockPending := mocks.NewMockPendingRequestService(t)
+			mockPending.EXPECT().Register(mock.Anything, "msg-123", "6281234567890", "n8n").Return(nil)
+			if tt.expectError {
+				mockPending.EXPECT().MarkFailed(mock.Anything, "msg-123", mock.Anything).Return(nil)
+			}
+
+			service := NewN8NService(&N8NConfig{
+				WorkflowURL:   server.URL,
+				Timeout:       time.Second,
+				RetryAttempts: 2,
+				RetryDelay:    time.Millisecond,
+			}, mockPending, nil)
+
+			err := service.SendMessageToWorkflow(context.Background(), &models.WorkflowRequest{
+				MessageID:   "msg-123",
+				UserContext: &models.UserContext{Name: "Budi", Phone: "6281234567890"},
+				Message:     "Halo",
+			})
+
+			assert.Equal(t, tt.expectError, err != nil, "unexpected error result: %v", err)
+			assert.Equal(t, tt.expectedAttempts, atomic.LoadInt32(&attempts))
+		})
+	}
+}
+
+// TestFlowiseService_SendMessageToWorkflow_TruncatedBody
+// Summary: Test a Flowise prediction answered with an unreadable body
+// Purpose: Validate that a 200 whose body cannot be decoded is not posted again
+func TestFlowiseService_SendMessageToWorkflow_TruncatedBody(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"text":"Halo`))
+	}))
+	defer server.Close()
+
+	mockPending := mocks.NewMockPendingRequestService(t)
+	mockPending.EXPECT().Register(mock.Anything, "msg-123", "6281234567890", WorkflowTypeFlowise).Return(nil)
+	mockPending.EXPECT().MarkFailed(mock.Anything, "msg-123", mock.Anything).Return(nil)
+
+	service := NewFlowiseService(&FlowiseConfig{
+		BaseURL:       server.URL,
+		FlowID:        "flow-123",
+		Timeout:       time.Second,
+		RetryAttempts: 2,
+		RetryDelay:    time.Millisecond,
+	}, mockPending, nil)
+
+	err := service.SendMessageToWorkflow(context.Background(), &models.WorkflowRequest{
+		MessageID:   "msg-123",
+		UserContext: &models.UserContext{Name: "Budi", Phone: "6281234567890"},
+		Message:     "Halo",
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/repositories"
+
+	"github.com/google/uuid"
+)
+
+var ErrUnknownRole = errors.New("unknown role")
+
+type RoleService interface {
+	ListRoles(ctx context.Context) ([]*models.Role, error)
+	GetUserRoles(ctx context.Context, userID uuid.UUID) ([]string, error)
+	// SetUserRoles replaces the roles of a user and returns them sorted
+	SetUserRoles(ctx context.Context, userID uuid.UUID, roles []string) ([]string, error)
+	// GetPermissions returns the permissions granted by any of roles
+	GetPermissions(ctx context.Context, roles []string) ([]string, error)
+}
+
+type roleService struct {
+	roleRepo repositories.RoleRepository
+	userRepo repositories.UserRepository
+}
+
+func NewRoleService(roleRepo repositories.RoleRepository, userRepo repositories.UserRepository) RoleService {
+	return &roleService{
+		roleRepo: roleRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (s *roleService) ListRoles(ctx context.Context) ([]*models.Role, error) {
+	roles, err := s.roleRepo.List(ctx)
+	if err != nil {
+		log.Printf("[RoleService] Failed to list roles: %v", err)
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (s *roleService) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	roles, err := s.roleRepo.GetUserRoles(ctx, userID)
+	if err != nil {
+		log.Printf("[RoleService] Failed to get roles of user %s: %v", userID, err)
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (s *roleService) SetUserRoles(ctx context.Context, userID uuid.UUID, roles []string) ([]string, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	known, err := s.roleRepo.List(ctx)
+	if err != nil {
+		log.Printf("[RoleService] Failed to list roles: %v", err)
+		return nil, err
+	}
+	knownNames := make(map[string]bool, len(known))
+	for _, role := range known {
+		knownNames[role.Name] = true
+	}
+
+	unique := make(map[string]bool, len(roles))
+	assigned := make([]string, 0, len(roles))
+	for _, role := range roles {
+		if !knownNames[role] {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownRole, role)
+		}
+		if !unique[role] {
+			unique[role] = true
+			assigned = append(assigned, role)
+		}
+	}
+	sort.Strings(assigned)
+
+	if err := s.roleRepo.SetUserRoles(ctx, userID, assigned); err != nil {
+		log.Printf("[RoleService] Failed to set roles of user %s: %v", userID, err)
+		return nil, err
+	}
+
+	log.Printf("[RoleService] Roles of user %s set to %v", userID, assigned)
+	return assigned, nil
+}
+
+func (s *roleService) GetPermissions(ctx context.Context, roles []string) ([]string, error) {
+	return s.roleRepo.GetPermissions(ctx, roles)
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/testutils/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestRoleService_SetUserRoles
+// Summary: Test replacing the roles of a user
+// Purpose: Validate role de-duplication and sorting, rejection of unknown roles and missing users
+func TestRoleService_SetUserRoles(t *testing.T) {
+	id := uuid.New()
+	knownRoles := []*models.Role{{Name: models.RoleAdmin}, {Name: models.RoleAgent}, {Name: models.RoleSubscriber}}
+
+	tests := []struct {
+		name          string
+		roles         []string
+		userErr       error
+		expectedRoles []string
+		expectedErr   error
+	}{
+		{
+			name:          "Assign roles",
+			roles:         []string{models.RoleSubscriber, models.RoleAgent, models.RoleAgent},
+			expectedRoles: []string{models.RoleAgent, models.RoleSubscriber},
+		},
+		{
+			name:          "Remove every role",
+			roles:         []string{},
+			expectedRoles: []string{},
+		},
+		{
+			name:        "Unknown role",
+			roles:       []string{models.RoleAgent, "owner"},
+			expectedErr: ErrUnknownRole,
+		},
+		{
+			name:        "Missing user",
+			roles:       []string{models.RoleAgent},
+			userErr:     ErrUserNotFound,
+			expectedErr: ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRoleRepo := mocks.NewMockRoleRepository(t)
+			mockUserRepo := mocks.NewMockUserRepository(t)
+
+			if tt.userErr != nil {
+				mockUserRepo.EXPECT().GetByID(mock.Anything, id).Return(nil, tt.userErr)
+			} else {
+				mockUserRepo.EXPECT().GetByID(mock.Anything, id).Return(&models.User{ID: id}, nil)
+				mockRoleRepo.EXPECT().List(mock.Anything).Return(knownRoles, nil)
+			}
+			if tt.expectedErr == nil {
+				mockRoleRepo.EXPECT().SetUserRoles(mock.Anything, id, tt.expectedRoles).Return(nil)
+			}
+
+			service := NewRoleService(mockRoleRepo, mockUserRepo)
+			roles, err := service.SetUserRoles(context.Background(), id, tt.roles)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, roles)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedRoles, roles)
+		})
+	}
+}
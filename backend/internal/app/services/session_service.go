@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/repositories"
+)
+
+type SessionService interface {
+	GetOrCreateSession(ctx context.Context, phone string) (*models.ConversationSession, error)
+	ResetSession(ctx context.Context, phone string) (bool, error)
+}
+
+type sessionService struct {
+	sessionRepo repositories.SessionRepository
+	timeout     time.Duration
+	now         func() time.Time
+}
+
+// NewSessionService creates a session service; a timeout of zero keeps sessions open until reset
+func NewSessionService(sessionRepo repositories.SessionRepository, timeout time.Duration) SessionService {
+	return &sessionService{
+		sessionRepo: sessionRepo,
+		timeout:     timeout,
+		now:         time.Now,
+	}
+}
+
+func (s *sessionService) GetOrCreateSession(ctx context.Context, phone string) (*models.ConversationSession, error) {
+	session, err := s.sessionRepo.GetActiveByPhone(ctx, phone)
+	if err != nil {
+		log.Printf("[SessionService] Failed to get active session for %s: %v", phone, err)
+		return nil, err
+	}
+
+	if session != nil && !s.isExpired(session) {
+		if err := s.sessionRepo.Touch(ctx, session.ID); err != nil {
+			log.Printf("[SessionService] Failed to touch session %s: %v", session.ID.String(), err)
+			return nil, err
+		}
+		return session, nil
+	}
+
+	if session != nil {
+		log.Printf("[SessionService] Session %s for %s expired after %v of inactivity", session.ID.String(), phone, s.timeout)
+		if err := s.sessionRepo.End(ctx, session.ID); err != nil {
+			log.Printf("[SessionService] Failed to end expired session %s: %v", session.ID.String(), err)
+			return nil, err
+		}
+	}
+
+	session, err = s.sessionRepo.Create(ctx, phone)
+	if err != nil {
+		log.Printf("[SessionService] Failed to create session for %s: %v", phone, err)
+		return nil, fmt.Errorf("failed to start session: %w", err)
+	}
+
+	log.Printf("[SessionService] Started session %s for %s", session.ID.String(), phone)
+	return session, nil
+}
+
+func (s *sessionService) ResetSession(ctx context.Context, phone string) (bool, error) {
+	log.Printf("[SessionService] Resetting session for %s", phone)
+
+	ended, err := s.sessionRepo.EndActiveByPhone(ctx, phone)
+	if err != nil {
+		log.Printf("[SessionService] Failed to reset session for %s: %v", phone, err)
+		return false, err
+	}
+
+	return ended, nil
+}
+
+func (s *sessionService) isExpired(session *models.ConversationSession) bool {
+	if s.timeout <= 0 {
+		return false
+	}
+	return s.now().Sub(session.LastActivityAt) > s.timeout
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/testutils/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestSessionService_GetOrCreateSession
+// Summary: Test session reuse, expiry and creation
+// Purpose: Validate that a phone keeps a stable session until it is idle longer than the timeout
+func TestSessionService_GetOrCreateSession(t *testing.T) {
+	phone := "6281234567890"
+	now := time.Date(2025, 8, 10, 12, 0, 0, 0, time.UTC)
+	existingID := uuid.New()
+	newID := uuid.New()
+
+	tests := []struct {
+		name       string
+		timeout    time.Duration
+		active     *models.ConversationSession
+		setupMocks func(repo *mocks.MockSessionRepository)
+		expectedID uuid.UUID
+	}{
+		{
+			name:    "Active session is reused",
+			timeout: time.Hour,
+			active:  &models.ConversationSession{ID: existingID, Phone: phone, LastActivityAt: now.Add(-10 * time.Minute)},
+			setupMocks: func(repo *mocks.MockSessionRepository) {
+				repo.EXPECT().Touch(mock.Anything, existingID).Return(nil)
+			},
+			expectedID: existingID,
+		},
+		{
+			name:    "Expired session is replaced",
+			timeout: time.Hour,
+			active:  &models.ConversationSession{ID: existingID, Phone: phone, LastActivityAt: now.Add(-2 * time.Hour)},
+			setupMocks: func(repo *mocks.MockSessionRepository) {
+				repo.EXPECT().End(mock.Anything, existingID).Return(nil)
+				repo.EXPECT().Create(mock.Anything, phone).Return(&models.ConversationSession{ID: newID, Phone: phone}, nil)
+			},
+			expectedID: newID,
+		},
+		{
+			name:    "Zero timeout never expires",
+			timeout: 0,
+			active:  &models.ConversationSession{ID: existingID, Phone: phone, LastActivityAt: now.Add(-48 * time.Hour)},
+			setupMocks: func(repo *mocks.MockSessionRepository) {
+				repo.EXPECT().Touch(mock.Anything, existingID).Return(nil)
+			},
+			expectedID: existingID,
+		},
+		{
+			name:    "New session is created when none is open",
+			timeout: time.Hour,
+			active:  nil,
+			setupMocks: func(repo *mocks.MockSessionRepository) {
+				repo.EXPECT().Create(mock.Anything, phone).Return(&models.ConversationSession{ID: newID, Phone: phone}, nil)
+			},
+			expectedID: newID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockSessionRepository(t)
+			mockRepo.EXPECT().GetActiveByPhone(mock.Anything, phone).Return(tt.active, nil)
+			tt.setupMocks(mockRepo)
+
+			service := &sessionService{
+				sessionRepo: mockRepo,
+				timeout:     tt.timeout,
+				now:         func() time.Time { return now },
+			}
+
+			session, err := service.GetOrCreateSession(context.Background(), phone)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedID, session.ID)
+		})
+	}
+}
+
+// TestSessionService_ResetSession
+// Summary: Test ending the open session on user request
+// Purpose: Validate that /reset ends the session and surfaces repository errors
+func TestSessionService_ResetSession(t *testing.T) {
+	tests := []struct {
+		name        string
+		ended       bool
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:  "Open session ended",
+			ended: true,
+		},
+		{
+			name:  "No open session",
+			ended: false,
+		},
+		{
+			name:        "Repository error",
+			mockError:   fmt.Errorf("database connection failed"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockSessionRepository(t)
+			mockRepo.EXPECT().EndActiveByPhone(mock.Anything, "6281234567890").Return(tt.ended, tt.mockError)
+
+			service := NewSessionService(mockRepo, time.Hour)
+			ended, err := service.ResetSession(context.Background(), "6281234567890")
+
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.ended, ended)
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package services
+
+import (
+	"context"
+	"log"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/repositories"
+
+	"github.com/google/uuid"
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultSignalWorkers = 4
+	// signalSendTimeout bounds one send, which is allowed to finish on shutdown
+	signalSendTimeout = 30 * time.Second
+	// signalInterruptedError is recorded for deliveries a previous process
+	// claimed but never recorded; they may or may not have been delivered
+	signalInterruptedError = "interrupted while sending"
+)
+
+// SignalDispatcher sends signal broadcasts in the background through a bounded
+// pool of workers. Sends are throttled across all workers and spread with a
+// random jitter so that a large broadcast does not look like a burst to WhatsApp.
+type SignalDispatcher interface {
+	// Start launches the workers and resumes broadcasts left unfinished by a
+	// previous process
+	Start(ctx context.Context) error
+	// Dispatch queues the pending deliveries of a stored broadcast
+	Dispatch(broadcast *models.SignalBroadcast, deliveries []*models.SignalDelivery)
+	// Stop waits for in-flight sends; queued deliveries stay pending and are resumed on the next Start
+	Stop()
+}
+
+// SignalDispatchConfig configures the signal workers. A RatePerSecond of zero
+// disables throttling; each send waits a random delay of up to Jitter.
+type SignalDispatchConfig struct {
+	Workers       int
+	RatePerSecond float64
+	Jitter        time.Duration
+}
+
+// signalSend is one delivery waiting for a worker. A follow-up quotes the
+// original message, which the recipient holds as delivery.ReplyToMessageID.
+type signalSend struct {
+	delivery *models.SignalDelivery
+	message  string
+	quoted   string
+}
+
+type signalDispatcher struct {
+	workers         int
+	jitter          time.Duration
+	limiter         *rate.Limiter
+	broadcastRepo   repositories.SignalBroadcastRepository
+	whatsappService WhatsAppService
+
+	queue chan signalSend
+	// remaining counts the deliveries of each broadcast being dispatched that
+	// are neither sent nor dropped
+	mu        sync.Mutex
+	remaining map[uuid.UUID]int
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewSignalDispatcher(config *SignalDispatchConfig, broadcastRepo repositories.SignalBroadcastRepository, whatsappService WhatsAppService) SignalDispatcher {
+	workers := config.Workers
+	if workers <= 0 {
+		workers = defaultSignalWorkers
+	}
+
+	limit := rate.Inf
+	if config.RatePerSecond > 0 {
+		limit = rate.Limit(config.RatePerSecond)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &signalDispatcher{
+		workers:         workers,
+		jitter:          config.Jitter,
+		limiter:         rate.NewLimiter(limit, 1),
+		broadcastRepo:   broadcastRepo,
+		whatsappService: whatsappService,
+		queue:           make(chan signalSend),
+		remaining:       make(map[uuid.UUID]int),
+		ctx:             ctx,
+		cancel:          cancel,
+	}
+}
+
+func (d *signalDispatcher) Start(ctx context.Context) error {
+	interrupted, err := d.broadcastRepo.FailInterrupted(ctx, signalInterruptedError)
+	if err != nil {
+		log.Printf("[SignalDispatcher] Failed to fail interrupted deliveries: %v", err)
+		return err
+	}
+	if interrupted > 0 {
+		log.Printf("[SignalDispatcher] Marked %d deliveries interrupted while sending as failed", interrupted)
+	}
+
+	for range d.workers {
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			d.work()
+		}()
+	}
+	log.Printf("[SignalDispatcher] Started %d workers", d.workers)
+
+	broadcasts, err := d.broadcastRepo.ListUnfinished(ctx)
+	if err != nil {
+		log.Printf("[SignalDispatcher] Failed to list unfinished broadcasts: %v", err)
+		return err
+	}
+
+	for _, broadcast := range broadcasts {
+		deliveries, err := d.broadcastRepo.ListDeliveries(ctx, broadcast.ID, models.SignalDeliveryPending)
+		if err != nil {
+			log.Printf("[SignalDispatcher] Failed to list pending deliveries of broadcast %s: %v", broadcast.ID, err)
+			return err
+		}
+		log.Printf("[SignalDispatcher] Resuming broadcast %s with %d pending deliveries", broadcast.ID, len(deliveries))
+		d.Dispatch(broadcast, deliveries)
+	}
+
+	return nil
+}
+
+func (d *signalDispatcher) Dispatch(broadcast *models.SignalBroadcast, deliveries []*models.SignalDelivery) {
+	if len(deliveries) == 0 {
+		d.finish(broadcast.ID)
+		return
+	}
+
+	d.mu.Lock()
+	d.remaining[broadcast.ID] = len(deliveries)
+	d.mu.Unlock()
+
+	// Feed the workers from a goroutine so the caller never waits for the queue
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+
+		if err := d.broadcastRepo.Start(d.ctx, broadcast.ID); err != nil {
+			log.Printf("[SignalDispatcher] Failed to mark broadcast %s as sending: %v", broadcast.ID, err)
+		}
+
+		for _, delivery := range deliveries {
+			// Deliveries carry the copy rendered in the recipient's language;
+			// older ones fall back to the broadcast's
+			send := signalSend{delivery: delivery, message: delivery.Message, quoted: delivery.QuotedMessage}
+			if send.message == "" {
+				send.message = broadcast.Message
+			}
+			if send.quoted == "" {
+				send.quoted = broadcast.QuotedMessage
+			}
+
+			select {
+			case <-d.ctx.Done():
+				return
+			case d.queue <- send:
+			}
+		}
+	}()
+}
+
+func (d *signalDispatcher) Stop() {
+	d.cancel()
+	d.wg.Wait()
+}
+
+func (d *signalDispatcher) work() {
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case send := <-d.queue:
+			if !d.throttle() {
+				return
+			}
+			d.send(send)
+		}
+	}
+}
+
+// throttle waits for the rate limiter and the jitter; it returns false when
+// the dispatcher is stopping, leaving the delivery pending
+func (d *signalDispatcher) throttle() bool {
+	if err := d.limiter.Wait(d.ctx); err != nil {
+		return false
+	}
+
+	if d.jitter <= 0 {
+		return true
+	}
+
+	select {
+	case <-d.ctx.Done():
+		return false
+	case <-time.After(rand.N(d.jitter)):
+		return true
+	}
+}
+
+// send delivers one message and records the outcome. It uses its own context
+// so that a send already under way completes on shutdown. The delivery is
+// claimed first, so that a cancellation or update cannot change it while it
+// goes out: one cancelled since it was queued is dropped, one rewritten by an
+// update is sent as rewritten, and one that cannot be claimed stays pending
+// with its broadcast unfinished, to be resumed on the next start.
+func (d *signalDispatcher) send(send signalSend) {
+	ctx, cancel := context.WithTimeout(context.Background(), signalSendTimeout)
+	defer cancel()
+
+	delivery := send.delivery
+	current, err := d.broadcastRepo.ClaimDelivery(ctx, delivery.ID)
+	if err != nil {
+		log.Printf("[SignalDispatcher] Failed to claim delivery %s, leaving it pending: %v", delivery.ID, err)
+		return
+	}
+	defer d.complete(delivery.BroadcastID)
+
+	if current == nil {
+		log.Printf("[SignalDispatcher] Dropping delivery %s of broadcast %s; it is no longer pending", delivery.ID, delivery.BroadcastID)
+		return
+	}
+	if current.Message != "" {
+		send.message = current.Message
+	}
+
+	status, errMsg := models.SignalDeliverySent, ""
+	var messageID string
+	if delivery.ReplyToMessageID != "" {
+		messageID, err = d.whatsappService.SendReplyWithID(ctx, delivery.Phone, send.message, delivery.ReplyToMessageID, send.quoted)
+	} else {
+		messageID, err = d.whatsappService.SendMessageWithID(ctx, delivery.Phone, send.message)
+	}
+	if err != nil {
+		log.Printf("[SignalDispatcher] Failed to send broadcast %s to %s: %v", delivery.BroadcastID, delivery.Phone, err)
+		status, errMsg = models.SignalDeliveryFailed, err.Error()
+	}
+
+	if err := d.broadcastRepo.RecordDelivery(ctx, delivery.ID, status, messageID, errMsg); err != nil {
+		log.Printf("[SignalDispatcher] Failed to record delivery %s: %v", delivery.ID, err)
+	}
+}
+
+// complete counts one delivery of a broadcast as done, finishing the broadcast
+// after its last one
+func (d *signalDispatcher) complete(broadcastID uuid.UUID) {
+	d.mu.Lock()
+	d.remaining[broadcastID]--
+	done := d.remaining[broadcastID] == 0
+	if done {
+		delete(d.remaining, broadcastID)
+	}
+	d.mu.Unlock()
+
+	if done {
+		d.finish(broadcastID)
+	}
+}
+
+func (d *signalDispatcher) finish(broadcastID uuid.UUID) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := d.broadcastRepo.Finish(ctx, broadcastID); err != nil {
+		log.Printf("[SignalDispatcher] Failed to finish broadcast %s: %v", broadcastID, err)
+		return
+	}
+	log.Printf("[SignalDispatcher] Broadcast %s completed", broadcastID)
+}
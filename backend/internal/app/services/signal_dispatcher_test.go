@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/testutils/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSignalDispatcher_Dispatch
+// Summary: Test background delivery of a signal broadcast
+// Purpose: Validate that every delivery is sent, in its own language when it has a message of its own, and recorded with its outcome, that a delivery replying to an earlier message quotes it, that a delivery cancelled or rewritten since it was queued is dropped or sent as rewritten, and that the broadcast is finished after the last one
+func TestSignalDispatcher_Dispatch(t *testing.T) {
+	broadcast := &models.SignalBroadcast{ID: uuid.New(), Ticker: "BBCA", Message: "signal", QuotedMessage: "original"}
+	deliveries := []*models.SignalDelivery{
+		{ID: uuid.New(), BroadcastID: broadcast.ID, Phone: "6281234567890"},
+		{ID: uuid.New(), BroadcastID: broadcast.ID, Phone: "6281111111111"},
+		{ID: uuid.New(), BroadcastID: broadcast.ID, Phone: "6282222222222", Language: "en", Message: "signal (en)"},
+		{ID: uuid.New(), BroadcastID: broadcast.ID, Phone: "6283333333333", ReplyToMessageID: "ORIG4"},
+		{ID: uuid.New(), BroadcastID: broadcast.ID, Phone: "6284444444444", Language: "en", Message: "signal (en)", ReplyToMessageID: "ORIG5", QuotedMessage: "original (en)"},
+		{ID: uuid.New(), BroadcastID: broadcast.ID, Phone: "6285555555555", Language: "en", Message: "signal (en)"},
+		{ID: uuid.New(), BroadcastID: broadcast.ID, Phone: "6286666666666", Language: "en", Message: "signal (en)"},
+	}
+
+	// The last two were cancelled and rewritten after they were queued
+	stored := make(map[uuid.UUID]*models.SignalDelivery)
+	for _, delivery := range deliveries {
+		current := *delivery
+		current.Status = models.SignalDeliveryPending
+		stored[delivery.ID] = &current
+	}
+	stored[deliveries[5].ID].Status = models.SignalDeliveryCancelled
+	stored[deliveries[6].ID].Message = "updated signal (en)"
+
+	mockWhatsApp := mocks.NewMockWhatsAppService(t)
+	mockWhatsApp.EXPECT().SendMessageWithID(mock.Anything, "6281234567890", "signal").Return("MSG1", nil)
+	mockWhatsApp.EXPECT().SendMessageWithID(mock.Anything, "6281111111111", "signal").Return("", errors.New("not on WhatsApp"))
+	mockWhatsApp.EXPECT().SendMessageWithID(mock.Anything, "6282222222222", "signal (en)").Return("MSG3", nil)
+	mockWhatsApp.EXPECT().SendReplyWithID(mock.Anything, "6283333333333", "signal", "ORIG4", "original").Return("MSG4", nil)
+	mockWhatsApp.EXPECT().SendReplyWithID(mock.Anything, "6284444444444", "signal (en)", "ORIG5", "original (en)").Return("MSG5", nil)
+	mockWhatsApp.EXPECT().SendMessageWithID(mock.Anything, "6286666666666", "updated signal (en)").Return("MSG7", nil)
+
+	var mu sync.Mutex
+	recorded := make(map[uuid.UUID]string)
+	finished := make(chan struct{})
+
+	mockRepo := mocks.NewMockSignalBroadcastRepository(t)
+	mockRepo.EXPECT().FailInterrupted(mock.Anything, signalInterruptedError).Return(0, nil)
+	mockRepo.EXPECT().ListUnfinished(mock.Anything).Return(nil, nil)
+	mockRepo.EXPECT().Start(mock.Anything, broadcast.ID).Return(nil)
+	mockRepo.EXPECT().ClaimDelivery(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, id uuid.UUID) (*models.SignalDelivery, error) {
+		if stored[id].Status != models.SignalDeliveryPending {
+			return nil, nil
+		}
+		return stored[id], nil
+	})
+	mockRepo.EXPECT().RecordDelivery(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, id uuid.UUID, status, whatsAppMessageID, errMsg string) {
+			mu.Lock()
+			defer mu.Unlock()
+			recorded[id] = status
+		}).Return(nil)
+	mockRepo.EXPECT().Finish(mock.Anything, broadcast.ID).Run(func(ctx context.Context, id uuid.UUID) {
+		close(finished)
+	}).Return(nil)
+
+	dispatcher := NewSignalDispatcher(&SignalDispatchConfig{Workers: 2, RatePerSecond: 1000, Jitter: time.Millisecond}, mockRepo, mockWhatsApp)
+	require.NoError(t, dispatcher.Start(context.Background()))
+	dispatcher.Dispatch(broadcast, deliveries)
+
+	select {
+	case <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("broadcast was not finished")
+	}
+	dispatcher.Stop()
+
+	assert.Equal(t, map[uuid.UUID]string{
+		deliveries[0].ID: models.SignalDeliverySent,
+		deliveries[1].ID: models.SignalDeliveryFailed,
+		deliveries[2].ID: models.SignalDeliverySent,
+		deliveries[3].ID: models.SignalDeliverySent,
+		deliveries[4].ID: models.SignalDeliverySent,
+		deliveries[6].ID: models.SignalDeliverySent,
+	}, recorded)
+}
+
+// TestSignalDispatcher_Start
+// Summary: Test resuming broadcasts on start
+// Purpose: Validate that deliveries interrupted while sending are failed, that pending deliveries of unfinished broadcasts are sent again and that a broadcast without pending deliveries is finished
+func TestSignalDispatcher_Start(t *testing.T) {
+	resumed := &models.SignalBroadcast{ID: uuid.New(), Message: "resumed", Status: models.SignalBroadcastSending}
+	drained := &models.SignalBroadcast{ID: uuid.New(), Message: "drained", Status: models.SignalBroadcastSending}
+	pending := []*models.SignalDelivery{{ID: uuid.New(), BroadcastID: resumed.ID, Phone: "6281234567890"}}
+
+	mockWhatsApp := mocks.NewMockWhatsAppService(t)
+	mockWhatsApp.EXPECT().SendMessageWithID(mock.Anything, "6281234567890", "resumed").Return("MSG1", nil)
+
+	finished := make(chan uuid.UUID, 2)
+	mockRepo := mocks.NewMockSignalBroadcastRepository(t)
+	mockRepo.EXPECT().FailInterrupted(mock.Anything, signalInterruptedError).Return(1, nil)
+	mockRepo.EXPECT().ListUnfinished(mock.Anything).Return([]*models.SignalBroadcast{resumed, drained}, nil)
+	mockRepo.EXPECT().ListDeliveries(mock.Anything, resumed.ID, models.SignalDeliveryPending).Return(pending, nil)
+	mockRepo.EXPECT().ListDeliveries(mock.Anything, drained.ID, models.SignalDeliveryPending).Return([]*models.SignalDelivery{}, nil)
+	mockRepo.EXPECT().Start(mock.Anything, resumed.ID).Return(nil)
+	mockRepo.EXPECT().ClaimDelivery(mock.Anything, pending[0].ID).Return(&models.SignalDelivery{ID: pending[0].ID, Status: models.SignalDeliverySending}, nil)
+	mockRepo.EXPECT().RecordDelivery(mock.Anything, pending[0].ID, models.SignalDeliverySent, "MSG1", "").Return(nil)
+	mockRepo.EXPECT().Finish(mock.Anything, mock.Anything).Run(func(ctx context.Context, id uuid.UUID) {
+		finished <- id
+	}).Return(nil)
+
+	dispatcher := NewSignalDispatcher(&SignalDispatchConfig{Workers: 1}, mockRepo, mockWhatsApp)
+	require.NoError(t, dispatcher.Start(context.Background()))
+
+	var ids []uuid.UUID
+	for range 2 {
+		select {
+		case id := <-finished:
+			ids = append(ids, id)
+		case <-time.After(5 * time.Second):
+			t.Fatal("broadcasts were not finished")
+		}
+	}
+	dispatcher.Stop()
+
+	assert.ElementsMatch(t, []uuid.UUID{resumed.ID, drained.ID}, ids)
+}
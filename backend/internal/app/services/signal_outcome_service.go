@@ -0,0 +1,440 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/repositories"
+)
+
+const (
+	defaultSignalExpiryDays    = 10
+	defaultConfluenceBucket    = 1.0
+	defaultTrackedSignalsLimit = 50
+	maxTrackedSignalsLimit     = 500
+)
+
+var (
+	ErrInvalidStatsGroup = errors.New("group_by must be ticker or confluence")
+	ErrInvalidOutcome    = errors.New("outcome must be open, target_hit, stopped_out, expired or cancelled")
+	ErrSignalNotFound    = errors.New("signal not found")
+	ErrSignalClosed      = errors.New("signal is already resolved or cancelled")
+)
+
+// SignalOutcomeService stores every signal and judges it against later daily
+// price bars. A signal is assumed to be filled at its entry price; it is
+// resolved by the first bar after its date that reaches the stop or the
+// target, or expires at the close of the first bar on or after its expiry date.
+// Signals are identified by ticker, last date and direction.
+type SignalOutcomeService interface {
+	// Track stores a new signal. It returns false with the stored signal when
+	// the signal was received before.
+	Track(ctx context.Context, signal *models.Signal) (*models.TrackedSignal, bool, error)
+	// Amend replaces the levels of an open signal. It returns false when the
+	// update was received before.
+	Amend(ctx context.Context, signal *models.Signal) (*models.TrackedSignal, bool, error)
+	// Cancel stops tracking an open signal. It returns false when the signal
+	// was already cancelled.
+	Cancel(ctx context.Context, signal *models.Signal) (*models.TrackedSignal, bool, error)
+	// RecordPrices stores price bars and resolves the open signals they decide.
+	// Invalid bars are reported and skipped.
+	RecordPrices(ctx context.Context, prices []*models.SignalPrice) (*models.SignalPriceReport, error)
+	ListSignals(ctx context.Context, filter *models.TrackedSignalFilter, limit int) ([]*models.TrackedSignal, error)
+	// Stats compares realised win rates with the claimed backtest win rates,
+	// grouped by ticker or by confluence score buckets of bucketSize points
+	Stats(ctx context.Context, groupBy string, bucketSize float64) (*models.SignalStatsReport, error)
+}
+
+// SignalOutcomeConfig sets how many days after its date an unresolved signal expires
+type SignalOutcomeConfig struct {
+	ExpiryDays int
+}
+
+type signalOutcomeService struct {
+	expiryDays  int
+	trackedRepo repositories.TrackedSignalRepository
+	now         func() time.Time
+}
+
+func NewSignalOutcomeService(config *SignalOutcomeConfig, trackedRepo repositories.TrackedSignalRepository) SignalOutcomeService {
+	expiryDays := config.ExpiryDays
+	if expiryDays <= 0 {
+		expiryDays = defaultSignalExpiryDays
+	}
+
+	return &signalOutcomeService{
+		expiryDays:  expiryDays,
+		trackedRepo: trackedRepo,
+		now:         time.Now,
+	}
+}
+
+func (s *signalOutcomeService) Track(ctx context.Context, signal *models.Signal) (*models.TrackedSignal, bool, error) {
+	tracked := s.newTrackedSignal(signal)
+
+	created, err := s.trackedRepo.Create(ctx, tracked, signal)
+	if err != nil {
+		log.Printf("[SignalOutcomeService] Failed to store signal for %s: %v", signal.Ticker, err)
+		return nil, false, err
+	}
+	if !created {
+		existing, err := s.getByIdentity(ctx, tracked)
+		if err != nil {
+			return nil, false, err
+		}
+		log.Printf("[SignalOutcomeService] Signal %s %s %s was already received as %s", tracked.Ticker, tracked.LastDate, tracked.Direction, existing.ID)
+		return existing, false, nil
+	}
+
+	s.resolveFromStoredPrices(ctx, tracked)
+	return tracked, true, nil
+}
+
+func (s *signalOutcomeService) Amend(ctx context.Context, signal *models.Signal) (*models.TrackedSignal, bool, error) {
+	tracked := s.newTrackedSignal(signal)
+
+	existing, err := s.getByIdentity(ctx, tracked)
+	if err != nil {
+		return nil, false, err
+	}
+	if existing.Outcome != models.SignalOutcomeOpen {
+		return nil, false, ErrSignalClosed
+	}
+
+	tracked.ID = existing.ID
+	tracked.LastDate = existing.LastDate
+	tracked.ExpiresOn = existing.ExpiresOn
+	tracked.CreatedAt = existing.CreatedAt
+
+	amended, err := s.trackedRepo.Amend(ctx, tracked, signal)
+	if err != nil {
+		log.Printf("[SignalOutcomeService] Failed to amend signal %s: %v", existing.ID, err)
+		return nil, false, err
+	}
+	if !amended {
+		// Either the same update again, or the signal closed in the meantime
+		if existing, err = s.getByIdentity(ctx, tracked); err != nil {
+			return nil, false, err
+		}
+		if existing.Outcome != models.SignalOutcomeOpen {
+			return nil, false, ErrSignalClosed
+		}
+		return existing, false, nil
+	}
+
+	log.Printf("[SignalOutcomeService] Signal %s %s amended: entry %.2f, stop %.2f, target %.2f", tracked.ID, tracked.Ticker, tracked.EntryPrice, tracked.Stop, tracked.Target)
+	s.resolveFromStoredPrices(ctx, tracked)
+	return tracked, true, nil
+}
+
+func (s *signalOutcomeService) Cancel(ctx context.Context, signal *models.Signal) (*models.TrackedSignal, bool, error) {
+	existing, err := s.getByIdentity(ctx, s.newTrackedSignal(signal))
+	if err != nil {
+		return nil, false, err
+	}
+
+	cancelled, err := s.trackedRepo.Cancel(ctx, existing.ID)
+	if err != nil {
+		log.Printf("[SignalOutcomeService] Failed to cancel signal %s: %v", existing.ID, err)
+		return nil, false, err
+	}
+	if !cancelled {
+		if existing, err = s.getByIdentity(ctx, existing); err != nil {
+			return nil, false, err
+		}
+		if existing.Outcome != models.SignalOutcomeCancelled {
+			return nil, false, ErrSignalClosed
+		}
+		return existing, false, nil
+	}
+
+	log.Printf("[SignalOutcomeService] Signal %s %s cancelled", existing.ID, existing.Ticker)
+	existing.Outcome = models.SignalOutcomeCancelled
+	return existing, true, nil
+}
+
+// getByIdentity returns the stored signal with the identity of tracked
+func (s *signalOutcomeService) getByIdentity(ctx context.Context, tracked *models.TrackedSignal) (*models.TrackedSignal, error) {
+	existing, err := s.trackedRepo.GetByIdentity(ctx, tracked.Ticker, tracked.LastDate, tracked.Direction)
+	if err != nil {
+		log.Printf("[SignalOutcomeService] Failed to get signal %s %s %s: %v", tracked.Ticker, tracked.LastDate, tracked.Direction, err)
+		return nil, err
+	}
+	if existing == nil {
+		return nil, ErrSignalNotFound
+	}
+	return existing, nil
+}
+
+// newTrackedSignal builds the open tracked signal of signal, dated today when
+// its last date is invalid
+func (s *signalOutcomeService) newTrackedSignal(signal *models.Signal) *models.TrackedSignal {
+	date, err := time.Parse(models.SignalDateLayout, signal.LastDate)
+	if err != nil {
+		log.Printf("[SignalOutcomeService] Signal %s has an invalid last_date %q; tracking from today", signal.Ticker, signal.LastDate)
+		date = s.now().UTC().Truncate(24 * time.Hour)
+	}
+
+	return &models.TrackedSignal{
+		Ticker:           strings.ToUpper(signal.Ticker),
+		LastDate:         date.Format(models.SignalDateLayout),
+		Direction:        signalDirection(signal),
+		EntryPrice:       float64(signal.EntryPrice),
+		Stop:             signal.Stop,
+		Target:           signal.Target,
+		RiskReward:       signal.RiskReward,
+		BacktestWinRate:  signal.BacktestWinRate,
+		TotalTrades:      signal.TotalTrades,
+		ConfluenceScore:  signal.ConfluenceScore,
+		OverallSentiment: signal.OverallSentiment,
+		Outcome:          models.SignalOutcomeOpen,
+		ExpiresOn:        date.AddDate(0, 0, s.expiryDays).Format(models.SignalDateLayout),
+	}
+}
+
+// resolveFromStoredPrices judges a new or amended signal against the prices
+// already stored, which may have been imported before it arrived
+func (s *signalOutcomeService) resolveFromStoredPrices(ctx context.Context, tracked *models.TrackedSignal) {
+	prices, err := s.trackedRepo.ListPrices(ctx, tracked.Ticker, tracked.LastDate)
+	if err != nil {
+		log.Printf("[SignalOutcomeService] Failed to list prices for %s: %v", tracked.Ticker, err)
+		return
+	}
+	for _, price := range prices {
+		if evaluateOutcome(tracked, price) {
+			if _, err := s.trackedRepo.Resolve(ctx, tracked); err != nil {
+				log.Printf("[SignalOutcomeService] Failed to resolve signal %s: %v", tracked.ID, err)
+			}
+			return
+		}
+	}
+}
+
+func (s *signalOutcomeService) RecordPrices(ctx context.Context, prices []*models.SignalPrice) (*models.SignalPriceReport, error) {
+	report := &models.SignalPriceReport{}
+
+	valid := make([]*models.SignalPrice, 0, len(prices))
+	for i, price := range prices {
+		if err := normalizePrice(price); err != nil {
+			report.Rejected++
+			report.Errors = append(report.Errors, fmt.Sprintf("price %d: %v", i+1, err))
+			continue
+		}
+		valid = append(valid, price)
+	}
+
+	// Bars are applied in date order so that the earliest decides each signal
+	sort.SliceStable(valid, func(i, j int) bool { return valid[i].Date < valid[j].Date })
+
+	for _, price := range valid {
+		if err := s.trackedRepo.SavePrice(ctx, price); err != nil {
+			log.Printf("[SignalOutcomeService] Failed to save price %s %s: %v", price.Ticker, price.Date, err)
+			return nil, err
+		}
+		report.Prices++
+
+		open, err := s.trackedRepo.ListOpen(ctx, price.Ticker, price.Date)
+		if err != nil {
+			log.Printf("[SignalOutcomeService] Failed to list open signals for %s: %v", price.Ticker, err)
+			return nil, err
+		}
+
+		for _, tracked := range open {
+			if !evaluateOutcome(tracked, price) {
+				continue
+			}
+
+			resolved, err := s.trackedRepo.Resolve(ctx, tracked)
+			if err != nil {
+				log.Printf("[SignalOutcomeService] Failed to resolve signal %s: %v", tracked.ID, err)
+				return nil, err
+			}
+			if !resolved {
+				continue
+			}
+
+			report.Resolved++
+			switch tracked.Outcome {
+			case models.SignalOutcomeTargetHit:
+				report.TargetHit++
+			case models.SignalOutcomeStoppedOut:
+				report.StoppedOut++
+			case models.SignalOutcomeExpired:
+				report.Expired++
+			}
+			log.Printf("[SignalOutcomeService] Signal %s %s on %s: %s", tracked.ID, tracked.Ticker, price.Date, tracked.Outcome)
+		}
+	}
+
+	return report, nil
+}
+
+func (s *signalOutcomeService) ListSignals(ctx context.Context, filter *models.TrackedSignalFilter, limit int) ([]*models.TrackedSignal, error) {
+	switch filter.Outcome {
+	case "", models.SignalOutcomeOpen, models.SignalOutcomeTargetHit, models.SignalOutcomeStoppedOut, models.SignalOutcomeExpired, models.SignalOutcomeCancelled:
+	default:
+		return nil, ErrInvalidOutcome
+	}
+
+	if limit <= 0 {
+		limit = defaultTrackedSignalsLimit
+	}
+	limit = min(limit, maxTrackedSignalsLimit)
+	filter.Ticker = strings.ToUpper(strings.TrimSpace(filter.Ticker))
+
+	signals, err := s.trackedRepo.List(ctx, filter, limit)
+	if err != nil {
+		log.Printf("[SignalOutcomeService] Failed to list signals: %v", err)
+		return nil, err
+	}
+
+	return signals, nil
+}
+
+func (s *signalOutcomeService) Stats(ctx context.Context, groupBy string, bucketSize float64) (*models.SignalStatsReport, error) {
+	if groupBy != models.SignalStatsByTicker && groupBy != models.SignalStatsByConfluence {
+		return nil, ErrInvalidStatsGroup
+	}
+	if bucketSize <= 0 {
+		bucketSize = defaultConfluenceBucket
+	}
+
+	overall, err := s.trackedRepo.Stats(ctx, "", 0)
+	if err != nil {
+		log.Printf("[SignalOutcomeService] Failed to get overall stats: %v", err)
+		return nil, err
+	}
+
+	groups, err := s.trackedRepo.Stats(ctx, groupBy, bucketSize)
+	if err != nil {
+		log.Printf("[SignalOutcomeService] Failed to get stats by %s: %v", groupBy, err)
+		return nil, err
+	}
+
+	report := &models.SignalStatsReport{
+		GroupBy: groupBy,
+		Overall: &models.SignalStats{Group: "all"},
+		Groups:  groups,
+	}
+	if len(overall) > 0 {
+		report.Overall = overall[0]
+	}
+
+	completeStats(report.Overall)
+	for _, stats := range groups {
+		completeStats(stats)
+	}
+
+	return report, nil
+}
+
+// completeStats derives the win rate and rounds the averages
+func completeStats(stats *models.SignalStats) {
+	if resolved := stats.TargetHit + stats.StoppedOut + stats.Expired; resolved > 0 {
+		winRate := round2(float64(stats.TargetHit) / float64(resolved) * 100)
+		stats.WinRate = &winRate
+	}
+	if stats.AvgRMultiple != nil {
+		avg := round2(*stats.AvgRMultiple)
+		stats.AvgRMultiple = &avg
+	}
+	stats.AvgBacktestWinRate = round2(stats.AvgBacktestWinRate)
+}
+
+// normalizePrice validates a price bar, filling in a missing high or low from the close
+func normalizePrice(price *models.SignalPrice) error {
+	if price == nil {
+		return errors.New("empty price")
+	}
+
+	price.Ticker = strings.ToUpper(strings.TrimSpace(price.Ticker))
+	if !tickerPattern.MatchString(price.Ticker) {
+		return ErrInvalidTicker
+	}
+
+	price.Date = strings.TrimSpace(price.Date)
+	if _, err := time.Parse(models.SignalDateLayout, price.Date); err != nil {
+		return fmt.Errorf("date must be YYYY-MM-DD")
+	}
+
+	if price.Close <= 0 || price.High < 0 || price.Low < 0 {
+		return fmt.Errorf("prices must be positive")
+	}
+	if price.High == 0 {
+		price.High = price.Close
+	}
+	if price.Low == 0 {
+		price.Low = price.Close
+	}
+	if price.Low > price.High || price.Close < price.Low || price.Close > price.High {
+		return fmt.Errorf("close must lie between low and high")
+	}
+
+	return nil
+}
+
+// evaluateOutcome judges an open signal against a price bar and records the
+// outcome on it, reporting whether the bar resolved it. When a bar reaches both
+// the stop and the target the stop is assumed to have come first.
+func evaluateOutcome(tracked *models.TrackedSignal, price *models.SignalPrice) bool {
+	if price.Date <= tracked.LastDate {
+		return false
+	}
+
+	long := tracked.Direction == models.SignalDirectionLong
+	var outcome string
+	var exit float64
+
+	switch {
+	case long && price.Low <= tracked.Stop, !long && price.High >= tracked.Stop:
+		outcome, exit = models.SignalOutcomeStoppedOut, tracked.Stop
+	case long && price.High >= tracked.Target, !long && price.Low <= tracked.Target:
+		outcome, exit = models.SignalOutcomeTargetHit, tracked.Target
+	case price.Date >= tracked.ExpiresOn:
+		outcome, exit = models.SignalOutcomeExpired, price.Close
+	default:
+		return false
+	}
+
+	date := price.Date
+	tracked.Outcome = outcome
+	tracked.ExitPrice = &exit
+	tracked.RMultiple = rMultiple(tracked, exit)
+	tracked.ResolvedOn = &date
+	return true
+}
+
+// rMultiple is the result of exiting at exit in units of the initial risk, or
+// nil when the stop equals the entry
+func rMultiple(tracked *models.TrackedSignal, exit float64) *float64 {
+	risk := math.Abs(tracked.EntryPrice - tracked.Stop)
+	if risk == 0 {
+		return nil
+	}
+
+	result := (exit - tracked.EntryPrice) / risk
+	if tracked.Direction == models.SignalDirectionShort {
+		result = -result
+	}
+	result = round2(result)
+	return &result
+}
+
+// signalDirection is long when the target is above the entry
+func signalDirection(signal *models.Signal) string {
+	if signal.Target < float64(signal.EntryPrice) {
+		return models.SignalDirectionShort
+	}
+	return models.SignalDirectionLong
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
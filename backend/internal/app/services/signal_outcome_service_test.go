@@ -0,0 +1,479 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/testutils/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func floatPtr(value float64) *float64 {
+	return &value
+}
+
+// TestEvaluateOutcome
+// Summary: Test judging a signal against a daily price bar
+// Purpose: Validate target, stop and expiry detection for long and short signals, the stop-first rule and the realised R multiple
+func TestEvaluateOutcome(t *testing.T) {
+	long := models.TrackedSignal{
+		LastDate: "2025-08-10", Direction: models.SignalDirectionLong,
+		EntryPrice: 1000, Stop: 950, Target: 1100, ExpiresOn: "2025-08-20",
+	}
+	short := models.TrackedSignal{
+		LastDate: "2025-08-10", Direction: models.SignalDirectionShort,
+		EntryPrice: 1000, Stop: 1050, Target: 900, ExpiresOn: "2025-08-20",
+	}
+
+	tests := []struct {
+		name            string
+		signal          models.TrackedSignal
+		price           models.SignalPrice
+		expectResolved  bool
+		expectedOutcome string
+		expectedExit    float64
+		expectedR       *float64
+	}{
+		{
+			name:            "Long reaches target",
+			signal:          long,
+			price:           models.SignalPrice{Date: "2025-08-12", High: 1120, Low: 990, Close: 1110},
+			expectResolved:  true,
+			expectedOutcome: models.SignalOutcomeTargetHit,
+			expectedExit:    1100,
+			expectedR:       floatPtr(2),
+		},
+		{
+			name:            "Long stopped out",
+			signal:          long,
+			price:           models.SignalPrice{Date: "2025-08-12", High: 1010, Low: 940, Close: 960},
+			expectResolved:  true,
+			expectedOutcome: models.SignalOutcomeStoppedOut,
+			expectedExit:    950,
+			expectedR:       floatPtr(-1),
+		},
+		{
+			name:            "Bar reaching both levels counts as stopped out",
+			signal:          long,
+			price:           models.SignalPrice{Date: "2025-08-12", High: 1150, Low: 900, Close: 1000},
+			expectResolved:  true,
+			expectedOutcome: models.SignalOutcomeStoppedOut,
+			expectedExit:    950,
+			expectedR:       floatPtr(-1),
+		},
+		{
+			name:           "Long within range stays open",
+			signal:         long,
+			price:          models.SignalPrice{Date: "2025-08-12", High: 1050, Low: 980, Close: 1020},
+			expectResolved: false,
+		},
+		{
+			name:           "Bar on the signal date is ignored",
+			signal:         long,
+			price:          models.SignalPrice{Date: "2025-08-10", High: 1200, Low: 900, Close: 1000},
+			expectResolved: false,
+		},
+		{
+			name:            "Long expires at the close",
+			signal:          long,
+			price:           models.SignalPrice{Date: "2025-08-20", High: 1040, Low: 1000, Close: 1025},
+			expectResolved:  true,
+			expectedOutcome: models.SignalOutcomeExpired,
+			expectedExit:    1025,
+			expectedR:       floatPtr(0.5),
+		},
+		{
+			name:            "Short reaches target",
+			signal:          short,
+			price:           models.SignalPrice{Date: "2025-08-13", High: 1000, Low: 880, Close: 890},
+			expectResolved:  true,
+			expectedOutcome: models.SignalOutcomeTargetHit,
+			expectedExit:    900,
+			expectedR:       floatPtr(2),
+		},
+		{
+			name:            "Short stopped out",
+			signal:          short,
+			price:           models.SignalPrice{Date: "2025-08-13", High: 1060, Low: 990, Close: 1055},
+			expectResolved:  true,
+			expectedOutcome: models.SignalOutcomeStoppedOut,
+			expectedExit:    1050,
+			expectedR:       floatPtr(-1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signal := tt.signal
+			resolved := evaluateOutcome(&signal, &tt.price)
+
+			assert.Equal(t, tt.expectResolved, resolved)
+			if !tt.expectResolved {
+				assert.Empty(t, signal.Outcome)
+				return
+			}
+			assert.Equal(t, tt.expectedOutcome, signal.Outcome)
+			assert.Equal(t, tt.expectedExit, *signal.ExitPrice)
+			assert.Equal(t, tt.expectedR, signal.RMultiple)
+			assert.Equal(t, tt.price.Date, *signal.ResolvedOn)
+		})
+	}
+}
+
+// TestSignalOutcomeService_Track
+// Summary: Test storing an incoming signal
+// Purpose: Validate direction, expiry date and fallback date of a tracked signal, resolution against prices already recorded, and that a duplicate returns the stored signal
+func TestSignalOutcomeService_Track(t *testing.T) {
+	now := time.Date(2025, 8, 15, 9, 30, 0, 0, time.UTC)
+	existing := &models.TrackedSignal{ID: uuid.New(), Ticker: "BBCA", LastDate: "2025-08-10", Direction: models.SignalDirectionLong, Outcome: models.SignalOutcomeOpen}
+
+	tests := []struct {
+		name              string
+		signal            *models.Signal
+		prices            []*models.SignalPrice
+		existing          *models.TrackedSignal
+		expectedDirection string
+		expectedLastDate  string
+		expectedExpiresOn string
+		expectedOutcome   string
+	}{
+		{
+			name:              "Long signal stays open",
+			signal:            &models.Signal{Ticker: "bbca", LastDate: "2025-08-10", EntryPrice: 9655, Stop: 9180, Target: 9720},
+			expectedDirection: models.SignalDirectionLong,
+			expectedLastDate:  "2025-08-10",
+			expectedExpiresOn: "2025-08-20",
+			expectedOutcome:   models.SignalOutcomeOpen,
+		},
+		{
+			name:              "Short signal with invalid date is tracked from today",
+			signal:            &models.Signal{Ticker: "TLKM", LastDate: "10/08/2025", EntryPrice: 3437, Stop: 3600, Target: 3300},
+			expectedDirection: models.SignalDirectionShort,
+			expectedLastDate:  "2025-08-15",
+			expectedExpiresOn: "2025-08-25",
+			expectedOutcome:   models.SignalOutcomeOpen,
+		},
+		{
+			name:   "Recorded prices resolve the signal",
+			signal: &models.Signal{Ticker: "BBCA", LastDate: "2025-08-10", EntryPrice: 9655, Stop: 9180, Target: 9720},
+			prices: []*models.SignalPrice{
+				{Ticker: "BBCA", Date: "2025-08-11", High: 9700, Low: 9500, Close: 9600},
+				{Ticker: "BBCA", Date: "2025-08-12", High: 9750, Low: 9550, Close: 9700},
+			},
+			expectedDirection: models.SignalDirectionLong,
+			expectedLastDate:  "2025-08-10",
+			expectedExpiresOn: "2025-08-20",
+			expectedOutcome:   models.SignalOutcomeTargetHit,
+		},
+		{
+			name:              "Duplicate returns the stored signal",
+			signal:            &models.Signal{Ticker: "BBCA", LastDate: "2025-08-10", EntryPrice: 9655, Stop: 9180, Target: 9720},
+			existing:          existing,
+			expectedDirection: models.SignalDirectionLong,
+			expectedLastDate:  "2025-08-10",
+			expectedOutcome:   models.SignalOutcomeOpen,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockTrackedSignalRepository(t)
+			mockRepo.EXPECT().Create(mock.Anything, mock.Anything, tt.signal).Return(tt.existing == nil, nil)
+			if tt.existing != nil {
+				mockRepo.EXPECT().GetByIdentity(mock.Anything, "BBCA", tt.expectedLastDate, tt.expectedDirection).Return(tt.existing, nil)
+			} else {
+				mockRepo.EXPECT().ListPrices(mock.Anything, strings.ToUpper(tt.signal.Ticker), tt.expectedLastDate).Return(tt.prices, nil)
+			}
+			if tt.expectedOutcome != models.SignalOutcomeOpen {
+				mockRepo.EXPECT().Resolve(mock.Anything, mock.Anything).Return(true, nil)
+			}
+
+			service := &signalOutcomeService{expiryDays: 10, trackedRepo: mockRepo, now: func() time.Time { return now }}
+			tracked, created, err := service.Track(context.Background(), tt.signal)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.existing == nil, created)
+			if tt.existing != nil {
+				assert.Same(t, tt.existing, tracked)
+				return
+			}
+			assert.Equal(t, strings.ToUpper(tt.signal.Ticker), tracked.Ticker)
+			assert.Equal(t, tt.expectedDirection, tracked.Direction)
+			assert.Equal(t, tt.expectedLastDate, tracked.LastDate)
+			assert.Equal(t, tt.expectedExpiresOn, tracked.ExpiresOn)
+			assert.Equal(t, tt.expectedOutcome, tracked.Outcome)
+		})
+	}
+}
+
+// TestSignalOutcomeService_Amend
+// Summary: Test updating the levels of a signal
+// Purpose: Validate that only open signals are amended, keeping their date and expiry, and that a repeated update is reported as unchanged
+func TestSignalOutcomeService_Amend(t *testing.T) {
+	signal := &models.Signal{Operation: models.SignalOperationUpdate, Ticker: "BBCA", LastDate: "2025-08-10", EntryPrice: 9600, Stop: 9300, Target: 9900}
+
+	tests := []struct {
+		name            string
+		stored          *models.TrackedSignal
+		amended         bool
+		expectedChanged bool
+		expectedError   error
+	}{
+		{
+			name:            "Open signal is amended",
+			stored:          &models.TrackedSignal{ID: uuid.New(), Ticker: "BBCA", LastDate: "2025-08-10", ExpiresOn: "2025-08-18", Outcome: models.SignalOutcomeOpen},
+			amended:         true,
+			expectedChanged: true,
+		},
+		{
+			name:   "Repeated update is unchanged",
+			stored: &models.TrackedSignal{ID: uuid.New(), Ticker: "BBCA", LastDate: "2025-08-10", ExpiresOn: "2025-08-18", Outcome: models.SignalOutcomeOpen},
+		},
+		{
+			name:          "Resolved signal is closed",
+			stored:        &models.TrackedSignal{ID: uuid.New(), Ticker: "BBCA", LastDate: "2025-08-10", Outcome: models.SignalOutcomeStoppedOut},
+			expectedError: ErrSignalClosed,
+		},
+		{
+			name:          "Unknown signal",
+			expectedError: ErrSignalNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockTrackedSignalRepository(t)
+			mockRepo.EXPECT().GetByIdentity(mock.Anything, "BBCA", "2025-08-10", models.SignalDirectionLong).Return(tt.stored, nil)
+			if tt.stored != nil && tt.stored.Outcome == models.SignalOutcomeOpen {
+				mockRepo.EXPECT().Amend(mock.Anything, mock.Anything, signal).Return(tt.amended, nil)
+			}
+			if tt.amended {
+				mockRepo.EXPECT().ListPrices(mock.Anything, "BBCA", "2025-08-10").Return(nil, nil)
+			}
+
+			service := NewSignalOutcomeService(&SignalOutcomeConfig{}, mockRepo)
+			tracked, changed, err := service.Amend(context.Background(), signal)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedChanged, changed)
+			assert.Equal(t, tt.stored.ID, tracked.ID)
+			assert.Equal(t, "2025-08-18", tracked.ExpiresOn)
+			if tt.expectedChanged {
+				assert.Equal(t, 9300.0, tracked.Stop)
+			}
+		})
+	}
+}
+
+// TestSignalOutcomeService_Cancel
+// Summary: Test cancelling a signal
+// Purpose: Validate that an open signal is cancelled once, a repeated cancel is unchanged and a resolved signal cannot be cancelled
+func TestSignalOutcomeService_Cancel(t *testing.T) {
+	signal := &models.Signal{Operation: models.SignalOperationCancel, Ticker: "TLKM", LastDate: "2025-08-10", EntryPrice: 3437, Stop: 3600, Target: 3300}
+
+	tests := []struct {
+		name            string
+		outcome         string
+		cancelled       bool
+		expectedChanged bool
+		expectedError   error
+	}{
+		{
+			name:            "Open signal is cancelled",
+			outcome:         models.SignalOutcomeOpen,
+			cancelled:       true,
+			expectedChanged: true,
+		},
+		{
+			name:    "Repeated cancel is unchanged",
+			outcome: models.SignalOutcomeCancelled,
+		},
+		{
+			name:          "Resolved signal is closed",
+			outcome:       models.SignalOutcomeTargetHit,
+			expectedError: ErrSignalClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored := &models.TrackedSignal{ID: uuid.New(), Ticker: "TLKM", LastDate: "2025-08-10", Direction: models.SignalDirectionShort, Outcome: tt.outcome}
+
+			mockRepo := mocks.NewMockTrackedSignalRepository(t)
+			mockRepo.EXPECT().GetByIdentity(mock.Anything, "TLKM", "2025-08-10", models.SignalDirectionShort).Return(stored, nil)
+			mockRepo.EXPECT().Cancel(mock.Anything, stored.ID).Return(tt.cancelled, nil)
+
+			service := NewSignalOutcomeService(&SignalOutcomeConfig{}, mockRepo)
+			tracked, changed, err := service.Cancel(context.Background(), signal)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedChanged, changed)
+			assert.Equal(t, models.SignalOutcomeCancelled, tracked.Outcome)
+		})
+	}
+}
+
+// TestSignalOutcomeService_RecordPrices
+// Summary: Test recording price updates
+// Purpose: Validate that invalid bars are reported, valid bars are stored in date order and decided signals are counted by outcome
+func TestSignalOutcomeService_RecordPrices(t *testing.T) {
+	open := &models.TrackedSignal{
+		ID: uuid.New(), Ticker: "BBCA", LastDate: "2025-08-10", Direction: models.SignalDirectionLong,
+		EntryPrice: 1000, Stop: 950, Target: 1100, ExpiresOn: "2025-08-20", Outcome: models.SignalOutcomeOpen,
+	}
+	prices := []*models.SignalPrice{
+		{Ticker: "bbca", Date: "2025-08-12", High: 1120, Low: 1000, Close: 1110},
+		{Ticker: "BBCA", Date: "2025-08-11", Close: 1020},
+		{Ticker: "BBCA", Date: "11-08-2025", Close: 1020},
+		{Ticker: "BBCA", Date: "2025-08-13", High: 1000, Low: 1050, Close: 1020},
+	}
+
+	var saved []string
+	mockRepo := mocks.NewMockTrackedSignalRepository(t)
+	mockRepo.EXPECT().SavePrice(mock.Anything, mock.Anything).Run(func(ctx context.Context, price *models.SignalPrice) {
+		saved = append(saved, price.Date)
+	}).Return(nil)
+	mockRepo.EXPECT().ListOpen(mock.Anything, "BBCA", "2025-08-11").Return([]*models.TrackedSignal{open}, nil)
+	mockRepo.EXPECT().ListOpen(mock.Anything, "BBCA", "2025-08-12").Return([]*models.TrackedSignal{open}, nil)
+	mockRepo.EXPECT().Resolve(mock.Anything, open).Return(true, nil).Once()
+
+	service := NewSignalOutcomeService(&SignalOutcomeConfig{}, mockRepo)
+	report, err := service.RecordPrices(context.Background(), prices)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2025-08-11", "2025-08-12"}, saved)
+	assert.Equal(t, 2, report.Prices)
+	assert.Equal(t, 2, report.Rejected)
+	assert.Len(t, report.Errors, 2)
+	assert.Equal(t, 1, report.Resolved)
+	assert.Equal(t, 1, report.TargetHit)
+	assert.Equal(t, models.SignalOutcomeTargetHit, open.Outcome)
+	assert.Equal(t, 1020.0, prices[1].High)
+}
+
+// TestSignalOutcomeService_Stats
+// Summary: Test outcome statistics
+// Purpose: Validate the grouping check, the default confluence bucket and the derived win rate
+func TestSignalOutcomeService_Stats(t *testing.T) {
+	tests := []struct {
+		name          string
+		groupBy       string
+		bucketSize    float64
+		expectedSize  float64
+		expectedError error
+	}{
+		{
+			name:         "By ticker",
+			groupBy:      models.SignalStatsByTicker,
+			expectedSize: 1,
+		},
+		{
+			name:         "By confluence with custom buckets",
+			groupBy:      models.SignalStatsByConfluence,
+			bucketSize:   2.5,
+			expectedSize: 2.5,
+		},
+		{
+			name:          "Unknown grouping",
+			groupBy:       "sector",
+			expectedError: ErrInvalidStatsGroup,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockTrackedSignalRepository(t)
+			if tt.expectedError == nil {
+				mockRepo.EXPECT().Stats(mock.Anything, "", 0.0).Return([]*models.SignalStats{
+					{Group: "all", Signals: 5, Open: 1, TargetHit: 3, StoppedOut: 1, AvgRMultiple: floatPtr(0.8333), AvgBacktestWinRate: 68.456},
+				}, nil)
+				mockRepo.EXPECT().Stats(mock.Anything, tt.groupBy, tt.expectedSize).Return([]*models.SignalStats{
+					{Group: "BBCA", Signals: 2, Open: 2},
+				}, nil)
+			}
+
+			service := NewSignalOutcomeService(&SignalOutcomeConfig{}, mockRepo)
+			report, err := service.Stats(context.Background(), tt.groupBy, tt.bucketSize)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 75.0, *report.Overall.WinRate)
+			assert.Equal(t, 0.83, *report.Overall.AvgRMultiple)
+			assert.Equal(t, 68.46, report.Overall.AvgBacktestWinRate)
+			assert.Nil(t, report.Groups[0].WinRate)
+		})
+	}
+}
+
+// TestParseSignalPrices
+// Summary: Test reading price CSV files
+// Purpose: Validate header mapping, optional high and low columns and malformed input
+func TestParseSignalPrices(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    []*models.SignalPrice
+		expectError bool
+	}{
+		{
+			name:  "Full bars with extra column",
+			input: "\ufeffDate,Ticker,Open,High,Low,Close\n2025-08-11,BBCA,9600,9700,9500,9650\n",
+			expected: []*models.SignalPrice{
+				{Ticker: "BBCA", Date: "2025-08-11", High: 9700, Low: 9500, Close: 9650},
+			},
+		},
+		{
+			name:  "Close only",
+			input: "ticker,date,close\nTLKM,2025-08-11,3450\n",
+			expected: []*models.SignalPrice{
+				{Ticker: "TLKM", Date: "2025-08-11", Close: 3450},
+			},
+		},
+		{
+			name:        "Missing close column",
+			input:       "ticker,date,high\nBBCA,2025-08-11,9700\n",
+			expectError: true,
+		},
+		{
+			name:        "Non-numeric price",
+			input:       "ticker,date,close\nBBCA,2025-08-11,n/a\n",
+			expectError: true,
+		},
+		{
+			name:        "Empty file",
+			input:       "",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prices, err := ParseSignalPrices(strings.NewReader(tt.input))
+
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, prices)
+		})
+	}
+}
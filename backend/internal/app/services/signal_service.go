@@ -2,151 +2,453 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
 	"time"
 
 	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/repositories"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/pkg/locale"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// signalSendingWait bounds how long a follow-up waits for copies of the
+	// original being sent; a send itself is bounded by signalSendTimeout
+	signalSendingWait = signalSendTimeout + 5*time.Second
+	signalSendingPoll = 200 * time.Millisecond
+)
+
+var (
+	ErrSignalBroadcastNotFound = errors.New("signal broadcast not found")
+	ErrInvalidDeliveryStatus   = errors.New("delivery status must be pending, sending, sent, failed or cancelled")
 )
 
 type SignalService interface {
+	// ProcessSignal applies the operation of a signal and stores a broadcast to
+	// its recipients for the dispatcher; it returns before any message is sent.
+	// A new signal goes to its subscribers, and an update or cancellation goes
+	// as a reply to everyone who received the original; recipients still
+	// waiting for the original get it updated, or not at all once cancelled.
+	// A signal received before returns the earlier broadcast without sending
+	// anything, also when posted concurrently. A signal failing validation is
+	// stored for review and returns a *SignalRejectedError.
 	ProcessSignal(ctx context.Context, signal *models.Signal) (*models.SignalResponse, error)
-	FormatSignalMessage(signal *models.Signal) string
+	GetBroadcast(ctx context.Context, id uuid.UUID) (*models.SignalBroadcast, error)
+	// ListDeliveries returns the deliveries of a broadcast, optionally only those with status
+	ListDeliveries(ctx context.Context, broadcastID uuid.UUID, status string) ([]*models.SignalDelivery, error)
+	// ListRejected returns the most recent signals that failed validation
+	ListRejected(ctx context.Context, filter *models.RejectedSignalFilter, limit int) ([]*models.RejectedSignal, error)
 }
 
 type signalService struct {
-	userService     UserService
-	whatsappService WhatsAppService
+	broadcastRepo       repositories.SignalBroadcastRepository
+	rejectedRepo        repositories.RejectedSignalRepository
+	validator           SignalValidator
+	subscriptionService SubscriptionService
+	outcomeService      SignalOutcomeService
+	templateService     SignalTemplateService
+	dispatcher          SignalDispatcher
+	sendingWait         time.Duration
+	sendingPoll         time.Duration
 }
 
-func NewSignalService(userService UserService, whatsappService WhatsAppService) SignalService {
+func NewSignalService(broadcastRepo repositories.SignalBroadcastRepository, rejectedRepo repositories.RejectedSignalRepository, validator SignalValidator, subscriptionService SubscriptionService, outcomeService SignalOutcomeService, templateService SignalTemplateService, dispatcher SignalDispatcher) SignalService {
 	return &signalService{
-		userService:     userService,
-		whatsappService: whatsappService,
+		broadcastRepo:       broadcastRepo,
+		rejectedRepo:        rejectedRepo,
+		validator:           validator,
+		subscriptionService: subscriptionService,
+		outcomeService:      outcomeService,
+		templateService:     templateService,
+		dispatcher:          dispatcher,
+		sendingWait:         signalSendingWait,
+		sendingPoll:         signalSendingPoll,
 	}
 }
 
 func (s *signalService) ProcessSignal(ctx context.Context, signal *models.Signal) (*models.SignalResponse, error) {
-	log.Printf("[SignalService] Processing signal for ticker: %s", signal.Ticker)
-	startTime := time.Now()
+	if signal.Operation == "" {
+		signal.Operation = models.SignalOperationNew
+	}
+	log.Printf("[SignalService] Processing %s signal for ticker: %s", signal.Operation, signal.Ticker)
+
+	if violations := s.validator.Validate(signal); len(violations) > 0 {
+		return nil, s.reject(ctx, signal, violations)
+	}
 
-	users, err := s.userService.GetEligibleUsers(ctx)
+	switch signal.Operation {
+	case models.SignalOperationUpdate:
+		tracked, changed, err := s.outcomeService.Amend(ctx, signal)
+		if err != nil {
+			return nil, err
+		}
+		return s.sendFollowUp(ctx, signal, tracked, models.SignalBroadcastUpdate, changed)
+	case models.SignalOperationCancel:
+		tracked, changed, err := s.outcomeService.Cancel(ctx, signal)
+		if err != nil {
+			return nil, err
+		}
+		return s.sendFollowUp(ctx, signal, tracked, models.SignalBroadcastCancel, changed)
+	}
+
+	tracked, created, err := s.outcomeService.Track(ctx, signal)
 	if err != nil {
-		log.Printf("[SignalService] Failed to get eligible users: %v", err)
-		return nil, fmt.Errorf("failed to get eligible users: %w", err)
+		return nil, fmt.Errorf("failed to store signal: %w", err)
+	}
+
+	// A signal stored without a broadcast is sent now, so that a retry after
+	// a failure below completes the delivery
+	if !created {
+		if response, err := s.duplicateResponse(ctx, signal, tracked, models.SignalBroadcastSignal); response != nil || err != nil {
+			return response, err
+		}
 	}
 
-	if len(users) == 0 {
-		log.Printf("[SignalService] No eligible users found")
-		return &models.SignalResponse{
-			Ticker:           signal.Ticker,
-			UsersNotified:    0,
-			Timestamp:        time.Now(),
-			ProcessingTimeMs: time.Since(startTime).Milliseconds(),
-		}, nil
+	users, err := s.subscriptionService.FindRecipients(ctx, signal)
+	if err != nil {
+		log.Printf("[SignalService] Failed to find signal recipients: %v", err)
+		return nil, fmt.Errorf("failed to find signal recipients: %w", err)
 	}
 
-	message := s.FormatSignalMessage(signal)
-	successCount := 0
+	render := s.renderer(ctx, models.SignalBroadcastSignal, signal)
+	message, err := render(locale.Default)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, user := range users {
-		err := s.whatsappService.SendMessage(ctx, user.Phone, message)
+	broadcast := &models.SignalBroadcast{
+		SignalID: &tracked.ID,
+		Kind:     models.SignalBroadcastSignal,
+		Ticker:   signal.Ticker,
+		Message:  message,
+	}
+	deliveries := make([]*models.SignalDelivery, len(users))
+	for i, user := range users {
+		language := userLanguage(user.Language)
+		message, err := render(language)
 		if err != nil {
-			log.Printf("[SignalService] Failed to send signal to user %s (%s): %v", user.Name, user.Phone, err)
-			continue
+			return nil, err
 		}
-		successCount++
-		log.Printf("[SignalService] Signal sent successfully to user %s (%s)", user.Name, user.Phone)
+		deliveries[i] = &models.SignalDelivery{UserID: &user.ID, Phone: user.Phone, Language: language, Message: message}
 	}
 
-	processingTime := time.Since(startTime).Milliseconds()
-	log.Printf("[SignalService] Signal processing completed for %s: %d/%d users notified in %dms",
-		signal.Ticker, successCount, len(users), processingTime)
-
-	return &models.SignalResponse{
-		Ticker:           signal.Ticker,
-		UsersNotified:    successCount,
-		Timestamp:        time.Now(),
-		ProcessingTimeMs: processingTime,
-	}, nil
+	return s.broadcast(ctx, signal, tracked, broadcast, deliveries)
 }
 
-func (s *signalService) FormatSignalMessage(signal *models.Signal) string {
-	var builder strings.Builder
-
-	builder.WriteString(fmt.Sprintf("🚀 *SIGNAL ALERT: %s*\n\n", signal.Ticker))
-
-	builder.WriteString("📊 *TRADING DETAILS*\n")
-	builder.WriteString(fmt.Sprintf("• Last Price: Rp %s\n", formatNumber(signal.LastClose)))
-	builder.WriteString(fmt.Sprintf("• *Entry Price*: Rp %s\n", formatNumber(signal.EntryPrice)))
-	builder.WriteString(fmt.Sprintf("• *Date*: %s\n", signal.LastDate))
-	builder.WriteString(fmt.Sprintf("• Entry Gap: %+.1f%%\n\n", signal.EntryGapPercent))
-
-	builder.WriteString("🎯 *TRADING LEVELS*\n")
-	builder.WriteString(fmt.Sprintf("• *Stop Loss*: Rp %s\n", formatCurrency(signal.Stop)))
-	builder.WriteString(fmt.Sprintf("• *Target*: Rp %s\n", formatCurrency(signal.Target)))
-	builder.WriteString(fmt.Sprintf("• Risk/Reward: 1:%.2f\n\n", signal.RiskReward))
-
-	builder.WriteString("📈 *ANALYSIS METRICS*\n")
-	builder.WriteString(fmt.Sprintf("• *Confluence Score*: %.1f/10\n", signal.ConfluenceScore))
-	if signal.ConfluenceHits != "" {
-		builder.WriteString("• *Confluence Details*:\n")
-		confluenceItems := strings.Split(signal.ConfluenceHits, "|")
-		for _, item := range confluenceItems {
-			item = strings.TrimSpace(item)
-			if item != "" {
-				builder.WriteString(fmt.Sprintf("  - ✓ %s\n", item))
+// sendFollowUp broadcasts an update or cancellation as a reply to each copy
+// of the original signal message that was delivered. Copies not sent yet are
+// dropped on a cancellation and rewritten on an update, and copies being sent
+// are waited for, so that no recipient gets a stale signal without its
+// follow-up.
+func (s *signalService) sendFollowUp(ctx context.Context, signal *models.Signal, tracked *models.TrackedSignal, kind string, changed bool) (*models.SignalResponse, error) {
+	if !changed {
+		if response, err := s.duplicateResponse(ctx, signal, tracked, kind); response != nil || err != nil {
+			return response, err
+		}
+	}
+
+	if kind == models.SignalBroadcastCancel {
+		cancelled, err := s.broadcastRepo.CancelPending(ctx, tracked.ID)
+		if err != nil {
+			log.Printf("[SignalService] Failed to cancel pending deliveries of signal %s: %v", tracked.ID, err)
+			return nil, err
+		}
+		if cancelled > 0 {
+			log.Printf("[SignalService] Cancelled %d pending deliveries of signal %s", cancelled, tracked.ID)
+		}
+	}
+
+	original, err := s.broadcastRepo.GetLatest(ctx, tracked.ID, models.SignalBroadcastSignal)
+	if err != nil {
+		log.Printf("[SignalService] Failed to get original broadcast of signal %s: %v", tracked.ID, err)
+		return nil, err
+	}
+	if original != nil {
+		if kind == models.SignalBroadcastUpdate {
+			if err := s.rewritePending(ctx, signal, original); err != nil {
+				return nil, err
 			}
 		}
+		if err := s.waitForSending(ctx, original); err != nil {
+			return nil, err
+		}
 	}
-	builder.WriteString(fmt.Sprintf("• Backtest Win Rate: %.1f%%\n", signal.BacktestWinRate))
-	builder.WriteString(fmt.Sprintf("• Total Trades: %d\n", signal.TotalTrades))
-	builder.WriteString(fmt.Sprintf("• Confidence: %.1f%%\n\n", signal.ConfidenceScore))
 
-	builder.WriteString("💭 *SENTIMENT ANALYSIS*\n")
-	builder.WriteString(fmt.Sprintf("• Overall: %s\n", strings.Title(signal.OverallSentiment)))
-	builder.WriteString(fmt.Sprintf("• Sentiment Score: %.1f%%\n\n", signal.SentimentScore))
+	render := s.renderer(ctx, kind, signal)
+	message, err := render(locale.Default)
+	if err != nil {
+		return nil, err
+	}
 
-	builder.WriteString("📋 *SUMMARY*\n")
-	builder.WriteString(fmt.Sprintf("%s\n\n", signal.AnalysisSummary))
+	broadcast := &models.SignalBroadcast{
+		SignalID: &tracked.ID,
+		Kind:     kind,
+		Ticker:   signal.Ticker,
+		Message:  message,
+	}
+	if kind == models.SignalBroadcastUpdate {
+		broadcast.Revision = tracked.UpdatedAt
+	}
 
-	builder.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
-	builder.WriteString("❓ *Punya pertanyaan tentang signal ini?*\n")
-	builder.WriteString("• Metode perhitungan\n")
-	builder.WriteString("• Analisis teknikal\n")
-	builder.WriteString("• Analisis sentimen\n")
-	builder.WriteString("• Strategi trading\n\n")
-	builder.WriteString("Silahkan tanyakan langsung! 💬")
+	var deliveries []*models.SignalDelivery
+	if original != nil {
+		broadcast.ParentID = &original.ID
+		broadcast.QuotedMessage = original.Message
 
-	return builder.String()
+		received, err := s.broadcastRepo.ListDeliveries(ctx, original.ID, models.SignalDeliverySent)
+		if err != nil {
+			log.Printf("[SignalService] Failed to list recipients of broadcast %s: %v", original.ID, err)
+			return nil, err
+		}
+		for _, delivery := range received {
+			if delivery.WhatsAppMessageID == "" {
+				continue
+			}
+
+			// Follow up in the language of the original and quote the copy the recipient got
+			language := userLanguage(delivery.Language)
+			message, err := render(language)
+			if err != nil {
+				return nil, err
+			}
+			quoted := delivery.Message
+			if quoted == "" {
+				quoted = original.Message
+			}
+
+			deliveries = append(deliveries, &models.SignalDelivery{
+				UserID:           delivery.UserID,
+				Phone:            delivery.Phone,
+				Language:         language,
+				Message:          message,
+				ReplyToMessageID: delivery.WhatsAppMessageID,
+				QuotedMessage:    quoted,
+			})
+		}
+	}
+
+	return s.broadcast(ctx, signal, tracked, broadcast, deliveries)
+}
+
+// rewritePending renders the original signal message again from an updated
+// signal for the recipients it has not been sent to yet
+func (s *signalService) rewritePending(ctx context.Context, signal *models.Signal, original *models.SignalBroadcast) error {
+	pending, err := s.broadcastRepo.ListDeliveries(ctx, original.ID, models.SignalDeliveryPending)
+	if err != nil {
+		log.Printf("[SignalService] Failed to list pending deliveries of broadcast %s: %v", original.ID, err)
+		return err
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	render := s.renderer(ctx, models.SignalBroadcastSignal, signal)
+	for _, delivery := range pending {
+		if delivery.Message, err = render(userLanguage(delivery.Language)); err != nil {
+			return err
+		}
+	}
+
+	rewritten, err := s.broadcastRepo.RewritePending(ctx, pending)
+	if err != nil {
+		log.Printf("[SignalService] Failed to rewrite pending deliveries of broadcast %s: %v", original.ID, err)
+		return err
+	}
+	log.Printf("[SignalService] Rewrote %d pending deliveries of broadcast %s with the update", rewritten, original.ID)
+	return nil
 }
 
-func formatNumber(price int) string {
-	priceStr := fmt.Sprintf("%d", price)
-	result := ""
+// waitForSending waits until no copy of broadcast is being sent, so that the
+// copies listed as sent afterwards include every one that went out without
+// the follow-up. Copies still sending after sendingWait are left out.
+func (s *signalService) waitForSending(ctx context.Context, broadcast *models.SignalBroadcast) error {
+	deadline := time.Now().Add(s.sendingWait)
+	for {
+		sending, err := s.broadcastRepo.ListDeliveries(ctx, broadcast.ID, models.SignalDeliverySending)
+		if err != nil {
+			log.Printf("[SignalService] Failed to list deliveries being sent of broadcast %s: %v", broadcast.ID, err)
+			return err
+		}
+		if len(sending) == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			log.Printf("[SignalService] %d deliveries of broadcast %s are still being sent; following up without them", len(sending), broadcast.ID)
+			return nil
+		}
 
-	for i, digit := range priceStr {
-		if i > 0 && (len(priceStr)-i)%3 == 0 {
-			result += ","
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(s.sendingPoll):
 		}
-		result += string(digit)
+	}
+}
+
+// reject stores a signal that failed validation for review. The signal is
+// rejected even when it cannot be stored, so a sender retrying on errors does
+// not repeat it.
+func (s *signalService) reject(ctx context.Context, signal *models.Signal, violations []models.SignalViolation) error {
+	rejected := &models.RejectedSignal{
+		Ticker:     strings.ToUpper(strings.TrimSpace(signal.Ticker)),
+		LastDate:   signal.LastDate,
+		Operation:  signal.Operation,
+		Payload:    signal,
+		Violations: violations,
 	}
 
-	return result
+	if err := s.rejectedRepo.Create(ctx, rejected); err != nil {
+		log.Printf("[SignalService] Failed to store rejected %s signal: %v", rejected.Ticker, err)
+	} else {
+		log.Printf("[SignalService] Rejected %s signal for %s with %d violations as %s", signal.Operation, rejected.Ticker, len(violations), rejected.ID)
+	}
+
+	return &SignalRejectedError{Rejected: rejected}
 }
 
-func formatCurrency(price float64) string {
-	priceInt := int(price)
-	priceStr := fmt.Sprintf("%d", priceInt)
-	result := ""
+// renderer returns a function rendering signal as a message of kind, rendering
+// each language once
+func (s *signalService) renderer(ctx context.Context, kind string, signal *models.Signal) func(language string) (string, error) {
+	messages := make(map[string]string)
+	return func(language string) (string, error) {
+		if message, exists := messages[language]; exists {
+			return message, nil
+		}
 
-	for i, digit := range priceStr {
-		if i > 0 && (len(priceStr)-i)%3 == 0 {
-			result += ","
+		message, err := s.templateService.Render(ctx, kind, language, signal)
+		if err != nil {
+			log.Printf("[SignalService] Failed to render %s message of %s in %s: %v", kind, signal.Ticker, language, err)
+			return "", fmt.Errorf("failed to render signal message: %w", err)
 		}
-		result += string(digit)
+		messages[language] = message
+		return message, nil
+	}
+}
+
+// userLanguage is the language to write to a user in, locale.Default when unset or unsupported
+func userLanguage(language string) string {
+	if locale.IsSupported(language) {
+		return language
+	}
+	return locale.Default
+}
+
+// duplicateResponse describes the latest broadcast of kind for a signal that
+// was received before, or returns nil when there is none
+func (s *signalService) duplicateResponse(ctx context.Context, signal *models.Signal, tracked *models.TrackedSignal, kind string) (*models.SignalResponse, error) {
+	broadcast, err := s.broadcastRepo.GetLatest(ctx, tracked.ID, kind)
+	if err != nil {
+		log.Printf("[SignalService] Failed to get %s broadcast of signal %s: %v", kind, tracked.ID, err)
+		return nil, err
+	}
+	if broadcast == nil {
+		return nil, nil
+	}
+
+	log.Printf("[SignalService] Ignoring duplicate %s signal for %s; already broadcast as %s", signal.Operation, signal.Ticker, broadcast.ID)
+	return &models.SignalResponse{
+		SignalID:    tracked.ID,
+		BroadcastID: broadcast.ID,
+		Operation:   signal.Operation,
+		Duplicate:   true,
+		Ticker:      signal.Ticker,
+		Recipients:  broadcast.Total,
+		Status:      broadcast.Status,
+		Timestamp:   time.Now(),
+	}, nil
+}
+
+// broadcast stores a broadcast with its deliveries and hands them to the
+// dispatcher. A broadcast stored meanwhile by a concurrent post of the same
+// signal is returned as a duplicate instead.
+func (s *signalService) broadcast(ctx context.Context, signal *models.Signal, tracked *models.TrackedSignal, broadcast *models.SignalBroadcast, deliveries []*models.SignalDelivery) (*models.SignalResponse, error) {
+	broadcast.Status = models.SignalBroadcastQueued
+	if len(deliveries) == 0 {
+		log.Printf("[SignalService] No recipients for %s %s", signal.Ticker, broadcast.Kind)
+		now := time.Now()
+		broadcast.Status = models.SignalBroadcastCompleted
+		broadcast.FinishedAt = &now
+	}
+
+	created, err := s.broadcastRepo.Create(ctx, broadcast, deliveries)
+	if err != nil {
+		log.Printf("[SignalService] Failed to store broadcast for %s: %v", signal.Ticker, err)
+		return nil, fmt.Errorf("failed to store signal broadcast: %w", err)
+	}
+	if !created {
+		return s.duplicateResponse(ctx, signal, tracked, broadcast.Kind)
+	}
+
+	if len(deliveries) > 0 {
+		s.dispatcher.Dispatch(broadcast, deliveries)
+		log.Printf("[SignalService] Queued %s broadcast %s of %s to %d users", broadcast.Kind, broadcast.ID, signal.Ticker, len(deliveries))
+	}
+
+	return &models.SignalResponse{
+		SignalID:    tracked.ID,
+		BroadcastID: broadcast.ID,
+		Operation:   signal.Operation,
+		Ticker:      signal.Ticker,
+		Recipients:  len(deliveries),
+		Status:      broadcast.Status,
+		Timestamp:   time.Now(),
+	}, nil
+}
+
+func (s *signalService) GetBroadcast(ctx context.Context, id uuid.UUID) (*models.SignalBroadcast, error) {
+	broadcast, err := s.broadcastRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("[SignalService] Failed to get broadcast %s: %v", id, err)
+		return nil, err
+	}
+
+	if broadcast == nil {
+		return nil, ErrSignalBroadcastNotFound
+	}
+
+	return broadcast, nil
+}
+
+func (s *signalService) ListDeliveries(ctx context.Context, broadcastID uuid.UUID, status string) ([]*models.SignalDelivery, error) {
+	switch status {
+	case "", models.SignalDeliveryPending, models.SignalDeliverySending, models.SignalDeliverySent, models.SignalDeliveryFailed, models.SignalDeliveryCancelled:
+	default:
+		return nil, ErrInvalidDeliveryStatus
+	}
+
+	if _, err := s.GetBroadcast(ctx, broadcastID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.broadcastRepo.ListDeliveries(ctx, broadcastID, status)
+	if err != nil {
+		log.Printf("[SignalService] Failed to list deliveries of broadcast %s: %v", broadcastID, err)
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (s *signalService) ListRejected(ctx context.Context, filter *models.RejectedSignalFilter, limit int) ([]*models.RejectedSignal, error) {
+	if filter.Rule != "" && !isSignalRule(filter.Rule) {
+		return nil, ErrInvalidSignalRule
+	}
+
+	if limit <= 0 {
+		limit = defaultTrackedSignalsLimit
+	}
+	limit = min(limit, maxTrackedSignalsLimit)
+	filter.Ticker = strings.ToUpper(strings.TrimSpace(filter.Ticker))
+
+	rejected, err := s.rejectedRepo.List(ctx, filter, limit)
+	if err != nil {
+		log.Printf("[SignalService] Failed to list rejected signals: %v", err)
+		return nil, err
 	}
 
-	return result
+	return rejected, nil
 }
@@ -1,199 +1,435 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/testutils/mocks"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
-// TestFormatNumber
-// Summary: Test number formatting for Indonesian currency
-// Purpose: Validate that numbers are properly formatted with comma separators
-func TestFormatNumber(t *testing.T) {
+// TestSignalService_ProcessSignal
+// Summary: Test queuing a signal broadcast for subscribers
+// Purpose: Validate that a broadcast with a delivery per matched recipient, rendered in the recipient's language, is stored and dispatched, and that a signal without recipients is stored as completed
+func TestSignalService_ProcessSignal(t *testing.T) {
+	signal := &models.Signal{Ticker: "BBCA", OverallSentiment: "bullish", ConfluenceScore: 8}
+	broadcastID := uuid.New()
+	tracked := &models.TrackedSignal{ID: uuid.New(), Ticker: "BBCA"}
+
 	tests := []struct {
-		name     string
-		input    int
-		expected string
+		name           string
+		recipients     []*models.User
+		expectedStatus string
+		expectDispatch bool
 	}{
 		{
-			name:     "Single digit",
-			input:    5,
-			expected: "5",
+			name: "Recipients are queued",
+			recipients: []*models.User{
+				{ID: uuid.New(), Name: "Budi", Phone: "6281234567890", Language: "en"},
+				{ID: uuid.New(), Name: "Siti", Phone: "6281111111111"},
+			},
+			expectedStatus: models.SignalBroadcastQueued,
+			expectDispatch: true,
 		},
 		{
-			name:     "Three digits",
-			input:    123,
-			expected: "123",
+			name:           "No recipients",
+			recipients:     []*models.User{},
+			expectedStatus: models.SignalBroadcastCompleted,
 		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockOutcomeService := mocks.NewMockSignalOutcomeService(t)
+			mockOutcomeService.EXPECT().Track(mock.Anything, signal).Return(tracked, true, nil)
+			mockSubscriptionService := mocks.NewMockSubscriptionService(t)
+			mockSubscriptionService.EXPECT().FindRecipients(mock.Anything, signal).Return(tt.recipients, nil)
+
+			mockBroadcastRepo := mocks.NewMockSignalBroadcastRepository(t)
+			mockBroadcastRepo.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).
+				Run(func(ctx context.Context, broadcast *models.SignalBroadcast, deliveries []*models.SignalDelivery) {
+					assert.Equal(t, "BBCA", broadcast.Ticker)
+					assert.Equal(t, tracked.ID, *broadcast.SignalID)
+					assert.Equal(t, models.SignalBroadcastSignal, broadcast.Kind)
+					assert.Contains(t, broadcast.Message, "🚀 *SIGNAL: BBCA*")
+					assert.Equal(t, tt.expectedStatus, broadcast.Status)
+					assert.Len(t, deliveries, len(tt.recipients))
+					for i, delivery := range deliveries {
+						assert.Equal(t, tt.recipients[i].ID, *delivery.UserID)
+						assert.Equal(t, tt.recipients[i].Phone, delivery.Phone)
+					}
+					if len(deliveries) == 2 {
+						assert.Equal(t, "en", deliveries[0].Language)
+						assert.Contains(t, deliveries[0].Message, "🚀 *SIGNAL ALERT: BBCA*")
+						assert.Equal(t, "id", deliveries[1].Language)
+						assert.Equal(t, broadcast.Message, deliveries[1].Message)
+					}
+					broadcast.ID = broadcastID
+				}).Return(true, nil)
+
+			mockDispatcher := mocks.NewMockSignalDispatcher(t)
+			if tt.expectDispatch {
+				mockDispatcher.EXPECT().Dispatch(mock.Anything, mock.Anything).Return()
+			}
+
+			mockTemplateRepo := mocks.NewMockSignalTemplateRepository(t)
+			mockTemplateRepo.EXPECT().Get(mock.Anything, models.SignalBroadcastSignal, mock.Anything).Return(nil, nil)
+
+			mockValidator := mocks.NewMockSignalValidator(t)
+			mockValidator.EXPECT().Validate(signal).Return(nil)
+
+			service := NewSignalService(mockBroadcastRepo, mocks.NewMockRejectedSignalRepository(t), mockValidator, mockSubscriptionService, mockOutcomeService, NewSignalTemplateService(mockTemplateRepo), mockDispatcher)
+			response, err := service.ProcessSignal(context.Background(), signal)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tracked.ID, response.SignalID)
+			assert.Equal(t, broadcastID, response.BroadcastID)
+			assert.Equal(t, "BBCA", response.Ticker)
+			assert.Equal(t, len(tt.recipients), response.Recipients)
+			assert.Equal(t, tt.expectedStatus, response.Status)
+			assert.Equal(t, models.SignalOperationNew, response.Operation)
+			assert.False(t, response.Duplicate)
+		})
+	}
+}
+
+// TestSignalService_ProcessSignalFollowUp
+// Summary: Test duplicate signals, updates and cancellations
+// Purpose: Validate that a repeated or concurrent post returns the earlier broadcast without sending, that a follow-up replies to each delivered copy of the original message in the language it was sent in, and that copies not sent yet are cancelled or rewritten
+func TestSignalService_ProcessSignalFollowUp(t *testing.T) {
+	updatedAt := time.Date(2025, 8, 11, 2, 30, 0, 0, time.UTC)
+	tracked := &models.TrackedSignal{ID: uuid.New(), Ticker: "BBCA", UpdatedAt: &updatedAt}
+	original := &models.SignalBroadcast{ID: uuid.New(), Kind: models.SignalBroadcastSignal, Message: "🚀 *SIGNAL: BBCA*", Status: models.SignalBroadcastCompleted, Total: 3}
+	previousCancel := &models.SignalBroadcast{ID: uuid.New(), Kind: models.SignalBroadcastCancel, Status: models.SignalBroadcastSending, Total: 2}
+	userID := uuid.New()
+	received := []*models.SignalDelivery{
+		{UserID: &userID, Phone: "6281234567890", Language: "en", Message: "🚀 *SIGNAL ALERT: BBCA*", Status: models.SignalDeliverySent, WhatsAppMessageID: "MSG1"},
+		{Phone: "6281111111111", Status: models.SignalDeliverySent},
+	}
+	waiting := []*models.SignalDelivery{
+		{ID: uuid.New(), Phone: "6282222222222", Language: "en", Message: "🚀 *SIGNAL ALERT: BBCA* (stale)", Status: models.SignalDeliveryPending},
+	}
+	concurrentUpdate := &models.SignalBroadcast{ID: uuid.New(), Kind: models.SignalBroadcastUpdate, Status: models.SignalBroadcastQueued, Total: 1}
+
+	tests := []struct {
+		name              string
+		operation         string
+		changed           bool
+		latest            *models.SignalBroadcast
+		concurrent        *models.SignalBroadcast
+		expectedKind      string
+		expectedMessage   string
+		expectedReply     string
+		expectedDuplicate bool
+		expectedBroadcast uuid.UUID
+	}{
 		{
-			name:     "Four digits",
-			input:    1234,
-			expected: "1,234",
+			name:              "Duplicate signal returns the original broadcast",
+			operation:         models.SignalOperationNew,
+			latest:            original,
+			expectedDuplicate: true,
+			expectedBroadcast: original.ID,
 		},
 		{
-			name:     "Five digits",
-			input:    12345,
-			expected: "12,345",
+			name:            "Update replies to delivered recipients",
+			operation:       models.SignalOperationUpdate,
+			changed:         true,
+			expectedKind:    models.SignalBroadcastUpdate,
+			expectedMessage: "PEMBARUAN SIGNAL: BBCA",
+			expectedReply:   "SIGNAL UPDATE: BBCA",
 		},
 		{
-			name:     "Seven digits",
-			input:    1234567,
-			expected: "1,234,567",
+			name:            "Cancel replies to delivered recipients",
+			operation:       models.SignalOperationCancel,
+			changed:         true,
+			expectedKind:    models.SignalBroadcastCancel,
+			expectedMessage: "SIGNAL DIBATALKAN: BBCA",
+			expectedReply:   "SIGNAL CANCELLED: BBCA",
 		},
 		{
-			name:     "Large number (stock price)",
-			input:    9420,
-			expected: "9,420",
+			name:              "Repeated cancel returns the earlier follow-up",
+			operation:         models.SignalOperationCancel,
+			latest:            previousCancel,
+			expectedDuplicate: true,
+			expectedBroadcast: previousCancel.ID,
 		},
 		{
-			name:     "Zero value",
-			input:    0,
-			expected: "0",
+			name:              "Concurrent update returns the broadcast stored meanwhile",
+			operation:         models.SignalOperationUpdate,
+			changed:           true,
+			concurrent:        concurrentUpdate,
+			expectedKind:      models.SignalBroadcastUpdate,
+			expectedMessage:   "PEMBARUAN SIGNAL: BBCA",
+			expectedReply:     "SIGNAL UPDATE: BBCA",
+			expectedDuplicate: true,
+			expectedBroadcast: concurrentUpdate.ID,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := formatNumber(tt.input)
-			assert.Equal(t, tt.expected, result)
+			signal := &models.Signal{Operation: tt.operation, Ticker: "BBCA", LastDate: "2025-08-10", EntryPrice: 9600, Stop: 9300, Target: 9900, RiskReward: 1, Note: "Gap up at open"}
+			broadcastID := uuid.New()
+
+			mockOutcomeService := mocks.NewMockSignalOutcomeService(t)
+			switch tt.operation {
+			case models.SignalOperationNew:
+				mockOutcomeService.EXPECT().Track(mock.Anything, signal).Return(tracked, false, nil)
+			case models.SignalOperationUpdate:
+				mockOutcomeService.EXPECT().Amend(mock.Anything, signal).Return(tracked, tt.changed, nil)
+			case models.SignalOperationCancel:
+				mockOutcomeService.EXPECT().Cancel(mock.Anything, signal).Return(tracked, tt.changed, nil)
+			}
+
+			mockBroadcastRepo := mocks.NewMockSignalBroadcastRepository(t)
+			mockDispatcher := mocks.NewMockSignalDispatcher(t)
+			mockTemplateRepo := mocks.NewMockSignalTemplateRepository(t)
+			if tt.latest != nil {
+				mockBroadcastRepo.EXPECT().GetLatest(mock.Anything, tracked.ID, tt.latest.Kind).Return(tt.latest, nil)
+			} else {
+				mockBroadcastRepo.EXPECT().GetLatest(mock.Anything, tracked.ID, models.SignalBroadcastSignal).Return(original, nil)
+				switch tt.expectedKind {
+				case models.SignalBroadcastCancel:
+					mockBroadcastRepo.EXPECT().CancelPending(mock.Anything, tracked.ID).Return(len(waiting), nil)
+				case models.SignalBroadcastUpdate:
+					mockBroadcastRepo.EXPECT().ListDeliveries(mock.Anything, original.ID, models.SignalDeliveryPending).Return(waiting, nil)
+					mockBroadcastRepo.EXPECT().RewritePending(mock.Anything, waiting).
+						Run(func(ctx context.Context, deliveries []*models.SignalDelivery) {
+							require.Len(t, deliveries, 1)
+							assert.Contains(t, deliveries[0].Message, "🚀 *SIGNAL ALERT: BBCA*")
+							assert.NotContains(t, deliveries[0].Message, "stale")
+						}).Return(len(waiting), nil)
+					mockTemplateRepo.EXPECT().Get(mock.Anything, models.SignalBroadcastSignal, "en").Return(nil, nil)
+				}
+				mockBroadcastRepo.EXPECT().ListDeliveries(mock.Anything, original.ID, models.SignalDeliverySending).Return([]*models.SignalDelivery{}, nil)
+				mockBroadcastRepo.EXPECT().ListDeliveries(mock.Anything, original.ID, models.SignalDeliverySent).Return(received, nil)
+				mockBroadcastRepo.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).
+					Run(func(ctx context.Context, broadcast *models.SignalBroadcast, deliveries []*models.SignalDelivery) {
+						assert.Equal(t, tt.expectedKind, broadcast.Kind)
+						assert.Equal(t, original.ID, *broadcast.ParentID)
+						assert.Equal(t, original.Message, broadcast.QuotedMessage)
+						assert.Contains(t, broadcast.Message, tt.expectedMessage)
+						assert.Contains(t, broadcast.Message, "Gap up at open")
+						if tt.expectedKind == models.SignalBroadcastUpdate {
+							assert.Equal(t, tracked.UpdatedAt, broadcast.Revision)
+						} else {
+							assert.Nil(t, broadcast.Revision)
+						}
+						require.Len(t, deliveries, 1)
+						assert.Equal(t, userID, *deliveries[0].UserID)
+						assert.Equal(t, "MSG1", deliveries[0].ReplyToMessageID)
+						assert.Equal(t, "en", deliveries[0].Language)
+						assert.Contains(t, deliveries[0].Message, tt.expectedReply)
+						assert.Equal(t, received[0].Message, deliveries[0].QuotedMessage)
+						broadcast.ID = broadcastID
+					}).Return(tt.concurrent == nil, nil)
+				mockTemplateRepo.EXPECT().Get(mock.Anything, tt.expectedKind, mock.Anything).Return(nil, nil)
+				if tt.concurrent != nil {
+					mockBroadcastRepo.EXPECT().GetLatest(mock.Anything, tracked.ID, tt.concurrent.Kind).Return(tt.concurrent, nil)
+				} else {
+					mockDispatcher.EXPECT().Dispatch(mock.Anything, mock.Anything).Return()
+				}
+			}
+
+			mockValidator := mocks.NewMockSignalValidator(t)
+			mockValidator.EXPECT().Validate(signal).Return(nil)
+
+			service := NewSignalService(mockBroadcastRepo, mocks.NewMockRejectedSignalRepository(t), mockValidator, mocks.NewMockSubscriptionService(t), mockOutcomeService, NewSignalTemplateService(mockTemplateRepo), mockDispatcher)
+			response, err := service.ProcessSignal(context.Background(), signal)
+
+			require.NoError(t, err)
+			assert.Equal(t, tracked.ID, response.SignalID)
+			assert.Equal(t, tt.operation, response.Operation)
+			assert.Equal(t, tt.expectedDuplicate, response.Duplicate)
+			if tt.expectedDuplicate {
+				earlier := tt.latest
+				if earlier == nil {
+					earlier = tt.concurrent
+				}
+				assert.Equal(t, tt.expectedBroadcast, response.BroadcastID)
+				assert.Equal(t, earlier.Total, response.Recipients)
+			} else {
+				assert.Equal(t, broadcastID, response.BroadcastID)
+				assert.Equal(t, 1, response.Recipients)
+			}
 		})
 	}
 }
 
-// TestFormatCurrency
-// Summary: Test currency formatting for Indonesian Rupiah
-// Purpose: Validate that float prices are properly formatted with comma separators
-func TestFormatCurrency(t *testing.T) {
+// TestSignalService_ProcessSignalFollowUpWhileSending
+// Summary: Test a cancellation arriving while the original is being sent
+// Purpose: Validate that a follow-up waits for the copies of the original being sent and replies to them once they are recorded as sent
+func TestSignalService_ProcessSignalFollowUpWhileSending(t *testing.T) {
+	tracked := &models.TrackedSignal{ID: uuid.New(), Ticker: "BBCA"}
+	original := &models.SignalBroadcast{ID: uuid.New(), Kind: models.SignalBroadcastSignal, Message: "🚀 *SIGNAL: BBCA*", Status: models.SignalBroadcastSending, Total: 1}
+	inFlight := &models.SignalDelivery{ID: uuid.New(), BroadcastID: original.ID, Phone: "6281234567890", Language: "id", Message: original.Message, Status: models.SignalDeliverySending}
+	delivered := *inFlight
+	delivered.Status, delivered.WhatsAppMessageID = models.SignalDeliverySent, "MSG1"
+	signal := &models.Signal{Operation: models.SignalOperationCancel, Ticker: "BBCA", LastDate: "2025-08-10", Note: "Setup invalidated"}
+
+	mockOutcomeService := mocks.NewMockSignalOutcomeService(t)
+	mockOutcomeService.EXPECT().Cancel(mock.Anything, signal).Return(tracked, true, nil)
+
+	mockBroadcastRepo := mocks.NewMockSignalBroadcastRepository(t)
+	mockBroadcastRepo.EXPECT().CancelPending(mock.Anything, tracked.ID).Return(0, nil)
+	mockBroadcastRepo.EXPECT().GetLatest(mock.Anything, tracked.ID, models.SignalBroadcastSignal).Return(original, nil)
+	mockBroadcastRepo.EXPECT().ListDeliveries(mock.Anything, original.ID, models.SignalDeliverySending).Return([]*models.SignalDelivery{inFlight}, nil).Twice()
+	mockBroadcastRepo.EXPECT().ListDeliveries(mock.Anything, original.ID, models.SignalDeliverySending).Return([]*models.SignalDelivery{}, nil).Once()
+	mockBroadcastRepo.EXPECT().ListDeliveries(mock.Anything, original.ID, models.SignalDeliverySent).Return([]*models.SignalDelivery{&delivered}, nil)
+	mockBroadcastRepo.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, broadcast *models.SignalBroadcast, deliveries []*models.SignalDelivery) {
+			assert.Equal(t, models.SignalBroadcastCancel, broadcast.Kind)
+			require.Len(t, deliveries, 1)
+			assert.Equal(t, "6281234567890", deliveries[0].Phone)
+			assert.Equal(t, "MSG1", deliveries[0].ReplyToMessageID)
+		}).Return(true, nil)
+
+	mockTemplateRepo := mocks.NewMockSignalTemplateRepository(t)
+	mockTemplateRepo.EXPECT().Get(mock.Anything, models.SignalBroadcastCancel, mock.Anything).Return(nil, nil)
+	mockDispatcher := mocks.NewMockSignalDispatcher(t)
+	mockDispatcher.EXPECT().Dispatch(mock.Anything, mock.Anything).Return()
+	mockValidator := mocks.NewMockSignalValidator(t)
+	mockValidator.EXPECT().Validate(signal).Return(nil)
+
+	service := NewSignalService(mockBroadcastRepo, mocks.NewMockRejectedSignalRepository(t), mockValidator, mocks.NewMockSubscriptionService(t), mockOutcomeService, NewSignalTemplateService(mockTemplateRepo), mockDispatcher)
+	service.(*signalService).sendingPoll = time.Millisecond
+	response, err := service.ProcessSignal(context.Background(), signal)
+
+	require.NoError(t, err)
+	assert.False(t, response.Duplicate)
+	assert.Equal(t, 1, response.Recipients)
+}
+
+// TestSignalService_ProcessSignalRejected
+// Summary: Test rejecting signals that fail validation
+// Purpose: Validate that a rejected signal is stored with its violations and nothing is tracked or sent, and that it is rejected even when it cannot be stored
+func TestSignalService_ProcessSignalRejected(t *testing.T) {
+	violations := []models.SignalViolation{
+		{Rule: models.SignalRuleLevels, Field: "stop", Message: "stop 9700 must be below entry_price 9655 when the target 9900 is above it"},
+		{Rule: models.SignalRuleTicker, Field: "ticker", Message: "ticker BBCA1 does not match ^[A-Z]{4}$"},
+	}
+
 	tests := []struct {
-		name     string
-		input    float64
-		expected string
+		name       string
+		storeErr   error
+		expectedID bool
 	}{
 		{
-			name:     "Small price",
-			input:    123.0,
-			expected: "123",
-		},
-		{
-			name:     "Four digit price",
-			input:    1234.5,
-			expected: "1,234",
+			name:       "Rejected signal is stored for review",
+			expectedID: true,
 		},
 		{
-			name:     "Stock price with decimals",
-			input:    9180.75,
-			expected: "9,180",
-		},
-		{
-			name:     "Large price",
-			input:    123456.99,
-			expected: "123,456",
-		},
-		{
-			name:     "Zero value",
-			input:    0.0,
-			expected: "0",
-		},
-		{
-			name:     "Fractional value rounds down",
-			input:    999.99,
-			expected: "999",
+			name:     "Rejected even when it cannot be stored",
+			storeErr: errors.New("connection refused"),
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := formatCurrency(tt.input)
-			assert.Equal(t, tt.expected, result)
+			signal := &models.Signal{Ticker: " bbca1", LastDate: "2025-08-10", EntryPrice: 9655, Stop: 9700, Target: 9900}
+			rejectedID := uuid.New()
+
+			mockValidator := mocks.NewMockSignalValidator(t)
+			mockValidator.EXPECT().Validate(signal).Return(violations)
+
+			mockRejectedRepo := mocks.NewMockRejectedSignalRepository(t)
+			mockRejectedRepo.EXPECT().Create(mock.Anything, mock.Anything).
+				Run(func(ctx context.Context, rejected *models.RejectedSignal) {
+					assert.Equal(t, "BBCA1", rejected.Ticker)
+					assert.Equal(t, "2025-08-10", rejected.LastDate)
+					assert.Equal(t, models.SignalOperationNew, rejected.Operation)
+					assert.Same(t, signal, rejected.Payload)
+					assert.Equal(t, violations, rejected.Violations)
+					if tt.storeErr == nil {
+						rejected.ID = rejectedID
+					}
+				}).Return(tt.storeErr)
+
+			service := NewSignalService(mocks.NewMockSignalBroadcastRepository(t), mockRejectedRepo, mockValidator, mocks.NewMockSubscriptionService(t),
+				mocks.NewMockSignalOutcomeService(t), NewSignalTemplateService(mocks.NewMockSignalTemplateRepository(t)), mocks.NewMockSignalDispatcher(t))
+			response, err := service.ProcessSignal(context.Background(), signal)
+
+			assert.Nil(t, response)
+			assert.ErrorIs(t, err, ErrSignalRejected)
+			var rejectedErr *SignalRejectedError
+			require.ErrorAs(t, err, &rejectedErr)
+			assert.Equal(t, violations, rejectedErr.Rejected.Violations)
+			assert.Equal(t, "signal rejected: 2 violations", err.Error())
+			if tt.expectedID {
+				assert.Equal(t, rejectedID, rejectedErr.Rejected.ID)
+			} else {
+				assert.Equal(t, uuid.Nil, rejectedErr.Rejected.ID)
+			}
 		})
 	}
 }
 
-// TestFormatSignalMessage
-// Summary: Test signal message formatting includes entry price
-// Purpose: Validate that formatted signal messages contain entry price information
-func TestFormatSignalMessage(t *testing.T) {
+// TestSignalService_ListDeliveries
+// Summary: Test listing the deliveries of a broadcast
+// Purpose: Validate status filter checking and the not-found error for unknown broadcasts
+func TestSignalService_ListDeliveries(t *testing.T) {
+	broadcastID := uuid.New()
+
 	tests := []struct {
-		name             string
-		signal           *models.Signal
-		expectedContains []string
+		name          string
+		status        string
+		broadcast     *models.SignalBroadcast
+		expectLookup  bool
+		expectList    bool
+		expectedError error
 	}{
 		{
-			name: "Bullish signal with entry price",
-			signal: &models.Signal{
-				Ticker:           "BBCA",
-				LastDate:         "2025-08-10",
-				LastClose:        9420,
-				EntryPrice:       9655,
-				EntryGapPercent:  2.5,
-				Stop:             9180.0,
-				Target:           9720.0,
-				RiskReward:       1.85,
-				BacktestWinRate:  68.5,
-				TotalTrades:      147,
-				ConfluenceScore:  8.2,
-				ConfluenceHits:   "Strong bullish signals",
-				OverallSentiment: "bullish",
-				ConfidenceScore:  85.3,
-				SentimentScore:   72.8,
-				AnalysisSummary:  "Strong technical confluence with bullish sentiment",
-			},
-			expectedContains: []string{
-				"🚀 *SIGNAL ALERT: BBCA*",
-				"• Last Price: Rp 9,420",
-				"• *Entry Price*: Rp 9,655",
-				"• Entry Gap: +2.5%",
-				"• *Stop Loss*: Rp 9,180",
-				"• *Target*: Rp 9,720",
-				"• Risk/Reward: 1:1.85",
-			},
+			name:         "All deliveries",
+			broadcast:    &models.SignalBroadcast{ID: broadcastID},
+			expectLookup: true,
+			expectList:   true,
 		},
 		{
-			name: "Bearish signal with entry price",
-			signal: &models.Signal{
-				Ticker:           "TLKM",
-				LastDate:         "2025-08-10",
-				LastClose:        3500,
-				EntryPrice:       3437,
-				EntryGapPercent:  -1.8,
-				Stop:             3600.0,
-				Target:           3300.0,
-				RiskReward:       1.67,
-				BacktestWinRate:  72.1,
-				TotalTrades:      89,
-				ConfluenceScore:  7.8,
-				ConfluenceHits:   "Bearish divergence signals",
-				OverallSentiment: "bearish",
-				ConfidenceScore:  78.9,
-				SentimentScore:   45.2,
-				AnalysisSummary:  "Bearish technical indicators with high volume",
-			},
-			expectedContains: []string{
-				"🚀 *SIGNAL ALERT: TLKM*",
-				"• Last Price: Rp 3,500",
-				"• *Entry Price*: Rp 3,437",
-				"• Entry Gap: -1.8%",
-				"• *Stop Loss*: Rp 3,600",
-				"• *Target*: Rp 3,300",
-			},
+			name:         "Failed deliveries",
+			status:       models.SignalDeliveryFailed,
+			broadcast:    &models.SignalBroadcast{ID: broadcastID},
+			expectLookup: true,
+			expectList:   true,
+		},
+		{
+			name:          "Unknown status",
+			status:        "delivered",
+			expectedError: ErrInvalidDeliveryStatus,
+		},
+		{
+			name:          "Unknown broadcast",
+			expectLookup:  true,
+			expectedError: ErrSignalBroadcastNotFound,
 		},
 	}
 
-	service := &signalService{}
-
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := service.FormatSignalMessage(tt.signal)
-
-			for _, expected := range tt.expectedContains {
-				assert.Contains(t, result, expected, "Message should contain: %s", expected)
+			mockBroadcastRepo := mocks.NewMockSignalBroadcastRepository(t)
+			if tt.expectLookup {
+				mockBroadcastRepo.EXPECT().GetByID(mock.Anything, broadcastID).Return(tt.broadcast, nil)
+			}
+			deliveries := []*models.SignalDelivery{{BroadcastID: broadcastID, Phone: "6281234567890", Status: models.SignalDeliveryFailed}}
+			if tt.expectList {
+				mockBroadcastRepo.EXPECT().ListDeliveries(mock.Anything, broadcastID, tt.status).Return(deliveries, nil)
 			}
 
-			assert.Contains(t, result, "📊 *TRADING DETAILS*", "Should contain trading details section")
-			assert.Contains(t, result, "🎯 *TRADING LEVELS*", "Should contain trading levels section")
-			assert.Contains(t, result, "📈 *ANALYSIS METRICS*", "Should contain analysis metrics section")
+			service := NewSignalService(mockBroadcastRepo, mocks.NewMockRejectedSignalRepository(t), mocks.NewMockSignalValidator(t), mocks.NewMockSubscriptionService(t), mocks.NewMockSignalOutcomeService(t), NewSignalTemplateService(mocks.NewMockSignalTemplateRepository(t)), mocks.NewMockSignalDispatcher(t))
+			result, err := service.ListDeliveries(context.Background(), broadcastID, tt.status)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, deliveries, result)
 		})
 	}
 }
@@ -0,0 +1,473 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/repositories"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/pkg/locale"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUnknownSignalTemplate = errors.New("template kind must be signal, update or cancel, and language id or en")
+	ErrInvalidSignalTemplate = errors.New("invalid signal template")
+)
+
+// SignalTemplateService renders signal messages from text/template templates,
+// one per kind of broadcast and language. Admins may replace the built-in
+// templates; a replaced template that fails for a signal falls back to the
+// built-in one so the signal still goes out.
+//
+// Templates see the fields of models.Signal plus Direction and
+// ConfluenceItems (ConfluenceHits split on '|'), and these functions, which
+// format in the template's language:
+//
+//	number x n       x with n decimals: 1.234,5 / 1,234.5
+//	price x          a price, with decimals only when it has a fraction
+//	rupiah x         price with the currency: Rp 9.655
+//	percent x n      68,5%
+//	signedPercent x n +2,5%
+//	capitalize s     first letter in upper case
+//	upper s          all letters in upper case
+//
+// The number functions fail the template when x is not a number.
+type SignalTemplateService interface {
+	// List returns the current template of every kind and language
+	List(ctx context.Context) ([]*models.SignalTemplate, error)
+	Get(ctx context.Context, kind, language string) (*models.SignalTemplate, error)
+	// Save replaces a template after checking that it renders the sample signal
+	Save(ctx context.Context, kind, language, body string, updatedBy *uuid.UUID) (*models.SignalTemplate, error)
+	// Reset restores the built-in template
+	Reset(ctx context.Context, kind, language string) (*models.SignalTemplate, error)
+	// Preview renders a template, or a draft body, without storing anything
+	Preview(ctx context.Context, req *models.SignalTemplatePreviewRequest) (*models.SignalTemplatePreview, error)
+	// Render formats signal as a message of kind in language; an unsupported
+	// language uses locale.Default
+	Render(ctx context.Context, kind, language string, signal *models.Signal) (string, error)
+}
+
+type signalTemplateService struct {
+	templateRepo repositories.SignalTemplateRepository
+}
+
+func NewSignalTemplateService(templateRepo repositories.SignalTemplateRepository) SignalTemplateService {
+	return &signalTemplateService{templateRepo: templateRepo}
+}
+
+// signalTemplateKinds are the kinds of signal message, in listing order
+var signalTemplateKinds = []string{models.SignalBroadcastSignal, models.SignalBroadcastUpdate, models.SignalBroadcastCancel}
+
+func (s *signalTemplateService) List(ctx context.Context) ([]*models.SignalTemplate, error) {
+	stored, err := s.templateRepo.List(ctx)
+	if err != nil {
+		log.Printf("[SignalTemplateService] Failed to list templates: %v", err)
+		return nil, err
+	}
+
+	replaced := make(map[string]*models.SignalTemplate, len(stored))
+	for _, tmpl := range stored {
+		replaced[tmpl.Kind+"/"+tmpl.Language] = tmpl
+	}
+
+	var templates []*models.SignalTemplate
+	for _, kind := range signalTemplateKinds {
+		for _, language := range locale.Languages() {
+			if tmpl, exists := replaced[kind+"/"+language]; exists {
+				templates = append(templates, tmpl)
+				continue
+			}
+			templates = append(templates, builtInSignalTemplate(kind, language))
+		}
+	}
+
+	return templates, nil
+}
+
+func (s *signalTemplateService) Get(ctx context.Context, kind, language string) (*models.SignalTemplate, error) {
+	if !isSignalTemplate(kind, language) {
+		return nil, ErrUnknownSignalTemplate
+	}
+
+	tmpl, err := s.templateRepo.Get(ctx, kind, language)
+	if err != nil {
+		log.Printf("[SignalTemplateService] Failed to get template %s/%s: %v", kind, language, err)
+		return nil, err
+	}
+	if tmpl == nil {
+		return builtInSignalTemplate(kind, language), nil
+	}
+
+	return tmpl, nil
+}
+
+func (s *signalTemplateService) Save(ctx context.Context, kind, language, body string, updatedBy *uuid.UUID) (*models.SignalTemplate, error) {
+	if !isSignalTemplate(kind, language) {
+		return nil, ErrUnknownSignalTemplate
+	}
+
+	sample := sampleSignal(kind)
+	if _, err := renderSignalTemplate(kind, language, body, sample); err != nil {
+		return nil, err
+	}
+
+	tmpl := &models.SignalTemplate{Kind: kind, Language: language, Body: body, UpdatedBy: updatedBy}
+	if err := s.templateRepo.Save(ctx, tmpl); err != nil {
+		log.Printf("[SignalTemplateService] Failed to save template %s/%s: %v", kind, language, err)
+		return nil, err
+	}
+
+	log.Printf("[SignalTemplateService] Template %s/%s replaced", kind, language)
+	return tmpl, nil
+}
+
+func (s *signalTemplateService) Reset(ctx context.Context, kind, language string) (*models.SignalTemplate, error) {
+	if !isSignalTemplate(kind, language) {
+		return nil, ErrUnknownSignalTemplate
+	}
+
+	if _, err := s.templateRepo.Delete(ctx, kind, language); err != nil {
+		log.Printf("[SignalTemplateService] Failed to reset template %s/%s: %v", kind, language, err)
+		return nil, err
+	}
+
+	log.Printf("[SignalTemplateService] Template %s/%s reset to the built-in template", kind, language)
+	return builtInSignalTemplate(kind, language), nil
+}
+
+func (s *signalTemplateService) Preview(ctx context.Context, req *models.SignalTemplatePreviewRequest) (*models.SignalTemplatePreview, error) {
+	if !isSignalTemplate(req.Kind, req.Language) {
+		return nil, ErrUnknownSignalTemplate
+	}
+
+	body := req.Body
+	if body == "" {
+		tmpl, err := s.Get(ctx, req.Kind, req.Language)
+		if err != nil {
+			return nil, err
+		}
+		body = tmpl.Body
+	}
+
+	signal := req.Signal
+	if signal == nil {
+		signal = sampleSignal(req.Kind)
+	}
+
+	message, err := renderSignalTemplate(req.Kind, req.Language, body, signal)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SignalTemplatePreview{Kind: req.Kind, Language: req.Language, Message: message}, nil
+}
+
+func (s *signalTemplateService) Render(ctx context.Context, kind, language string, signal *models.Signal) (string, error) {
+	if !locale.IsSupported(language) {
+		language = locale.Default
+	}
+	if !isSignalTemplate(kind, language) {
+		return "", ErrUnknownSignalTemplate
+	}
+
+	tmpl, err := s.templateRepo.Get(ctx, kind, language)
+	if err != nil {
+		log.Printf("[SignalTemplateService] Failed to get template %s/%s, using the built-in template: %v", kind, language, err)
+	}
+	if tmpl != nil {
+		message, err := renderSignalTemplate(kind, language, tmpl.Body, signal)
+		if err == nil {
+			return message, nil
+		}
+		log.Printf("[SignalTemplateService] Template %s/%s failed for %s, using the built-in template: %v", kind, language, signal.Ticker, err)
+	}
+
+	return renderSignalTemplate(kind, language, defaultSignalTemplates[kind][language], signal)
+}
+
+// signalTemplateData is what a signal template is executed with
+type signalTemplateData struct {
+	*models.Signal
+	Direction       string
+	ConfluenceItems []string
+}
+
+// renderSignalTemplate parses and executes body for signal, wrapping parse and
+// execution errors in ErrInvalidSignalTemplate
+func renderSignalTemplate(kind, language, body string, signal *models.Signal) (string, error) {
+	tmpl, err := template.New(kind + "/" + language).Funcs(signalTemplateFuncs(locale.For(language))).Parse(body)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidSignalTemplate, err)
+	}
+
+	data := &signalTemplateData{Signal: signal, Direction: signalDirection(signal)}
+	for _, item := range strings.Split(signal.ConfluenceHits, "|") {
+		if item = strings.TrimSpace(item); item != "" {
+			data.ConfluenceItems = append(data.ConfluenceItems, item)
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, data); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidSignalTemplate, err)
+	}
+
+	message := strings.TrimSpace(buffer.String())
+	if message == "" {
+		return "", fmt.Errorf("%w: the message is empty", ErrInvalidSignalTemplate)
+	}
+
+	return message, nil
+}
+
+func signalTemplateFuncs(l locale.Locale) template.FuncMap {
+	return template.FuncMap{
+		"number": func(value any, decimals int) (string, error) {
+			return formatFloat(value, func(x float64) string { return l.Number(x, decimals) })
+		},
+		"price":  func(value any) (string, error) { return formatFloat(value, l.Amount) },
+		"rupiah": func(value any) (string, error) { return formatFloat(value, l.Currency) },
+		"percent": func(value any, decimals int) (string, error) {
+			return formatFloat(value, func(x float64) string { return l.Percent(x, decimals) })
+		},
+		"signedPercent": func(value any, decimals int) (string, error) {
+			return formatFloat(value, func(x float64) string { return l.SignedPercent(x, decimals) })
+		},
+		"capitalize": capitalize,
+		"upper":      strings.ToUpper,
+	}
+}
+
+// formatFloat formats value with format, failing for anything but a number
+func formatFloat(value any, format func(float64) string) (string, error) {
+	x, err := toFloat(value)
+	if err != nil {
+		return "", err
+	}
+	return format(x), nil
+}
+
+// toFloat converts the numbers templates pass to the format functions
+func toFloat(value any) (float64, error) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), nil
+	case int8:
+		return float64(v), nil
+	case int16:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case uint:
+		return float64(v), nil
+	case uint8:
+		return float64(v), nil
+	case uint16:
+		return float64(v), nil
+	case uint32:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	default:
+		return 0, fmt.Errorf("expected a number, got %T", value)
+	}
+}
+
+// capitalize upper-cases the first letter of s, replacing strings.Title for
+// the single words signal fields hold
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+func isSignalTemplate(kind, language string) bool {
+	_, exists := defaultSignalTemplates[kind][language]
+	return exists
+}
+
+func builtInSignalTemplate(kind, language string) *models.SignalTemplate {
+	return &models.SignalTemplate{Kind: kind, Language: language, Body: defaultSignalTemplates[kind][language], IsDefault: true}
+}
+
+// sampleSignal is the signal templates are checked and previewed with
+func sampleSignal(kind string) *models.Signal {
+	signal := &models.Signal{
+		Operation:        models.SignalOperationNew,
+		Ticker:           "BBCA",
+		LastDate:         "2025-08-10",
+		LastClose:        9420,
+		EntryPrice:       9655,
+		EntryGapPercent:  2.5,
+		Stop:             9180.5,
+		Target:           9720,
+		RiskReward:       1.85,
+		BacktestWinRate:  68.5,
+		TotalTrades:      147,
+		ConfluenceScore:  8.2,
+		ConfluenceHits:   "Near Bollinger lower band | Volume spike detected | RSI oversold signal",
+		OverallSentiment: "bullish",
+		ConfidenceScore:  85.3,
+		SentimentScore:   72.8,
+		AnalysisSummary:  "Strong technical confluence with bullish sentiment.",
+	}
+
+	switch kind {
+	case models.SignalBroadcastUpdate:
+		signal.Operation = models.SignalOperationUpdate
+		signal.EntryPrice, signal.Stop, signal.Target, signal.RiskReward = 9600, 9300, 9900, 1
+		signal.Note = "Entry moved after the opening gap"
+	case models.SignalBroadcastCancel:
+		signal.Operation = models.SignalOperationCancel
+		signal.Note = "Setup invalidated"
+	}
+
+	return signal
+}
+
+// defaultSignalTemplates are the built-in templates by kind and language
+var defaultSignalTemplates = map[string]map[string]string{
+	models.SignalBroadcastSignal: {
+		locale.Indonesian: `🚀 *SIGNAL: {{.Ticker}}*
+
+📊 *DETAIL TRADING*
+• Harga Terakhir: {{rupiah .LastClose}}
+• *Harga Entry*: {{rupiah .EntryPrice}}
+• *Tanggal*: {{.LastDate}}
+• Gap Entry: {{signedPercent .EntryGapPercent 1}}
+
+🎯 *LEVEL TRADING*
+• *Stop Loss*: {{rupiah .Stop}}
+• *Target*: {{rupiah .Target}}
+• Risk/Reward: 1:{{number .RiskReward 2}}
+
+📈 *METRIK ANALISIS*
+• *Skor Konfluensi*: {{number .ConfluenceScore 1}}/10
+{{- if .ConfluenceItems}}
+• *Detail Konfluensi*:
+{{- range .ConfluenceItems}}
+  - ✓ {{.}}
+{{- end}}
+{{- end}}
+• Win Rate Backtest: {{percent .BacktestWinRate 1}}
+• Total Trade: {{number .TotalTrades 0}}
+• Keyakinan: {{percent .ConfidenceScore 1}}
+
+💭 *ANALISIS SENTIMEN*
+• Keseluruhan: {{capitalize .OverallSentiment}}
+• Skor Sentimen: {{percent .SentimentScore 1}}
+{{- if .AnalysisSummary}}
+
+📋 *RINGKASAN*
+{{.AnalysisSummary}}
+{{- end}}
+
+━━━━━━━━━━━━━━━━━━━━
+❓ *Punya pertanyaan tentang signal ini?*
+• Metode perhitungan
+• Analisis teknikal
+• Analisis sentimen
+• Strategi trading
+
+Silakan tanyakan langsung! 💬`,
+		locale.English: `🚀 *SIGNAL ALERT: {{.Ticker}}*
+
+📊 *TRADING DETAILS*
+• Last Price: {{rupiah .LastClose}}
+• *Entry Price*: {{rupiah .EntryPrice}}
+• *Date*: {{.LastDate}}
+• Entry Gap: {{signedPercent .EntryGapPercent 1}}
+
+🎯 *TRADING LEVELS*
+• *Stop Loss*: {{rupiah .Stop}}
+• *Target*: {{rupiah .Target}}
+• Risk/Reward: 1:{{number .RiskReward 2}}
+
+📈 *ANALYSIS METRICS*
+• *Confluence Score*: {{number .ConfluenceScore 1}}/10
+{{- if .ConfluenceItems}}
+• *Confluence Details*:
+{{- range .ConfluenceItems}}
+  - ✓ {{.}}
+{{- end}}
+{{- end}}
+• Backtest Win Rate: {{percent .BacktestWinRate 1}}
+• Total Trades: {{number .TotalTrades 0}}
+• Confidence: {{percent .ConfidenceScore 1}}
+
+💭 *SENTIMENT ANALYSIS*
+• Overall: {{capitalize .OverallSentiment}}
+• Sentiment Score: {{percent .SentimentScore 1}}
+{{- if .AnalysisSummary}}
+
+📋 *SUMMARY*
+{{.AnalysisSummary}}
+{{- end}}
+
+━━━━━━━━━━━━━━━━━━━━
+❓ *Questions about this signal?*
+• Calculation method
+• Technical analysis
+• Sentiment analysis
+• Trading strategy
+
+Just ask! 💬`,
+	},
+	models.SignalBroadcastUpdate: {
+		locale.Indonesian: `✏️ *PEMBARUAN SIGNAL: {{.Ticker}}*
+
+Level signal {{.Ticker}} tanggal {{.LastDate}} di atas diperbarui:
+• *Harga Entry*: {{rupiah .EntryPrice}}
+• *Stop Loss*: {{rupiah .Stop}}
+• *Target*: {{rupiah .Target}}
+• Risk/Reward: 1:{{number .RiskReward 2}}
+{{- if .Note}}
+
+📝 *Catatan*: {{.Note}}
+{{- end}}`,
+		locale.English: `✏️ *SIGNAL UPDATE: {{.Ticker}}*
+
+The levels of the {{.Ticker}} signal of {{.LastDate}} above have changed:
+• *Entry Price*: {{rupiah .EntryPrice}}
+• *Stop Loss*: {{rupiah .Stop}}
+• *Target*: {{rupiah .Target}}
+• Risk/Reward: 1:{{number .RiskReward 2}}
+{{- if .Note}}
+
+📝 *Note*: {{.Note}}
+{{- end}}`,
+	},
+	models.SignalBroadcastCancel: {
+		locale.Indonesian: `❌ *SIGNAL DIBATALKAN: {{.Ticker}}*
+
+Signal {{.Ticker}} tanggal {{.LastDate}} di atas dibatalkan. Abaikan level entry, stop loss dan target sebelumnya.
+{{- if .Note}}
+
+📝 *Catatan*: {{.Note}}
+{{- end}}`,
+		locale.English: `❌ *SIGNAL CANCELLED: {{.Ticker}}*
+
+The {{.Ticker}} signal of {{.LastDate}} above is cancelled. Disregard its entry, stop loss and target.
+{{- if .Note}}
+
+📝 *Note*: {{.Note}}
+{{- end}}`,
+	},
+}
@@ -0,0 +1,288 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/testutils/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSignalTemplateService_Render
+// Summary: Test rendering signal messages per language
+// Purpose: Validate the built-in templates and their locale formatting, that a saved template replaces the built-in one, and that a failing saved template or store falls back to the built-in one
+func TestSignalTemplateService_Render(t *testing.T) {
+	signal := sampleSignal(models.SignalBroadcastSignal)
+
+	tests := []struct {
+		name        string
+		language    string
+		stored      *models.SignalTemplate
+		storeErr    error
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:     "Indonesian built-in template",
+			language: "id",
+			contains: []string{
+				"🚀 *SIGNAL: BBCA*",
+				"• *Harga Entry*: Rp 9.655",
+				"• *Stop Loss*: Rp 9.180,50",
+				"• Gap Entry: +2,5%",
+				"• Win Rate Backtest: 68,5%",
+				"• Keseluruhan: Bullish",
+				"  - ✓ Volume spike detected",
+			},
+			notContains: []string{"ALERT"},
+		},
+		{
+			name:     "English built-in template",
+			language: "en",
+			contains: []string{
+				"🚀 *SIGNAL ALERT: BBCA*",
+				"• *Entry Price*: Rp 9,655",
+				"• *Stop Loss*: Rp 9,180.50",
+				"• Entry Gap: +2.5%",
+				"• Backtest Win Rate: 68.5%",
+				"• Overall: Bullish",
+			},
+		},
+		{
+			name:     "Unsupported language uses the default",
+			language: "fr",
+			contains: []string{"🚀 *SIGNAL: BBCA*", "Rp 9.655"},
+		},
+		{
+			name:     "Saved template replaces the built-in one",
+			language: "en",
+			stored:   &models.SignalTemplate{Kind: models.SignalBroadcastSignal, Language: "en", Body: "{{upper .OverallSentiment}} {{.Ticker}} @ {{rupiah .EntryPrice}}"},
+			contains: []string{"BULLISH BBCA @ Rp 9,655"},
+		},
+		{
+			name:        "Failing saved template falls back",
+			language:    "en",
+			stored:      &models.SignalTemplate{Kind: models.SignalBroadcastSignal, Language: "en", Body: "{{.Missing}}"},
+			contains:    []string{"🚀 *SIGNAL ALERT: BBCA*"},
+			notContains: []string{"no value"},
+		},
+		{
+			name:        "Format function given a non-number falls back",
+			language:    "en",
+			stored:      &models.SignalTemplate{Kind: models.SignalBroadcastSignal, Language: "en", Body: "{{.Ticker}} @ {{rupiah .Ticker}}"},
+			contains:    []string{"🚀 *SIGNAL ALERT: BBCA*"},
+			notContains: []string{"Rp 0"},
+		},
+		{
+			name:     "Store error falls back",
+			language: "id",
+			storeErr: errors.New("connection refused"),
+			contains: []string{"🚀 *SIGNAL: BBCA*"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockSignalTemplateRepository(t)
+			mockRepo.EXPECT().Get(mock.Anything, models.SignalBroadcastSignal, mock.Anything).Return(tt.stored, tt.storeErr)
+
+			service := NewSignalTemplateService(mockRepo)
+			message, err := service.Render(context.Background(), models.SignalBroadcastSignal, tt.language, signal)
+
+			require.NoError(t, err)
+			for _, expected := range tt.contains {
+				assert.Contains(t, message, expected)
+			}
+			for _, unexpected := range tt.notContains {
+				assert.NotContains(t, message, unexpected)
+			}
+		})
+	}
+}
+
+// TestSignalTemplateService_Save
+// Summary: Test replacing a signal template
+// Purpose: Validate that a template is stored only when it renders the sample signal, and that unknown kinds and languages are rejected
+func TestSignalTemplateService_Save(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name        string
+		kind        string
+		language    string
+		body        string
+		expectSave  bool
+		expectedErr error
+	}{
+		{
+			name:       "Valid template is saved",
+			kind:       models.SignalBroadcastCancel,
+			language:   "en",
+			body:       "❌ {{.Ticker}} cancelled{{if .Note}}: {{.Note}}{{end}}",
+			expectSave: true,
+		},
+		{
+			name:        "Syntax error",
+			kind:        models.SignalBroadcastSignal,
+			language:    "id",
+			body:        "{{.Ticker",
+			expectedErr: ErrInvalidSignalTemplate,
+		},
+		{
+			name:        "Unknown field",
+			kind:        models.SignalBroadcastSignal,
+			language:    "id",
+			body:        "{{.Symbol}}",
+			expectedErr: ErrInvalidSignalTemplate,
+		},
+		{
+			name:        "Non-number passed to a format function",
+			kind:        models.SignalBroadcastSignal,
+			language:    "id",
+			body:        "{{percent .OverallSentiment 1}}",
+			expectedErr: ErrInvalidSignalTemplate,
+		},
+		{
+			name:        "Empty message",
+			kind:        models.SignalBroadcastUpdate,
+			language:    "id",
+			body:        "{{if false}}{{.Ticker}}{{end}}",
+			expectedErr: ErrInvalidSignalTemplate,
+		},
+		{
+			name:        "Unknown kind",
+			kind:        "reminder",
+			language:    "id",
+			body:        "{{.Ticker}}",
+			expectedErr: ErrUnknownSignalTemplate,
+		},
+		{
+			name:        "Unknown language",
+			kind:        models.SignalBroadcastSignal,
+			language:    "fr",
+			body:        "{{.Ticker}}",
+			expectedErr: ErrUnknownSignalTemplate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockSignalTemplateRepository(t)
+			if tt.expectSave {
+				mockRepo.EXPECT().Save(mock.Anything, mock.Anything).
+					Run(func(ctx context.Context, template *models.SignalTemplate) {
+						assert.Equal(t, tt.kind, template.Kind)
+						assert.Equal(t, tt.language, template.Language)
+						assert.Equal(t, tt.body, template.Body)
+						assert.Equal(t, userID, *template.UpdatedBy)
+					}).Return(nil)
+			}
+
+			service := NewSignalTemplateService(mockRepo)
+			template, err := service.Save(context.Background(), tt.kind, tt.language, tt.body, &userID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, template)
+				return
+			}
+			require.NoError(t, err)
+			assert.False(t, template.IsDefault)
+		})
+	}
+}
+
+// TestSignalTemplateService_List
+// Summary: Test listing the signal templates
+// Purpose: Validate that every kind and language is listed, with saved templates in place of the built-in ones
+func TestSignalTemplateService_List(t *testing.T) {
+	saved := &models.SignalTemplate{Kind: models.SignalBroadcastUpdate, Language: "en", Body: "{{.Ticker}} updated"}
+
+	mockRepo := mocks.NewMockSignalTemplateRepository(t)
+	mockRepo.EXPECT().List(mock.Anything).Return([]*models.SignalTemplate{saved}, nil)
+
+	service := NewSignalTemplateService(mockRepo)
+	templates, err := service.List(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, templates, 6)
+	for _, template := range templates {
+		if template.Kind == saved.Kind && template.Language == saved.Language {
+			assert.Same(t, saved, template)
+			continue
+		}
+		assert.True(t, template.IsDefault, "%s/%s should be built in", template.Kind, template.Language)
+		assert.NotEmpty(t, template.Body)
+	}
+}
+
+// TestSignalTemplateService_Preview
+// Summary: Test previewing signal templates
+// Purpose: Validate that a draft body, the current template and a given signal are rendered without storing anything
+func TestSignalTemplateService_Preview(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         *models.SignalTemplatePreviewRequest
+		stored      *models.SignalTemplate
+		expectGet   bool
+		expected    string
+		contains    string
+		expectedErr error
+	}{
+		{
+			name:     "Draft body with the sample signal",
+			req:      &models.SignalTemplatePreviewRequest{Kind: models.SignalBroadcastSignal, Language: "id", Body: "{{.Ticker}} {{rupiah .Stop}} {{percent .BacktestWinRate 1}}"},
+			expected: "BBCA Rp 9.180,50 68,5%",
+		},
+		{
+			name:      "Current saved template",
+			req:       &models.SignalTemplatePreviewRequest{Kind: models.SignalBroadcastCancel, Language: "en"},
+			stored:    &models.SignalTemplate{Body: "{{.Ticker}}: {{.Note}}"},
+			expectGet: true,
+			expected:  "BBCA: Setup invalidated",
+		},
+		{
+			name:      "Built-in template with a given signal",
+			req:       &models.SignalTemplatePreviewRequest{Kind: models.SignalBroadcastUpdate, Language: "en", Signal: &models.Signal{Ticker: "TLKM", LastDate: "2025-08-11", EntryPrice: 3100, Stop: 2950, Target: 3400, RiskReward: 2}},
+			expectGet: true,
+			contains:  "✏️ *SIGNAL UPDATE: TLKM*",
+		},
+		{
+			name:        "Broken draft",
+			req:         &models.SignalTemplatePreviewRequest{Kind: models.SignalBroadcastSignal, Language: "en", Body: "{{range}}"},
+			expectedErr: ErrInvalidSignalTemplate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockSignalTemplateRepository(t)
+			if tt.expectGet {
+				mockRepo.EXPECT().Get(mock.Anything, tt.req.Kind, tt.req.Language).Return(tt.stored, nil)
+			}
+
+			service := NewSignalTemplateService(mockRepo)
+			preview, err := service.Preview(context.Background(), tt.req)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.req.Kind, preview.Kind)
+			assert.Equal(t, tt.req.Language, preview.Language)
+			if tt.expected != "" {
+				assert.Equal(t, tt.expected, preview.Message)
+			}
+			if tt.contains != "" {
+				assert.Contains(t, preview.Message, tt.contains)
+			}
+		})
+	}
+}
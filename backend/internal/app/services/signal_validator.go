@@ -0,0 +1,327 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// defaultRiskRewardTolerance is the relative difference allowed between the
+// claimed and the computed risk/reward when none is configured
+const defaultRiskRewardTolerance = 0.05
+
+var (
+	ErrSignalRejected    = errors.New("signal rejected")
+	ErrInvalidSignalRule = errors.New("rule must be fields, levels, risk_reward, freshness, ticker or thresholds")
+)
+
+// SignalRejectedError reports a signal that failed validation. Rejected holds
+// every violation; its ID is set once the signal is stored for review.
+type SignalRejectedError struct {
+	Rejected *models.RejectedSignal
+}
+
+func (e *SignalRejectedError) Error() string {
+	if len(e.Rejected.Violations) == 1 {
+		return "signal rejected: " + e.Rejected.Violations[0].Message
+	}
+	return fmt.Sprintf("signal rejected: %d violations", len(e.Rejected.Violations))
+}
+
+func (e *SignalRejectedError) Unwrap() error {
+	return ErrSignalRejected
+}
+
+// SignalValidator checks incoming signals beyond what a single field can tell.
+// The fields rule (the binding tags of models.Signal) runs first; the other
+// rules only run on signals that pass it:
+//
+//	levels       stop and target on either side of the entry, the target above
+//	             it for a bullish signal and below it for a bearish one
+//	risk_reward  risk_reward within a tolerance of |target-entry|/|entry-stop|
+//	freshness    last_date a valid date, not in the future nor too old
+//	ticker       the ticker matches the pattern and, if set, the allowed list
+//	thresholds   minimum risk/reward, confluence score, win rate and trades
+//
+// A cancellation is only checked against the ticker rule, and freshness only
+// applies to new signals: updates and cancellations refer to a signal that
+// was already accepted.
+type SignalValidator interface {
+	// Validate returns every violation of signal, or nil when it passes
+	Validate(signal *models.Signal) []models.SignalViolation
+}
+
+// SignalValidationConfig sets the rules signals must pass. Zero minimums, an
+// empty pattern and an empty ticker list disable those checks.
+type SignalValidationConfig struct {
+	// MaxAgeDays is how many days old last_date may be
+	MaxAgeDays int
+	// TickerPattern is a regular expression tickers must match, such as
+	// ^[A-Z]{4}$ for IDX codes
+	TickerPattern string
+	// Tickers, when set, are the only tickers accepted
+	Tickers []string
+	// RiskRewardTolerance is the relative difference allowed between the
+	// claimed and the computed risk/reward
+	RiskRewardTolerance float64
+	MinRiskReward       float64
+	MinConfluenceScore  float64
+	MinBacktestWinRate  float64
+	MinTotalTrades      int
+	// DisabledRules are the names of the rules to skip
+	DisabledRules []string
+}
+
+// signalRule checks one aspect of a signal of the given operations
+type signalRule struct {
+	name       string
+	operations []string
+	check      func(signal *models.Signal) []models.SignalViolation
+}
+
+type signalValidator struct {
+	config        SignalValidationConfig
+	tickerPattern *regexp.Regexp
+	tickers       map[string]bool
+	rules         []signalRule
+	now           func() time.Time
+}
+
+func NewSignalValidator(config *SignalValidationConfig) (SignalValidator, error) {
+	v := &signalValidator{
+		config:  *config,
+		tickers: make(map[string]bool, len(config.Tickers)),
+		now:     time.Now,
+	}
+	if v.config.RiskRewardTolerance <= 0 {
+		v.config.RiskRewardTolerance = defaultRiskRewardTolerance
+	}
+
+	if config.TickerPattern != "" {
+		pattern, err := regexp.Compile(config.TickerPattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ticker pattern: %w", err)
+		}
+		v.tickerPattern = pattern
+	}
+	for _, ticker := range config.Tickers {
+		if ticker = strings.ToUpper(strings.TrimSpace(ticker)); ticker != "" {
+			v.tickers[ticker] = true
+		}
+	}
+
+	opening := []string{models.SignalOperationNew, models.SignalOperationUpdate}
+	rules := []signalRule{
+		{name: models.SignalRuleLevels, operations: opening, check: v.checkLevels},
+		{name: models.SignalRuleRiskReward, operations: opening, check: v.checkRiskReward},
+		{name: models.SignalRuleFreshness, operations: []string{models.SignalOperationNew}, check: v.checkFreshness},
+		{name: models.SignalRuleTicker, operations: []string{models.SignalOperationNew, models.SignalOperationUpdate, models.SignalOperationCancel}, check: v.checkTicker},
+		{name: models.SignalRuleThresholds, operations: opening, check: v.checkThresholds},
+	}
+
+	for _, name := range config.DisabledRules {
+		if !isSignalRule(name) || name == models.SignalRuleFields {
+			return nil, fmt.Errorf("unknown signal rule %q", name)
+		}
+	}
+	for _, rule := range rules {
+		if !slices.Contains(config.DisabledRules, rule.name) {
+			v.rules = append(v.rules, rule)
+		}
+	}
+
+	return v, nil
+}
+
+func (v *signalValidator) Validate(signal *models.Signal) []models.SignalViolation {
+	if violations := checkSignalFields(signal); len(violations) > 0 {
+		return violations
+	}
+
+	operation := signal.Operation
+	if operation == "" {
+		operation = models.SignalOperationNew
+	}
+
+	var violations []models.SignalViolation
+	for _, rule := range v.rules {
+		if slices.Contains(rule.operations, operation) {
+			violations = append(violations, rule.check(signal)...)
+		}
+	}
+
+	return violations
+}
+
+// checkSignalFields applies the binding tags of models.Signal, which gin no
+// longer checks so that these violations are reported like the others
+func checkSignalFields(signal *models.Signal) []models.SignalViolation {
+	err := bindingValidator.Struct(signal)
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		return []models.SignalViolation{{Rule: models.SignalRuleFields, Message: err.Error()}}
+	}
+
+	violations := make([]models.SignalViolation, len(fieldErrors))
+	for i, fieldError := range fieldErrors {
+		violations[i] = models.SignalViolation{Rule: models.SignalRuleFields, Field: fieldError.Field(), Message: describeFieldError(fieldError)}
+	}
+	return violations
+}
+
+func (v *signalValidator) checkLevels(signal *models.Signal) []models.SignalViolation {
+	entry := float64(signal.EntryPrice)
+
+	var violations []models.SignalViolation
+	if signal.Stop == entry {
+		violations = append(violations, levelViolation("stop", "stop must differ from entry_price"))
+	}
+	if signal.Target == entry {
+		violations = append(violations, levelViolation("target", "target must differ from entry_price"))
+	}
+	if len(violations) > 0 {
+		return violations
+	}
+
+	direction := signalDirection(signal)
+	if direction == models.SignalDirectionLong && signal.Stop > entry {
+		violations = append(violations, levelViolation("stop", fmt.Sprintf(
+			"stop %s must be below entry_price %d when the target %s is above it", formatLevel(signal.Stop), signal.EntryPrice, formatLevel(signal.Target))))
+	}
+	if direction == models.SignalDirectionShort && signal.Stop < entry {
+		violations = append(violations, levelViolation("stop", fmt.Sprintf(
+			"stop %s must be above entry_price %d when the target %s is below it", formatLevel(signal.Stop), signal.EntryPrice, formatLevel(signal.Target))))
+	}
+
+	switch strings.ToLower(signal.OverallSentiment) {
+	case "bullish":
+		if direction != models.SignalDirectionLong {
+			violations = append(violations, levelViolation("target", fmt.Sprintf(
+				"target %s must be above entry_price %d for a bullish signal", formatLevel(signal.Target), signal.EntryPrice)))
+		}
+	case "bearish":
+		if direction != models.SignalDirectionShort {
+			violations = append(violations, levelViolation("target", fmt.Sprintf(
+				"target %s must be below entry_price %d for a bearish signal", formatLevel(signal.Target), signal.EntryPrice)))
+		}
+	}
+
+	return violations
+}
+
+func levelViolation(field, message string) models.SignalViolation {
+	return models.SignalViolation{Rule: models.SignalRuleLevels, Field: field, Message: message}
+}
+
+func (v *signalValidator) checkRiskReward(signal *models.Signal) []models.SignalViolation {
+	entry := float64(signal.EntryPrice)
+	if signal.Stop == entry {
+		// Reported by the levels rule; there is no risk to divide by
+		return nil
+	}
+
+	expected := math.Abs(signal.Target-entry) / math.Abs(entry-signal.Stop)
+	if math.Abs(signal.RiskReward-expected) <= v.config.RiskRewardTolerance*expected {
+		return nil
+	}
+
+	return []models.SignalViolation{{
+		Rule:  models.SignalRuleRiskReward,
+		Field: "risk_reward",
+		Message: fmt.Sprintf("risk_reward %s does not match the levels: |target - entry_price| / |entry_price - stop| is %.2f",
+			formatLevel(signal.RiskReward), expected),
+	}}
+}
+
+func (v *signalValidator) checkFreshness(signal *models.Signal) []models.SignalViolation {
+	violation := func(message string) []models.SignalViolation {
+		return []models.SignalViolation{{Rule: models.SignalRuleFreshness, Field: "last_date", Message: message}}
+	}
+
+	date, err := time.Parse(models.SignalDateLayout, signal.LastDate)
+	if err != nil {
+		return violation(fmt.Sprintf("last_date %q must be a date in the form YYYY-MM-DD", signal.LastDate))
+	}
+
+	// Dates are compared in UTC, so a date one day ahead is allowed for
+	// signals sent early in the morning in Jakarta
+	today := v.now().UTC().Truncate(24 * time.Hour)
+	age := int(today.Sub(date).Hours() / 24)
+	if age < -1 {
+		return violation(fmt.Sprintf("last_date %s is in the future", signal.LastDate))
+	}
+	if v.config.MaxAgeDays > 0 && age > v.config.MaxAgeDays {
+		return violation(fmt.Sprintf("last_date %s is %d days old; signals may be at most %d days old", signal.LastDate, age, v.config.MaxAgeDays))
+	}
+
+	return nil
+}
+
+func (v *signalValidator) checkTicker(signal *models.Signal) []models.SignalViolation {
+	ticker := strings.ToUpper(strings.TrimSpace(signal.Ticker))
+	violation := func(message string) []models.SignalViolation {
+		return []models.SignalViolation{{Rule: models.SignalRuleTicker, Field: "ticker", Message: message}}
+	}
+
+	if v.tickerPattern != nil && !v.tickerPattern.MatchString(ticker) {
+		return violation(fmt.Sprintf("ticker %s does not match %s", ticker, v.tickerPattern))
+	}
+	if len(v.tickers) > 0 && !v.tickers[ticker] {
+		return violation(fmt.Sprintf("ticker %s is not in the list of allowed tickers", ticker))
+	}
+
+	return nil
+}
+
+func (v *signalValidator) checkThresholds(signal *models.Signal) []models.SignalViolation {
+	thresholds := []struct {
+		field   string
+		value   float64
+		minimum float64
+	}{
+		{"risk_reward", signal.RiskReward, v.config.MinRiskReward},
+		{"confluence_score", signal.ConfluenceScore, v.config.MinConfluenceScore},
+		{"backtest_win_rate", signal.BacktestWinRate, v.config.MinBacktestWinRate},
+		{"total_trades", float64(signal.TotalTrades), float64(v.config.MinTotalTrades)},
+	}
+
+	var violations []models.SignalViolation
+	for _, threshold := range thresholds {
+		if threshold.minimum > 0 && threshold.value < threshold.minimum {
+			violations = append(violations, models.SignalViolation{
+				Rule:    models.SignalRuleThresholds,
+				Field:   threshold.field,
+				Message: fmt.Sprintf("%s %s is below the minimum of %s", threshold.field, formatLevel(threshold.value), formatLevel(threshold.minimum)),
+			})
+		}
+	}
+
+	return violations
+}
+
+// formatLevel writes a number without trailing zeros
+func formatLevel(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func isSignalRule(name string) bool {
+	switch name {
+	case models.SignalRuleFields, models.SignalRuleLevels, models.SignalRuleRiskReward,
+		models.SignalRuleFreshness, models.SignalRuleTicker, models.SignalRuleThresholds:
+		return true
+	}
+	return false
+}
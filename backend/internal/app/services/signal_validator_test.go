@@ -0,0 +1,224 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSignalValidator_Validate
+// Summary: Test the signal validation rules
+// Purpose: Validate that cross-field levels, risk/reward, freshness, ticker and threshold violations are all reported, that field violations skip the other rules, and that rules apply only to their operations and can be disabled
+func TestSignalValidator_Validate(t *testing.T) {
+	now := time.Date(2025, 8, 12, 9, 0, 0, 0, time.UTC)
+	newSignal := func() *models.Signal {
+		return &models.Signal{
+			Ticker:           "BBCA",
+			LastDate:         "2025-08-10",
+			LastClose:        9420,
+			EntryPrice:       9655,
+			EntryGapPercent:  2.5,
+			Stop:             9180,
+			Target:           10534,
+			RiskReward:       1.85,
+			BacktestWinRate:  68.5,
+			TotalTrades:      147,
+			ConfluenceScore:  8.2,
+			OverallSentiment: "bullish",
+		}
+	}
+
+	tests := []struct {
+		name      string
+		configure func(config *SignalValidationConfig)
+		modify    func(signal *models.Signal)
+		expected  []string
+	}{
+		{
+			name: "Valid bullish signal",
+		},
+		{
+			name: "Valid bearish signal",
+			modify: func(signal *models.Signal) {
+				signal.Ticker, signal.EntryPrice, signal.Stop, signal.Target, signal.RiskReward = "TLKM", 3437, 3600, 3165, 1.67
+				signal.OverallSentiment = "bearish"
+			},
+		},
+		{
+			name:   "Risk/reward within the tolerance",
+			modify: func(signal *models.Signal) { signal.RiskReward = 1.9 },
+		},
+		{
+			name:     "Bullish signal with the stop above the entry",
+			modify:   func(signal *models.Signal) { signal.Stop, signal.RiskReward = 9700, 19.53 },
+			expected: []string{"levels/stop"},
+		},
+		{
+			name:     "Bullish signal with the target below the entry",
+			modify:   func(signal *models.Signal) { signal.Stop, signal.Target, signal.RiskReward = 9800, 9300, 2.45 },
+			expected: []string{"levels/target"},
+		},
+		{
+			name:     "Stop at the entry",
+			modify:   func(signal *models.Signal) { signal.Stop = 9655 },
+			expected: []string{"levels/stop"},
+		},
+		{
+			name:     "Risk/reward does not match the levels",
+			modify:   func(signal *models.Signal) { signal.RiskReward = 3 },
+			expected: []string{"risk_reward/risk_reward"},
+		},
+		{
+			name:     "Stale signal",
+			modify:   func(signal *models.Signal) { signal.LastDate = "2025-08-01" },
+			expected: []string{"freshness/last_date"},
+		},
+		{
+			name:   "Signal dated tomorrow in Jakarta",
+			modify: func(signal *models.Signal) { signal.LastDate = "2025-08-13" },
+		},
+		{
+			name:     "Signal dated in the future",
+			modify:   func(signal *models.Signal) { signal.LastDate = "2025-08-14" },
+			expected: []string{"freshness/last_date"},
+		},
+		{
+			name:     "Invalid date",
+			modify:   func(signal *models.Signal) { signal.LastDate = "10/08/2025" },
+			expected: []string{"freshness/last_date"},
+		},
+		{
+			name:   "Lower-case ticker",
+			modify: func(signal *models.Signal) { signal.Ticker = "bbca" },
+		},
+		{
+			name:     "Ticker is not an IDX code",
+			modify:   func(signal *models.Signal) { signal.Ticker = "BBCA1" },
+			expected: []string{"ticker/ticker"},
+		},
+		{
+			name:      "Ticker is not allowed",
+			configure: func(config *SignalValidationConfig) { config.Tickers = []string{"tlkm", "ASII"} },
+			expected:  []string{"ticker/ticker"},
+		},
+		{
+			name: "Below the minimum thresholds",
+			configure: func(config *SignalValidationConfig) {
+				config.MinRiskReward, config.MinConfluenceScore, config.MinBacktestWinRate, config.MinTotalTrades = 1.5, 9, 60, 200
+			},
+			expected: []string{"thresholds/confluence_score", "thresholds/total_trades"},
+		},
+		{
+			name: "Every violation is reported",
+			modify: func(signal *models.Signal) {
+				signal.Ticker, signal.LastDate, signal.Stop, signal.RiskReward = "BBCA1", "2025-07-01", 9700, 1.85
+			},
+			expected: []string{"levels/stop", "risk_reward/risk_reward", "freshness/last_date", "ticker/ticker"},
+		},
+		{
+			name: "Field violations skip the other rules",
+			modify: func(signal *models.Signal) {
+				signal.Ticker, signal.Stop, signal.BacktestWinRate, signal.LastDate = "", 0, 120, "2025-07-01"
+			},
+			expected: []string{"fields/ticker", "fields/stop", "fields/backtest_win_rate"},
+		},
+		{
+			name:     "Unknown operation",
+			modify:   func(signal *models.Signal) { signal.Operation = "delete" },
+			expected: []string{"fields/operation"},
+		},
+		{
+			name: "Update is not checked for freshness",
+			modify: func(signal *models.Signal) {
+				signal.Operation, signal.LastDate = models.SignalOperationUpdate, "2025-07-01"
+			},
+		},
+		{
+			name: "Cancellation is only checked against the ticker",
+			modify: func(signal *models.Signal) {
+				signal.Operation, signal.Ticker, signal.LastDate, signal.Stop = models.SignalOperationCancel, "BBCA1", "2025-07-01", 9700
+			},
+			expected: []string{"ticker/ticker"},
+		},
+		{
+			name:      "Disabled rules are skipped",
+			configure: func(config *SignalValidationConfig) { config.DisabledRules = []string{"freshness", "risk_reward"} },
+			modify: func(signal *models.Signal) {
+				signal.LastDate, signal.RiskReward = "2025-07-01", 3
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &SignalValidationConfig{MaxAgeDays: 5, TickerPattern: "^[A-Z]{4}$", RiskRewardTolerance: 0.05}
+			if tt.configure != nil {
+				tt.configure(config)
+			}
+			v, err := NewSignalValidator(config)
+			require.NoError(t, err)
+			v.(*signalValidator).now = func() time.Time { return now }
+
+			signal := newSignal()
+			if tt.modify != nil {
+				tt.modify(signal)
+			}
+			violations := v.Validate(signal)
+
+			var got []string
+			for _, violation := range violations {
+				assert.NotEmpty(t, violation.Message)
+				got = append(got, violation.Rule+"/"+violation.Field)
+			}
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+// TestNewSignalValidator
+// Summary: Test the validation config checks
+// Purpose: Validate that an invalid ticker pattern and unknown or mandatory disabled rules are refused at startup
+func TestNewSignalValidator(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *SignalValidationConfig
+		expectError bool
+	}{
+		{
+			name:   "Default config",
+			config: &SignalValidationConfig{MaxAgeDays: 5, TickerPattern: "^[A-Z]{4}$"},
+		},
+		{
+			name:        "Invalid ticker pattern",
+			config:      &SignalValidationConfig{TickerPattern: "^[A-Z"},
+			expectError: true,
+		},
+		{
+			name:        "Unknown rule",
+			config:      &SignalValidationConfig{DisabledRules: []string{"spread"}},
+			expectError: true,
+		},
+		{
+			name:        "Fields rule cannot be disabled",
+			config:      &SignalValidationConfig{DisabledRules: []string{"fields"}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := NewSignalValidator(tt.config)
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, v)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, v)
+		})
+	}
+}
@@ -0,0 +1,321 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/repositories"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSubscriptionNotFound = errors.New("subscription not found")
+	ErrInvalidTicker        = errors.New("ticker must be 1-10 letters or digits")
+	ErrTickerRequired       = errors.New("ticker is required; use all for every ticker")
+)
+
+// allTickers subscribes to, or unsubscribes from, every ticker
+const allTickers = "ALL"
+
+var tickerPattern = regexp.MustCompile(`^[A-Z0-9]{1,10}$`)
+
+type SubscriptionService interface {
+	ListSubscriptions(ctx context.Context, userID uuid.UUID) ([]*models.SignalSubscription, error)
+	// Subscribe creates the user's subscription for the ticker of req, or
+	// replaces its filters
+	Subscribe(ctx context.Context, userID uuid.UUID, req *models.SubscribeRequest) (*models.SignalSubscription, error)
+	// Unsubscribe removes the user's subscription for ticker, or all of them
+	// when ticker is "all", and returns how many were removed. A ticker is also
+	// excluded from the user's all-tickers subscription, which counts as one.
+	Unsubscribe(ctx context.Context, userID uuid.UUID, ticker string) (int, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	// FindRecipients returns the users a signal should be sent to
+	FindRecipients(ctx context.Context, signal *models.Signal) ([]*models.User, error)
+	// HandleCommand answers the /subscribe, /unsubscribe and /mysubs chat
+	// commands. It reports false when text is not one of them. user is nil for
+	// senders who are not registered.
+	HandleCommand(ctx context.Context, user *models.User, text string) (string, bool)
+}
+
+type subscriptionService struct {
+	subscriptionRepo repositories.SubscriptionRepository
+	userRepo         repositories.UserRepository
+}
+
+func NewSubscriptionService(subscriptionRepo repositories.SubscriptionRepository, userRepo repositories.UserRepository) SubscriptionService {
+	return &subscriptionService{
+		subscriptionRepo: subscriptionRepo,
+		userRepo:         userRepo,
+	}
+}
+
+func (s *subscriptionService) ListSubscriptions(ctx context.Context, userID uuid.UUID) ([]*models.SignalSubscription, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	return s.subscriptionRepo.ListByUser(ctx, userID)
+}
+
+func (s *subscriptionService) Subscribe(ctx context.Context, userID uuid.UUID, req *models.SubscribeRequest) (*models.SignalSubscription, error) {
+	ticker, err := normalizeTicker(req.Ticker)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	subscription := &models.SignalSubscription{
+		UserID:             userID,
+		Ticker:             ticker,
+		MinConfluenceScore: req.MinConfluenceScore,
+		MinBacktestWinRate: req.MinBacktestWinRate,
+	}
+	if sentiment := strings.ToLower(strings.TrimSpace(req.Sentiment)); sentiment != "" {
+		subscription.Sentiment = &sentiment
+	}
+
+	saved, err := s.subscriptionRepo.Upsert(ctx, subscription)
+	if err != nil {
+		log.Printf("[SubscriptionService] Failed to subscribe user %s: %v", userID, err)
+		return nil, err
+	}
+
+	log.Printf("[SubscriptionService] User %s subscribed to %s", userID, describeTicker(saved.Ticker))
+	return saved, nil
+}
+
+func (s *subscriptionService) Unsubscribe(ctx context.Context, userID uuid.UUID, ticker string) (int, error) {
+	normalized, err := normalizeTicker(ticker)
+	if err != nil {
+		return 0, err
+	}
+
+	removed, err := s.subscriptionRepo.DeleteByUser(ctx, userID, normalized)
+	if err != nil {
+		log.Printf("[SubscriptionService] Failed to unsubscribe user %s: %v", userID, err)
+		return 0, err
+	}
+
+	if normalized != nil {
+		excluded, err := s.subscriptionRepo.ExcludeTicker(ctx, userID, *normalized)
+		if err != nil {
+			log.Printf("[SubscriptionService] Failed to exclude %s for user %s: %v", *normalized, userID, err)
+			return 0, err
+		}
+		if excluded {
+			removed++
+		}
+	}
+
+	log.Printf("[SubscriptionService] User %s unsubscribed from %s (%d removed)", userID, describeTicker(normalized), removed)
+	return removed, nil
+}
+
+func (s *subscriptionService) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	deleted, err := s.subscriptionRepo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrSubscriptionNotFound
+	}
+	return nil
+}
+
+func (s *subscriptionService) FindRecipients(ctx context.Context, signal *models.Signal) ([]*models.User, error) {
+	return s.subscriptionRepo.FindRecipients(ctx, signal)
+}
+
+const subscriptionCommandHelp = "Usage:\n" +
+	"/subscribe BBCA - signals for one ticker\n" +
+	"/subscribe all - signals for every ticker\n" +
+	"/subscribe BBCA sentiment=bullish min_score=7 min_winrate=60 - only matching signals\n" +
+	"/unsubscribe BBCA or /unsubscribe all\n" +
+	"/mysubs - list your subscriptions"
+
+func (s *subscriptionService) HandleCommand(ctx context.Context, user *models.User, text string) (string, bool) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return "", false
+	}
+
+	command := strings.ToLower(fields[0])
+	if command != "/subscribe" && command != "/unsubscribe" && command != "/mysubs" {
+		return "", false
+	}
+
+	if user == nil {
+		return "Only registered users can subscribe to signals. Please contact the administrator for access.", true
+	}
+
+	var reply string
+	var err error
+	switch command {
+	case "/subscribe":
+		reply, err = s.subscribeCommand(ctx, user, fields[1:])
+	case "/unsubscribe":
+		reply, err = s.unsubscribeCommand(ctx, user, fields[1:])
+	case "/mysubs":
+		reply, err = s.listCommand(ctx, user)
+	}
+
+	if err != nil {
+		log.Printf("[SubscriptionService] Failed to handle %s from %s: %v", command, user.Phone, err)
+		return "Sorry, we couldn't update your subscriptions. Please try again later.", true
+	}
+	return reply, true
+}
+
+func (s *subscriptionService) subscribeCommand(ctx context.Context, user *models.User, args []string) (string, error) {
+	req, problem := parseSubscribeArgs(args)
+	if problem != "" {
+		return problem + "\n\n" + subscriptionCommandHelp, nil
+	}
+
+	subscription, err := s.Subscribe(ctx, user.ID, req)
+	if errors.Is(err, ErrInvalidTicker) {
+		return fmt.Sprintf("%q is not a valid ticker.\n\n%s", req.Ticker, subscriptionCommandHelp), nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return "Subscribed: " + describeSubscription(subscription), nil
+}
+
+func (s *subscriptionService) unsubscribeCommand(ctx context.Context, user *models.User, args []string) (string, error) {
+	if len(args) != 1 {
+		return subscriptionCommandHelp, nil
+	}
+
+	removed, err := s.Unsubscribe(ctx, user.ID, args[0])
+	if errors.Is(err, ErrInvalidTicker) {
+		return fmt.Sprintf("%q is not a valid ticker.\n\n%s", args[0], subscriptionCommandHelp), nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if strings.EqualFold(args[0], allTickers) {
+		return fmt.Sprintf("Unsubscribed from all signals (%d removed).", removed), nil
+	}
+	if removed == 0 {
+		return fmt.Sprintf("You were not subscribed to %s. Send /mysubs to see your subscriptions.", strings.ToUpper(args[0])), nil
+	}
+	return fmt.Sprintf("Unsubscribed from %s.", strings.ToUpper(args[0])), nil
+}
+
+func (s *subscriptionService) listCommand(ctx context.Context, user *models.User) (string, error) {
+	subscriptions, err := s.subscriptionRepo.ListByUser(ctx, user.ID)
+	if err != nil {
+		return "", err
+	}
+
+	if len(subscriptions) == 0 {
+		return "You have no signal subscriptions. Send /subscribe all or /subscribe BBCA to start.", nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString("Your signal subscriptions:\n")
+	for _, subscription := range subscriptions {
+		builder.WriteString("• " + describeSubscription(subscription) + "\n")
+	}
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+// parseSubscribeArgs reads "[TICKER|all] [sentiment=X] [min_score=N] [min_winrate=N]"
+// and returns a problem description instead when the arguments are invalid
+func parseSubscribeArgs(args []string) (*models.SubscribeRequest, string) {
+	req := &models.SubscribeRequest{}
+
+	for _, arg := range args {
+		key, value, isOption := strings.Cut(arg, "=")
+		if !isOption {
+			if req.Ticker != "" {
+				return nil, "Subscribe to one ticker at a time."
+			}
+			req.Ticker = arg
+			continue
+		}
+
+		switch strings.ToLower(key) {
+		case "sentiment":
+			req.Sentiment = value
+		case "min_score":
+			score, err := strconv.ParseFloat(value, 64)
+			if err != nil || score < 0 {
+				return nil, fmt.Sprintf("min_score must be a number of at least 0, not %q.", value)
+			}
+			req.MinConfluenceScore = &score
+		case "min_winrate":
+			winRate, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+			if err != nil || winRate < 0 || winRate > 100 {
+				return nil, fmt.Sprintf("min_winrate must be a percentage between 0 and 100, not %q.", value)
+			}
+			req.MinBacktestWinRate = &winRate
+		default:
+			return nil, fmt.Sprintf("Unknown option %q.", key)
+		}
+	}
+
+	if req.Ticker == "" {
+		return nil, "Name a ticker, or all for every ticker."
+	}
+
+	return req, ""
+}
+
+// normalizeTicker upper-cases ticker and returns nil for all. Every ticker has
+// to be asked for explicitly, so an empty ticker is refused.
+func normalizeTicker(ticker string) (*string, error) {
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	if ticker == "" {
+		return nil, ErrTickerRequired
+	}
+	if ticker == allTickers {
+		return nil, nil
+	}
+	if !tickerPattern.MatchString(ticker) {
+		return nil, ErrInvalidTicker
+	}
+	return &ticker, nil
+}
+
+func describeTicker(ticker *string) string {
+	if ticker == nil {
+		return "all tickers"
+	}
+	return *ticker
+}
+
+func describeSubscription(subscription *models.SignalSubscription) string {
+	description := describeTicker(subscription.Ticker)
+	if subscription.Ticker == nil && len(subscription.ExcludedTickers) > 0 {
+		description += " except " + strings.Join(subscription.ExcludedTickers, ", ")
+	}
+
+	var filters []string
+	if subscription.Sentiment != nil {
+		filters = append(filters, "sentiment "+*subscription.Sentiment)
+	}
+	if subscription.MinConfluenceScore != nil {
+		filters = append(filters, fmt.Sprintf("score ≥ %g", *subscription.MinConfluenceScore))
+	}
+	if subscription.MinBacktestWinRate != nil {
+		filters = append(filters, fmt.Sprintf("win rate ≥ %g%%", *subscription.MinBacktestWinRate))
+	}
+
+	if len(filters) > 0 {
+		description += " (" + strings.Join(filters, ", ") + ")"
+	}
+	return description
+}
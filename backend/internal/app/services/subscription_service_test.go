@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/testutils/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestSubscriptionService_HandleCommand
+// Summary: Test the /subscribe, /unsubscribe and /mysubs chat commands
+// Purpose: Validate command parsing, the saved filters, replies to bad input, that unsubscribing from one ticker excludes it from an all-tickers subscription, and that other messages are left for the workflow
+func TestSubscriptionService_HandleCommand(t *testing.T) {
+	user := &models.User{ID: uuid.New(), Name: "Budi", Phone: "6281234567890"}
+	ticker := "BBCA"
+	sentiment := "bullish"
+	score := 7.0
+	winRate := 60.0
+
+	tests := []struct {
+		name            string
+		user            *models.User
+		text            string
+		setup           func(subscriptions *mocks.MockSubscriptionRepository, users *mocks.MockUserRepository)
+		expectedHandled bool
+		expectedReply   string
+	}{
+		{
+			name:            "Regular message",
+			user:            user,
+			text:            "what is BBCA's outlook?",
+			expectedHandled: false,
+		},
+		{
+			name: "Subscribe to a ticker",
+			user: user,
+			text: "/subscribe bbca",
+			setup: func(subscriptions *mocks.MockSubscriptionRepository, users *mocks.MockUserRepository) {
+				users.EXPECT().GetByID(mock.Anything, user.ID).Return(user, nil)
+				subscriptions.EXPECT().Upsert(mock.Anything, &models.SignalSubscription{UserID: user.ID, Ticker: &ticker}).
+					Return(&models.SignalSubscription{UserID: user.ID, Ticker: &ticker}, nil)
+			},
+			expectedHandled: true,
+			expectedReply:   "Subscribed: BBCA",
+		},
+		{
+			name: "Subscribe with filters",
+			user: user,
+			text: "/Subscribe BBCA sentiment=Bullish min_score=7 min_winrate=60%",
+			setup: func(subscriptions *mocks.MockSubscriptionRepository, users *mocks.MockUserRepository) {
+				saved := &models.SignalSubscription{UserID: user.ID, Ticker: &ticker, Sentiment: &sentiment, MinConfluenceScore: &score, MinBacktestWinRate: &winRate}
+				users.EXPECT().GetByID(mock.Anything, user.ID).Return(user, nil)
+				subscriptions.EXPECT().Upsert(mock.Anything, saved).Return(saved, nil)
+			},
+			expectedHandled: true,
+			expectedReply:   "Subscribed: BBCA (sentiment bullish, score ≥ 7, win rate ≥ 60%)",
+		},
+		{
+			name: "Subscribe to every ticker",
+			user: user,
+			text: "/subscribe all",
+			setup: func(subscriptions *mocks.MockSubscriptionRepository, users *mocks.MockUserRepository) {
+				users.EXPECT().GetByID(mock.Anything, user.ID).Return(user, nil)
+				subscriptions.EXPECT().Upsert(mock.Anything, &models.SignalSubscription{UserID: user.ID}).
+					Return(&models.SignalSubscription{UserID: user.ID}, nil)
+			},
+			expectedHandled: true,
+			expectedReply:   "Subscribed: all tickers",
+		},
+		{
+			name:            "Invalid ticker",
+			user:            user,
+			text:            "/subscribe BB-CA",
+			expectedHandled: true,
+			expectedReply:   "\"BB-CA\" is not a valid ticker.\n\n" + subscriptionCommandHelp,
+		},
+		{
+			name:            "Subscribe without a ticker",
+			user:            user,
+			text:            "/subscribe",
+			expectedHandled: true,
+			expectedReply:   "Name a ticker, or all for every ticker.\n\n" + subscriptionCommandHelp,
+		},
+		{
+			name:            "Subscribe with filters but no ticker",
+			user:            user,
+			text:            "/subscribe sentiment=bullish",
+			expectedHandled: true,
+			expectedReply:   "Name a ticker, or all for every ticker.\n\n" + subscriptionCommandHelp,
+		},
+		{
+			name:            "Invalid option",
+			user:            user,
+			text:            "/subscribe BBCA min_score=high",
+			expectedHandled: true,
+			expectedReply:   "min_score must be a number of at least 0, not \"high\".\n\n" + subscriptionCommandHelp,
+		},
+		{
+			name: "Unsubscribe from everything",
+			user: user,
+			text: "/unsubscribe all",
+			setup: func(subscriptions *mocks.MockSubscriptionRepository, users *mocks.MockUserRepository) {
+				subscriptions.EXPECT().DeleteByUser(mock.Anything, user.ID, (*string)(nil)).Return(3, nil)
+			},
+			expectedHandled: true,
+			expectedReply:   "Unsubscribed from all signals (3 removed).",
+		},
+		{
+			name: "Unsubscribe from a ticker without a subscription",
+			user: user,
+			text: "/unsubscribe bbca",
+			setup: func(subscriptions *mocks.MockSubscriptionRepository, users *mocks.MockUserRepository) {
+				subscriptions.EXPECT().DeleteByUser(mock.Anything, user.ID, &ticker).Return(0, nil)
+				subscriptions.EXPECT().ExcludeTicker(mock.Anything, user.ID, ticker).Return(false, nil)
+			},
+			expectedHandled: true,
+			expectedReply:   "You were not subscribed to BBCA. Send /mysubs to see your subscriptions.",
+		},
+		{
+			name: "Unsubscribe from a ticker while subscribed to every ticker",
+			user: user,
+			text: "/unsubscribe BBCA",
+			setup: func(subscriptions *mocks.MockSubscriptionRepository, users *mocks.MockUserRepository) {
+				subscriptions.EXPECT().DeleteByUser(mock.Anything, user.ID, &ticker).Return(0, nil)
+				subscriptions.EXPECT().ExcludeTicker(mock.Anything, user.ID, ticker).Return(true, nil)
+			},
+			expectedHandled: true,
+			expectedReply:   "Unsubscribed from BBCA.",
+		},
+		{
+			name: "List subscriptions",
+			user: user,
+			text: "/mysubs",
+			setup: func(subscriptions *mocks.MockSubscriptionRepository, users *mocks.MockUserRepository) {
+				subscriptions.EXPECT().ListByUser(mock.Anything, user.ID).Return([]*models.SignalSubscription{
+					{Ticker: nil, MinConfluenceScore: &score, ExcludedTickers: []string{"TLKM", "ASII"}},
+					{Ticker: &ticker},
+				}, nil)
+			},
+			expectedHandled: true,
+			expectedReply:   "Your signal subscriptions:\n• all tickers except TLKM, ASII (score ≥ 7)\n• BBCA",
+		},
+		{
+			name:            "Unregistered sender",
+			user:            nil,
+			text:            "/mysubs",
+			expectedHandled: true,
+			expectedReply:   "Only registered users can subscribe to signals. Please contact the administrator for access.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSubscriptionRepo := mocks.NewMockSubscriptionRepository(t)
+			mockUserRepo := mocks.NewMockUserRepository(t)
+			if tt.setup != nil {
+				tt.setup(mockSubscriptionRepo, mockUserRepo)
+			}
+
+			service := NewSubscriptionService(mockSubscriptionRepo, mockUserRepo)
+			reply, handled := service.HandleCommand(context.Background(), tt.user, tt.text)
+
+			assert.Equal(t, tt.expectedHandled, handled)
+			assert.Equal(t, tt.expectedReply, reply)
+		})
+	}
+}
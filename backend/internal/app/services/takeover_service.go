@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/repositories"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/pkg/phonenumber"
+)
+
+// TakeoverWorkflowType is the workflow_type recorded on messages sent by an agent
+const TakeoverWorkflowType = "agent"
+
+var (
+	ErrConversationTakenOver = errors.New("conversation is taken over by another agent")
+	ErrNotTakenOver          = errors.New("conversation is not taken over")
+)
+
+// TakeoverService lets support agents answer a conversation themselves. While
+// a conversation is taken over, inbound messages are recorded for the agent
+// instead of being routed to the workflow.
+type TakeoverService interface {
+	// Start hands the conversation to agent; starting it again is a no-op
+	Start(ctx context.Context, phone string, agent *models.Principal) (*models.ConversationTakeover, error)
+	// Release hands the conversation back to the workflow
+	Release(ctx context.Context, phone string) error
+	List(ctx context.Context) ([]*models.ConversationTakeover, error)
+	// IsTakenOver reports whether inbound messages from phone skip the workflow
+	IsTakenOver(ctx context.Context, phone string) (bool, error)
+	// CheckAgent returns the takeover of the conversation if agent holds it
+	CheckAgent(ctx context.Context, phone string, agent *models.Principal) (*models.ConversationTakeover, error)
+}
+
+type takeoverService struct {
+	takeoverRepo repositories.TakeoverRepository
+}
+
+func NewTakeoverService(takeoverRepo repositories.TakeoverRepository) TakeoverService {
+	return &takeoverService{
+		takeoverRepo: takeoverRepo,
+	}
+}
+
+func (s *takeoverService) Start(ctx context.Context, phone string, agent *models.Principal) (*models.ConversationTakeover, error) {
+	phone, err := phonenumber.Normalize(phone)
+	if err != nil {
+		return nil, ErrInvalidPhone
+	}
+
+	takeover := &models.ConversationTakeover{
+		Phone:       phone,
+		AgentUserID: agent.UserID,
+		AgentName:   agent.Name,
+	}
+	created, err := s.takeoverRepo.Create(ctx, takeover)
+	if err != nil {
+		log.Printf("[TakeoverService] Failed to take over %s: %v", phone, err)
+		return nil, err
+	}
+	if created {
+		log.Printf("[TakeoverService] %s took over the conversation with %s", agent.Name, phone)
+		return takeover, nil
+	}
+
+	return s.CheckAgent(ctx, phone, agent)
+}
+
+func (s *takeoverService) Release(ctx context.Context, phone string) error {
+	phone, err := phonenumber.Normalize(phone)
+	if err != nil {
+		return ErrInvalidPhone
+	}
+
+	released, err := s.takeoverRepo.Delete(ctx, phone)
+	if err != nil {
+		log.Printf("[TakeoverService] Failed to release %s: %v", phone, err)
+		return err
+	}
+	if !released {
+		return ErrNotTakenOver
+	}
+
+	log.Printf("[TakeoverService] Conversation with %s handed back to the workflow", phone)
+	return nil
+}
+
+func (s *takeoverService) List(ctx context.Context) ([]*models.ConversationTakeover, error) {
+	return s.takeoverRepo.List(ctx)
+}
+
+func (s *takeoverService) IsTakenOver(ctx context.Context, phone string) (bool, error) {
+	takeover, err := s.takeoverRepo.Get(ctx, phone)
+	if err != nil {
+		return false, err
+	}
+
+	return takeover != nil, nil
+}
+
+func (s *takeoverService) CheckAgent(ctx context.Context, phone string, agent *models.Principal) (*models.ConversationTakeover, error) {
+	phone, err := phonenumber.Normalize(phone)
+	if err != nil {
+		return nil, ErrInvalidPhone
+	}
+
+	takeover, err := s.takeoverRepo.Get(ctx, phone)
+	if err != nil {
+		return nil, err
+	}
+	if takeover == nil {
+		return nil, ErrNotTakenOver
+	}
+	if !isTakeoverAgent(takeover, agent) {
+		return nil, ErrConversationTakenOver
+	}
+
+	return takeover, nil
+}
+
+// isTakeoverAgent matches users by ID and the bootstrap key, which has none, by name
+func isTakeoverAgent(takeover *models.ConversationTakeover, agent *models.Principal) bool {
+	if agent.UserID == nil || takeover.AgentUserID == nil {
+		return agent.UserID == nil && takeover.AgentUserID == nil && agent.Name == takeover.AgentName
+	}
+
+	return *agent.UserID == *takeover.AgentUserID
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/testutils/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestTakeoverService_Start
+// Summary: Test an agent taking over a conversation
+// Purpose: Validate that phones are normalised, that taking over again is a no-op for the same agent and refused for another one
+func TestTakeoverService_Start(t *testing.T) {
+	agentID, otherID := uuid.New(), uuid.New()
+	agent := &models.Principal{UserID: &agentID, Name: "Siti"}
+
+	tests := []struct {
+		name        string
+		phone       string
+		agent       *models.Principal
+		created     bool
+		existing    *models.ConversationTakeover
+		expectedErr error
+	}{
+		{
+			name:    "New takeover",
+			phone:   "0812-3456-7890",
+			agent:   agent,
+			created: true,
+		},
+		{
+			name:     "Already held by the same agent",
+			phone:    "6281234567890",
+			agent:    agent,
+			existing: &models.ConversationTakeover{Phone: "6281234567890", AgentUserID: &agentID, AgentName: "Siti"},
+		},
+		{
+			name:        "Held by another agent",
+			phone:       "6281234567890",
+			agent:       agent,
+			existing:    &models.ConversationTakeover{Phone: "6281234567890", AgentUserID: &otherID, AgentName: "Andi"},
+			expectedErr: ErrConversationTakenOver,
+		},
+		{
+			name:        "Bootstrap key does not match a user",
+			phone:       "6281234567890",
+			agent:       &models.Principal{Name: "Siti"},
+			existing:    &models.ConversationTakeover{Phone: "6281234567890", AgentUserID: &agentID, AgentName: "Siti"},
+			expectedErr: ErrConversationTakenOver,
+		},
+		{
+			name:        "Invalid phone",
+			phone:       "abc",
+			agent:       agent,
+			expectedErr: ErrInvalidPhone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockTakeoverRepository(t)
+			if tt.expectedErr != ErrInvalidPhone {
+				mockRepo.EXPECT().Create(mock.Anything, mock.Anything).
+					Run(func(ctx context.Context, takeover *models.ConversationTakeover) {
+						assert.Equal(t, "6281234567890", takeover.Phone)
+						assert.Equal(t, tt.agent.Name, takeover.AgentName)
+					}).Return(tt.created, nil)
+			}
+			if tt.existing != nil {
+				mockRepo.EXPECT().Get(mock.Anything, "6281234567890").Return(tt.existing, nil)
+			}
+
+			service := NewTakeoverService(mockRepo)
+			takeover, err := service.Start(context.Background(), tt.phone, tt.agent)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, takeover)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "6281234567890", takeover.Phone)
+		})
+	}
+}
+
+// TestTakeoverService_CheckAgent
+// Summary: Test who may answer a taken over conversation
+// Purpose: Validate that only the agent holding the takeover may send, and that a conversation not taken over is refused
+func TestTakeoverService_CheckAgent(t *testing.T) {
+	agentID := uuid.New()
+	held := &models.ConversationTakeover{Phone: "6281234567890", AgentName: "ADMIN_API_KEY"}
+
+	tests := []struct {
+		name        string
+		agent       *models.Principal
+		existing    *models.ConversationTakeover
+		expectedErr error
+	}{
+		{
+			name:     "Bootstrap key holding the takeover",
+			agent:    &models.Principal{Name: "ADMIN_API_KEY"},
+			existing: held,
+		},
+		{
+			name:        "Another agent",
+			agent:       &models.Principal{UserID: &agentID, Name: "ADMIN_API_KEY"},
+			existing:    held,
+			expectedErr: ErrConversationTakenOver,
+		},
+		{
+			name:        "Not taken over",
+			agent:       &models.Principal{UserID: &agentID, Name: "Siti"},
+			expectedErr: ErrNotTakenOver,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockTakeoverRepository(t)
+			mockRepo.EXPECT().Get(mock.Anything, "6281234567890").Return(tt.existing, nil)
+
+			service := NewTakeoverService(mockRepo)
+			takeover, err := service.CheckAgent(context.Background(), "+62 812 3456 7890", tt.agent)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Same(t, held, takeover)
+		})
+	}
+
+	t.Run("Releasing a conversation not taken over", func(t *testing.T) {
+		mockRepo := mocks.NewMockTakeoverRepository(t)
+		mockRepo.EXPECT().Delete(mock.Anything, "6281234567890").Return(false, nil)
+
+		err := NewTakeoverService(mockRepo).Release(context.Background(), "6281234567890")
+		assert.ErrorIs(t, err, ErrNotTakenOver)
+	})
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// defaultSeparators are tried in order: paragraphs, lines, words, characters
+var defaultSeparators = []string{"\n\n", "\n", " ", ""}
+
+// TextSplitter is a recursive character splitter: it splits on the coarsest
+// separator that keeps pieces under ChunkSize, then merges neighbouring pieces
+// back into chunks that overlap by up to ChunkOverlap characters.
+type TextSplitter struct {
+	ChunkSize    int
+	ChunkOverlap int
+	Separators   []string
+}
+
+func NewRecursiveTextSplitter(chunkSize, chunkOverlap int) *TextSplitter {
+	if chunkOverlap >= chunkSize {
+		chunkOverlap = chunkSize / 2
+	}
+
+	return &TextSplitter{
+		ChunkSize:    chunkSize,
+		ChunkOverlap: chunkOverlap,
+		Separators:   defaultSeparators,
+	}
+}
+
+// Split returns the chunks of text; sizes are measured in characters, not bytes
+func (s *TextSplitter) Split(text string) []string {
+	return s.split(strings.TrimSpace(text), s.Separators)
+}
+
+func (s *TextSplitter) split(text string, separators []string) []string {
+	// Use the first separator present in text; "" always matches
+	separator := separators[len(separators)-1]
+	remaining := []string{}
+	for i, candidate := range separators {
+		if candidate == "" || strings.Contains(text, candidate) {
+			separator = candidate
+			remaining = separators[i+1:]
+			break
+		}
+	}
+
+	var chunks []string
+	var pending []string
+	for _, piece := range strings.Split(text, separator) {
+		if strings.TrimSpace(piece) == "" {
+			continue
+		}
+
+		if utf8.RuneCountInString(piece) <= s.ChunkSize {
+			pending = append(pending, piece)
+			continue
+		}
+
+		// Piece is too large on its own: flush what we have and split it further
+		chunks = append(chunks, s.merge(pending, separator)...)
+		pending = nil
+		if len(remaining) == 0 {
+			chunks = append(chunks, strings.TrimSpace(piece))
+		} else {
+			chunks = append(chunks, s.split(piece, remaining)...)
+		}
+	}
+
+	return append(chunks, s.merge(pending, separator)...)
+}
+
+// merge joins pieces into chunks of at most ChunkSize, carrying trailing pieces
+// of up to ChunkOverlap characters into the next chunk
+func (s *TextSplitter) merge(pieces []string, separator string) []string {
+	separatorLen := utf8.RuneCountInString(separator)
+
+	var chunks []string
+	var current []string
+	total := 0
+	for _, piece := range pieces {
+		pieceLen := utf8.RuneCountInString(piece)
+		joinLen := 0
+		if len(current) > 0 {
+			joinLen = separatorLen
+		}
+
+		if total+joinLen+pieceLen > s.ChunkSize && len(current) > 0 {
+			chunks = appendChunk(chunks, strings.Join(current, separator))
+
+			// Drop leading pieces until the carried overlap fits
+			for len(current) > 0 && (total > s.ChunkOverlap || total+separatorLen+pieceLen > s.ChunkSize) {
+				total -= utf8.RuneCountInString(current[0])
+				if len(current) > 1 {
+					total -= separatorLen
+				}
+				current = current[1:]
+			}
+		}
+
+		if len(current) > 0 {
+			total += separatorLen
+		}
+		current = append(current, piece)
+		total += pieceLen
+	}
+
+	if len(current) > 0 {
+		chunks = appendChunk(chunks, strings.Join(current, separator))
+	}
+
+	return chunks
+}
+
+func appendChunk(chunks []string, chunk string) []string {
+	chunk = strings.TrimSpace(chunk)
+	if chunk == "" {
+		return chunks
+	}
+	return append(chunks, chunk)
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTextSplitter_Split
+// Summary: Test recursive character splitting with overlap
+// Purpose: Validate chunk size limits, separator preference and overlap between chunks
+func TestTextSplitter_Split(t *testing.T) {
+	paragraph := strings.Repeat("Langkah reset password melalui portal. ", 5)
+	text := paragraph + "\n\n" + paragraph + "\n\n" + strings.Repeat("kata ", 80)
+
+	tests := []struct {
+		name      string
+		chunkSize int
+		overlap   int
+		text      string
+		expected  []string
+	}{
+		{
+			name:      "Short text is one chunk",
+			chunkSize: 100,
+			overlap:   10,
+			text:      "  Jam operasional IT support 08.00-17.00  ",
+			expected:  []string{"Jam operasional IT support 08.00-17.00"},
+		},
+		{
+			name:      "Paragraphs merged up to chunk size",
+			chunkSize: 20,
+			overlap:   0,
+			text:      "satu dua\n\ntiga empat\n\nlima enam tujuh",
+			expected:  []string{"satu dua\n\ntiga empat", "lima enam tujuh"},
+		},
+		{
+			name:      "Words carry overlap into next chunk",
+			chunkSize: 15,
+			overlap:   5,
+			text:      "alpha beta gamma delta epsilon",
+			expected:  []string{"alpha beta", "beta gamma", "gamma delta", "delta epsilon"},
+		},
+		{
+			name:      "Long word falls back to characters",
+			chunkSize: 4,
+			overlap:   0,
+			text:      "abcdefghij",
+			expected:  []string{"abcd", "efgh", "ij"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NewRecursiveTextSplitter(tt.chunkSize, tt.overlap).Split(tt.text))
+		})
+	}
+
+	t.Run("Chunks never exceed chunk size", func(t *testing.T) {
+		chunks := NewRecursiveTextSplitter(120, 30).Split(text)
+		assert.Greater(t, len(chunks), 2)
+		for _, chunk := range chunks {
+			assert.LessOrEqual(t, utf8.RuneCountInString(chunk), 120)
+		}
+	})
+}
+
+// TestSplitSections
+// Summary: Test splitting knowledge base documents on ruled headings
+// Purpose: Validate that headings framed by rule lines become section names
+func TestSplitSections(t *testing.T) {
+	text := strings.Join([]string{
+		"WORK INSTRUCTION - PASSWORD RESET",
+		"Document ID: WI-IT-002",
+		"",
+		"==========================",
+		"OVERVIEW",
+		"==========================",
+		"",
+		"Tujuan: Panduan reset password",
+		"",
+		"==========================",
+		"A. SELF-SERVICE PASSWORD RESET",
+		"==========================",
+		"Langkah 1: Akses portal",
+	}, "\n")
+
+	sections := splitSections(text)
+
+	assert.Equal(t, []documentSection{
+		{heading: "", body: "WORK INSTRUCTION - PASSWORD RESET\nDocument ID: WI-IT-002"},
+		{heading: "OVERVIEW", body: "Tujuan: Panduan reset password"},
+		{heading: "A. SELF-SERVICE PASSWORD RESET", body: "Langkah 1: Akses portal"},
+	}, sections)
+}
@@ -0,0 +1,227 @@
+package services
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/pkg/phonenumber"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Bulk user file formats
+const (
+	UserFormatCSV  = "csv"
+	UserFormatJSON = "json"
+)
+
+var ErrUnsupportedUserFormat = errors.New("unsupported format; use csv or json")
+
+// userCSVColumns is the export column order. Import needs name, phone and email
+// in any order, reads language when present and ignores the rest, so an export
+// can be edited and re-imported.
+var userCSVColumns = []string{"id", "name", "phone", "email", "language", "is_active", "created_at", "updated_at"}
+
+// bindingValidator checks import rows and signals against the same binding
+// tags gin applies, reporting fields by their JSON names
+var bindingValidator = func() *validator.Validate {
+	v := validator.New()
+	v.SetTagName("binding")
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return strings.Split(field.Tag.Get("json"), ",")[0]
+	})
+	return v
+}()
+
+// ParseUsers reads user rows in the given format
+func ParseUsers(r io.Reader, format string) ([]*models.CreateUserRequest, error) {
+	switch format {
+	case UserFormatCSV:
+		return parseUsersCSV(r)
+	case UserFormatJSON:
+		return parseUsersJSON(r)
+	default:
+		return nil, ErrUnsupportedUserFormat
+	}
+}
+
+// WriteUsers writes users in the given format
+func WriteUsers(w io.Writer, format string, users []*models.User) error {
+	switch format {
+	case UserFormatCSV:
+		return writeUsersCSV(w, users)
+	case UserFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(users)
+	default:
+		return ErrUnsupportedUserFormat
+	}
+}
+
+// parseUsersCSV reads a CSV file whose header names the name, phone and email
+// columns, and optionally language (case-insensitive)
+func parseUsersCSV(r io.Reader) ([]*models.CreateUserRequest, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("empty CSV file")
+		}
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, required := range []string{"name", "phone", "email"} {
+		if _, exists := columns[required]; !exists {
+			return nil, fmt.Errorf("CSV header is missing the %s column", required)
+		}
+	}
+
+	field := func(record []string, column string) string {
+		if i, exists := columns[column]; exists && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	var requests []*models.CreateUserRequest
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+
+		requests = append(requests, &models.CreateUserRequest{
+			Name:     field(record, "name"),
+			Phone:    field(record, "phone"),
+			Email:    field(record, "email"),
+			Language: field(record, "language"),
+		})
+	}
+
+	return requests, nil
+}
+
+// parseUsersJSON reads a JSON array of user objects
+func parseUsersJSON(r io.Reader) ([]*models.CreateUserRequest, error) {
+	var requests []*models.CreateUserRequest
+	if err := json.NewDecoder(r).Decode(&requests); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	for i, request := range requests {
+		if request == nil {
+			requests[i] = &models.CreateUserRequest{}
+		}
+	}
+
+	return requests, nil
+}
+
+func writeUsersCSV(w io.Writer, users []*models.User) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(userCSVColumns); err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		record := []string{
+			user.ID.String(),
+			user.Name,
+			user.Phone,
+			user.Email,
+			user.Language,
+			strconv.FormatBool(user.IsActive),
+			user.CreatedAt.Format(time.RFC3339),
+			user.UpdatedAt.Format(time.RFC3339),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// prepareImportRow trims the row, normalises its phone to E.164 and validates it
+// against the CreateUserRequest rules, reporting every problem at once
+func prepareImportRow(request *models.CreateUserRequest) error {
+	request.Name = strings.TrimSpace(request.Name)
+	request.Email = strings.TrimSpace(request.Email)
+	request.Phone = strings.TrimSpace(request.Phone)
+	request.Language = strings.ToLower(strings.TrimSpace(request.Language))
+
+	var messages []string
+	invalidPhone := false
+	if request.Phone != "" {
+		if phone, err := phonenumber.Normalize(request.Phone); err == nil {
+			request.Phone = phone
+		} else {
+			invalidPhone = true
+		}
+	}
+
+	if err := bindingValidator.Struct(request); err != nil {
+		var fieldErrors validator.ValidationErrors
+		if !errors.As(err, &fieldErrors) {
+			return err
+		}
+		for _, fieldError := range fieldErrors {
+			if invalidPhone && fieldError.Field() == "phone" {
+				continue
+			}
+			messages = append(messages, describeFieldError(fieldError))
+		}
+	}
+
+	if invalidPhone {
+		messages = append(messages, "phone is not a valid phone number")
+	}
+
+	if len(messages) > 0 {
+		return errors.New(strings.Join(messages, "; "))
+	}
+	return nil
+}
+
+func describeFieldError(fieldError validator.FieldError) string {
+	switch fieldError.Tag() {
+	case "required":
+		return fieldError.Field() + " is required"
+	case "min":
+		return fmt.Sprintf("%s must be at least %s characters", fieldError.Field(), fieldError.Param())
+	case "max":
+		return fmt.Sprintf("%s must be at most %s characters", fieldError.Field(), fieldError.Param())
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s", fieldError.Field(), fieldError.Param())
+	case "gte":
+		return fmt.Sprintf("%s must be at least %s", fieldError.Field(), fieldError.Param())
+	case "lte":
+		return fmt.Sprintf("%s must be at most %s", fieldError.Field(), fieldError.Param())
+	case "email":
+		return fieldError.Field() + " must be a valid email address"
+	case "oneof":
+		return fmt.Sprintf("%s must be one of %s", fieldError.Field(), strings.ReplaceAll(fieldError.Param(), " ", ", "))
+	default:
+		return fmt.Sprintf("%s failed %s validation", fieldError.Field(), fieldError.Tag())
+	}
+}
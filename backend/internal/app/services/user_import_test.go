@@ -0,0 +1,175 @@
+package services
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseUsers
+// Summary: Test reading bulk user files
+// Purpose: Validate CSV header mapping, ignored columns, JSON arrays and malformed input
+func TestParseUsers(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      string
+		input       string
+		expected    []*models.CreateUserRequest
+		expectError bool
+	}{
+		{
+			name:   "CSV with reordered, mixed-case and extra columns",
+			format: UserFormatCSV,
+			input:  "\ufeffEmail,Name,Phone,is_active\nbudi@example.com,Budi Santoso,+62 812-3456-7890,true\nsiti@example.com,Siti,6281111111111\n",
+			expected: []*models.CreateUserRequest{
+				{Name: "Budi Santoso", Phone: "+62 812-3456-7890", Email: "budi@example.com"},
+				{Name: "Siti", Phone: "6281111111111", Email: "siti@example.com"},
+			},
+		},
+		{
+			name:   "CSV with language column",
+			format: UserFormatCSV,
+			input:  "name,phone,email,language\nBudi,6281234567890,budi@example.com,en\n",
+			expected: []*models.CreateUserRequest{
+				{Name: "Budi", Phone: "6281234567890", Email: "budi@example.com", Language: "en"},
+			},
+		},
+		{
+			name:        "CSV without phone column",
+			format:      UserFormatCSV,
+			input:       "name,email\nBudi,budi@example.com\n",
+			expectError: true,
+		},
+		{
+			name:        "Empty CSV",
+			format:      UserFormatCSV,
+			input:       "",
+			expectError: true,
+		},
+		{
+			name:   "JSON array",
+			format: UserFormatJSON,
+			input:  `[{"name":"Budi Santoso","phone":"6281234567890","email":"budi@example.com"}]`,
+			expected: []*models.CreateUserRequest{
+				{Name: "Budi Santoso", Phone: "6281234567890", Email: "budi@example.com"},
+			},
+		},
+		{
+			name:        "JSON object instead of array",
+			format:      UserFormatJSON,
+			input:       `{"name":"Budi"}`,
+			expectError: true,
+		},
+		{
+			name:        "Unsupported format",
+			format:      "xlsx",
+			input:       "",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := ParseUsers(strings.NewReader(tt.input), tt.format)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, rows)
+		})
+	}
+}
+
+// TestWriteUsers_RoundTrip
+// Summary: Test exporting users as CSV and importing the file again
+// Purpose: Validate that an export can be edited and re-imported unchanged
+func TestWriteUsers_RoundTrip(t *testing.T) {
+	users := []*models.User{{
+		ID:        uuid.New(),
+		Name:      "Budi, S.Kom",
+		Phone:     "6281234567890",
+		Email:     "budi@example.com",
+		Language:  "en",
+		IsActive:  true,
+		CreatedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		UpdatedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}}
+
+	var buffer bytes.Buffer
+	assert.NoError(t, WriteUsers(&buffer, UserFormatCSV, users))
+	assert.True(t, strings.HasPrefix(buffer.String(), "id,name,phone,email,language,is_active,created_at,updated_at\n"))
+
+	rows, err := ParseUsers(&buffer, UserFormatCSV)
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.CreateUserRequest{{Name: "Budi, S.Kom", Phone: "6281234567890", Email: "budi@example.com", Language: "en"}}, rows)
+}
+
+// TestPrepareImportRow
+// Summary: Test normalising and validating import rows
+// Purpose: Validate phone normalisation and that CreateUserRequest rules are applied with readable errors
+func TestPrepareImportRow(t *testing.T) {
+	tests := []struct {
+		name          string
+		row           *models.CreateUserRequest
+		expectedPhone string
+		expectedError string
+	}{
+		{
+			name:          "Phone separators are stripped",
+			row:           &models.CreateUserRequest{Name: " Budi Santoso ", Phone: "+62 812-3456-7890", Email: "budi@example.com"},
+			expectedPhone: "6281234567890",
+		},
+		{
+			name:          "Invalid email",
+			row:           &models.CreateUserRequest{Name: "Budi", Phone: "6281234567890", Email: "budi"},
+			expectedError: "email must be a valid email address",
+		},
+		{
+			name:          "Indonesian trunk prefix becomes the country code",
+			row:           &models.CreateUserRequest{Name: "Budi Santoso", Phone: "0812 3456 7890", Email: "budi@example.com"},
+			expectedPhone: "6281234567890",
+		},
+		{
+			name:          "Missing name and phone",
+			row:           &models.CreateUserRequest{Email: "budi@example.com"},
+			expectedError: "name is required; phone is required",
+		},
+		{
+			name:          "Short phone and missing name",
+			row:           &models.CreateUserRequest{Phone: "0812", Email: "budi@example.com"},
+			expectedError: "name is required; phone is not a valid phone number",
+		},
+		{
+			name:          "Unsupported language",
+			row:           &models.CreateUserRequest{Name: "Budi", Phone: "6281234567890", Email: "budi@example.com", Language: "fr"},
+			expectedError: "language must be one of id, en",
+		},
+		{
+			name:          "Phone of separators only",
+			row:           &models.CreateUserRequest{Name: "Budi", Phone: "+ -", Email: "budi@example.com"},
+			expectedError: "phone is not a valid phone number",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := prepareImportRow(tt.row)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedPhone, tt.row.Phone)
+			assert.Equal(t, "Budi Santoso", tt.row.Name)
+		})
+	}
+}
@@ -6,10 +6,22 @@ import (
 
 	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
 	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/repositories"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/pkg/phonenumber"
 
 	"github.com/google/uuid"
 )
 
+const (
+	defaultUserPageSize = 20
+	maxUserPageSize     = 100
+)
+
+var (
+	ErrUserNotFound  = repositories.ErrUserNotFound
+	ErrDuplicateUser = repositories.ErrDuplicateUser
+	ErrInvalidPhone  = phonenumber.ErrInvalidPhone
+)
+
 type UserService interface {
 	IsUserEligible(ctx context.Context, phone string) (bool, error)
 	GetUserByPhone(ctx context.Context, phone string) (*models.User, error)
@@ -18,6 +30,13 @@ type UserService interface {
 	UpdateUser(ctx context.Context, id uuid.UUID, req *models.UpdateUserRequest) (*models.User, error)
 	DeleteUser(ctx context.Context, id uuid.UUID) error
 	GetEligibleUsers(ctx context.Context) ([]*models.User, error)
+	ListUsers(ctx context.Context, filter *models.UserFilter, page, pageSize int) (*models.UserPage, error)
+	SetUserActive(ctx context.Context, id uuid.UUID, active bool) (*models.User, error)
+	// ImportUsers validates each row and upserts it on phone or email. A failed
+	// row is reported and does not stop the import; a dry run only validates.
+	ImportUsers(ctx context.Context, rows []*models.CreateUserRequest, dryRun bool) *models.UserImportReport
+	// ExportUsers returns every user matching filter, newest first
+	ExportUsers(ctx context.Context, filter *models.UserFilter) ([]*models.User, error)
 }
 
 type userService struct {
@@ -120,3 +139,108 @@ func (s *userService) GetEligibleUsers(ctx context.Context) ([]*models.User, err
 	log.Printf("[UserService] Found %d eligible users", len(users))
 	return users, nil
 }
+
+func (s *userService) ListUsers(ctx context.Context, filter *models.UserFilter, page, pageSize int) (*models.UserPage, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultUserPageSize
+	}
+	if pageSize > maxUserPageSize {
+		pageSize = maxUserPageSize
+	}
+
+	log.Printf("[UserService] Listing users (search %q, page %d, size %d)", filter.Search, page, pageSize)
+
+	users, total, err := s.userRepo.List(ctx, filter, pageSize, (page-1)*pageSize)
+	if err != nil {
+		log.Printf("[UserService] Failed to list users: %v", err)
+		return nil, err
+	}
+
+	return &models.UserPage{
+		Users:    users,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// SetUserActive activates or deactivates a user; inactive users are not eligible
+// to use the bot
+func (s *userService) SetUserActive(ctx context.Context, id uuid.UUID, active bool) (*models.User, error) {
+	return s.UpdateUser(ctx, id, &models.UpdateUserRequest{IsActive: &active})
+}
+
+func (s *userService) ImportUsers(ctx context.Context, rows []*models.CreateUserRequest, dryRun bool) *models.UserImportReport {
+	log.Printf("[UserService] Importing %d users (dry run: %t)", len(rows), dryRun)
+
+	report := &models.UserImportReport{
+		Total:  len(rows),
+		DryRun: dryRun,
+		Rows:   make([]*models.UserImportRow, 0, len(rows)),
+	}
+
+	for i, row := range rows {
+		result := &models.UserImportRow{Row: i + 1}
+		report.Rows = append(report.Rows, result)
+
+		err := prepareImportRow(row)
+		result.Phone = row.Phone
+		result.Email = row.Email
+		if err != nil {
+			result.Status = models.UserImportFailed
+			result.Error = err.Error()
+			report.Failed++
+			continue
+		}
+
+		if dryRun {
+			result.Status = models.UserImportValid
+			continue
+		}
+
+		user, created, err := s.userRepo.Upsert(ctx, row)
+		if err != nil {
+			log.Printf("[UserService] Failed to import row %d (Phone: %s): %v", result.Row, row.Phone, err)
+			result.Status = models.UserImportFailed
+			result.Error = err.Error()
+			report.Failed++
+			continue
+		}
+
+		result.UserID = &user.ID
+		if created {
+			result.Status = models.UserImportCreated
+			report.Created++
+		} else {
+			result.Status = models.UserImportUpdated
+			report.Updated++
+		}
+	}
+
+	log.Printf("[UserService] Import finished: %d created, %d updated, %d failed", report.Created, report.Updated, report.Failed)
+	return report
+}
+
+func (s *userService) ExportUsers(ctx context.Context, filter *models.UserFilter) ([]*models.User, error) {
+	log.Printf("[UserService] Exporting users (search %q)", filter.Search)
+
+	users := []*models.User{}
+	for offset := 0; ; offset += maxUserPageSize {
+		page, total, err := s.userRepo.List(ctx, filter, maxUserPageSize, offset)
+		if err != nil {
+			log.Printf("[UserService] Failed to export users: %v", err)
+			return nil, err
+		}
+
+		users = append(users, page...)
+		if len(page) < maxUserPageSize || len(users) >= total {
+			break
+		}
+	}
+
+	log.Printf("[UserService] Exported %d users", len(users))
+	return users, nil
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/testutils/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestUserService_ListUsers
+// Summary: Test paginated user listing
+// Purpose: Validate page defaults, page size limits, offset calculation and filter pass-through
+func TestUserService_ListUsers(t *testing.T) {
+	active := true
+
+	tests := []struct {
+		name             string
+		filter           *models.UserFilter
+		page             int
+		pageSize         int
+		expectedLimit    int
+		expectedOffset   int
+		expectedPage     int
+		expectedPageSize int
+	}{
+		{
+			name:             "Defaults for missing pagination",
+			filter:           &models.UserFilter{},
+			expectedLimit:    20,
+			expectedOffset:   0,
+			expectedPage:     1,
+			expectedPageSize: 20,
+		},
+		{
+			name:             "Second page of active users matching search",
+			filter:           &models.UserFilter{Search: "budi", IsActive: &active},
+			page:             2,
+			pageSize:         10,
+			expectedLimit:    10,
+			expectedOffset:   10,
+			expectedPage:     2,
+			expectedPageSize: 10,
+		},
+		{
+			name:             "Page size is capped",
+			filter:           &models.UserFilter{},
+			page:             1,
+			pageSize:         1000,
+			expectedLimit:    100,
+			expectedOffset:   0,
+			expectedPage:     1,
+			expectedPageSize: 100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockUserRepository(t)
+			users := []*models.User{{ID: uuid.New(), Name: "Budi", Phone: "6281234567890", IsActive: true}}
+			mockRepo.EXPECT().List(mock.Anything, tt.filter, tt.expectedLimit, tt.expectedOffset).Return(users, 11, nil)
+
+			service := NewUserService(mockRepo)
+			result, err := service.ListUsers(context.Background(), tt.filter, tt.page, tt.pageSize)
+
+			assert.NoError(t, err)
+			assert.Equal(t, 11, result.Total)
+			assert.Equal(t, tt.expectedPage, result.Page)
+			assert.Equal(t, tt.expectedPageSize, result.PageSize)
+			assert.Equal(t, users, result.Users)
+		})
+	}
+}
+
+// TestUserService_SetUserActive
+// Summary: Test activating and deactivating users
+// Purpose: Validate that only is_active is updated and that missing users return ErrUserNotFound
+func TestUserService_SetUserActive(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name        string
+		active      bool
+		repoErr     error
+		expectedErr error
+	}{
+		{name: "Activate user", active: true},
+		{name: "Deactivate user", active: false},
+		{name: "Missing user", active: true, repoErr: ErrUserNotFound, expectedErr: ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockUserRepository(t)
+			var updated *models.User
+			if tt.repoErr == nil {
+				updated = &models.User{ID: id, Name: "Budi", IsActive: tt.active}
+			}
+			mockRepo.EXPECT().Update(mock.Anything, id, mock.MatchedBy(func(req *models.UpdateUserRequest) bool {
+				return req.IsActive != nil && *req.IsActive == tt.active && req.Name == "" && req.Phone == "" && req.Email == ""
+			})).Return(updated, tt.repoErr)
+
+			service := NewUserService(mockRepo)
+			user, err := service.SetUserActive(context.Background(), id, tt.active)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, user)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.active, user.IsActive)
+		})
+	}
+}
+
+// TestUserService_ImportUsers
+// Summary: Test bulk user import with a per-row report
+// Purpose: Validate that rows are normalised, upserted, counted, and that failures do not stop the import
+func TestUserService_ImportUsers(t *testing.T) {
+	createdID := uuid.New()
+	updatedID := uuid.New()
+
+	mockRepo := mocks.NewMockUserRepository(t)
+	mockRepo.EXPECT().Upsert(mock.Anything, &models.CreateUserRequest{Name: "Budi Santoso", Phone: "6281234567890", Email: "budi@example.com"}).
+		Return(&models.User{ID: createdID}, true, nil)
+	mockRepo.EXPECT().Upsert(mock.Anything, &models.CreateUserRequest{Name: "Siti", Phone: "6281111111111", Email: "siti@example.com"}).
+		Return(&models.User{ID: updatedID}, false, nil)
+	mockRepo.EXPECT().Upsert(mock.Anything, &models.CreateUserRequest{Name: "Andi", Phone: "6282222222222", Email: "budi@example.com"}).
+		Return(nil, false, ErrDuplicateUser)
+
+	service := NewUserService(mockRepo)
+	report := service.ImportUsers(context.Background(), []*models.CreateUserRequest{
+		{Name: "Budi Santoso", Phone: "+62 812-3456-7890", Email: "budi@example.com"},
+		{Name: "Siti", Phone: "6281111111111", Email: "siti@example.com"},
+		{Name: "Rina", Phone: "6283333333333", Email: "not-an-email"},
+		{Name: "Andi", Phone: "6282222222222", Email: "budi@example.com"},
+	}, false)
+
+	assert.Equal(t, 4, report.Total)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Updated)
+	assert.Equal(t, 2, report.Failed)
+	assert.Len(t, report.Rows, 4)
+
+	assert.Equal(t, models.UserImportCreated, report.Rows[0].Status)
+	assert.Equal(t, "6281234567890", report.Rows[0].Phone)
+	assert.Equal(t, &createdID, report.Rows[0].UserID)
+	assert.Equal(t, models.UserImportUpdated, report.Rows[1].Status)
+	assert.Equal(t, 3, report.Rows[2].Row)
+	assert.Equal(t, models.UserImportFailed, report.Rows[2].Status)
+	assert.Equal(t, "email must be a valid email address", report.Rows[2].Error)
+	assert.Equal(t, models.UserImportFailed, report.Rows[3].Status)
+	assert.Nil(t, report.Rows[3].UserID)
+}
+
+// TestUserService_ImportUsers_DryRun
+// Summary: Test validating an import without writing
+// Purpose: Validate that a dry run never calls the repository
+func TestUserService_ImportUsers_DryRun(t *testing.T) {
+	service := NewUserService(mocks.NewMockUserRepository(t))
+	report := service.ImportUsers(context.Background(), []*models.CreateUserRequest{
+		{Name: "Budi Santoso", Phone: "6281234567890", Email: "budi@example.com"},
+		{Name: "B", Phone: "6281234567890", Email: "budi@example.com"},
+	}, true)
+
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 0, report.Created)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, models.UserImportValid, report.Rows[0].Status)
+	assert.Equal(t, models.UserImportFailed, report.Rows[1].Status)
+}
+
+// TestUserService_ExportUsers
+// Summary: Test exporting every matching user
+// Purpose: Validate that export pages through the repository until all users are read
+func TestUserService_ExportUsers(t *testing.T) {
+	filter := &models.UserFilter{Search: "budi"}
+	firstPage := make([]*models.User, maxUserPageSize)
+	for i := range firstPage {
+		firstPage[i] = &models.User{ID: uuid.New()}
+	}
+
+	mockRepo := mocks.NewMockUserRepository(t)
+	mockRepo.EXPECT().List(mock.Anything, filter, maxUserPageSize, 0).Return(firstPage, maxUserPageSize+1, nil)
+	mockRepo.EXPECT().List(mock.Anything, filter, maxUserPageSize, maxUserPageSize).Return([]*models.User{{ID: uuid.New()}}, maxUserPageSize+1, nil)
+
+	service := NewUserService(mockRepo)
+	users, err := service.ExportUsers(context.Background(), filter)
+
+	assert.NoError(t, err)
+	assert.Len(t, users, maxUserPageSize+1)
+}
@@ -3,12 +3,15 @@ package services
 import (
 	"context"
 	"fmt"
-	"github.com/google/uuid"
 	"log"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/pkg/phonenumber"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
 	"go.mau.fi/whatsmeow"
@@ -23,31 +26,58 @@ type WhatsAppService interface {
 	Start(ctx context.Context) error
 	Stop() error
 	SendMessage(ctx context.Context, phone, message string) error
+	// SendMessageWithID is SendMessage returning the WhatsApp message ID
+	SendMessageWithID(ctx context.Context, phone, message string) (string, error)
+	// SendReplyWithID sends message as a reply quoting quotedMessage, an earlier
+	// message of this device with WhatsApp ID quotedID, and returns the new message ID
+	SendReplyWithID(ctx context.Context, phone, message, quotedID, quotedMessage string) (string, error)
 	IsConnected() bool
 	GetQRCode() (string, error)
 	Logout() error
 }
 
+const (
+	// maxConcurrentRoutes bounds how many inbound messages are handed to workflows at once
+	maxConcurrentRoutes = 16
+	// routeTimeout bounds handing one message to a workflow, retries included
+	routeTimeout = 90 * time.Second
+)
+
 type whatsAppService struct {
 	client            *whatsmeow.Client
 	userService       UserService
-	n8nService        N8NService
-	flowiseService    FlowiseService
+	accessPolicySvc   AccessPolicyService
+	sessionSvc        SessionService
+	messageSvc        MessageService
+	workflowRegistry  WorkflowRegistry
 	workflowConfigSvc WorkflowConfigService
+	subscriptionSvc   SubscriptionService
+	takeoverSvc       TakeoverService
+	auditSvc          AuditService
 	dbPool            *pgxpool.Pool
 	container         *sqlstore.Container
 	device            *store.Device
 	isConnected       bool
 	qrCode            string
+	routes            chan struct{}
+	routing           sync.WaitGroup
+	routeTimeout      time.Duration
 }
 
-func NewWhatsAppService(userService UserService, n8nService N8NService, flowiseService FlowiseService, workflowConfigSvc WorkflowConfigService, dbPool *pgxpool.Pool) WhatsAppService {
+func NewWhatsAppService(userService UserService, accessPolicySvc AccessPolicyService, sessionSvc SessionService, messageSvc MessageService, workflowRegistry WorkflowRegistry, workflowConfigSvc WorkflowConfigService, subscriptionSvc SubscriptionService, takeoverSvc TakeoverService, auditSvc AuditService, dbPool *pgxpool.Pool) WhatsAppService {
 	return &whatsAppService{
 		userService:       userService,
-		n8nService:        n8nService,
-		flowiseService:    flowiseService,
+		accessPolicySvc:   accessPolicySvc,
+		sessionSvc:        sessionSvc,
+		messageSvc:        messageSvc,
+		workflowRegistry:  workflowRegistry,
 		workflowConfigSvc: workflowConfigSvc,
+		subscriptionSvc:   subscriptionSvc,
+		takeoverSvc:       takeoverSvc,
+		auditSvc:          auditSvc,
 		dbPool:            dbPool,
+		routes:            make(chan struct{}, maxConcurrentRoutes),
+		routeTimeout:      routeTimeout,
 	}
 }
 
@@ -102,38 +132,110 @@ func (s *whatsAppService) Stop() error {
 		s.isConnected = false
 	}
 
+	// Let messages already handed to workflows finish
+	s.routing.Wait()
+
 	log.Printf("[WhatsAppService] WhatsApp service stopped")
 	return nil
 }
 
 func (s *whatsAppService) SendMessage(ctx context.Context, phone, message string) error {
+	_, err := s.SendMessageWithID(ctx, phone, message)
+	return err
+}
+
+func (s *whatsAppService) SendMessageWithID(ctx context.Context, phone, message string) (string, error) {
 	log.Printf("[WhatsAppService] Sending message to %s: %s", phone, message)
 
+	// Record the transcript under the same number inbound messages use
+	if normalized, err := phonenumber.Normalize(phone); err == nil {
+		phone = normalized
+	}
+
+	whatsAppMessageID, err := s.sendText(ctx, phone, message, "", "")
+	s.recordOutbound(ctx, phone, message, whatsAppMessageID, err)
+
+	return whatsAppMessageID, err
+}
+
+func (s *whatsAppService) SendReplyWithID(ctx context.Context, phone, message, quotedID, quotedMessage string) (string, error) {
+	log.Printf("[WhatsAppService] Sending reply to %s quoting %s: %s", phone, quotedID, message)
+
+	if normalized, err := phonenumber.Normalize(phone); err == nil {
+		phone = normalized
+	}
+
+	whatsAppMessageID, err := s.sendText(ctx, phone, message, quotedID, quotedMessage)
+	s.recordOutbound(ctx, phone, message, whatsAppMessageID, err)
+
+	return whatsAppMessageID, err
+}
+
+// sendText sends a text message, as a reply to quotedID when it is set
+func (s *whatsAppService) sendText(ctx context.Context, phone, message, quotedID, quotedMessage string) (string, error) {
 	if !s.isConnected {
-		return fmt.Errorf("WhatsApp client not connected")
+		return "", fmt.Errorf("WhatsApp client not connected")
 	}
 
 	// Format phone number for WhatsApp JID
 	jid, err := s.formatPhoneToJID(phone)
 	if err != nil {
 		log.Printf("[WhatsAppService] Failed to format phone number %s: %v", phone, err)
-		return fmt.Errorf("invalid phone number: %w", err)
+		return "", fmt.Errorf("invalid phone number: %w", err)
 	}
 
 	// Create message
 	msg := &waE2E.Message{
 		Conversation: &message,
 	}
+	if quotedID != "" {
+		contextInfo := &waE2E.ContextInfo{
+			StanzaID:      &quotedID,
+			QuotedMessage: &waE2E.Message{Conversation: &quotedMessage},
+		}
+		// The quoted message was sent by this device
+		if s.client.Store.ID != nil {
+			participant := s.client.Store.ID.ToNonAD().String()
+			contextInfo.Participant = &participant
+		}
+		msg = &waE2E.Message{
+			ExtendedTextMessage: &waE2E.ExtendedTextMessage{
+				Text:        &message,
+				ContextInfo: contextInfo,
+			},
+		}
+	}
 
 	// Send message
 	resp, err := s.client.SendMessage(ctx, jid, msg)
 	if err != nil {
 		log.Printf("[WhatsAppService] Failed to send message to %s: %v", phone, err)
-		return fmt.Errorf("failed to send message: %w", err)
+		return "", fmt.Errorf("failed to send message: %w", err)
 	}
 
 	log.Printf("[WhatsAppService] Message sent successfully to %s (ID: %s)", phone, resp.ID)
-	return nil
+	return resp.ID, nil
+}
+
+func (s *whatsAppService) recordOutbound(ctx context.Context, phone, content, whatsAppMessageID string, sendErr error) {
+	meta := MessageMetaFromContext(ctx)
+
+	outbound := &models.Message{
+		Phone:             phone,
+		Direction:         models.MessageDirectionOutbound,
+		WhatsAppMessageID: whatsAppMessageID,
+		CorrelationID:     meta.CorrelationID,
+		WorkflowType:      meta.WorkflowType,
+		Content:           content,
+		Status:            models.MessageStatusSent,
+	}
+
+	if sendErr != nil {
+		outbound.Status = models.MessageStatusFailed
+		outbound.Error = sendErr.Error()
+	}
+
+	s.recordMessage(ctx, outbound)
 }
 
 func (s *whatsAppService) IsConnected() bool {
@@ -157,6 +259,8 @@ func (s *whatsAppService) handleEvent(evt interface{}) {
 		s.handleConnected(v)
 	case *events.Disconnected:
 		s.handleDisconnected(v)
+	case *events.PairSuccess:
+		s.handlePairSuccess(v)
 	case *events.LoggedOut:
 		s.handleLoggedOut(v)
 	}
@@ -178,27 +282,6 @@ func (s *whatsAppService) handleIncomingMessage(evt *events.Message) {
 	}
 
 	ctx := context.Background()
-	// Check user eligibility
-	// TODO: Uncomment when you want spesific user AI Reply
-	//eligible, err := s.userService.IsUserEligible(ctx, phone)
-	//if err != nil {
-	//	log.Printf("[WhatsAppService] Failed to check user eligibility for %s: %v", phone, err)
-	//	return
-	//}
-	//
-	//if !eligible {
-	//	log.Printf("[WhatsAppService] User %s is not eligible, ignoring message", phone)
-	//	// Optionally send a response to unregistered users
-	//	//s.sendUnregisteredUserMessage(ctx, phone)
-	//	return
-	//}
-	//
-	//// Get user details
-	//user, err := s.userService.GetUserByPhone(ctx, phone)
-	//if err != nil {
-	//	log.Printf("[WhatsAppService] Failed to get user by phone %s: %v", phone, err)
-	//	return
-	//}
 
 	// Extract message text
 	messageText := s.extractMessageText(evt.Message)
@@ -207,29 +290,148 @@ func (s *whatsAppService) handleIncomingMessage(evt *events.Message) {
 		return
 	}
 
-	//log.Printf("[WhatsAppService] Processing message from %s (%s): %s", user.Name, phone, messageText)
+	inbound := &models.Message{
+		Phone:             phone,
+		Direction:         models.MessageDirectionInbound,
+		WhatsAppMessageID: evt.Info.ID,
+		Content:           messageText,
+		Status:            models.MessageStatusReceived,
+	}
+
+	// Check access policy (open, registered or allowlist)
+	decision, err := s.accessPolicySvc.Authorize(ctx, phone, evt.Info.PushName)
+	if err != nil {
+		log.Printf("[WhatsAppService] Failed to authorize user %s: %v", phone, err)
+		inbound.Status = models.MessageStatusFailed
+		inbound.Error = err.Error()
+		s.recordMessage(ctx, inbound)
+		return
+	}
+
+	if !decision.Allowed {
+		log.Printf("[WhatsAppService] User %s is not allowed (%s), ignoring message", phone, decision.Reason)
+		inbound.Status = models.MessageStatusRejected
+		inbound.Error = decision.Reason
+		s.recordMessage(ctx, inbound)
+		if decision.NotifyUnregistered {
+			s.sendUnregisteredUserMessage(ctx, phone)
+		}
+		return
+	}
 
 	// Create user context
-	userContext := &models.UserContext{
-		UserID: uuid.New(),
-		//Name:   user.Name,
-		Name:  "Dummy",
-		Phone: phone,
-		Email: "dummy@email.com",
+	userContext := s.buildUserContext(phone, evt.Info.PushName, decision.User)
+
+	log.Printf("[WhatsAppService] Processing message from %s (%s): %s", userContext.Name, phone, messageText)
+
+	// Handle reset command before anything reaches the workflow
+	if s.isResetCommand(messageText) {
+		s.recordMessage(ctx, inbound)
+		s.handleResetCommand(ctx, phone)
+		return
+	}
+
+	// Signal subscription commands are answered here and never reach the workflow
+	if reply, handled := s.subscriptionSvc.HandleCommand(ctx, decision.User, messageText); handled {
+		s.recordMessage(ctx, inbound)
+		if err := s.SendMessage(ctx, phone, reply); err != nil {
+			log.Printf("[WhatsAppService] Failed to send subscription reply to %s: %v", phone, err)
+		}
+		return
+	}
+
+	// A support agent answers conversations they took over; the message is
+	// only recorded for them
+	takenOver, err := s.takeoverSvc.IsTakenOver(ctx, phone)
+	if err != nil {
+		log.Printf("[WhatsAppService] Failed to check takeover for %s, routing to the workflow: %v", phone, err)
+	}
+	if takenOver {
+		log.Printf("[WhatsAppService] Conversation with %s is taken over, not routing to the workflow", phone)
+		s.recordMessage(ctx, inbound)
+		return
+	}
+
+	// Get conversation session so workflows can keep memory across turns
+	session, err := s.sessionSvc.GetOrCreateSession(ctx, phone)
+	if err != nil {
+		log.Printf("[WhatsAppService] Failed to get session for user %s: %v", phone, err)
+		inbound.Status = models.MessageStatusFailed
+		inbound.Error = err.Error()
+		s.recordMessage(ctx, inbound)
+		s.sendErrorMessage(ctx, phone)
+		return
+	}
+
+	request := &models.WorkflowRequest{
+		MessageID:   uuid.New().String(),
+		SessionID:   session.ID.String(),
+		UserContext: userContext,
+		Message:     messageText,
+	}
+
+	inbound.SessionID = &session.ID
+	inbound.CorrelationID = request.MessageID
+	s.recordMessage(ctx, inbound)
+
+	// Workflow calls retry with backoff, so they run outside the whatsmeow
+	// event handler to keep one slow workflow from stalling every inbound message
+	s.routing.Add(1)
+	go func() {
+		defer s.routing.Done()
+		s.routeInbound(inbound, request)
+	}()
+}
+
+// routeInbound hands a recorded inbound message to the active workflow, waiting
+// for a free routing slot and giving up once routeTimeout has passed
+func (s *whatsAppService) routeInbound(inbound *models.Message, request *models.WorkflowRequest) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.routeTimeout)
+	defer cancel()
+
+	phone := inbound.Phone
+
+	var workflowType string
+	var err error
+	select {
+	case s.routes <- struct{}{}:
+		workflowType, err = s.routeMessageToWorkflow(ctx, request)
+		<-s.routes
+	case <-ctx.Done():
+		err = fmt.Errorf("no free routing slot: %w", ctx.Err())
 	}
 
-	// Route message to appropriate workflow
-	err := s.routeMessageToWorkflow(ctx, userContext, messageText)
+	// The routing deadline may have passed; status updates and replies get their own
+	ctx = context.Background()
 	if err != nil {
 		log.Printf("[WhatsAppService] Failed to route message for user %s: %v", phone, err)
+		s.updateMessageStatus(ctx, inbound, models.MessageStatusFailed, workflowType, err.Error())
 		// Send error message to user
 		s.sendErrorMessage(ctx, phone)
 		return
 	}
 
+	s.updateMessageStatus(ctx, inbound, models.MessageStatusRouted, workflowType, "")
 	log.Printf("[WhatsAppService] Message routed to workflow successfully for user %s", phone)
 }
 
+// recordMessage stores a transcript entry; failures are logged and never block messaging
+func (s *whatsAppService) recordMessage(ctx context.Context, message *models.Message) {
+	if err := s.messageSvc.Record(ctx, message); err != nil {
+		log.Printf("[WhatsAppService] Failed to record %s message for %s: %v", message.Direction, message.Phone, err)
+	}
+}
+
+func (s *whatsAppService) updateMessageStatus(ctx context.Context, message *models.Message, status, workflowType, errMsg string) {
+	if message.ID == uuid.Nil {
+		return // Message was never recorded
+	}
+
+	if err := s.messageSvc.UpdateStatus(ctx, message.ID, status, workflowType, errMsg); err != nil {
+		log.Printf("[WhatsAppService] Failed to update message %s status: %v", message.ID.String(), err)
+	}
+}
+
 func (s *whatsAppService) handleQRCode(evt *events.QR) {
 	log.Printf("[WhatsAppService] QR code received, ready for scanning")
 	s.qrCode = evt.Codes[0]
@@ -246,19 +448,38 @@ func (s *whatsAppService) handleDisconnected(_ *events.Disconnected) {
 	s.isConnected = false
 }
 
-func (s *whatsAppService) handleLoggedOut(_ *events.LoggedOut) {
+// handlePairSuccess audits a scan of the QR code that linked a phone to the bot
+func (s *whatsAppService) handlePairSuccess(evt *events.PairSuccess) {
+	log.Printf("[WhatsAppService] Paired with %s (%s)", evt.ID.String(), evt.Platform)
+	s.auditSvc.Record(context.Background(), &models.AuditEntry{
+		Action: models.AuditActionWhatsAppPaired,
+		Actor:  models.AuditActorWhatsApp,
+		Details: map[string]string{
+			"jid":           evt.ID.String(),
+			"platform":      evt.Platform,
+			"business_name": evt.BusinessName,
+		},
+	})
+}
+
+// handleLoggedOut audits the device being unlinked from the phone or by WhatsApp;
+// a logout requested through the API is audited by the caller
+func (s *whatsAppService) handleLoggedOut(evt *events.LoggedOut) {
 	log.Printf("[WhatsAppService] Logged out from WhatsApp")
 	s.isConnected = false
+	s.auditSvc.Record(context.Background(), &models.AuditEntry{
+		Action: models.AuditActionWhatsAppLoggedOut,
+		Actor:  models.AuditActorWhatsApp,
+		Details: map[string]string{
+			"reason": evt.Reason.String(),
+		},
+	})
 }
 
 func (s *whatsAppService) formatPhoneToJID(phone string) (types.JID, error) {
-	// Remove non-numeric characters
-	cleanPhone := strings.ReplaceAll(phone, "+", "")
-	cleanPhone = strings.ReplaceAll(cleanPhone, "-", "")
-	cleanPhone = strings.ReplaceAll(cleanPhone, " ", "")
-
-	if len(cleanPhone) == 0 {
-		return types.JID{}, fmt.Errorf("invalid phone number")
+	cleanPhone, err := phonenumber.Normalize(phone)
+	if err != nil {
+		return types.JID{}, err
 	}
 
 	// Create JID for individual chat
@@ -270,6 +491,24 @@ func (s *whatsAppService) extractPhoneFromJID(jid types.JID) string {
 	return jid.User
 }
 
+// buildUserContext returns the registered identity when known, otherwise an
+// anonymous context carrying only the phone and WhatsApp display name
+func (s *whatsAppService) buildUserContext(phone, displayName string, user *models.User) *models.UserContext {
+	if user == nil {
+		return &models.UserContext{
+			Name:  displayName,
+			Phone: phone,
+		}
+	}
+
+	return &models.UserContext{
+		UserID: user.ID,
+		Name:   user.Name,
+		Phone:  phone,
+		Email:  user.Email,
+	}
+}
+
 func (s *whatsAppService) extractMessageText(msg *waE2E.Message) string {
 	if msg == nil {
 		return ""
@@ -304,40 +543,48 @@ func (s *whatsAppService) sendErrorMessage(ctx context.Context, phone string) {
 	}
 }
 
-func (s *whatsAppService) routeMessageToWorkflow(ctx context.Context, userContext *models.UserContext, message string) error {
+func (s *whatsAppService) isResetCommand(message string) bool {
+	return strings.EqualFold(strings.TrimSpace(message), "/reset")
+}
+
+func (s *whatsAppService) handleResetCommand(ctx context.Context, phone string) {
+	_, err := s.sessionSvc.ResetSession(ctx, phone)
+	if err != nil {
+		log.Printf("[WhatsAppService] Failed to reset session for %s: %v", phone, err)
+		s.sendErrorMessage(ctx, phone)
+		return
+	}
+
+	message := "Your conversation has been reset. Send a new message to start a fresh conversation."
+	if err := s.SendMessage(ctx, phone, message); err != nil {
+		log.Printf("[WhatsAppService] Failed to send reset confirmation to %s: %v", phone, err)
+	}
+}
+
+func (s *whatsAppService) routeMessageToWorkflow(ctx context.Context, request *models.WorkflowRequest) (string, error) {
 	// Get global workflow configuration
 	workflowType, err := s.workflowConfigSvc.GetActiveWorkflowType(ctx)
 	if err != nil {
 		log.Printf("[WhatsAppService] Failed to get workflow config: %v", err)
-		workflowType = "n8n" // Default fallback
+		workflowType = "" // Registry falls back to the default backend
+	}
+
+	backend, err := s.workflowRegistry.Resolve(workflowType)
+	if err != nil {
+		log.Printf("[WhatsAppService] No workflow backend for %s: %v", workflowType, err)
+		return workflowType, err
 	}
+	workflowType = backend.Type()
 
 	log.Printf("[WhatsAppService] Routing message to workflow: %s", workflowType)
 
-	// Route to appropriate workflow
-	switch workflowType {
-	case "flowise":
-		err = s.flowiseService.SendMessageToWorkflow(ctx, userContext, message)
-		if err != nil {
-			log.Printf("[WhatsAppService] Failed to send message to Flowise: %v", err)
-			return fmt.Errorf("failed to send message to Flowise: %w", err)
-		}
-	case "n8n":
-		err = s.n8nService.SendMessageToWorkflow(ctx, userContext, message)
-		if err != nil {
-			log.Printf("[WhatsAppService] Failed to send message to N8N: %v", err)
-			return fmt.Errorf("failed to send message to N8N: %w", err)
-		}
-	default:
-		log.Printf("[WhatsAppService] Unknown workflow type %s, defaulting to N8N", workflowType)
-		err = s.n8nService.SendMessageToWorkflow(ctx, userContext, message)
-		if err != nil {
-			log.Printf("[WhatsAppService] Failed to send message to N8N (default): %v", err)
-			return fmt.Errorf("failed to send message to N8N (default): %w", err)
-		}
+	err = backend.SendMessageToWorkflow(ctx, request)
+	if err != nil {
+		log.Printf("[WhatsAppService] Failed to send message to %s: %v", workflowType, err)
+		return workflowType, fmt.Errorf("failed to send message to %s: %w", workflowType, err)
 	}
 
-	return nil
+	return workflowType, nil
 }
 
 func (s *whatsAppService) Logout() error {
@@ -2,14 +2,20 @@ package services
 
 import (
 	"context"
-	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/testutils/mocks"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mau.fi/whatsmeow/proto/waE2E"
 	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
 )
 
 // TestFormatPhoneToJID
@@ -189,18 +195,77 @@ func TestExtractMessageText(t *testing.T) {
 	}
 }
 
+// TestBuildUserContext
+// Summary: Test user context creation for workflow requests
+// Purpose: Validate that registered users carry their real identity and anonymous users only phone and display name
+func TestBuildUserContext(t *testing.T) {
+	service := &whatsAppService{}
+	userID := uuid.New()
+
+	tests := []struct {
+		name        string
+		phone       string
+		displayName string
+		user        *models.User
+		expected    *models.UserContext
+	}{
+		{
+			name:        "Registered user",
+			phone:       "6281234567890",
+			displayName: "Budi WA",
+			user: &models.User{
+				ID:    userID,
+				Name:  "Budi Santoso",
+				Phone: "6281234567890",
+				Email: "budi@example.com",
+			},
+			expected: &models.UserContext{
+				UserID: userID,
+				Name:   "Budi Santoso",
+				Phone:  "6281234567890",
+				Email:  "budi@example.com",
+			},
+		},
+		{
+			name:        "Anonymous user",
+			phone:       "6289876543210",
+			displayName: "Siti",
+			user:        nil,
+			expected: &models.UserContext{
+				UserID: uuid.Nil,
+				Name:   "Siti",
+				Phone:  "6289876543210",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := service.buildUserContext(tt.phone, tt.displayName, tt.user)
+
+			if *result != *tt.expected {
+				t.Errorf("Expected user context %+v, but got %+v", tt.expected, result)
+			}
+		})
+	}
+}
+
 // TestWhatsAppServiceCreation
 // Summary: Test WhatsApp service creation and initialization
 // Purpose: Validate that WhatsApp service can be created with required dependencies
 func TestWhatsAppServiceCreation(t *testing.T) {
 	// Mock services (in real implementation, these would be proper mocks)
 	userService := &mockUserService{}
-	n8nService := &mockN8NService{}
-	flowiseService := &mockFlowiseService{}
+	accessPolicyService := &mockAccessPolicyService{}
+	sessionService := &mockSessionService{}
+	messageService := &mockMessageService{}
+	workflowRegistry := NewWorkflowRegistry(WorkflowTypeN8N)
+	workflowRegistry.Register(&mockN8NService{})
+	workflowRegistry.Register(&mockFlowiseService{})
 	workflowConfigService := &mockWorkflowConfigService{}
 	var mockPool *pgxpool.Pool // nil pool for basic testing
 
-	service := NewWhatsAppService(userService, n8nService, flowiseService, workflowConfigService, mockPool)
+	service := NewWhatsAppService(userService, accessPolicyService, sessionService, messageService, workflowRegistry, workflowConfigService, mocks.NewMockSubscriptionService(t), mocks.NewMockTakeoverService(t), mocks.NewMockAuditService(t), mockPool)
 
 	if service == nil {
 		t.Error("Expected WhatsApp service to be created, but got nil")
@@ -220,6 +285,121 @@ func TestWhatsAppServiceCreation(t *testing.T) {
 	}
 }
 
+// TestWhatsAppService_RouteInbound
+// Summary: Test handing a recorded inbound message to the workflow
+// Purpose: Ensure a stalled workflow or a full set of routing slots cannot hold a message past the routing deadline
+func TestWhatsAppService_RouteInbound(t *testing.T) {
+	tests := []struct {
+		name           string
+		workflowBlocks bool
+		slotsTaken     bool
+		expectedCalls  int
+		expectedStatus string
+	}{
+		{
+			name:           "Workflow accepts the message",
+			expectedCalls:  1,
+			expectedStatus: models.MessageStatusRouted,
+		},
+		{
+			name:           "Workflow stalls past the deadline",
+			workflowBlocks: true,
+			expectedCalls:  1,
+			expectedStatus: models.MessageStatusFailed,
+		},
+		{
+			name:           "No free routing slot before the deadline",
+			slotsTaken:     true,
+			expectedCalls:  0,
+			expectedStatus: models.MessageStatusFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := &blockingWorkflowBackend{block: tt.workflowBlocks}
+			messageService := &recordingMessageService{}
+			service := newRoutingTestService(backend, messageService)
+			service.routeTimeout = 50 * time.Millisecond
+			if tt.slotsTaken {
+				for i := 0; i < cap(service.routes); i++ {
+					service.routes <- struct{}{}
+				}
+			}
+
+			inbound := &models.Message{ID: uuid.New(), Phone: "6281234567890", Direction: models.MessageDirectionInbound}
+			request := &models.WorkflowRequest{MessageID: "msg-123", Message: "hello"}
+
+			done := make(chan struct{})
+			go func() {
+				service.routeInbound(inbound, request)
+				close(done)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(2 * time.Second):
+				t.Fatal("routeInbound did not return after the routing deadline")
+			}
+
+			assert.Equal(t, tt.expectedCalls, backend.callCount())
+			assert.Equal(t, []string{tt.expectedStatus}, messageService.statusUpdates())
+		})
+	}
+}
+
+// TestWhatsAppService_HandleIncomingMessageDoesNotWaitForWorkflow
+// Summary: Test that the WhatsApp event handler returns while the workflow is still being called
+// Purpose: Ensure a slow workflow does not stall processing of other inbound messages
+func TestWhatsAppService_HandleIncomingMessageDoesNotWaitForWorkflow(t *testing.T) {
+	backend := &blockingWorkflowBackend{block: true, release: make(chan struct{})}
+	messageService := &recordingMessageService{}
+	service := newRoutingTestService(backend, messageService)
+
+	mockSubscription := mocks.NewMockSubscriptionService(t)
+	mockSubscription.EXPECT().HandleCommand(mock.Anything, mock.Anything, "hello").Return("", false)
+	mockTakeover := mocks.NewMockTakeoverService(t)
+	mockTakeover.EXPECT().IsTakenOver(mock.Anything, "6281234567890").Return(false, nil)
+	service.subscriptionSvc = mockSubscription
+	service.takeoverSvc = mockTakeover
+
+	evt := &events.Message{
+		Info: types.MessageInfo{
+			MessageSource: types.MessageSource{Sender: types.NewJID("6281234567890", types.DefaultUserServer)},
+			ID:            "wa-msg-1",
+			PushName:      "Test User",
+		},
+		Message: &waE2E.Message{Conversation: stringPtr("hello")},
+	}
+
+	handled := make(chan struct{})
+	go func() {
+		service.handleIncomingMessage(evt)
+		close(handled)
+	}()
+
+	select {
+	case <-handled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("handleIncomingMessage waited for the workflow")
+	}
+	assert.Empty(t, messageService.statusUpdates())
+
+	close(backend.release)
+	service.routing.Wait()
+
+	assert.Equal(t, 1, backend.callCount())
+	assert.Equal(t, []string{models.MessageStatusRouted}, messageService.statusUpdates())
+}
+
+func newRoutingTestService(backend WorkflowBackend, messageService MessageService) *whatsAppService {
+	workflowRegistry := NewWorkflowRegistry(WorkflowTypeN8N)
+	workflowRegistry.Register(backend)
+
+	service := NewWhatsAppService(&mockUserService{}, &mockAccessPolicyService{}, &mockSessionService{}, messageService, workflowRegistry, &mockWorkflowConfigService{}, nil, nil, nil, nil)
+	return service.(*whatsAppService)
+}
+
 // Mock implementations for testing
 type mockUserService struct{}
 
@@ -282,9 +462,31 @@ func (m *mockUserService) GetEligibleUsers(ctx context.Context) ([]*models.User,
 	}, nil
 }
 
+func (m *mockUserService) ListUsers(ctx context.Context, filter *models.UserFilter, page, pageSize int) (*models.UserPage, error) {
+	return &models.UserPage{Users: []*models.User{}, Page: page, PageSize: pageSize}, nil
+}
+
+func (m *mockUserService) SetUserActive(ctx context.Context, id uuid.UUID, active bool) (*models.User, error) {
+	return &models.User{
+		ID:       id,
+		Name:     "Test User",
+		Phone:    "12345678901",
+		Email:    "test@example.com",
+		IsActive: active,
+	}, nil
+}
+
+func (m *mockUserService) ImportUsers(ctx context.Context, rows []*models.CreateUserRequest, dryRun bool) *models.UserImportReport {
+	return &models.UserImportReport{Total: len(rows), DryRun: dryRun}
+}
+
+func (m *mockUserService) ExportUsers(ctx context.Context, filter *models.UserFilter) ([]*models.User, error) {
+	return []*models.User{}, nil
+}
+
 type mockN8NService struct{}
 
-func (m *mockN8NService) SendMessageToWorkflow(ctx context.Context, userContext *models.UserContext, message string) error {
+func (m *mockN8NService) SendMessageToWorkflow(ctx context.Context, request *models.WorkflowRequest) error {
 	return nil
 }
 
@@ -292,14 +494,14 @@ func (m *mockN8NService) HandleWorkflowResponse(response *models.N8NResponse) er
 	return nil
 }
 
-func (m *mockN8NService) SetWhatsAppService(whatsappSvc WhatsAppService) {
-	// Mock implementation
+func (m *mockN8NService) Type() string {
+	return WorkflowTypeN8N
 }
 
 // mockFlowiseService for testing
 type mockFlowiseService struct{}
 
-func (m *mockFlowiseService) SendMessageToWorkflow(ctx context.Context, userContext *models.UserContext, message string) error {
+func (m *mockFlowiseService) SendMessageToWorkflow(ctx context.Context, request *models.WorkflowRequest) error {
 	return nil
 }
 
@@ -307,8 +509,109 @@ func (m *mockFlowiseService) HandleWorkflowResponse(response *models.FlowiseResp
 	return nil
 }
 
-func (m *mockFlowiseService) SetWhatsAppService(whatsappSvc WhatsAppService) {
-	// Mock implementation
+func (m *mockFlowiseService) Type() string {
+	return WorkflowTypeFlowise
+}
+
+// mockAccessPolicyService for testing
+type mockAccessPolicyService struct{}
+
+func (m *mockAccessPolicyService) GetActivePolicy(ctx context.Context) (*models.AccessPolicy, error) {
+	return &models.AccessPolicy{ID: 1, Mode: models.AccessModeOpen}, nil
+}
+
+func (m *mockAccessPolicyService) UpdatePolicy(ctx context.Context, request *models.UpdateAccessPolicyRequest) (*models.AccessPolicy, error) {
+	return &models.AccessPolicy{ID: 1, Mode: request.Mode}, nil
+}
+
+func (m *mockAccessPolicyService) Authorize(ctx context.Context, phone, displayName string) (*models.AccessDecision, error) {
+	return &models.AccessDecision{Allowed: true}, nil
+}
+
+// mockSessionService for testing
+type mockSessionService struct{}
+
+func (m *mockSessionService) GetOrCreateSession(ctx context.Context, phone string) (*models.ConversationSession, error) {
+	return &models.ConversationSession{ID: uuid.New(), Phone: phone}, nil
+}
+
+func (m *mockSessionService) ResetSession(ctx context.Context, phone string) (bool, error) {
+	return true, nil
+}
+
+// mockMessageService for testing
+type mockMessageService struct{}
+
+func (m *mockMessageService) Record(ctx context.Context, message *models.Message) error {
+	return nil
+}
+
+func (m *mockMessageService) UpdateStatus(ctx context.Context, id uuid.UUID, status, workflowType, errMsg string) error {
+	return nil
+}
+
+func (m *mockMessageService) GetConversation(ctx context.Context, phone string, page, pageSize int) (*models.MessagePage, error) {
+	return &models.MessagePage{Phone: phone, Page: page, PageSize: pageSize}, nil
+}
+
+// recordingMessageService keeps the statuses inbound messages are moved to
+type recordingMessageService struct {
+	mockMessageService
+	mu       sync.Mutex
+	statuses []string
+}
+
+func (m *recordingMessageService) Record(ctx context.Context, message *models.Message) error {
+	message.ID = uuid.New()
+	return nil
+}
+
+func (m *recordingMessageService) UpdateStatus(ctx context.Context, id uuid.UUID, status, workflowType, errMsg string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.statuses = append(m.statuses, status)
+	return nil
+}
+
+func (m *recordingMessageService) statusUpdates() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.statuses...)
+}
+
+// blockingWorkflowBackend is an N8N backend that can hold messages until
+// released or until the routing deadline passes
+type blockingWorkflowBackend struct {
+	block   bool
+	release chan struct{}
+	mu      sync.Mutex
+	calls   int
+}
+
+func (b *blockingWorkflowBackend) Type() string {
+	return WorkflowTypeN8N
+}
+
+func (b *blockingWorkflowBackend) SendMessageToWorkflow(ctx context.Context, request *models.WorkflowRequest) error {
+	b.mu.Lock()
+	b.calls++
+	b.mu.Unlock()
+
+	if !b.block {
+		return nil
+	}
+	select {
+	case <-b.release:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *blockingWorkflowBackend) callCount() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.calls
 }
 
 // mockWorkflowConfigService for testing
@@ -318,8 +621,13 @@ func (m *mockWorkflowConfigService) GetActiveWorkflowType(ctx context.Context) (
 	return "n8n", nil
 }
 
-// Custom error for testing
-var ErrUserNotFound = fmt.Errorf("user not found")
+func (m *mockWorkflowConfigService) GetConfig(ctx context.Context) (*models.WorkflowConfig, error) {
+	return &models.WorkflowConfig{ID: 1, WorkflowType: "n8n", IsActive: true}, nil
+}
+
+func (m *mockWorkflowConfigService) SetWorkflowType(ctx context.Context, workflowType string) (*models.WorkflowConfig, error) {
+	return &models.WorkflowConfig{ID: 1, WorkflowType: workflowType, IsActive: true}, nil
+}
 
 // Helper function
 func stringPtr(s string) *string {
@@ -2,22 +2,34 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"slices"
+	"strings"
 
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
 	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/repositories"
 )
 
+var ErrUnknownWorkflowType = errors.New("unknown workflow type")
+
 type WorkflowConfigService interface {
 	GetActiveWorkflowType(ctx context.Context) (string, error)
+	GetConfig(ctx context.Context) (*models.WorkflowConfig, error)
+	// SetWorkflowType routes inbound messages to a registered workflow backend
+	SetWorkflowType(ctx context.Context, workflowType string) (*models.WorkflowConfig, error)
 }
 
 type workflowConfigService struct {
 	workflowConfigRepo repositories.WorkflowConfigRepository
+	workflowRegistry   WorkflowRegistry
 }
 
-func NewWorkflowConfigService(workflowConfigRepo repositories.WorkflowConfigRepository) WorkflowConfigService {
+func NewWorkflowConfigService(workflowConfigRepo repositories.WorkflowConfigRepository, workflowRegistry WorkflowRegistry) WorkflowConfigService {
 	return &workflowConfigService{
 		workflowConfigRepo: workflowConfigRepo,
+		workflowRegistry:   workflowRegistry,
 	}
 }
 
@@ -33,3 +45,31 @@ func (s *workflowConfigService) GetActiveWorkflowType(ctx context.Context) (stri
 	log.Printf("[WorkflowConfigService] Active workflow type: %s", workflowType)
 	return workflowType, nil
 }
+
+func (s *workflowConfigService) GetConfig(ctx context.Context) (*models.WorkflowConfig, error) {
+	config, err := s.workflowConfigRepo.GetConfig(ctx)
+	if err != nil {
+		log.Printf("[WorkflowConfigService] Failed to get workflow config: %v", err)
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func (s *workflowConfigService) SetWorkflowType(ctx context.Context, workflowType string) (*models.WorkflowConfig, error) {
+	workflowType = strings.ToLower(strings.TrimSpace(workflowType))
+
+	types := s.workflowRegistry.Types()
+	if !slices.Contains(types, workflowType) {
+		return nil, fmt.Errorf("%w %q; available: %s", ErrUnknownWorkflowType, workflowType, strings.Join(types, ", "))
+	}
+
+	config, err := s.workflowConfigRepo.SetWorkflowType(ctx, workflowType)
+	if err != nil {
+		log.Printf("[WorkflowConfigService] Failed to set workflow type %s: %v", workflowType, err)
+		return nil, err
+	}
+
+	log.Printf("[WorkflowConfigService] Routing inbound messages to %s", workflowType)
+	return config, nil
+}
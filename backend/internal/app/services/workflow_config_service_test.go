@@ -5,7 +5,11 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
 	"github.com/fajarAnd/workshop-brin/wa-service/testutils/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 // TestWorkflowConfigService_GetActiveWorkflowType
@@ -49,7 +53,7 @@ func TestWorkflowConfigService_GetActiveWorkflowType(t *testing.T) {
 			mockRepo.EXPECT().GetActiveWorkflowType(context.Background()).Return(tt.mockResponse, tt.mockError)
 
 			// Create service with mock repository
-			service := NewWorkflowConfigService(mockRepo)
+			service := NewWorkflowConfigService(mockRepo, NewWorkflowRegistry(WorkflowTypeN8N))
 
 			// Execute test
 			ctx := context.Background()
@@ -99,7 +103,7 @@ func TestWorkflowConfigService_GetActiveWorkflowType_Integration(t *testing.T) {
 			mockRepo.EXPECT().GetActiveWorkflowType(context.Background()).Return(tt.workflowType, nil)
 
 			// Create service
-			service := NewWorkflowConfigService(mockRepo)
+			service := NewWorkflowConfigService(mockRepo, NewWorkflowRegistry(WorkflowTypeN8N))
 
 			// Execute test
 			ctx := context.Background()
@@ -116,3 +120,56 @@ func TestWorkflowConfigService_GetActiveWorkflowType_Integration(t *testing.T) {
 		})
 	}
 }
+
+// TestWorkflowConfigService_SetWorkflowType
+// Summary: Test switching the workflow inbound messages are routed to
+// Purpose: Validate that only registered workflow backends can be selected
+func TestWorkflowConfigService_SetWorkflowType(t *testing.T) {
+	tests := []struct {
+		name         string
+		workflowType string
+		expectedType string
+		expectedErr  error
+	}{
+		{
+			name:         "Registered backend",
+			workflowType: "flowise",
+			expectedType: "flowise",
+		},
+		{
+			name:         "Backend in another case",
+			workflowType: " N8N ",
+			expectedType: "n8n",
+		},
+		{
+			name:         "Unregistered backend",
+			workflowType: "openai",
+			expectedErr:  ErrUnknownWorkflowType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewWorkflowRegistry(WorkflowTypeN8N)
+			registry.Register(&mockN8NService{})
+			registry.Register(&mockFlowiseService{})
+
+			mockRepo := mocks.NewMockWorkflowConfigRepository(t)
+			if tt.expectedErr == nil {
+				mockRepo.EXPECT().SetWorkflowType(mock.Anything, tt.expectedType).
+					Return(&models.WorkflowConfig{ID: 1, WorkflowType: tt.expectedType, IsActive: true}, nil)
+			}
+
+			service := NewWorkflowConfigService(mockRepo, registry)
+			config, err := service.SetWorkflowType(context.Background(), tt.workflowType)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, config)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedType, config.WorkflowType)
+		})
+	}
+}
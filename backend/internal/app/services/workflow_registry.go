@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+)
+
+// Workflow types stored in workflow_config.workflow_type
+const (
+	WorkflowTypeN8N     = "n8n"
+	WorkflowTypeFlowise = "flowise"
+	// WorkflowTypeOpenAI answers directly through an OpenAI-compatible API with RAG
+	WorkflowTypeOpenAI = "openai"
+)
+
+// WorkflowBackend is an AI engine that inbound WhatsApp messages can be routed to.
+// Backends deliver their replies through the MessageSender they were built with.
+type WorkflowBackend interface {
+	// Type is the workflow_type value that selects this backend
+	Type() string
+	SendMessageToWorkflow(ctx context.Context, request *models.WorkflowRequest) error
+}
+
+// MessageSender delivers a reply to a WhatsApp user
+type MessageSender interface {
+	SendMessage(ctx context.Context, phone, message string) error
+}
+
+// WorkflowRegistry holds the backends available for routing, keyed by workflow_type
+type WorkflowRegistry interface {
+	Register(backend WorkflowBackend)
+	// Resolve returns the backend for workflowType, falling back to the default backend
+	Resolve(workflowType string) (WorkflowBackend, error)
+	Types() []string
+}
+
+type workflowRegistry struct {
+	mu          sync.RWMutex
+	backends    map[string]WorkflowBackend
+	defaultType string
+}
+
+// NewWorkflowRegistry creates an empty registry that falls back to defaultType
+// for unknown workflow types
+func NewWorkflowRegistry(defaultType string) WorkflowRegistry {
+	return &workflowRegistry{
+		backends:    make(map[string]WorkflowBackend),
+		defaultType: defaultType,
+	}
+}
+
+func (r *workflowRegistry) Register(backend WorkflowBackend) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.backends[backend.Type()]; exists {
+		log.Printf("[WorkflowRegistry] Replacing backend for workflow type %s", backend.Type())
+	}
+
+	r.backends[backend.Type()] = backend
+	log.Printf("[WorkflowRegistry] Registered workflow backend: %s", backend.Type())
+}
+
+func (r *workflowRegistry) Resolve(workflowType string) (WorkflowBackend, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if backend, exists := r.backends[workflowType]; exists {
+		return backend, nil
+	}
+
+	backend, exists := r.backends[r.defaultType]
+	if !exists {
+		return nil, fmt.Errorf("no workflow backend registered for %q or default %q", workflowType, r.defaultType)
+	}
+
+	log.Printf("[WorkflowRegistry] Unknown workflow type %q, defaulting to %s", workflowType, r.defaultType)
+	return backend, nil
+}
+
+func (r *workflowRegistry) Types() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	types := make([]string, 0, len(r.backends))
+	for workflowType := range r.backends {
+		types = append(types, workflowType)
+	}
+	sort.Strings(types)
+
+	return types
+}
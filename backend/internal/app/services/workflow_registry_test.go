@@ -0,0 +1,43 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWorkflowRegistry_Resolve
+// Summary: Test resolving workflow backends by workflow_type
+// Purpose: Validate registered lookups, default fallback and the empty registry error
+func TestWorkflowRegistry_Resolve(t *testing.T) {
+	registry := NewWorkflowRegistry(WorkflowTypeN8N)
+	registry.Register(&mockN8NService{})
+	registry.Register(&mockFlowiseService{})
+
+	tests := []struct {
+		name         string
+		workflowType string
+		expectedType string
+	}{
+		{name: "Registered Flowise backend", workflowType: WorkflowTypeFlowise, expectedType: WorkflowTypeFlowise},
+		{name: "Registered N8N backend", workflowType: WorkflowTypeN8N, expectedType: WorkflowTypeN8N},
+		{name: "Unknown type falls back to default", workflowType: "rules", expectedType: WorkflowTypeN8N},
+		{name: "Empty type falls back to default", workflowType: "", expectedType: WorkflowTypeN8N},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, err := registry.Resolve(tt.workflowType)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedType, backend.Type())
+		})
+	}
+
+	assert.Equal(t, []string{WorkflowTypeFlowise, WorkflowTypeN8N}, registry.Types())
+
+	t.Run("Default backend not registered", func(t *testing.T) {
+		_, err := NewWorkflowRegistry(WorkflowTypeN8N).Resolve(WorkflowTypeFlowise)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+)
+
+const defaultWatchdogInterval = 15 * time.Second
+
+// WorkflowWatchdog tells users when a workflow is slow to answer or never does
+type WorkflowWatchdog interface {
+	Start(ctx context.Context)
+	Stop()
+}
+
+type WatchdogConfig struct {
+	Interval       time.Duration
+	SoftTimeout    time.Duration
+	SoftMessage    string
+	TimeoutMessage string
+}
+
+type workflowWatchdog struct {
+	config     *WatchdogConfig
+	pendingSvc PendingRequestService
+	sender     MessageSender
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+}
+
+// NewWorkflowWatchdog creates a watchdog over outstanding workflow requests.
+// The hard deadline is the one recorded when the request was registered.
+func NewWorkflowWatchdog(config *WatchdogConfig, pendingSvc PendingRequestService, sender MessageSender) WorkflowWatchdog {
+	checked := *config
+	if checked.Interval <= 0 {
+		checked.Interval = defaultWatchdogInterval
+	}
+
+	return &workflowWatchdog{
+		config:     &checked,
+		pendingSvc: pendingSvc,
+		sender:     sender,
+	}
+}
+
+func (w *workflowWatchdog) Start(ctx context.Context) {
+	ctx, w.cancel = context.WithCancel(ctx)
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.config.Interval)
+		defer ticker.Stop()
+
+		log.Printf("[WorkflowWatchdog] Started (interval %s, soft timeout %s)", w.config.Interval, w.config.SoftTimeout)
+		for {
+			select {
+			case <-ctx.Done():
+				log.Printf("[WorkflowWatchdog] Stopped")
+				return
+			case <-ticker.C:
+				w.check(ctx)
+			}
+		}
+	}()
+}
+
+func (w *workflowWatchdog) Stop() {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	w.wg.Wait()
+}
+
+// check expires overdue requests first so a user never gets both notices in one pass
+func (w *workflowWatchdog) check(ctx context.Context) {
+	expired, err := w.pendingSvc.ExpireOverdue(ctx)
+	if err != nil {
+		log.Printf("[WorkflowWatchdog] Failed to expire overdue requests: %v", err)
+	}
+	for _, request := range expired {
+		w.notify(ctx, request, w.config.TimeoutMessage)
+	}
+
+	if w.config.SoftTimeout <= 0 {
+		return
+	}
+
+	awaiting, err := w.pendingSvc.ListAwaitingSoftNotice(ctx, w.config.SoftTimeout)
+	if err != nil {
+		log.Printf("[WorkflowWatchdog] Failed to list requests awaiting notice: %v", err)
+		return
+	}
+	for _, request := range awaiting {
+		marked, err := w.pendingSvc.MarkSoftNotified(ctx, request.MessageID)
+		if err != nil || !marked {
+			continue
+		}
+		w.notify(ctx, request, w.config.SoftMessage)
+	}
+}
+
+func (w *workflowWatchdog) notify(ctx context.Context, request *models.PendingRequest, message string) {
+	if message == "" {
+		return
+	}
+
+	ctx = WithMessageMeta(ctx, MessageMeta{
+		CorrelationID: request.MessageID,
+		WorkflowType:  request.WorkflowType,
+	})
+
+	err := w.sender.SendMessage(ctx, request.Phone, message)
+	if err != nil {
+		log.Printf("[WorkflowWatchdog] Failed to notify %s about request %s: %v", request.Phone, request.MessageID, err)
+	}
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	"github.com/fajarAnd/workshop-brin/wa-service/testutils/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestWorkflowWatchdog_Check
+// Summary: Test a single watchdog pass over outstanding requests
+// Purpose: Validate that timed out requests get the apology and slow ones get one progress notice
+func TestWorkflowWatchdog_Check(t *testing.T) {
+	config := &WatchdogConfig{
+		Interval:       time.Second,
+		SoftTimeout:    time.Minute,
+		SoftMessage:    "Still working",
+		TimeoutMessage: "Please try again",
+	}
+
+	expired := &models.PendingRequest{MessageID: "msg-expired", Phone: "6281111111111", WorkflowType: "n8n"}
+	slow := &models.PendingRequest{MessageID: "msg-slow", Phone: "6282222222222", WorkflowType: "n8n"}
+	notified := &models.PendingRequest{MessageID: "msg-notified", Phone: "6283333333333", WorkflowType: "n8n"}
+
+	mockPending := mocks.NewMockPendingRequestService(t)
+	mockPending.EXPECT().ExpireOverdue(mock.Anything).Return([]*models.PendingRequest{expired}, nil)
+	mockPending.EXPECT().ListAwaitingSoftNotice(mock.Anything, time.Minute).Return([]*models.PendingRequest{slow, notified}, nil)
+	mockPending.EXPECT().MarkSoftNotified(mock.Anything, "msg-slow").Return(true, nil)
+	mockPending.EXPECT().MarkSoftNotified(mock.Anything, "msg-notified").Return(false, nil)
+
+	sent := map[string]string{}
+	whatsappSvc := &mockWhatsAppService{
+		sendMessageFunc: func(ctx context.Context, phone, message string) error {
+			sent[phone] = message
+			assert.NotEmpty(t, MessageMetaFromContext(ctx).CorrelationID)
+			return nil
+		},
+	}
+
+	watchdog := NewWorkflowWatchdog(config, mockPending, whatsappSvc).(*workflowWatchdog)
+	watchdog.check(context.Background())
+
+	assert.Equal(t, map[string]string{
+		"6281111111111": "Please try again",
+		"6282222222222": "Still working",
+	}, sent)
+}
+
+// TestNewWorkflowWatchdog
+// Summary: Test the watchdog interval check
+// Purpose: Validate that a zero or negative interval falls back to the default instead of panicking the ticker
+func TestNewWorkflowWatchdog(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval time.Duration
+		expected time.Duration
+	}{
+		{name: "Configured interval", interval: 5 * time.Second, expected: 5 * time.Second},
+		{name: "Zero interval", interval: 0, expected: defaultWatchdogInterval},
+		{name: "Negative interval", interval: -time.Second, expected: defaultWatchdogInterval},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &WatchdogConfig{Interval: tt.interval}
+			watchdog := NewWorkflowWatchdog(config, mocks.NewMockPendingRequestService(t), &mockWhatsAppService{})
+
+			assert.Equal(t, tt.expected, watchdog.(*workflowWatchdog).config.Interval)
+
+			watchdog.Start(context.Background())
+			watchdog.Stop()
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package jwt
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParse
+// Summary: Test verifying signed tokens
+// Purpose: Validate round trips and rejection of tampered, foreign, expired and non-HS256 tokens
+func TestParse(t *testing.T) {
+	secret := []byte("test-secret")
+	now := time.Unix(1_700_000_000, 0)
+	claims := &Claims{Subject: "user-1", Roles: []string{"admin"}, IssuedAt: now.Unix(), ExpiresAt: now.Add(time.Hour).Unix()}
+
+	valid, err := Sign(claims, secret)
+	assert.NoError(t, err)
+
+	parts := strings.Split(valid, ".")
+	noneAlg := encoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`)) + "." + parts[1] + "."
+	tampered := parts[0] + "." + encoding.EncodeToString([]byte(`{"sub":"user-2","roles":["admin"],"exp":9999999999}`)) + "." + parts[2]
+
+	tests := []struct {
+		name        string
+		token       string
+		secret      []byte
+		now         time.Time
+		expectedErr error
+	}{
+		{name: "Valid token", token: valid, secret: secret, now: now},
+		{name: "Wrong secret", token: valid, secret: []byte("other"), now: now, expectedErr: ErrInvalidToken},
+		{name: "Expired", token: valid, secret: secret, now: now.Add(time.Hour), expectedErr: ErrExpiredToken},
+		{name: "Tampered claims", token: tampered, secret: secret, now: now, expectedErr: ErrInvalidToken},
+		{name: "Unsigned none algorithm", token: noneAlg, secret: secret, now: now, expectedErr: ErrInvalidToken},
+		{name: "Not a JWT", token: "api-key-value", secret: secret, now: now, expectedErr: ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := Parse(tt.token, tt.secret, tt.now)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, parsed)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, claims, parsed)
+		})
+	}
+}
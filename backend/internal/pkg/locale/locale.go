@@ -0,0 +1,104 @@
+// Package locale formats numbers, percentages and Rupiah amounts for the
+// languages signal messages are written in: Indonesian (id-ID), which groups
+// thousands with '.' and uses ',' for decimals, and English (en-US), which
+// does the opposite.
+package locale
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Supported languages, as stored in users.language
+const (
+	Indonesian = "id"
+	English    = "en"
+
+	// Default is the language of users who have not chosen one
+	Default = Indonesian
+)
+
+// Locale formats numbers the way readers of one language expect
+type Locale struct {
+	Language string
+	Tag      string
+	group    string
+	decimal  string
+}
+
+var locales = map[string]Locale{
+	Indonesian: {Language: Indonesian, Tag: "id-ID", group: ".", decimal: ","},
+	English:    {Language: English, Tag: "en-US", group: ",", decimal: "."},
+}
+
+// Languages returns the supported languages, the default first
+func Languages() []string {
+	return []string{Indonesian, English}
+}
+
+// IsSupported reports whether language is one of Languages
+func IsSupported(language string) bool {
+	_, exists := locales[language]
+	return exists
+}
+
+// For returns the locale of language, or of Default when it is not supported
+func For(language string) Locale {
+	if l, exists := locales[language]; exists {
+		return l
+	}
+	return locales[Default]
+}
+
+// Number formats value rounded to decimals places with grouped thousands:
+// 9655.5 with 1 decimal is "9.655,5" in id-ID and "9,655.5" in en-US
+func (l Locale) Number(value float64, decimals int) string {
+	text := strconv.FormatFloat(math.Abs(value), 'f', decimals, 64)
+	integer, fraction, _ := strings.Cut(text, ".")
+
+	var builder strings.Builder
+	if value < 0 && strings.Trim(text, "0.") != "" {
+		builder.WriteString("-")
+	}
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			builder.WriteString(l.group)
+		}
+		builder.WriteRune(digit)
+	}
+	if fraction != "" {
+		builder.WriteString(l.decimal)
+		builder.WriteString(fraction)
+	}
+
+	return builder.String()
+}
+
+// Amount formats a price without decimals when it is whole and with two
+// otherwise, so that 2720.5 is "2.720,50" rather than "2.720"
+func (l Locale) Amount(value float64) string {
+	if math.Round(value*100) == math.Round(value)*100 {
+		return l.Number(value, 0)
+	}
+	return l.Number(value, 2)
+}
+
+// Currency formats a Rupiah amount, e.g. "Rp 9.655" or "Rp 2,720.50"
+func (l Locale) Currency(value float64) string {
+	return "Rp " + l.Amount(value)
+}
+
+// Percent formats value as a percentage with decimals places, e.g. "68,5%"
+func (l Locale) Percent(value float64, decimals int) string {
+	return l.Number(value, decimals) + "%"
+}
+
+// SignedPercent is Percent with a '+' in front of positive values, e.g. "+2,5%"
+func (l Locale) SignedPercent(value float64, decimals int) string {
+	text := l.Percent(value, decimals)
+	if value > 0 && strings.Trim(l.Number(value, decimals), "0.,") != "" {
+		return "+" + text
+	}
+	return text
+}
@@ -0,0 +1,85 @@
+package locale
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLocale_Number
+// Summary: Test locale-aware number formatting
+// Purpose: Validate thousands grouping, decimal separators, rounding and negative values in id-ID and en-US
+func TestLocale_Number(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		value    float64
+		decimals int
+		expected string
+	}{
+		{name: "Indonesian thousands", language: Indonesian, value: 9655, expected: "9.655"},
+		{name: "English thousands", language: English, value: 9655, expected: "9,655"},
+		{name: "Indonesian millions with decimals", language: Indonesian, value: 1234567.891, decimals: 2, expected: "1.234.567,89"},
+		{name: "English millions with decimals", language: English, value: 1234567.891, decimals: 2, expected: "1,234,567.89"},
+		{name: "Small number", language: Indonesian, value: 54, expected: "54"},
+		{name: "Rounding up", language: English, value: 999.96, decimals: 1, expected: "1,000.0"},
+		{name: "Negative", language: Indonesian, value: -1800.5, decimals: 1, expected: "-1.800,5"},
+		{name: "Negative rounds to zero", language: English, value: -0.004, decimals: 2, expected: "0.00"},
+		{name: "Unsupported language uses the default", language: "fr", value: 9655, expected: "9.655"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, For(tt.language).Number(tt.value, tt.decimals))
+		})
+	}
+}
+
+// TestLocale_Currency
+// Summary: Test Rupiah formatting
+// Purpose: Validate that whole prices have no decimals and fractional prices keep two in both locales
+func TestLocale_Currency(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		value    float64
+		expected string
+	}{
+		{name: "Whole Indonesian price", language: Indonesian, value: 9655, expected: "Rp 9.655"},
+		{name: "Whole English price", language: English, value: 9655, expected: "Rp 9,655"},
+		{name: "Fractional Indonesian price", language: Indonesian, value: 2720.5, expected: "Rp 2.720,50"},
+		{name: "Fractional English price", language: English, value: 2550.75, expected: "Rp 2,550.75"},
+		{name: "Large price", language: Indonesian, value: 1234567, expected: "Rp 1.234.567"},
+		{name: "Zero", language: English, value: 0, expected: "Rp 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, For(tt.language).Currency(tt.value))
+		})
+	}
+}
+
+// TestLocale_SignedPercent
+// Summary: Test signed percentage formatting
+// Purpose: Validate the sign of positive, negative and zero values and the locale decimal separator
+func TestLocale_SignedPercent(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		value    float64
+		expected string
+	}{
+		{name: "Positive Indonesian", language: Indonesian, value: 2.5, expected: "+2,5%"},
+		{name: "Positive English", language: English, value: 2.5, expected: "+2.5%"},
+		{name: "Negative", language: Indonesian, value: -1.8, expected: "-1,8%"},
+		{name: "Zero", language: English, value: 0, expected: "0.0%"},
+		{name: "Positive rounds to zero", language: English, value: 0.01, expected: "0.0%"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, For(tt.language).SignedPercent(tt.value, 1))
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Package phonenumber normalises phone numbers to the form stored in users.phone and
+// used as the WhatsApp JID user: E.164 without the leading '+', e.g. 6281234567890.
+//
+// Numbers written with a '+' or '00' prefix are taken as international.
+// Numbers starting with the trunk prefix '0' are Indonesian. Any other string
+// of digits is assumed to already start with its country code, which is how
+// WhatsApp and most integrations send numbers.
+package phonenumber
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	// IndonesiaCountryCode is the country code assumed for numbers written with a leading 0
+	IndonesiaCountryCode = "62"
+
+	// E.164 numbers have at most 15 digits; shorter than 8 is never a full number
+	minDigits = 8
+	maxDigits = 15
+
+	// Indonesian national significant numbers (after 62) have 8 to 12 digits
+	minIndonesianNational = 8
+	maxIndonesianNational = 12
+)
+
+var ErrInvalidPhone = errors.New("invalid phone number")
+
+// separators are the characters people type between digit groups, plus the
+// non-breaking space numbers pick up when copied from web pages
+var separators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "", "\u00a0", "")
+
+// Normalize returns phone as E.164 digits without the '+'. "0812-3456-7890",
+// "+62 812 3456 7890" and "6281234567890" all normalise to "6281234567890".
+func Normalize(phone string) (string, error) {
+	digits := separators.Replace(strings.TrimSpace(phone))
+
+	switch {
+	case strings.HasPrefix(digits, "+"):
+		digits = digits[1:]
+	case strings.HasPrefix(digits, "00"):
+		digits = digits[2:]
+	case strings.HasPrefix(digits, "0"):
+		digits = IndonesiaCountryCode + digits[1:]
+	}
+
+	// "+62 (0)812..." keeps the trunk prefix after the country code
+	if strings.HasPrefix(digits, IndonesiaCountryCode+"0") {
+		digits = IndonesiaCountryCode + digits[len(IndonesiaCountryCode)+1:]
+	}
+
+	if !isDigits(digits) || digits[0] == '0' || len(digits) < minDigits || len(digits) > maxDigits {
+		return "", ErrInvalidPhone
+	}
+
+	if strings.HasPrefix(digits, IndonesiaCountryCode) {
+		national := len(digits) - len(IndonesiaCountryCode)
+		if national < minIndonesianNational || national > maxIndonesianNational {
+			return "", ErrInvalidPhone
+		}
+	}
+
+	return digits, nil
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+package phonenumber
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNormalize
+// Summary: Test phone normalisation to E.164 digits
+// Purpose: Validate Indonesian trunk prefixes, international prefixes, separators and length limits
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name        string
+		phone       string
+		expected    string
+		expectError bool
+	}{
+		{name: "Indonesian mobile with trunk prefix", phone: "081234567890", expected: "6281234567890"},
+		{name: "Indonesian mobile with plus", phone: "+6281234567890", expected: "6281234567890"},
+		{name: "Indonesian mobile with country code", phone: "6281234567890", expected: "6281234567890"},
+		{name: "Separators are removed", phone: "+62 812-3456.7890", expected: "6281234567890"},
+		{name: "Trunk prefix after country code", phone: "+62 (0)812 3456 7890", expected: "6281234567890"},
+		{name: "International 00 prefix", phone: "00 62 812 3456 7890", expected: "6281234567890"},
+		{name: "Jakarta landline", phone: "(021) 5551234", expected: "62215551234"},
+		{name: "US number with plus", phone: "+1 415 555 2671", expected: "14155552671"},
+		{name: "WhatsApp JID user from Japan", phone: "819012345678", expected: "819012345678"},
+		{name: "Surrounding whitespace", phone: "  081234567890\n", expected: "6281234567890"},
+		{name: "Empty", phone: "", expectError: true},
+		{name: "Separators only", phone: "+- ", expectError: true},
+		{name: "Letters", phone: "0812-CALL-NOW", expectError: true},
+		{name: "Too short", phone: "0812", expectError: true},
+		{name: "Longer than E.164 allows", phone: "+1234567890123456", expectError: true},
+		{name: "Indonesian national number too long", phone: "0812345678901234", expectError: true},
+		{name: "Plus in the middle", phone: "62+81234567890", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalized, err := Normalize(tt.phone)
+
+			if tt.expectError {
+				assert.ErrorIs(t, err, ErrInvalidPhone)
+				assert.Empty(t, normalized)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, normalized)
+		})
+	}
+}
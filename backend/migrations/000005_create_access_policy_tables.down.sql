@@ -0,0 +1,3 @@
+-- Drop access policy tables
+DROP TABLE IF EXISTS phone_allowlist;
+DROP TABLE IF EXISTS access_policy;
\ No newline at end of file
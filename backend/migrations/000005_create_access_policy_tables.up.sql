@@ -0,0 +1,19 @@
+-- Create access_policy table for global inbound access control
+-- mode: open (anyone), registered (active users only), allowlist (active users + auto-enrol allowlisted phones)
+CREATE TABLE access_policy (
+    id SERIAL PRIMARY KEY,
+    mode VARCHAR(20) NOT NULL DEFAULT 'open',
+    notify_unregistered BOOLEAN DEFAULT false,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Insert default policy (open for backward compatibility)
+INSERT INTO access_policy (id, mode) VALUES (1, 'open');
+
+-- Create phone_allowlist table for auto-enrolment in allowlist mode
+CREATE TABLE phone_allowlist (
+    phone VARCHAR(20) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    email VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,2 @@
+-- Drop conversation_sessions table
+DROP TABLE IF EXISTS conversation_sessions;
\ No newline at end of file
@@ -0,0 +1,11 @@
+-- Create conversation_sessions table for per-phone conversation memory keys
+CREATE TABLE conversation_sessions (
+    id UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    phone VARCHAR(20) NOT NULL,
+    started_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_activity_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    ended_at TIMESTAMP
+);
+
+-- Only one open session per phone
+CREATE UNIQUE INDEX idx_conversation_sessions_active_phone ON conversation_sessions(phone) WHERE ended_at IS NULL;
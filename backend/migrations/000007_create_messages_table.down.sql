@@ -0,0 +1,2 @@
+-- Drop messages table
+DROP TABLE IF EXISTS messages;
\ No newline at end of file
@@ -0,0 +1,21 @@
+-- Create messages table for inbound and outbound WhatsApp transcripts
+CREATE TABLE messages (
+    id UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    phone VARCHAR(20) NOT NULL,
+    direction VARCHAR(10) NOT NULL, -- inbound | outbound
+    whatsapp_message_id VARCHAR(100),
+    correlation_id VARCHAR(100), -- message_id shared with N8N/Flowise
+    session_id UUID,
+    workflow_type VARCHAR(20),
+    content TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    error TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Transcript lookup by phone (main query pattern)
+CREATE INDEX idx_messages_phone_created_at ON messages(phone, created_at DESC);
+
+-- Correlation lookup between inbound message and workflow reply
+CREATE INDEX idx_messages_correlation_id ON messages(correlation_id);
@@ -0,0 +1,2 @@
+-- Drop pending_requests table
+DROP TABLE IF EXISTS pending_requests;
\ No newline at end of file
@@ -0,0 +1,14 @@
+-- Create pending_requests table to correlate workflow callbacks with issued requests
+CREATE TABLE pending_requests (
+    message_id VARCHAR(100) PRIMARY KEY,
+    phone VARCHAR(20) NOT NULL,
+    workflow_type VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending | completed | failed
+    error TEXT,
+    sent_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deadline_at TIMESTAMP NOT NULL,
+    completed_at TIMESTAMP
+);
+
+-- Outstanding request lookup
+CREATE INDEX idx_pending_requests_status_deadline ON pending_requests(status, deadline_at);
@@ -0,0 +1,5 @@
+-- Drop watchdog tracking columns
+DROP INDEX IF EXISTS idx_pending_requests_timed_out_at;
+ALTER TABLE pending_requests
+    DROP COLUMN IF EXISTS timed_out_at,
+    DROP COLUMN IF EXISTS soft_notified_at;
\ No newline at end of file
@@ -0,0 +1,10 @@
+-- Track watchdog notices and timeouts for outstanding workflow requests
+ALTER TABLE pending_requests
+    ADD COLUMN soft_notified_at TIMESTAMP,
+    ADD COLUMN timed_out_at TIMESTAMP;
+
+-- status now also accepts 'timed_out'
+COMMENT ON COLUMN pending_requests.status IS 'pending | completed | failed | timed_out';
+
+-- Timeout analysis lookup
+CREATE INDEX idx_pending_requests_timed_out_at ON pending_requests(timed_out_at) WHERE timed_out_at IS NOT NULL;
@@ -0,0 +1,3 @@
+-- Drop knowledge metadata indexes
+DROP INDEX IF EXISTS idx_simple_knowledge_vectors_content_hash;
+DROP INDEX IF EXISTS idx_simple_knowledge_vectors_source;
\ No newline at end of file
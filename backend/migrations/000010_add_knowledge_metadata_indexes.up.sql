@@ -0,0 +1,3 @@
+-- Index metadata keys used by cmd/kb ingestion and knowledge document lookups
+CREATE INDEX idx_simple_knowledge_vectors_source ON simple_knowledge_vectors ((metadata->>'source'));
+CREATE INDEX idx_simple_knowledge_vectors_content_hash ON simple_knowledge_vectors ((metadata->>'content_hash'));
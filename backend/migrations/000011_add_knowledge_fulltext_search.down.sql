@@ -0,0 +1,3 @@
+-- Drop knowledge full-text search
+DROP INDEX IF EXISTS idx_simple_knowledge_vectors_content_tsv;
+ALTER TABLE simple_knowledge_vectors DROP COLUMN IF EXISTS content_tsv;
\ No newline at end of file
@@ -0,0 +1,9 @@
+-- Full-text search over knowledge chunks for hybrid retrieval. The 'simple'
+-- configuration does no stemming, so exact terms such as hostnames, VLAN names
+-- and asset tags are matched as written (PostgreSQL ships no Indonesian config).
+ALTER TABLE simple_knowledge_vectors
+    ADD COLUMN content_tsv TSVECTOR GENERATED ALWAYS AS (
+        to_tsvector('simple', COALESCE(metadata->>'section', '') || ' ' || content)
+    ) STORED;
+
+CREATE INDEX idx_simple_knowledge_vectors_content_tsv ON simple_knowledge_vectors USING GIN (content_tsv);
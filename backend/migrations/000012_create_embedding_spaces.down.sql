@@ -0,0 +1,5 @@
+-- Drop embedding spaces
+DROP TABLE IF EXISTS embedding_jobs;
+DROP TABLE IF EXISTS knowledge_embeddings;
+DROP INDEX IF EXISTS idx_simple_knowledge_vectors_embedding_hnsw;
+DROP TABLE IF EXISTS embedding_spaces;
\ No newline at end of file
@@ -0,0 +1,50 @@
+-- Named embedding spaces: each has its own model, dimension and vector index.
+-- The 'default' space is stored in simple_knowledge_vectors.embedding, which the
+-- n8n PGVector workflows read; other spaces are stored in knowledge_embeddings.
+CREATE TABLE embedding_spaces (
+    id UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    name VARCHAR(50) UNIQUE NOT NULL,
+    model VARCHAR(100) NOT NULL,
+    dimension INTEGER NOT NULL CHECK (dimension > 0),
+    index_type VARCHAR(20) NOT NULL DEFAULT 'hnsw', -- hnsw | ivfflat | none
+    base_url TEXT, -- NULL uses OPENAI_BASE_URL
+    is_active BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- At most one space answers retrieval queries
+CREATE UNIQUE INDEX idx_embedding_spaces_active ON embedding_spaces(is_active) WHERE is_active;
+
+INSERT INTO embedding_spaces (name, model, dimension, index_type, is_active)
+VALUES ('default', 'text-embedding-3-small', 1536, 'hnsw', true);
+
+CREATE INDEX idx_simple_knowledge_vectors_embedding_hnsw ON simple_knowledge_vectors USING hnsw (embedding vector_cosine_ops);
+
+-- Embeddings for non-default spaces. The column has no fixed dimension; each
+-- space gets a partial expression index casting to its own dimension.
+CREATE TABLE knowledge_embeddings (
+    chunk_id UUID NOT NULL REFERENCES simple_knowledge_vectors(id) ON DELETE CASCADE,
+    space_id UUID NOT NULL REFERENCES embedding_spaces(id) ON DELETE CASCADE,
+    embedding VECTOR NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (space_id, chunk_id)
+);
+
+CREATE INDEX idx_knowledge_embeddings_chunk ON knowledge_embeddings(chunk_id);
+
+-- Background jobs that embed knowledge chunks into another space
+CREATE TABLE embedding_jobs (
+    id UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    source_space_id UUID REFERENCES embedding_spaces(id) ON DELETE SET NULL,
+    target_space_id UUID NOT NULL REFERENCES embedding_spaces(id) ON DELETE CASCADE,
+    activate_on_finish BOOLEAN NOT NULL DEFAULT false,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending | running | completed | failed | cancelled
+    total INTEGER NOT NULL DEFAULT 0,
+    processed INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX idx_embedding_jobs_target_status ON embedding_jobs(target_space_id, status);
@@ -0,0 +1,12 @@
+-- Restore the phones changed by the normalisation migration
+UPDATE users u
+SET phone = r.original_phone, updated_at = CURRENT_TIMESTAMP
+FROM phone_normalization_report r
+WHERE r.table_name = 'users' AND r.status = 'normalized' AND u.id::text = r.row_key;
+
+UPDATE phone_allowlist a
+SET phone = r.original_phone
+FROM phone_normalization_report r
+WHERE r.table_name = 'phone_allowlist' AND r.status = 'normalized' AND a.phone = r.normalized_phone;
+
+DROP TABLE IF EXISTS phone_normalization_report;
\ No newline at end of file
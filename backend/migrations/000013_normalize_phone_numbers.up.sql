@@ -0,0 +1,110 @@
+-- Normalise stored phone numbers to E.164 digits without the '+', mirroring
+-- internal/pkg/phonenumber. Rows that cannot be normalised, or whose normalised
+-- phone would collide with another row, are left unchanged. Every row this
+-- migration looked at is recorded in phone_normalization_report:
+--   SELECT * FROM phone_normalization_report WHERE status <> 'normalized';
+CREATE TABLE phone_normalization_report (
+    id SERIAL PRIMARY KEY,
+    table_name VARCHAR(50) NOT NULL,
+    row_key TEXT NOT NULL,
+    original_phone VARCHAR(20) NOT NULL,
+    normalized_phone VARCHAR(20),
+    status VARCHAR(20) NOT NULL, -- normalized | invalid | conflict
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE FUNCTION normalize_phone_e164(raw TEXT) RETURNS TEXT AS $$
+DECLARE
+    digits TEXT := regexp_replace(regexp_replace(raw, '^\s+|\s+$', '', 'g'), '[ .()' || chr(160) || '-]', '', 'g');
+BEGIN
+    IF digits LIKE '+%' THEN
+        digits := substr(digits, 2);
+    ELSIF digits LIKE '00%' THEN
+        digits := substr(digits, 3);
+    ELSIF digits LIKE '0%' THEN
+        digits := '62' || substr(digits, 2);
+    END IF;
+
+    -- "+62 (0)812..." keeps the trunk prefix after the country code
+    IF digits LIKE '620%' THEN
+        digits := '62' || substr(digits, 4);
+    END IF;
+
+    IF digits !~ '^[1-9][0-9]{7,14}$' THEN
+        RETURN NULL;
+    END IF;
+
+    IF digits LIKE '62%' AND length(digits) NOT BETWEEN 10 AND 14 THEN
+        RETURN NULL;
+    END IF;
+
+    RETURN digits;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+-- Users: a row conflicts when another user already has, or would normalise to, the same phone
+WITH candidates AS (
+    SELECT id, phone, normalize_phone_e164(phone) AS normalized FROM users
+)
+INSERT INTO phone_normalization_report (table_name, row_key, original_phone, normalized_phone, status)
+SELECT 'users', c.id::text, c.phone, c.normalized,
+    CASE
+        WHEN c.normalized IS NULL THEN 'invalid'
+        WHEN EXISTS (
+            SELECT 1 FROM candidates o
+            WHERE o.id <> c.id AND (o.phone = c.normalized OR o.normalized = c.normalized)
+        ) THEN 'conflict'
+        ELSE 'normalized'
+    END
+FROM candidates c
+WHERE c.normalized IS DISTINCT FROM c.phone;
+
+UPDATE users u
+SET phone = r.normalized_phone, updated_at = CURRENT_TIMESTAMP
+FROM phone_normalization_report r
+WHERE r.table_name = 'users' AND r.status = 'normalized' AND r.row_key = u.id::text;
+
+-- Allowlist entries are keyed by phone
+WITH candidates AS (
+    SELECT phone, normalize_phone_e164(phone) AS normalized FROM phone_allowlist
+)
+INSERT INTO phone_normalization_report (table_name, row_key, original_phone, normalized_phone, status)
+SELECT 'phone_allowlist', c.phone, c.phone, c.normalized,
+    CASE
+        WHEN c.normalized IS NULL THEN 'invalid'
+        WHEN EXISTS (
+            SELECT 1 FROM candidates o
+            WHERE o.phone <> c.phone AND (o.phone = c.normalized OR o.normalized = c.normalized)
+        ) THEN 'conflict'
+        ELSE 'normalized'
+    END
+FROM candidates c
+WHERE c.normalized IS DISTINCT FROM c.phone;
+
+UPDATE phone_allowlist a
+SET phone = r.normalized_phone
+FROM phone_normalization_report r
+WHERE r.table_name = 'phone_allowlist' AND r.status = 'normalized' AND r.row_key = a.phone;
+
+-- Transcripts of messages sent to unnormalised user phones join the conversation
+-- recorded under the normalised phone. This is not reverted by the down migration.
+UPDATE messages
+SET phone = normalize_phone_e164(phone)
+WHERE normalize_phone_e164(phone) IS NOT NULL AND normalize_phone_e164(phone) <> phone;
+
+DROP FUNCTION normalize_phone_e164(TEXT);
+
+DO $$
+DECLARE
+    normalized_count INTEGER;
+    problem_count INTEGER;
+BEGIN
+    SELECT COUNT(*) FILTER (WHERE status = 'normalized'), COUNT(*) FILTER (WHERE status <> 'normalized')
+    INTO normalized_count, problem_count
+    FROM phone_normalization_report;
+
+    RAISE NOTICE 'Normalised % phone numbers', normalized_count;
+    IF problem_count > 0 THEN
+        RAISE WARNING '% phone numbers were left unchanged (invalid or conflicting); see phone_normalization_report', problem_count;
+    END IF;
+END $$;
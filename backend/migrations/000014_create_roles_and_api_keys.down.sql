@@ -0,0 +1,5 @@
+-- Drop roles and API keys
+DROP TABLE IF EXISTS api_keys;
+DROP TABLE IF EXISTS user_roles;
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS roles;
\ No newline at end of file
@@ -0,0 +1,54 @@
+-- Roles group permissions; users may hold several roles
+CREATE TABLE roles (
+    name VARCHAR(50) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE role_permissions (
+    role VARCHAR(50) NOT NULL REFERENCES roles(name) ON DELETE CASCADE,
+    permission VARCHAR(100) NOT NULL,
+    PRIMARY KEY (role, permission)
+);
+
+CREATE TABLE user_roles (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(50) NOT NULL REFERENCES roles(name) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, role)
+);
+
+CREATE INDEX idx_user_roles_role ON user_roles(role);
+
+INSERT INTO roles (name, description) VALUES
+    ('admin', 'Manages users, roles, workflow routing and the WhatsApp connection'),
+    ('agent', 'Support staff who read and take over conversations'),
+    ('subscriber', 'Receives trading signals');
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'users:manage'),
+    ('admin', 'routing:manage'),
+    ('admin', 'whatsapp:manage'),
+    ('admin', 'conversations:read'),
+    ('admin', 'conversations:takeover'),
+    ('agent', 'conversations:read'),
+    ('agent', 'conversations:takeover'),
+    ('subscriber', 'signals:receive');
+
+-- Everyone registered so far receives signals
+INSERT INTO user_roles (user_id, role)
+SELECT id, 'subscriber' FROM users;
+
+-- Per-user API keys. Only the SHA-256 of a key is stored; the prefix identifies it in listings.
+CREATE TABLE api_keys (
+    id UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(16) NOT NULL,
+    key_hash CHAR(64) UNIQUE NOT NULL,
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_api_keys_user_id ON api_keys(user_id);
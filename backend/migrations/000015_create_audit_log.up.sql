@@ -0,0 +1,13 @@
+-- Security-relevant operator actions, such as WhatsApp pairing and logout
+CREATE TABLE audit_log (
+    id UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    action VARCHAR(100) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    actor_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    remote_addr VARCHAR(64),
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_log_action_created_at ON audit_log(action, created_at DESC);
+CREATE INDEX idx_audit_log_created_at ON audit_log(created_at DESC);
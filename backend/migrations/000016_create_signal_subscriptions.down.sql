@@ -0,0 +1,2 @@
+-- Drop signal subscriptions
+DROP TABLE IF EXISTS signal_subscriptions;
\ No newline at end of file
@@ -0,0 +1,21 @@
+-- Signal subscriptions. A signal goes to every active user with the
+-- signals:receive permission who has at least one matching subscription.
+-- NULL filters match anything; a NULL ticker subscribes to every ticker.
+CREATE TABLE signal_subscriptions (
+    id UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    ticker VARCHAR(10),
+    sentiment VARCHAR(20),
+    min_confluence_score NUMERIC(6, 2),
+    min_backtest_win_rate NUMERIC(5, 2),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One subscription per user and ticker (or per user for all tickers)
+CREATE UNIQUE INDEX idx_signal_subscriptions_user_ticker ON signal_subscriptions(user_id, (COALESCE(ticker, '')));
+CREATE INDEX idx_signal_subscriptions_ticker ON signal_subscriptions(ticker);
+
+-- Everyone received every signal so far; keep it that way until they opt out
+INSERT INTO signal_subscriptions (user_id)
+SELECT id FROM users;
@@ -0,0 +1,4 @@
+-- Drop signal broadcasts
+DELETE FROM role_permissions WHERE permission = 'signals:manage';
+DROP TABLE IF EXISTS signal_deliveries;
+DROP TABLE IF EXISTS signal_broadcasts;
\ No newline at end of file
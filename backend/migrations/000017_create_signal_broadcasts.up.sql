@@ -0,0 +1,31 @@
+-- A broadcast is one signal fanned out to its recipients in the background.
+-- Each recipient gets a delivery row that records the outcome of the send.
+CREATE TABLE signal_broadcasts (
+    id UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    ticker VARCHAR(10) NOT NULL,
+    message TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'queued',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX idx_signal_broadcasts_status ON signal_broadcasts(status);
+
+CREATE TABLE signal_deliveries (
+    id UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    broadcast_id UUID NOT NULL REFERENCES signal_broadcasts(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    phone VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    whatsapp_message_id VARCHAR(100),
+    error TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    attempted_at TIMESTAMP
+);
+
+CREATE INDEX idx_signal_deliveries_broadcast_status ON signal_deliveries(broadcast_id, status);
+
+-- Admins follow broadcasts and their deliveries
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'signals:manage');
@@ -0,0 +1,4 @@
+-- Drop tracked signals and prices
+ALTER TABLE signal_broadcasts DROP COLUMN IF EXISTS signal_id;
+DROP TABLE IF EXISTS signal_prices;
+DROP TABLE IF EXISTS tracked_signals;
\ No newline at end of file
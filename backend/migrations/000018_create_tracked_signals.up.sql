@@ -0,0 +1,40 @@
+-- Every signal received, with the levels its outcome is judged against.
+-- Direction is long when the target is above the entry, short otherwise.
+CREATE TABLE tracked_signals (
+    id UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    ticker VARCHAR(10) NOT NULL,
+    last_date DATE NOT NULL,
+    direction VARCHAR(10) NOT NULL,
+    entry_price NUMERIC(14, 2) NOT NULL,
+    stop NUMERIC(14, 2) NOT NULL,
+    target NUMERIC(14, 2) NOT NULL,
+    risk_reward NUMERIC(8, 2) NOT NULL,
+    backtest_win_rate NUMERIC(5, 2) NOT NULL,
+    total_trades INTEGER NOT NULL,
+    confluence_score NUMERIC(6, 2) NOT NULL,
+    overall_sentiment VARCHAR(20),
+    payload JSONB NOT NULL,
+    outcome VARCHAR(20) NOT NULL DEFAULT 'open',
+    exit_price NUMERIC(14, 2),
+    r_multiple NUMERIC(8, 2),
+    expires_on DATE NOT NULL,
+    resolved_on DATE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_tracked_signals_ticker_outcome ON tracked_signals(ticker, outcome);
+CREATE INDEX idx_tracked_signals_created_at ON tracked_signals(created_at);
+
+-- Daily price bars used to resolve open signals, one per ticker and date
+CREATE TABLE signal_prices (
+    ticker VARCHAR(10) NOT NULL,
+    price_date DATE NOT NULL,
+    high NUMERIC(14, 2) NOT NULL,
+    low NUMERIC(14, 2) NOT NULL,
+    close NUMERIC(14, 2) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (ticker, price_date)
+);
+
+-- A broadcast sends one tracked signal
+ALTER TABLE signal_broadcasts ADD COLUMN signal_id UUID REFERENCES tracked_signals(id) ON DELETE SET NULL;
@@ -0,0 +1,9 @@
+-- Drop signal identity, follow-up broadcasts and reply context
+DROP INDEX IF EXISTS idx_signal_broadcasts_signal_id;
+
+ALTER TABLE signal_deliveries DROP COLUMN IF EXISTS reply_to_message_id;
+ALTER TABLE signal_broadcasts DROP COLUMN IF EXISTS parent_id;
+ALTER TABLE signal_broadcasts DROP COLUMN IF EXISTS kind;
+
+ALTER TABLE tracked_signals DROP COLUMN IF EXISTS updated_at;
+DROP INDEX IF EXISTS idx_tracked_signals_identity;
\ No newline at end of file
@@ -0,0 +1,29 @@
+-- A signal is identified by its ticker, date and direction. Keep the first of
+-- any duplicates received so far and point their broadcasts at it.
+WITH ranked AS (
+    SELECT id, FIRST_VALUE(id) OVER (PARTITION BY ticker, last_date, direction ORDER BY created_at, id) AS keep_id
+    FROM tracked_signals
+)
+UPDATE signal_broadcasts b
+SET signal_id = ranked.keep_id
+FROM ranked
+WHERE b.signal_id = ranked.id AND ranked.id <> ranked.keep_id;
+
+DELETE FROM tracked_signals t
+USING tracked_signals earlier
+WHERE t.ticker = earlier.ticker
+  AND t.last_date = earlier.last_date
+  AND t.direction = earlier.direction
+  AND (earlier.created_at, earlier.id) < (t.created_at, t.id);
+
+CREATE UNIQUE INDEX idx_tracked_signals_identity ON tracked_signals(ticker, last_date, direction);
+
+ALTER TABLE tracked_signals ADD COLUMN updated_at TIMESTAMP;
+
+-- Updates and cancellations are broadcast as replies to the original signal
+-- message of each recipient
+ALTER TABLE signal_broadcasts ADD COLUMN kind VARCHAR(10) NOT NULL DEFAULT 'signal';
+ALTER TABLE signal_broadcasts ADD COLUMN parent_id UUID REFERENCES signal_broadcasts(id) ON DELETE CASCADE;
+ALTER TABLE signal_deliveries ADD COLUMN reply_to_message_id VARCHAR(255);
+
+CREATE INDEX idx_signal_broadcasts_signal_id ON signal_broadcasts(signal_id, kind);
@@ -0,0 +1,8 @@
+-- Drop signal templates and preferred languages
+ALTER TABLE signal_deliveries DROP COLUMN IF EXISTS quoted_message;
+ALTER TABLE signal_deliveries DROP COLUMN IF EXISTS message;
+ALTER TABLE signal_deliveries DROP COLUMN IF EXISTS language;
+
+DROP TABLE IF EXISTS signal_templates;
+
+ALTER TABLE users DROP COLUMN IF EXISTS language;
\ No newline at end of file
@@ -0,0 +1,19 @@
+-- Preferred language of signal messages: id (Indonesian) or en (English)
+ALTER TABLE users ADD COLUMN language VARCHAR(10) NOT NULL DEFAULT 'id';
+
+-- Admin-edited text/template bodies of signal messages, one per kind and
+-- language. Kinds and languages without a row use the built-in template.
+CREATE TABLE signal_templates (
+    kind VARCHAR(10) NOT NULL,
+    language VARCHAR(10) NOT NULL,
+    body TEXT NOT NULL,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (kind, language)
+);
+
+-- Each recipient gets the message rendered in their language. Follow-ups use
+-- the language of the original and quote the recipient's own copy of it.
+ALTER TABLE signal_deliveries ADD COLUMN language VARCHAR(10);
+ALTER TABLE signal_deliveries ADD COLUMN message TEXT;
+ALTER TABLE signal_deliveries ADD COLUMN quoted_message TEXT;
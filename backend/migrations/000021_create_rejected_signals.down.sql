@@ -0,0 +1,2 @@
+-- Drop rejected signals
+DROP TABLE IF EXISTS rejected_signals;
\ No newline at end of file
@@ -0,0 +1,15 @@
+-- Signals that failed validation, kept with every violation for review.
+-- Ticker, last_date and operation are copied from the payload as received,
+-- so they may be invalid.
+CREATE TABLE rejected_signals (
+    id UUID DEFAULT uuid_generate_v4() PRIMARY KEY,
+    ticker TEXT NOT NULL DEFAULT '',
+    last_date TEXT NOT NULL DEFAULT '',
+    operation TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    violations JSONB NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_rejected_signals_created_at ON rejected_signals(created_at);
+CREATE INDEX idx_rejected_signals_ticker ON rejected_signals(ticker);
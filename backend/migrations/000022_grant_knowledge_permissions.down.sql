@@ -0,0 +1,2 @@
+-- Revoke knowledge permissions
+DELETE FROM role_permissions WHERE permission IN ('knowledge:read', 'knowledge:manage');
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- Support staff search the knowledge base; admins also change it
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'knowledge:read'),
+    ('admin', 'knowledge:manage'),
+    ('agent', 'knowledge:read');
@@ -0,0 +1,2 @@
+-- Drop conversation takeovers
+DROP TABLE IF EXISTS conversation_takeovers;
\ No newline at end of file
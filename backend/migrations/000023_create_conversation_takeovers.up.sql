@@ -0,0 +1,8 @@
+-- A conversation taken over by a support agent is answered by the agent instead of
+-- the workflow until it is released
+CREATE TABLE conversation_takeovers (
+    phone VARCHAR(20) PRIMARY KEY,
+    agent_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    agent_name VARCHAR(100) NOT NULL,
+    started_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,2 @@
+-- Drop the signal broadcast revision
+ALTER TABLE signal_broadcasts DROP COLUMN IF EXISTS revision;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- An update broadcast records the revision of the signal it carries, so that
+-- the same update posted twice is broadcast once. Deliveries dropped before
+-- they were sent, such as those of a cancelled signal, are 'cancelled'.
+ALTER TABLE signal_broadcasts ADD COLUMN revision TIMESTAMP;
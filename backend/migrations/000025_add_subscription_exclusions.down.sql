@@ -0,0 +1,2 @@
+-- Drop subscription exclusions
+ALTER TABLE signal_subscriptions DROP COLUMN IF EXISTS excluded_tickers;
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- Unsubscribing from one ticker while subscribed to every ticker excludes
+-- that ticker from the all-tickers subscription
+ALTER TABLE signal_subscriptions ADD COLUMN excluded_tickers VARCHAR(10)[] NOT NULL DEFAULT '{}';
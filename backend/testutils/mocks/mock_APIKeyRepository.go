@@ -0,0 +1,318 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockAPIKeyRepository is an autogenerated mock type for the APIKeyRepository type
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+type MockAPIKeyRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAPIKeyRepository) EXPECT() *MockAPIKeyRepository_Expecter {
+	return &MockAPIKeyRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, key, keyHash
+func (_m *MockAPIKeyRepository) Create(ctx context.Context, key *models.APIKey, keyHash string) error {
+	ret := _m.Called(ctx, key, keyHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.APIKey, string) error); ok {
+		r0 = rf(ctx, key, keyHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAPIKeyRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockAPIKeyRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key *models.APIKey
+//   - keyHash string
+func (_e *MockAPIKeyRepository_Expecter) Create(ctx interface{}, key interface{}, keyHash interface{}) *MockAPIKeyRepository_Create_Call {
+	return &MockAPIKeyRepository_Create_Call{Call: _e.mock.On("Create", ctx, key, keyHash)}
+}
+
+func (_c *MockAPIKeyRepository_Create_Call) Run(run func(ctx context.Context, key *models.APIKey, keyHash string)) *MockAPIKeyRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.APIKey), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_Create_Call) Return(_a0 error) *MockAPIKeyRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_Create_Call) RunAndReturn(run func(context.Context, *models.APIKey, string) error) *MockAPIKeyRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActiveByHash provides a mock function with given fields: ctx, keyHash
+func (_m *MockAPIKeyRepository) GetActiveByHash(ctx context.Context, keyHash string) (*models.APIKey, *models.User, error) {
+	ret := _m.Called(ctx, keyHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveByHash")
+	}
+
+	var r0 *models.APIKey
+	var r1 *models.User
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.APIKey, *models.User, error)); ok {
+		return rf(ctx, keyHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.APIKey); ok {
+		r0 = rf(ctx, keyHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) *models.User); ok {
+		r1 = rf(ctx, keyHash)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, keyHash)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockAPIKeyRepository_GetActiveByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveByHash'
+type MockAPIKeyRepository_GetActiveByHash_Call struct {
+	*mock.Call
+}
+
+// GetActiveByHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keyHash string
+func (_e *MockAPIKeyRepository_Expecter) GetActiveByHash(ctx interface{}, keyHash interface{}) *MockAPIKeyRepository_GetActiveByHash_Call {
+	return &MockAPIKeyRepository_GetActiveByHash_Call{Call: _e.mock.On("GetActiveByHash", ctx, keyHash)}
+}
+
+func (_c *MockAPIKeyRepository_GetActiveByHash_Call) Run(run func(ctx context.Context, keyHash string)) *MockAPIKeyRepository_GetActiveByHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_GetActiveByHash_Call) Return(_a0 *models.APIKey, _a1 *models.User, _a2 error) *MockAPIKeyRepository_GetActiveByHash_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_GetActiveByHash_Call) RunAndReturn(run func(context.Context, string) (*models.APIKey, *models.User, error)) *MockAPIKeyRepository_GetActiveByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByUser provides a mock function with given fields: ctx, userID
+func (_m *MockAPIKeyRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.APIKey, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByUser")
+	}
+
+	var r0 []*models.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*models.APIKey, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*models.APIKey); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAPIKeyRepository_ListByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByUser'
+type MockAPIKeyRepository_ListByUser_Call struct {
+	*mock.Call
+}
+
+// ListByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockAPIKeyRepository_Expecter) ListByUser(ctx interface{}, userID interface{}) *MockAPIKeyRepository_ListByUser_Call {
+	return &MockAPIKeyRepository_ListByUser_Call{Call: _e.mock.On("ListByUser", ctx, userID)}
+}
+
+func (_c *MockAPIKeyRepository_ListByUser_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockAPIKeyRepository_ListByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_ListByUser_Call) Return(_a0 []*models.APIKey, _a1 error) *MockAPIKeyRepository_ListByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_ListByUser_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*models.APIKey, error)) *MockAPIKeyRepository_ListByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revoke provides a mock function with given fields: ctx, id
+func (_m *MockAPIKeyRepository) Revoke(ctx context.Context, id uuid.UUID) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAPIKeyRepository_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
+type MockAPIKeyRepository_Revoke_Call struct {
+	*mock.Call
+}
+
+// Revoke is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockAPIKeyRepository_Expecter) Revoke(ctx interface{}, id interface{}) *MockAPIKeyRepository_Revoke_Call {
+	return &MockAPIKeyRepository_Revoke_Call{Call: _e.mock.On("Revoke", ctx, id)}
+}
+
+func (_c *MockAPIKeyRepository_Revoke_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockAPIKeyRepository_Revoke_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_Revoke_Call) Return(_a0 bool, _a1 error) *MockAPIKeyRepository_Revoke_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_Revoke_Call) RunAndReturn(run func(context.Context, uuid.UUID) (bool, error)) *MockAPIKeyRepository_Revoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TouchLastUsed provides a mock function with given fields: ctx, id
+func (_m *MockAPIKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TouchLastUsed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAPIKeyRepository_TouchLastUsed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TouchLastUsed'
+type MockAPIKeyRepository_TouchLastUsed_Call struct {
+	*mock.Call
+}
+
+// TouchLastUsed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockAPIKeyRepository_Expecter) TouchLastUsed(ctx interface{}, id interface{}) *MockAPIKeyRepository_TouchLastUsed_Call {
+	return &MockAPIKeyRepository_TouchLastUsed_Call{Call: _e.mock.On("TouchLastUsed", ctx, id)}
+}
+
+func (_c *MockAPIKeyRepository_TouchLastUsed_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockAPIKeyRepository_TouchLastUsed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_TouchLastUsed_Call) Return(_a0 error) *MockAPIKeyRepository_TouchLastUsed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_TouchLastUsed_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockAPIKeyRepository_TouchLastUsed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAPIKeyRepository creates a new instance of MockAPIKeyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAPIKeyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAPIKeyRepository {
+	mock := &MockAPIKeyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,214 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAccessPolicyRepository is an autogenerated mock type for the AccessPolicyRepository type
+type MockAccessPolicyRepository struct {
+	mock.Mock
+}
+
+type MockAccessPolicyRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAccessPolicyRepository) EXPECT() *MockAccessPolicyRepository_Expecter {
+	return &MockAccessPolicyRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetActivePolicy provides a mock function with given fields: ctx
+func (_m *MockAccessPolicyRepository) GetActivePolicy(ctx context.Context) (*models.AccessPolicy, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActivePolicy")
+	}
+
+	var r0 *models.AccessPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*models.AccessPolicy, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *models.AccessPolicy); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccessPolicyRepository_GetActivePolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActivePolicy'
+type MockAccessPolicyRepository_GetActivePolicy_Call struct {
+	*mock.Call
+}
+
+// GetActivePolicy is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAccessPolicyRepository_Expecter) GetActivePolicy(ctx interface{}) *MockAccessPolicyRepository_GetActivePolicy_Call {
+	return &MockAccessPolicyRepository_GetActivePolicy_Call{Call: _e.mock.On("GetActivePolicy", ctx)}
+}
+
+func (_c *MockAccessPolicyRepository_GetActivePolicy_Call) Run(run func(ctx context.Context)) *MockAccessPolicyRepository_GetActivePolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockAccessPolicyRepository_GetActivePolicy_Call) Return(_a0 *models.AccessPolicy, _a1 error) *MockAccessPolicyRepository_GetActivePolicy_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccessPolicyRepository_GetActivePolicy_Call) RunAndReturn(run func(context.Context) (*models.AccessPolicy, error)) *MockAccessPolicyRepository_GetActivePolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllowlistEntry provides a mock function with given fields: ctx, phone
+func (_m *MockAccessPolicyRepository) GetAllowlistEntry(ctx context.Context, phone string) (*models.AllowlistEntry, error) {
+	ret := _m.Called(ctx, phone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllowlistEntry")
+	}
+
+	var r0 *models.AllowlistEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.AllowlistEntry, error)); ok {
+		return rf(ctx, phone)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.AllowlistEntry); ok {
+		r0 = rf(ctx, phone)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AllowlistEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, phone)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccessPolicyRepository_GetAllowlistEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllowlistEntry'
+type MockAccessPolicyRepository_GetAllowlistEntry_Call struct {
+	*mock.Call
+}
+
+// GetAllowlistEntry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - phone string
+func (_e *MockAccessPolicyRepository_Expecter) GetAllowlistEntry(ctx interface{}, phone interface{}) *MockAccessPolicyRepository_GetAllowlistEntry_Call {
+	return &MockAccessPolicyRepository_GetAllowlistEntry_Call{Call: _e.mock.On("GetAllowlistEntry", ctx, phone)}
+}
+
+func (_c *MockAccessPolicyRepository_GetAllowlistEntry_Call) Run(run func(ctx context.Context, phone string)) *MockAccessPolicyRepository_GetAllowlistEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockAccessPolicyRepository_GetAllowlistEntry_Call) Return(_a0 *models.AllowlistEntry, _a1 error) *MockAccessPolicyRepository_GetAllowlistEntry_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccessPolicyRepository_GetAllowlistEntry_Call) RunAndReturn(run func(context.Context, string) (*models.AllowlistEntry, error)) *MockAccessPolicyRepository_GetAllowlistEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePolicy provides a mock function with given fields: ctx, mode, notifyUnregistered
+func (_m *MockAccessPolicyRepository) UpdatePolicy(ctx context.Context, mode string, notifyUnregistered *bool) (*models.AccessPolicy, error) {
+	ret := _m.Called(ctx, mode, notifyUnregistered)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePolicy")
+	}
+
+	var r0 *models.AccessPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *bool) (*models.AccessPolicy, error)); ok {
+		return rf(ctx, mode, notifyUnregistered)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *bool) *models.AccessPolicy); ok {
+		r0 = rf(ctx, mode, notifyUnregistered)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *bool) error); ok {
+		r1 = rf(ctx, mode, notifyUnregistered)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccessPolicyRepository_UpdatePolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePolicy'
+type MockAccessPolicyRepository_UpdatePolicy_Call struct {
+	*mock.Call
+}
+
+// UpdatePolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - mode string
+//   - notifyUnregistered *bool
+func (_e *MockAccessPolicyRepository_Expecter) UpdatePolicy(ctx interface{}, mode interface{}, notifyUnregistered interface{}) *MockAccessPolicyRepository_UpdatePolicy_Call {
+	return &MockAccessPolicyRepository_UpdatePolicy_Call{Call: _e.mock.On("UpdatePolicy", ctx, mode, notifyUnregistered)}
+}
+
+func (_c *MockAccessPolicyRepository_UpdatePolicy_Call) Run(run func(ctx context.Context, mode string, notifyUnregistered *bool)) *MockAccessPolicyRepository_UpdatePolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*bool))
+	})
+	return _c
+}
+
+func (_c *MockAccessPolicyRepository_UpdatePolicy_Call) Return(_a0 *models.AccessPolicy, _a1 error) *MockAccessPolicyRepository_UpdatePolicy_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccessPolicyRepository_UpdatePolicy_Call) RunAndReturn(run func(context.Context, string, *bool) (*models.AccessPolicy, error)) *MockAccessPolicyRepository_UpdatePolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAccessPolicyRepository creates a new instance of MockAccessPolicyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAccessPolicyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAccessPolicyRepository {
+	mock := &MockAccessPolicyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,144 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAuditRepository is an autogenerated mock type for the AuditRepository type
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+type MockAuditRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAuditRepository) EXPECT() *MockAuditRepository_Expecter {
+	return &MockAuditRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, entry
+func (_m *MockAuditRepository) Create(ctx context.Context, entry *models.AuditEntry) error {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuditEntry) error); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAuditRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockAuditRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entry *models.AuditEntry
+func (_e *MockAuditRepository_Expecter) Create(ctx interface{}, entry interface{}) *MockAuditRepository_Create_Call {
+	return &MockAuditRepository_Create_Call{Call: _e.mock.On("Create", ctx, entry)}
+}
+
+func (_c *MockAuditRepository_Create_Call) Run(run func(ctx context.Context, entry *models.AuditEntry)) *MockAuditRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.AuditEntry))
+	})
+	return _c
+}
+
+func (_c *MockAuditRepository_Create_Call) Return(_a0 error) *MockAuditRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAuditRepository_Create_Call) RunAndReturn(run func(context.Context, *models.AuditEntry) error) *MockAuditRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, action, limit
+func (_m *MockAuditRepository) List(ctx context.Context, action string, limit int) ([]*models.AuditEntry, error) {
+	ret := _m.Called(ctx, action, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.AuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]*models.AuditEntry, error)); ok {
+		return rf(ctx, action, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []*models.AuditEntry); ok {
+		r0 = rf(ctx, action, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, action, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuditRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockAuditRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - action string
+//   - limit int
+func (_e *MockAuditRepository_Expecter) List(ctx interface{}, action interface{}, limit interface{}) *MockAuditRepository_List_Call {
+	return &MockAuditRepository_List_Call{Call: _e.mock.On("List", ctx, action, limit)}
+}
+
+func (_c *MockAuditRepository_List_Call) Run(run func(ctx context.Context, action string, limit int)) *MockAuditRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockAuditRepository_List_Call) Return(_a0 []*models.AuditEntry, _a1 error) *MockAuditRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuditRepository_List_Call) RunAndReturn(run func(context.Context, string, int) ([]*models.AuditEntry, error)) *MockAuditRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAuditRepository creates a new instance of MockAuditRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuditRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuditRepository {
+	mock := &MockAuditRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
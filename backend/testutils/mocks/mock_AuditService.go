@@ -0,0 +1,131 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAuditService is an autogenerated mock type for the AuditService type
+type MockAuditService struct {
+	mock.Mock
+}
+
+type MockAuditService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAuditService) EXPECT() *MockAuditService_Expecter {
+	return &MockAuditService_Expecter{mock: &_m.Mock}
+}
+
+// List provides a mock function with given fields: ctx, action, limit
+func (_m *MockAuditService) List(ctx context.Context, action string, limit int) ([]*models.AuditEntry, error) {
+	ret := _m.Called(ctx, action, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.AuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]*models.AuditEntry, error)); ok {
+		return rf(ctx, action, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []*models.AuditEntry); ok {
+		r0 = rf(ctx, action, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, action, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuditService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockAuditService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - action string
+//   - limit int
+func (_e *MockAuditService_Expecter) List(ctx interface{}, action interface{}, limit interface{}) *MockAuditService_List_Call {
+	return &MockAuditService_List_Call{Call: _e.mock.On("List", ctx, action, limit)}
+}
+
+func (_c *MockAuditService_List_Call) Run(run func(ctx context.Context, action string, limit int)) *MockAuditService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockAuditService_List_Call) Return(_a0 []*models.AuditEntry, _a1 error) *MockAuditService_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuditService_List_Call) RunAndReturn(run func(context.Context, string, int) ([]*models.AuditEntry, error)) *MockAuditService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Record provides a mock function with given fields: ctx, entry
+func (_m *MockAuditService) Record(ctx context.Context, entry *models.AuditEntry) {
+	_m.Called(ctx, entry)
+}
+
+// MockAuditService_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type MockAuditService_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entry *models.AuditEntry
+func (_e *MockAuditService_Expecter) Record(ctx interface{}, entry interface{}) *MockAuditService_Record_Call {
+	return &MockAuditService_Record_Call{Call: _e.mock.On("Record", ctx, entry)}
+}
+
+func (_c *MockAuditService_Record_Call) Run(run func(ctx context.Context, entry *models.AuditEntry)) *MockAuditService_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.AuditEntry))
+	})
+	return _c
+}
+
+func (_c *MockAuditService_Record_Call) Return() *MockAuditService_Record_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuditService_Record_Call) RunAndReturn(run func(context.Context, *models.AuditEntry)) *MockAuditService_Record_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockAuditService creates a new instance of MockAuditService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuditService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuditService {
+	mock := &MockAuditService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
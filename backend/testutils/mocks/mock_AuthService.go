@@ -0,0 +1,323 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockAuthService is an autogenerated mock type for the AuthService type
+type MockAuthService struct {
+	mock.Mock
+}
+
+type MockAuthService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAuthService) EXPECT() *MockAuthService_Expecter {
+	return &MockAuthService_Expecter{mock: &_m.Mock}
+}
+
+// Authenticate provides a mock function with given fields: ctx, credential
+func (_m *MockAuthService) Authenticate(ctx context.Context, credential string) (*models.Principal, error) {
+	ret := _m.Called(ctx, credential)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authenticate")
+	}
+
+	var r0 *models.Principal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Principal, error)); ok {
+		return rf(ctx, credential)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Principal); ok {
+		r0 = rf(ctx, credential)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Principal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, credential)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuthService_Authenticate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authenticate'
+type MockAuthService_Authenticate_Call struct {
+	*mock.Call
+}
+
+// Authenticate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - credential string
+func (_e *MockAuthService_Expecter) Authenticate(ctx interface{}, credential interface{}) *MockAuthService_Authenticate_Call {
+	return &MockAuthService_Authenticate_Call{Call: _e.mock.On("Authenticate", ctx, credential)}
+}
+
+func (_c *MockAuthService_Authenticate_Call) Run(run func(ctx context.Context, credential string)) *MockAuthService_Authenticate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockAuthService_Authenticate_Call) Return(_a0 *models.Principal, _a1 error) *MockAuthService_Authenticate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuthService_Authenticate_Call) RunAndReturn(run func(context.Context, string) (*models.Principal, error)) *MockAuthService_Authenticate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateAPIKey provides a mock function with given fields: ctx, userID, name
+func (_m *MockAuthService) CreateAPIKey(ctx context.Context, userID uuid.UUID, name string) (*models.CreatedAPIKey, error) {
+	ret := _m.Called(ctx, userID, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAPIKey")
+	}
+
+	var r0 *models.CreatedAPIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*models.CreatedAPIKey, error)); ok {
+		return rf(ctx, userID, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *models.CreatedAPIKey); ok {
+		r0 = rf(ctx, userID, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CreatedAPIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, userID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuthService_CreateAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAPIKey'
+type MockAuthService_CreateAPIKey_Call struct {
+	*mock.Call
+}
+
+// CreateAPIKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - name string
+func (_e *MockAuthService_Expecter) CreateAPIKey(ctx interface{}, userID interface{}, name interface{}) *MockAuthService_CreateAPIKey_Call {
+	return &MockAuthService_CreateAPIKey_Call{Call: _e.mock.On("CreateAPIKey", ctx, userID, name)}
+}
+
+func (_c *MockAuthService_CreateAPIKey_Call) Run(run func(ctx context.Context, userID uuid.UUID, name string)) *MockAuthService_CreateAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockAuthService_CreateAPIKey_Call) Return(_a0 *models.CreatedAPIKey, _a1 error) *MockAuthService_CreateAPIKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuthService_CreateAPIKey_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) (*models.CreatedAPIKey, error)) *MockAuthService_CreateAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IssueToken provides a mock function with given fields: ctx, principal
+func (_m *MockAuthService) IssueToken(ctx context.Context, principal *models.Principal) (*models.AuthToken, error) {
+	ret := _m.Called(ctx, principal)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueToken")
+	}
+
+	var r0 *models.AuthToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Principal) (*models.AuthToken, error)); ok {
+		return rf(ctx, principal)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Principal) *models.AuthToken); ok {
+		r0 = rf(ctx, principal)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Principal) error); ok {
+		r1 = rf(ctx, principal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuthService_IssueToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IssueToken'
+type MockAuthService_IssueToken_Call struct {
+	*mock.Call
+}
+
+// IssueToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - principal *models.Principal
+func (_e *MockAuthService_Expecter) IssueToken(ctx interface{}, principal interface{}) *MockAuthService_IssueToken_Call {
+	return &MockAuthService_IssueToken_Call{Call: _e.mock.On("IssueToken", ctx, principal)}
+}
+
+func (_c *MockAuthService_IssueToken_Call) Run(run func(ctx context.Context, principal *models.Principal)) *MockAuthService_IssueToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Principal))
+	})
+	return _c
+}
+
+func (_c *MockAuthService_IssueToken_Call) Return(_a0 *models.AuthToken, _a1 error) *MockAuthService_IssueToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuthService_IssueToken_Call) RunAndReturn(run func(context.Context, *models.Principal) (*models.AuthToken, error)) *MockAuthService_IssueToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAPIKeys provides a mock function with given fields: ctx, userID
+func (_m *MockAuthService) ListAPIKeys(ctx context.Context, userID uuid.UUID) ([]*models.APIKey, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAPIKeys")
+	}
+
+	var r0 []*models.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*models.APIKey, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*models.APIKey); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuthService_ListAPIKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAPIKeys'
+type MockAuthService_ListAPIKeys_Call struct {
+	*mock.Call
+}
+
+// ListAPIKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockAuthService_Expecter) ListAPIKeys(ctx interface{}, userID interface{}) *MockAuthService_ListAPIKeys_Call {
+	return &MockAuthService_ListAPIKeys_Call{Call: _e.mock.On("ListAPIKeys", ctx, userID)}
+}
+
+func (_c *MockAuthService_ListAPIKeys_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockAuthService_ListAPIKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockAuthService_ListAPIKeys_Call) Return(_a0 []*models.APIKey, _a1 error) *MockAuthService_ListAPIKeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuthService_ListAPIKeys_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*models.APIKey, error)) *MockAuthService_ListAPIKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeAPIKey provides a mock function with given fields: ctx, id
+func (_m *MockAuthService) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAPIKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAuthService_RevokeAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeAPIKey'
+type MockAuthService_RevokeAPIKey_Call struct {
+	*mock.Call
+}
+
+// RevokeAPIKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockAuthService_Expecter) RevokeAPIKey(ctx interface{}, id interface{}) *MockAuthService_RevokeAPIKey_Call {
+	return &MockAuthService_RevokeAPIKey_Call{Call: _e.mock.On("RevokeAPIKey", ctx, id)}
+}
+
+func (_c *MockAuthService_RevokeAPIKey_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockAuthService_RevokeAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockAuthService_RevokeAPIKey_Call) Return(_a0 error) *MockAuthService_RevokeAPIKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAuthService_RevokeAPIKey_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockAuthService_RevokeAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAuthService creates a new instance of MockAuthService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuthService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuthService {
+	mock := &MockAuthService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,463 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockEmbeddingJobRepository is an autogenerated mock type for the EmbeddingJobRepository type
+type MockEmbeddingJobRepository struct {
+	mock.Mock
+}
+
+type MockEmbeddingJobRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEmbeddingJobRepository) EXPECT() *MockEmbeddingJobRepository_Expecter {
+	return &MockEmbeddingJobRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, job
+func (_m *MockEmbeddingJobRepository) Create(ctx context.Context, job *models.EmbeddingJob) error {
+	ret := _m.Called(ctx, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.EmbeddingJob) error); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockEmbeddingJobRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockEmbeddingJobRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - job *models.EmbeddingJob
+func (_e *MockEmbeddingJobRepository_Expecter) Create(ctx interface{}, job interface{}) *MockEmbeddingJobRepository_Create_Call {
+	return &MockEmbeddingJobRepository_Create_Call{Call: _e.mock.On("Create", ctx, job)}
+}
+
+func (_c *MockEmbeddingJobRepository_Create_Call) Run(run func(ctx context.Context, job *models.EmbeddingJob)) *MockEmbeddingJobRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.EmbeddingJob))
+	})
+	return _c
+}
+
+func (_c *MockEmbeddingJobRepository_Create_Call) Return(_a0 error) *MockEmbeddingJobRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockEmbeddingJobRepository_Create_Call) RunAndReturn(run func(context.Context, *models.EmbeddingJob) error) *MockEmbeddingJobRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FailUnfinished provides a mock function with given fields: ctx, errMsg
+func (_m *MockEmbeddingJobRepository) FailUnfinished(ctx context.Context, errMsg string) (int64, error) {
+	ret := _m.Called(ctx, errMsg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailUnfinished")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, errMsg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, errMsg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, errMsg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEmbeddingJobRepository_FailUnfinished_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FailUnfinished'
+type MockEmbeddingJobRepository_FailUnfinished_Call struct {
+	*mock.Call
+}
+
+// FailUnfinished is a helper method to define mock.On call
+//   - ctx context.Context
+//   - errMsg string
+func (_e *MockEmbeddingJobRepository_Expecter) FailUnfinished(ctx interface{}, errMsg interface{}) *MockEmbeddingJobRepository_FailUnfinished_Call {
+	return &MockEmbeddingJobRepository_FailUnfinished_Call{Call: _e.mock.On("FailUnfinished", ctx, errMsg)}
+}
+
+func (_c *MockEmbeddingJobRepository_FailUnfinished_Call) Run(run func(ctx context.Context, errMsg string)) *MockEmbeddingJobRepository_FailUnfinished_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockEmbeddingJobRepository_FailUnfinished_Call) Return(_a0 int64, _a1 error) *MockEmbeddingJobRepository_FailUnfinished_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEmbeddingJobRepository_FailUnfinished_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *MockEmbeddingJobRepository_FailUnfinished_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Finish provides a mock function with given fields: ctx, id, status, errMsg
+func (_m *MockEmbeddingJobRepository) Finish(ctx context.Context, id uuid.UUID, status string, errMsg string) error {
+	ret := _m.Called(ctx, id, status, errMsg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Finish")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string) error); ok {
+		r0 = rf(ctx, id, status, errMsg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockEmbeddingJobRepository_Finish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Finish'
+type MockEmbeddingJobRepository_Finish_Call struct {
+	*mock.Call
+}
+
+// Finish is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - status string
+//   - errMsg string
+func (_e *MockEmbeddingJobRepository_Expecter) Finish(ctx interface{}, id interface{}, status interface{}, errMsg interface{}) *MockEmbeddingJobRepository_Finish_Call {
+	return &MockEmbeddingJobRepository_Finish_Call{Call: _e.mock.On("Finish", ctx, id, status, errMsg)}
+}
+
+func (_c *MockEmbeddingJobRepository_Finish_Call) Run(run func(ctx context.Context, id uuid.UUID, status string, errMsg string)) *MockEmbeddingJobRepository_Finish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockEmbeddingJobRepository_Finish_Call) Return(_a0 error) *MockEmbeddingJobRepository_Finish_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockEmbeddingJobRepository_Finish_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, string) error) *MockEmbeddingJobRepository_Finish_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *MockEmbeddingJobRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.EmbeddingJob, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.EmbeddingJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.EmbeddingJob, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.EmbeddingJob); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EmbeddingJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEmbeddingJobRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockEmbeddingJobRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockEmbeddingJobRepository_Expecter) GetByID(ctx interface{}, id interface{}) *MockEmbeddingJobRepository_GetByID_Call {
+	return &MockEmbeddingJobRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *MockEmbeddingJobRepository_GetByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockEmbeddingJobRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockEmbeddingJobRepository_GetByID_Call) Return(_a0 *models.EmbeddingJob, _a1 error) *MockEmbeddingJobRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEmbeddingJobRepository_GetByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.EmbeddingJob, error)) *MockEmbeddingJobRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HasUnfinished provides a mock function with given fields: ctx, targetSpaceID
+func (_m *MockEmbeddingJobRepository) HasUnfinished(ctx context.Context, targetSpaceID uuid.UUID) (bool, error) {
+	ret := _m.Called(ctx, targetSpaceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasUnfinished")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (bool, error)); ok {
+		return rf(ctx, targetSpaceID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) bool); ok {
+		r0 = rf(ctx, targetSpaceID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, targetSpaceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEmbeddingJobRepository_HasUnfinished_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasUnfinished'
+type MockEmbeddingJobRepository_HasUnfinished_Call struct {
+	*mock.Call
+}
+
+// HasUnfinished is a helper method to define mock.On call
+//   - ctx context.Context
+//   - targetSpaceID uuid.UUID
+func (_e *MockEmbeddingJobRepository_Expecter) HasUnfinished(ctx interface{}, targetSpaceID interface{}) *MockEmbeddingJobRepository_HasUnfinished_Call {
+	return &MockEmbeddingJobRepository_HasUnfinished_Call{Call: _e.mock.On("HasUnfinished", ctx, targetSpaceID)}
+}
+
+func (_c *MockEmbeddingJobRepository_HasUnfinished_Call) Run(run func(ctx context.Context, targetSpaceID uuid.UUID)) *MockEmbeddingJobRepository_HasUnfinished_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockEmbeddingJobRepository_HasUnfinished_Call) Return(_a0 bool, _a1 error) *MockEmbeddingJobRepository_HasUnfinished_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEmbeddingJobRepository_HasUnfinished_Call) RunAndReturn(run func(context.Context, uuid.UUID) (bool, error)) *MockEmbeddingJobRepository_HasUnfinished_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, limit
+func (_m *MockEmbeddingJobRepository) List(ctx context.Context, limit int) ([]*models.EmbeddingJob, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.EmbeddingJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*models.EmbeddingJob, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*models.EmbeddingJob); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.EmbeddingJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEmbeddingJobRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockEmbeddingJobRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+func (_e *MockEmbeddingJobRepository_Expecter) List(ctx interface{}, limit interface{}) *MockEmbeddingJobRepository_List_Call {
+	return &MockEmbeddingJobRepository_List_Call{Call: _e.mock.On("List", ctx, limit)}
+}
+
+func (_c *MockEmbeddingJobRepository_List_Call) Run(run func(ctx context.Context, limit int)) *MockEmbeddingJobRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockEmbeddingJobRepository_List_Call) Return(_a0 []*models.EmbeddingJob, _a1 error) *MockEmbeddingJobRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEmbeddingJobRepository_List_Call) RunAndReturn(run func(context.Context, int) ([]*models.EmbeddingJob, error)) *MockEmbeddingJobRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Start provides a mock function with given fields: ctx, id, total
+func (_m *MockEmbeddingJobRepository) Start(ctx context.Context, id uuid.UUID, total int) error {
+	ret := _m.Called(ctx, id, total)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Start")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) error); ok {
+		r0 = rf(ctx, id, total)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockEmbeddingJobRepository_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
+type MockEmbeddingJobRepository_Start_Call struct {
+	*mock.Call
+}
+
+// Start is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - total int
+func (_e *MockEmbeddingJobRepository_Expecter) Start(ctx interface{}, id interface{}, total interface{}) *MockEmbeddingJobRepository_Start_Call {
+	return &MockEmbeddingJobRepository_Start_Call{Call: _e.mock.On("Start", ctx, id, total)}
+}
+
+func (_c *MockEmbeddingJobRepository_Start_Call) Run(run func(ctx context.Context, id uuid.UUID, total int)) *MockEmbeddingJobRepository_Start_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockEmbeddingJobRepository_Start_Call) Return(_a0 error) *MockEmbeddingJobRepository_Start_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockEmbeddingJobRepository_Start_Call) RunAndReturn(run func(context.Context, uuid.UUID, int) error) *MockEmbeddingJobRepository_Start_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateProgress provides a mock function with given fields: ctx, id, processed
+func (_m *MockEmbeddingJobRepository) UpdateProgress(ctx context.Context, id uuid.UUID, processed int) error {
+	ret := _m.Called(ctx, id, processed)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProgress")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) error); ok {
+		r0 = rf(ctx, id, processed)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockEmbeddingJobRepository_UpdateProgress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProgress'
+type MockEmbeddingJobRepository_UpdateProgress_Call struct {
+	*mock.Call
+}
+
+// UpdateProgress is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - processed int
+func (_e *MockEmbeddingJobRepository_Expecter) UpdateProgress(ctx interface{}, id interface{}, processed interface{}) *MockEmbeddingJobRepository_UpdateProgress_Call {
+	return &MockEmbeddingJobRepository_UpdateProgress_Call{Call: _e.mock.On("UpdateProgress", ctx, id, processed)}
+}
+
+func (_c *MockEmbeddingJobRepository_UpdateProgress_Call) Run(run func(ctx context.Context, id uuid.UUID, processed int)) *MockEmbeddingJobRepository_UpdateProgress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockEmbeddingJobRepository_UpdateProgress_Call) Return(_a0 error) *MockEmbeddingJobRepository_UpdateProgress_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockEmbeddingJobRepository_UpdateProgress_Call) RunAndReturn(run func(context.Context, uuid.UUID, int) error) *MockEmbeddingJobRepository_UpdateProgress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockEmbeddingJobRepository creates a new instance of MockEmbeddingJobRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEmbeddingJobRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEmbeddingJobRepository {
+	mock := &MockEmbeddingJobRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,414 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockEmbeddingSpaceRepository is an autogenerated mock type for the EmbeddingSpaceRepository type
+type MockEmbeddingSpaceRepository struct {
+	mock.Mock
+}
+
+type MockEmbeddingSpaceRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEmbeddingSpaceRepository) EXPECT() *MockEmbeddingSpaceRepository_Expecter {
+	return &MockEmbeddingSpaceRepository_Expecter{mock: &_m.Mock}
+}
+
+// BuildIndex provides a mock function with given fields: ctx, space
+func (_m *MockEmbeddingSpaceRepository) BuildIndex(ctx context.Context, space *models.EmbeddingSpace) error {
+	ret := _m.Called(ctx, space)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BuildIndex")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.EmbeddingSpace) error); ok {
+		r0 = rf(ctx, space)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockEmbeddingSpaceRepository_BuildIndex_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BuildIndex'
+type MockEmbeddingSpaceRepository_BuildIndex_Call struct {
+	*mock.Call
+}
+
+// BuildIndex is a helper method to define mock.On call
+//   - ctx context.Context
+//   - space *models.EmbeddingSpace
+func (_e *MockEmbeddingSpaceRepository_Expecter) BuildIndex(ctx interface{}, space interface{}) *MockEmbeddingSpaceRepository_BuildIndex_Call {
+	return &MockEmbeddingSpaceRepository_BuildIndex_Call{Call: _e.mock.On("BuildIndex", ctx, space)}
+}
+
+func (_c *MockEmbeddingSpaceRepository_BuildIndex_Call) Run(run func(ctx context.Context, space *models.EmbeddingSpace)) *MockEmbeddingSpaceRepository_BuildIndex_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.EmbeddingSpace))
+	})
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceRepository_BuildIndex_Call) Return(_a0 error) *MockEmbeddingSpaceRepository_BuildIndex_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceRepository_BuildIndex_Call) RunAndReturn(run func(context.Context, *models.EmbeddingSpace) error) *MockEmbeddingSpaceRepository_BuildIndex_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, space
+func (_m *MockEmbeddingSpaceRepository) Create(ctx context.Context, space *models.EmbeddingSpace) error {
+	ret := _m.Called(ctx, space)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.EmbeddingSpace) error); ok {
+		r0 = rf(ctx, space)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockEmbeddingSpaceRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockEmbeddingSpaceRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - space *models.EmbeddingSpace
+func (_e *MockEmbeddingSpaceRepository_Expecter) Create(ctx interface{}, space interface{}) *MockEmbeddingSpaceRepository_Create_Call {
+	return &MockEmbeddingSpaceRepository_Create_Call{Call: _e.mock.On("Create", ctx, space)}
+}
+
+func (_c *MockEmbeddingSpaceRepository_Create_Call) Run(run func(ctx context.Context, space *models.EmbeddingSpace)) *MockEmbeddingSpaceRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.EmbeddingSpace))
+	})
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceRepository_Create_Call) Return(_a0 error) *MockEmbeddingSpaceRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceRepository_Create_Call) RunAndReturn(run func(context.Context, *models.EmbeddingSpace) error) *MockEmbeddingSpaceRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActive provides a mock function with given fields: ctx
+func (_m *MockEmbeddingSpaceRepository) GetActive(ctx context.Context) (*models.EmbeddingSpace, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActive")
+	}
+
+	var r0 *models.EmbeddingSpace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*models.EmbeddingSpace, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *models.EmbeddingSpace); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EmbeddingSpace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEmbeddingSpaceRepository_GetActive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActive'
+type MockEmbeddingSpaceRepository_GetActive_Call struct {
+	*mock.Call
+}
+
+// GetActive is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockEmbeddingSpaceRepository_Expecter) GetActive(ctx interface{}) *MockEmbeddingSpaceRepository_GetActive_Call {
+	return &MockEmbeddingSpaceRepository_GetActive_Call{Call: _e.mock.On("GetActive", ctx)}
+}
+
+func (_c *MockEmbeddingSpaceRepository_GetActive_Call) Run(run func(ctx context.Context)) *MockEmbeddingSpaceRepository_GetActive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceRepository_GetActive_Call) Return(_a0 *models.EmbeddingSpace, _a1 error) *MockEmbeddingSpaceRepository_GetActive_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceRepository_GetActive_Call) RunAndReturn(run func(context.Context) (*models.EmbeddingSpace, error)) *MockEmbeddingSpaceRepository_GetActive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *MockEmbeddingSpaceRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.EmbeddingSpace, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.EmbeddingSpace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.EmbeddingSpace, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.EmbeddingSpace); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EmbeddingSpace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEmbeddingSpaceRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockEmbeddingSpaceRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockEmbeddingSpaceRepository_Expecter) GetByID(ctx interface{}, id interface{}) *MockEmbeddingSpaceRepository_GetByID_Call {
+	return &MockEmbeddingSpaceRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *MockEmbeddingSpaceRepository_GetByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockEmbeddingSpaceRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceRepository_GetByID_Call) Return(_a0 *models.EmbeddingSpace, _a1 error) *MockEmbeddingSpaceRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceRepository_GetByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.EmbeddingSpace, error)) *MockEmbeddingSpaceRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByName provides a mock function with given fields: ctx, name
+func (_m *MockEmbeddingSpaceRepository) GetByName(ctx context.Context, name string) (*models.EmbeddingSpace, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByName")
+	}
+
+	var r0 *models.EmbeddingSpace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.EmbeddingSpace, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.EmbeddingSpace); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EmbeddingSpace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEmbeddingSpaceRepository_GetByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByName'
+type MockEmbeddingSpaceRepository_GetByName_Call struct {
+	*mock.Call
+}
+
+// GetByName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockEmbeddingSpaceRepository_Expecter) GetByName(ctx interface{}, name interface{}) *MockEmbeddingSpaceRepository_GetByName_Call {
+	return &MockEmbeddingSpaceRepository_GetByName_Call{Call: _e.mock.On("GetByName", ctx, name)}
+}
+
+func (_c *MockEmbeddingSpaceRepository_GetByName_Call) Run(run func(ctx context.Context, name string)) *MockEmbeddingSpaceRepository_GetByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceRepository_GetByName_Call) Return(_a0 *models.EmbeddingSpace, _a1 error) *MockEmbeddingSpaceRepository_GetByName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceRepository_GetByName_Call) RunAndReturn(run func(context.Context, string) (*models.EmbeddingSpace, error)) *MockEmbeddingSpaceRepository_GetByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *MockEmbeddingSpaceRepository) List(ctx context.Context) ([]*models.EmbeddingSpace, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.EmbeddingSpace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.EmbeddingSpace, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.EmbeddingSpace); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.EmbeddingSpace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEmbeddingSpaceRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockEmbeddingSpaceRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockEmbeddingSpaceRepository_Expecter) List(ctx interface{}) *MockEmbeddingSpaceRepository_List_Call {
+	return &MockEmbeddingSpaceRepository_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *MockEmbeddingSpaceRepository_List_Call) Run(run func(ctx context.Context)) *MockEmbeddingSpaceRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceRepository_List_Call) Return(_a0 []*models.EmbeddingSpace, _a1 error) *MockEmbeddingSpaceRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceRepository_List_Call) RunAndReturn(run func(context.Context) ([]*models.EmbeddingSpace, error)) *MockEmbeddingSpaceRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetActive provides a mock function with given fields: ctx, id
+func (_m *MockEmbeddingSpaceRepository) SetActive(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetActive")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockEmbeddingSpaceRepository_SetActive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetActive'
+type MockEmbeddingSpaceRepository_SetActive_Call struct {
+	*mock.Call
+}
+
+// SetActive is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockEmbeddingSpaceRepository_Expecter) SetActive(ctx interface{}, id interface{}) *MockEmbeddingSpaceRepository_SetActive_Call {
+	return &MockEmbeddingSpaceRepository_SetActive_Call{Call: _e.mock.On("SetActive", ctx, id)}
+}
+
+func (_c *MockEmbeddingSpaceRepository_SetActive_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockEmbeddingSpaceRepository_SetActive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceRepository_SetActive_Call) Return(_a0 error) *MockEmbeddingSpaceRepository_SetActive_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceRepository_SetActive_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockEmbeddingSpaceRepository_SetActive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockEmbeddingSpaceRepository creates a new instance of MockEmbeddingSpaceRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEmbeddingSpaceRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEmbeddingSpaceRepository {
+	mock := &MockEmbeddingSpaceRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
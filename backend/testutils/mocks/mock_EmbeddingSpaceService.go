@@ -0,0 +1,438 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockEmbeddingSpaceService is an autogenerated mock type for the EmbeddingSpaceService type
+type MockEmbeddingSpaceService struct {
+	mock.Mock
+}
+
+type MockEmbeddingSpaceService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEmbeddingSpaceService) EXPECT() *MockEmbeddingSpaceService_Expecter {
+	return &MockEmbeddingSpaceService_Expecter{mock: &_m.Mock}
+}
+
+// Activate provides a mock function with given fields: ctx, name
+func (_m *MockEmbeddingSpaceService) Activate(ctx context.Context, name string) (*models.EmbeddingSpace, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Activate")
+	}
+
+	var r0 *models.EmbeddingSpace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.EmbeddingSpace, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.EmbeddingSpace); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EmbeddingSpace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEmbeddingSpaceService_Activate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Activate'
+type MockEmbeddingSpaceService_Activate_Call struct {
+	*mock.Call
+}
+
+// Activate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockEmbeddingSpaceService_Expecter) Activate(ctx interface{}, name interface{}) *MockEmbeddingSpaceService_Activate_Call {
+	return &MockEmbeddingSpaceService_Activate_Call{Call: _e.mock.On("Activate", ctx, name)}
+}
+
+func (_c *MockEmbeddingSpaceService_Activate_Call) Run(run func(ctx context.Context, name string)) *MockEmbeddingSpaceService_Activate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceService_Activate_Call) Return(_a0 *models.EmbeddingSpace, _a1 error) *MockEmbeddingSpaceService_Activate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceService_Activate_Call) RunAndReturn(run func(context.Context, string) (*models.EmbeddingSpace, error)) *MockEmbeddingSpaceService_Activate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BuildIndex provides a mock function with given fields: ctx, space
+func (_m *MockEmbeddingSpaceService) BuildIndex(ctx context.Context, space *models.EmbeddingSpace) error {
+	ret := _m.Called(ctx, space)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BuildIndex")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.EmbeddingSpace) error); ok {
+		r0 = rf(ctx, space)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockEmbeddingSpaceService_BuildIndex_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BuildIndex'
+type MockEmbeddingSpaceService_BuildIndex_Call struct {
+	*mock.Call
+}
+
+// BuildIndex is a helper method to define mock.On call
+//   - ctx context.Context
+//   - space *models.EmbeddingSpace
+func (_e *MockEmbeddingSpaceService_Expecter) BuildIndex(ctx interface{}, space interface{}) *MockEmbeddingSpaceService_BuildIndex_Call {
+	return &MockEmbeddingSpaceService_BuildIndex_Call{Call: _e.mock.On("BuildIndex", ctx, space)}
+}
+
+func (_c *MockEmbeddingSpaceService_BuildIndex_Call) Run(run func(ctx context.Context, space *models.EmbeddingSpace)) *MockEmbeddingSpaceService_BuildIndex_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.EmbeddingSpace))
+	})
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceService_BuildIndex_Call) Return(_a0 error) *MockEmbeddingSpaceService_BuildIndex_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceService_BuildIndex_Call) RunAndReturn(run func(context.Context, *models.EmbeddingSpace) error) *MockEmbeddingSpaceService_BuildIndex_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, request
+func (_m *MockEmbeddingSpaceService) Create(ctx context.Context, request *models.CreateEmbeddingSpaceRequest) (*models.EmbeddingSpace, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *models.EmbeddingSpace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CreateEmbeddingSpaceRequest) (*models.EmbeddingSpace, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CreateEmbeddingSpaceRequest) *models.EmbeddingSpace); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EmbeddingSpace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.CreateEmbeddingSpaceRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEmbeddingSpaceService_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockEmbeddingSpaceService_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *models.CreateEmbeddingSpaceRequest
+func (_e *MockEmbeddingSpaceService_Expecter) Create(ctx interface{}, request interface{}) *MockEmbeddingSpaceService_Create_Call {
+	return &MockEmbeddingSpaceService_Create_Call{Call: _e.mock.On("Create", ctx, request)}
+}
+
+func (_c *MockEmbeddingSpaceService_Create_Call) Run(run func(ctx context.Context, request *models.CreateEmbeddingSpaceRequest)) *MockEmbeddingSpaceService_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.CreateEmbeddingSpaceRequest))
+	})
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceService_Create_Call) Return(_a0 *models.EmbeddingSpace, _a1 error) *MockEmbeddingSpaceService_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceService_Create_Call) RunAndReturn(run func(context.Context, *models.CreateEmbeddingSpaceRequest) (*models.EmbeddingSpace, error)) *MockEmbeddingSpaceService_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Embed provides a mock function with given fields: ctx, space, input
+func (_m *MockEmbeddingSpaceService) Embed(ctx context.Context, space *models.EmbeddingSpace, input []string) ([][]float32, error) {
+	ret := _m.Called(ctx, space, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Embed")
+	}
+
+	var r0 [][]float32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.EmbeddingSpace, []string) ([][]float32, error)); ok {
+		return rf(ctx, space, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.EmbeddingSpace, []string) [][]float32); ok {
+		r0 = rf(ctx, space, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]float32)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.EmbeddingSpace, []string) error); ok {
+		r1 = rf(ctx, space, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEmbeddingSpaceService_Embed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Embed'
+type MockEmbeddingSpaceService_Embed_Call struct {
+	*mock.Call
+}
+
+// Embed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - space *models.EmbeddingSpace
+//   - input []string
+func (_e *MockEmbeddingSpaceService_Expecter) Embed(ctx interface{}, space interface{}, input interface{}) *MockEmbeddingSpaceService_Embed_Call {
+	return &MockEmbeddingSpaceService_Embed_Call{Call: _e.mock.On("Embed", ctx, space, input)}
+}
+
+func (_c *MockEmbeddingSpaceService_Embed_Call) Run(run func(ctx context.Context, space *models.EmbeddingSpace, input []string)) *MockEmbeddingSpaceService_Embed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.EmbeddingSpace), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceService_Embed_Call) Return(_a0 [][]float32, _a1 error) *MockEmbeddingSpaceService_Embed_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceService_Embed_Call) RunAndReturn(run func(context.Context, *models.EmbeddingSpace, []string) ([][]float32, error)) *MockEmbeddingSpaceService_Embed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, name
+func (_m *MockEmbeddingSpaceService) Get(ctx context.Context, name string) (*models.EmbeddingSpace, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *models.EmbeddingSpace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.EmbeddingSpace, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.EmbeddingSpace); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EmbeddingSpace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEmbeddingSpaceService_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockEmbeddingSpaceService_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockEmbeddingSpaceService_Expecter) Get(ctx interface{}, name interface{}) *MockEmbeddingSpaceService_Get_Call {
+	return &MockEmbeddingSpaceService_Get_Call{Call: _e.mock.On("Get", ctx, name)}
+}
+
+func (_c *MockEmbeddingSpaceService_Get_Call) Run(run func(ctx context.Context, name string)) *MockEmbeddingSpaceService_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceService_Get_Call) Return(_a0 *models.EmbeddingSpace, _a1 error) *MockEmbeddingSpaceService_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceService_Get_Call) RunAndReturn(run func(context.Context, string) (*models.EmbeddingSpace, error)) *MockEmbeddingSpaceService_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *MockEmbeddingSpaceService) List(ctx context.Context) ([]*models.EmbeddingSpace, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.EmbeddingSpace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.EmbeddingSpace, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.EmbeddingSpace); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.EmbeddingSpace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEmbeddingSpaceService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockEmbeddingSpaceService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockEmbeddingSpaceService_Expecter) List(ctx interface{}) *MockEmbeddingSpaceService_List_Call {
+	return &MockEmbeddingSpaceService_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *MockEmbeddingSpaceService_List_Call) Run(run func(ctx context.Context)) *MockEmbeddingSpaceService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceService_List_Call) Return(_a0 []*models.EmbeddingSpace, _a1 error) *MockEmbeddingSpaceService_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceService_List_Call) RunAndReturn(run func(context.Context) ([]*models.EmbeddingSpace, error)) *MockEmbeddingSpaceService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Resolve provides a mock function with given fields: ctx, name
+func (_m *MockEmbeddingSpaceService) Resolve(ctx context.Context, name string) (*models.EmbeddingSpace, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resolve")
+	}
+
+	var r0 *models.EmbeddingSpace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.EmbeddingSpace, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.EmbeddingSpace); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EmbeddingSpace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEmbeddingSpaceService_Resolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resolve'
+type MockEmbeddingSpaceService_Resolve_Call struct {
+	*mock.Call
+}
+
+// Resolve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockEmbeddingSpaceService_Expecter) Resolve(ctx interface{}, name interface{}) *MockEmbeddingSpaceService_Resolve_Call {
+	return &MockEmbeddingSpaceService_Resolve_Call{Call: _e.mock.On("Resolve", ctx, name)}
+}
+
+func (_c *MockEmbeddingSpaceService_Resolve_Call) Run(run func(ctx context.Context, name string)) *MockEmbeddingSpaceService_Resolve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceService_Resolve_Call) Return(_a0 *models.EmbeddingSpace, _a1 error) *MockEmbeddingSpaceService_Resolve_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEmbeddingSpaceService_Resolve_Call) RunAndReturn(run func(context.Context, string) (*models.EmbeddingSpace, error)) *MockEmbeddingSpaceService_Resolve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockEmbeddingSpaceService creates a new instance of MockEmbeddingSpaceService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEmbeddingSpaceService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEmbeddingSpaceService {
+	mock := &MockEmbeddingSpaceService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
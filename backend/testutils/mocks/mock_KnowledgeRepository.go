@@ -0,0 +1,659 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockKnowledgeRepository is an autogenerated mock type for the KnowledgeRepository type
+type MockKnowledgeRepository struct {
+	mock.Mock
+}
+
+type MockKnowledgeRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockKnowledgeRepository) EXPECT() *MockKnowledgeRepository_Expecter {
+	return &MockKnowledgeRepository_Expecter{mock: &_m.Mock}
+}
+
+// CountMissingEmbeddings provides a mock function with given fields: ctx, target, source
+func (_m *MockKnowledgeRepository) CountMissingEmbeddings(ctx context.Context, target *models.EmbeddingSpace, source *models.EmbeddingSpace) (int, error) {
+	ret := _m.Called(ctx, target, source)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountMissingEmbeddings")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.EmbeddingSpace, *models.EmbeddingSpace) (int, error)); ok {
+		return rf(ctx, target, source)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.EmbeddingSpace, *models.EmbeddingSpace) int); ok {
+		r0 = rf(ctx, target, source)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.EmbeddingSpace, *models.EmbeddingSpace) error); ok {
+		r1 = rf(ctx, target, source)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKnowledgeRepository_CountMissingEmbeddings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountMissingEmbeddings'
+type MockKnowledgeRepository_CountMissingEmbeddings_Call struct {
+	*mock.Call
+}
+
+// CountMissingEmbeddings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - target *models.EmbeddingSpace
+//   - source *models.EmbeddingSpace
+func (_e *MockKnowledgeRepository_Expecter) CountMissingEmbeddings(ctx interface{}, target interface{}, source interface{}) *MockKnowledgeRepository_CountMissingEmbeddings_Call {
+	return &MockKnowledgeRepository_CountMissingEmbeddings_Call{Call: _e.mock.On("CountMissingEmbeddings", ctx, target, source)}
+}
+
+func (_c *MockKnowledgeRepository_CountMissingEmbeddings_Call) Run(run func(ctx context.Context, target *models.EmbeddingSpace, source *models.EmbeddingSpace)) *MockKnowledgeRepository_CountMissingEmbeddings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.EmbeddingSpace), args[2].(*models.EmbeddingSpace))
+	})
+	return _c
+}
+
+func (_c *MockKnowledgeRepository_CountMissingEmbeddings_Call) Return(_a0 int, _a1 error) *MockKnowledgeRepository_CountMissingEmbeddings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKnowledgeRepository_CountMissingEmbeddings_Call) RunAndReturn(run func(context.Context, *models.EmbeddingSpace, *models.EmbeddingSpace) (int, error)) *MockKnowledgeRepository_CountMissingEmbeddings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteByIDs provides a mock function with given fields: ctx, ids
+func (_m *MockKnowledgeRepository) DeleteByIDs(ctx context.Context, ids []uuid.UUID) (int64, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByIDs")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) (int64, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) int64); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKnowledgeRepository_DeleteByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByIDs'
+type MockKnowledgeRepository_DeleteByIDs_Call struct {
+	*mock.Call
+}
+
+// DeleteByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []uuid.UUID
+func (_e *MockKnowledgeRepository_Expecter) DeleteByIDs(ctx interface{}, ids interface{}) *MockKnowledgeRepository_DeleteByIDs_Call {
+	return &MockKnowledgeRepository_DeleteByIDs_Call{Call: _e.mock.On("DeleteByIDs", ctx, ids)}
+}
+
+func (_c *MockKnowledgeRepository_DeleteByIDs_Call) Run(run func(ctx context.Context, ids []uuid.UUID)) *MockKnowledgeRepository_DeleteByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockKnowledgeRepository_DeleteByIDs_Call) Return(_a0 int64, _a1 error) *MockKnowledgeRepository_DeleteByIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKnowledgeRepository_DeleteByIDs_Call) RunAndReturn(run func(context.Context, []uuid.UUID) (int64, error)) *MockKnowledgeRepository_DeleteByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteBySource provides a mock function with given fields: ctx, source
+func (_m *MockKnowledgeRepository) DeleteBySource(ctx context.Context, source string) (int64, error) {
+	ret := _m.Called(ctx, source)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBySource")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, source)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, source)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, source)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKnowledgeRepository_DeleteBySource_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBySource'
+type MockKnowledgeRepository_DeleteBySource_Call struct {
+	*mock.Call
+}
+
+// DeleteBySource is a helper method to define mock.On call
+//   - ctx context.Context
+//   - source string
+func (_e *MockKnowledgeRepository_Expecter) DeleteBySource(ctx interface{}, source interface{}) *MockKnowledgeRepository_DeleteBySource_Call {
+	return &MockKnowledgeRepository_DeleteBySource_Call{Call: _e.mock.On("DeleteBySource", ctx, source)}
+}
+
+func (_c *MockKnowledgeRepository_DeleteBySource_Call) Run(run func(ctx context.Context, source string)) *MockKnowledgeRepository_DeleteBySource_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockKnowledgeRepository_DeleteBySource_Call) Return(_a0 int64, _a1 error) *MockKnowledgeRepository_DeleteBySource_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKnowledgeRepository_DeleteBySource_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *MockKnowledgeRepository_DeleteBySource_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Insert provides a mock function with given fields: ctx, chunk, space, embedding
+func (_m *MockKnowledgeRepository) Insert(ctx context.Context, chunk *models.KnowledgeChunk, space *models.EmbeddingSpace, embedding []float32) error {
+	ret := _m.Called(ctx, chunk, space, embedding)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Insert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.KnowledgeChunk, *models.EmbeddingSpace, []float32) error); ok {
+		r0 = rf(ctx, chunk, space, embedding)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockKnowledgeRepository_Insert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Insert'
+type MockKnowledgeRepository_Insert_Call struct {
+	*mock.Call
+}
+
+// Insert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chunk *models.KnowledgeChunk
+//   - space *models.EmbeddingSpace
+//   - embedding []float32
+func (_e *MockKnowledgeRepository_Expecter) Insert(ctx interface{}, chunk interface{}, space interface{}, embedding interface{}) *MockKnowledgeRepository_Insert_Call {
+	return &MockKnowledgeRepository_Insert_Call{Call: _e.mock.On("Insert", ctx, chunk, space, embedding)}
+}
+
+func (_c *MockKnowledgeRepository_Insert_Call) Run(run func(ctx context.Context, chunk *models.KnowledgeChunk, space *models.EmbeddingSpace, embedding []float32)) *MockKnowledgeRepository_Insert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.KnowledgeChunk), args[2].(*models.EmbeddingSpace), args[3].([]float32))
+	})
+	return _c
+}
+
+func (_c *MockKnowledgeRepository_Insert_Call) Return(_a0 error) *MockKnowledgeRepository_Insert_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockKnowledgeRepository_Insert_Call) RunAndReturn(run func(context.Context, *models.KnowledgeChunk, *models.EmbeddingSpace, []float32) error) *MockKnowledgeRepository_Insert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListBySource provides a mock function with given fields: ctx, source
+func (_m *MockKnowledgeRepository) ListBySource(ctx context.Context, source string) ([]*models.KnowledgeChunk, error) {
+	ret := _m.Called(ctx, source)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBySource")
+	}
+
+	var r0 []*models.KnowledgeChunk
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.KnowledgeChunk, error)); ok {
+		return rf(ctx, source)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.KnowledgeChunk); ok {
+		r0 = rf(ctx, source)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.KnowledgeChunk)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, source)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKnowledgeRepository_ListBySource_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBySource'
+type MockKnowledgeRepository_ListBySource_Call struct {
+	*mock.Call
+}
+
+// ListBySource is a helper method to define mock.On call
+//   - ctx context.Context
+//   - source string
+func (_e *MockKnowledgeRepository_Expecter) ListBySource(ctx interface{}, source interface{}) *MockKnowledgeRepository_ListBySource_Call {
+	return &MockKnowledgeRepository_ListBySource_Call{Call: _e.mock.On("ListBySource", ctx, source)}
+}
+
+func (_c *MockKnowledgeRepository_ListBySource_Call) Run(run func(ctx context.Context, source string)) *MockKnowledgeRepository_ListBySource_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockKnowledgeRepository_ListBySource_Call) Return(_a0 []*models.KnowledgeChunk, _a1 error) *MockKnowledgeRepository_ListBySource_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKnowledgeRepository_ListBySource_Call) RunAndReturn(run func(context.Context, string) ([]*models.KnowledgeChunk, error)) *MockKnowledgeRepository_ListBySource_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListMissingEmbeddings provides a mock function with given fields: ctx, target, source, limit
+func (_m *MockKnowledgeRepository) ListMissingEmbeddings(ctx context.Context, target *models.EmbeddingSpace, source *models.EmbeddingSpace, limit int) ([]*models.KnowledgeChunk, error) {
+	ret := _m.Called(ctx, target, source, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListMissingEmbeddings")
+	}
+
+	var r0 []*models.KnowledgeChunk
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.EmbeddingSpace, *models.EmbeddingSpace, int) ([]*models.KnowledgeChunk, error)); ok {
+		return rf(ctx, target, source, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.EmbeddingSpace, *models.EmbeddingSpace, int) []*models.KnowledgeChunk); ok {
+		r0 = rf(ctx, target, source, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.KnowledgeChunk)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.EmbeddingSpace, *models.EmbeddingSpace, int) error); ok {
+		r1 = rf(ctx, target, source, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKnowledgeRepository_ListMissingEmbeddings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListMissingEmbeddings'
+type MockKnowledgeRepository_ListMissingEmbeddings_Call struct {
+	*mock.Call
+}
+
+// ListMissingEmbeddings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - target *models.EmbeddingSpace
+//   - source *models.EmbeddingSpace
+//   - limit int
+func (_e *MockKnowledgeRepository_Expecter) ListMissingEmbeddings(ctx interface{}, target interface{}, source interface{}, limit interface{}) *MockKnowledgeRepository_ListMissingEmbeddings_Call {
+	return &MockKnowledgeRepository_ListMissingEmbeddings_Call{Call: _e.mock.On("ListMissingEmbeddings", ctx, target, source, limit)}
+}
+
+func (_c *MockKnowledgeRepository_ListMissingEmbeddings_Call) Run(run func(ctx context.Context, target *models.EmbeddingSpace, source *models.EmbeddingSpace, limit int)) *MockKnowledgeRepository_ListMissingEmbeddings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.EmbeddingSpace), args[2].(*models.EmbeddingSpace), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockKnowledgeRepository_ListMissingEmbeddings_Call) Return(_a0 []*models.KnowledgeChunk, _a1 error) *MockKnowledgeRepository_ListMissingEmbeddings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKnowledgeRepository_ListMissingEmbeddings_Call) RunAndReturn(run func(context.Context, *models.EmbeddingSpace, *models.EmbeddingSpace, int) ([]*models.KnowledgeChunk, error)) *MockKnowledgeRepository_ListMissingEmbeddings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSources provides a mock function with given fields: ctx
+func (_m *MockKnowledgeRepository) ListSources(ctx context.Context) ([]*models.KnowledgeDocument, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSources")
+	}
+
+	var r0 []*models.KnowledgeDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.KnowledgeDocument, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.KnowledgeDocument); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.KnowledgeDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKnowledgeRepository_ListSources_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSources'
+type MockKnowledgeRepository_ListSources_Call struct {
+	*mock.Call
+}
+
+// ListSources is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockKnowledgeRepository_Expecter) ListSources(ctx interface{}) *MockKnowledgeRepository_ListSources_Call {
+	return &MockKnowledgeRepository_ListSources_Call{Call: _e.mock.On("ListSources", ctx)}
+}
+
+func (_c *MockKnowledgeRepository_ListSources_Call) Run(run func(ctx context.Context)) *MockKnowledgeRepository_ListSources_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockKnowledgeRepository_ListSources_Call) Return(_a0 []*models.KnowledgeDocument, _a1 error) *MockKnowledgeRepository_ListSources_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKnowledgeRepository_ListSources_Call) RunAndReturn(run func(context.Context) ([]*models.KnowledgeDocument, error)) *MockKnowledgeRepository_ListSources_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveEmbedding provides a mock function with given fields: ctx, space, chunkID, embedding
+func (_m *MockKnowledgeRepository) SaveEmbedding(ctx context.Context, space *models.EmbeddingSpace, chunkID uuid.UUID, embedding []float32) error {
+	ret := _m.Called(ctx, space, chunkID, embedding)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveEmbedding")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.EmbeddingSpace, uuid.UUID, []float32) error); ok {
+		r0 = rf(ctx, space, chunkID, embedding)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockKnowledgeRepository_SaveEmbedding_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveEmbedding'
+type MockKnowledgeRepository_SaveEmbedding_Call struct {
+	*mock.Call
+}
+
+// SaveEmbedding is a helper method to define mock.On call
+//   - ctx context.Context
+//   - space *models.EmbeddingSpace
+//   - chunkID uuid.UUID
+//   - embedding []float32
+func (_e *MockKnowledgeRepository_Expecter) SaveEmbedding(ctx interface{}, space interface{}, chunkID interface{}, embedding interface{}) *MockKnowledgeRepository_SaveEmbedding_Call {
+	return &MockKnowledgeRepository_SaveEmbedding_Call{Call: _e.mock.On("SaveEmbedding", ctx, space, chunkID, embedding)}
+}
+
+func (_c *MockKnowledgeRepository_SaveEmbedding_Call) Run(run func(ctx context.Context, space *models.EmbeddingSpace, chunkID uuid.UUID, embedding []float32)) *MockKnowledgeRepository_SaveEmbedding_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.EmbeddingSpace), args[2].(uuid.UUID), args[3].([]float32))
+	})
+	return _c
+}
+
+func (_c *MockKnowledgeRepository_SaveEmbedding_Call) Return(_a0 error) *MockKnowledgeRepository_SaveEmbedding_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockKnowledgeRepository_SaveEmbedding_Call) RunAndReturn(run func(context.Context, *models.EmbeddingSpace, uuid.UUID, []float32) error) *MockKnowledgeRepository_SaveEmbedding_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchKeyword provides a mock function with given fields: ctx, query, topK, filters
+func (_m *MockKnowledgeRepository) SearchKeyword(ctx context.Context, query string, topK int, filters map[string]interface{}) ([]*models.KnowledgeChunk, error) {
+	ret := _m.Called(ctx, query, topK, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchKeyword")
+	}
+
+	var r0 []*models.KnowledgeChunk
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, map[string]interface{}) ([]*models.KnowledgeChunk, error)); ok {
+		return rf(ctx, query, topK, filters)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, map[string]interface{}) []*models.KnowledgeChunk); ok {
+		r0 = rf(ctx, query, topK, filters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.KnowledgeChunk)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, map[string]interface{}) error); ok {
+		r1 = rf(ctx, query, topK, filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKnowledgeRepository_SearchKeyword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchKeyword'
+type MockKnowledgeRepository_SearchKeyword_Call struct {
+	*mock.Call
+}
+
+// SearchKeyword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query string
+//   - topK int
+//   - filters map[string]interface{}
+func (_e *MockKnowledgeRepository_Expecter) SearchKeyword(ctx interface{}, query interface{}, topK interface{}, filters interface{}) *MockKnowledgeRepository_SearchKeyword_Call {
+	return &MockKnowledgeRepository_SearchKeyword_Call{Call: _e.mock.On("SearchKeyword", ctx, query, topK, filters)}
+}
+
+func (_c *MockKnowledgeRepository_SearchKeyword_Call) Run(run func(ctx context.Context, query string, topK int, filters map[string]interface{})) *MockKnowledgeRepository_SearchKeyword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(map[string]interface{}))
+	})
+	return _c
+}
+
+func (_c *MockKnowledgeRepository_SearchKeyword_Call) Return(_a0 []*models.KnowledgeChunk, _a1 error) *MockKnowledgeRepository_SearchKeyword_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKnowledgeRepository_SearchKeyword_Call) RunAndReturn(run func(context.Context, string, int, map[string]interface{}) ([]*models.KnowledgeChunk, error)) *MockKnowledgeRepository_SearchKeyword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchSimilar provides a mock function with given fields: ctx, space, embedding, topK, threshold, filters
+func (_m *MockKnowledgeRepository) SearchSimilar(ctx context.Context, space *models.EmbeddingSpace, embedding []float32, topK int, threshold float64, filters map[string]interface{}) ([]*models.KnowledgeChunk, error) {
+	ret := _m.Called(ctx, space, embedding, topK, threshold, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchSimilar")
+	}
+
+	var r0 []*models.KnowledgeChunk
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.EmbeddingSpace, []float32, int, float64, map[string]interface{}) ([]*models.KnowledgeChunk, error)); ok {
+		return rf(ctx, space, embedding, topK, threshold, filters)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.EmbeddingSpace, []float32, int, float64, map[string]interface{}) []*models.KnowledgeChunk); ok {
+		r0 = rf(ctx, space, embedding, topK, threshold, filters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.KnowledgeChunk)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.EmbeddingSpace, []float32, int, float64, map[string]interface{}) error); ok {
+		r1 = rf(ctx, space, embedding, topK, threshold, filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKnowledgeRepository_SearchSimilar_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchSimilar'
+type MockKnowledgeRepository_SearchSimilar_Call struct {
+	*mock.Call
+}
+
+// SearchSimilar is a helper method to define mock.On call
+//   - ctx context.Context
+//   - space *models.EmbeddingSpace
+//   - embedding []float32
+//   - topK int
+//   - threshold float64
+//   - filters map[string]interface{}
+func (_e *MockKnowledgeRepository_Expecter) SearchSimilar(ctx interface{}, space interface{}, embedding interface{}, topK interface{}, threshold interface{}, filters interface{}) *MockKnowledgeRepository_SearchSimilar_Call {
+	return &MockKnowledgeRepository_SearchSimilar_Call{Call: _e.mock.On("SearchSimilar", ctx, space, embedding, topK, threshold, filters)}
+}
+
+func (_c *MockKnowledgeRepository_SearchSimilar_Call) Run(run func(ctx context.Context, space *models.EmbeddingSpace, embedding []float32, topK int, threshold float64, filters map[string]interface{})) *MockKnowledgeRepository_SearchSimilar_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.EmbeddingSpace), args[2].([]float32), args[3].(int), args[4].(float64), args[5].(map[string]interface{}))
+	})
+	return _c
+}
+
+func (_c *MockKnowledgeRepository_SearchSimilar_Call) Return(_a0 []*models.KnowledgeChunk, _a1 error) *MockKnowledgeRepository_SearchSimilar_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKnowledgeRepository_SearchSimilar_Call) RunAndReturn(run func(context.Context, *models.EmbeddingSpace, []float32, int, float64, map[string]interface{}) ([]*models.KnowledgeChunk, error)) *MockKnowledgeRepository_SearchSimilar_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateMetadata provides a mock function with given fields: ctx, id, metadata
+func (_m *MockKnowledgeRepository) UpdateMetadata(ctx context.Context, id uuid.UUID, metadata map[string]interface{}) error {
+	ret := _m.Called(ctx, id, metadata)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMetadata")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[string]interface{}) error); ok {
+		r0 = rf(ctx, id, metadata)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockKnowledgeRepository_UpdateMetadata_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMetadata'
+type MockKnowledgeRepository_UpdateMetadata_Call struct {
+	*mock.Call
+}
+
+// UpdateMetadata is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - metadata map[string]interface{}
+func (_e *MockKnowledgeRepository_Expecter) UpdateMetadata(ctx interface{}, id interface{}, metadata interface{}) *MockKnowledgeRepository_UpdateMetadata_Call {
+	return &MockKnowledgeRepository_UpdateMetadata_Call{Call: _e.mock.On("UpdateMetadata", ctx, id, metadata)}
+}
+
+func (_c *MockKnowledgeRepository_UpdateMetadata_Call) Run(run func(ctx context.Context, id uuid.UUID, metadata map[string]interface{})) *MockKnowledgeRepository_UpdateMetadata_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(map[string]interface{}))
+	})
+	return _c
+}
+
+func (_c *MockKnowledgeRepository_UpdateMetadata_Call) Return(_a0 error) *MockKnowledgeRepository_UpdateMetadata_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockKnowledgeRepository_UpdateMetadata_Call) RunAndReturn(run func(context.Context, uuid.UUID, map[string]interface{}) error) *MockKnowledgeRepository_UpdateMetadata_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockKnowledgeRepository creates a new instance of MockKnowledgeRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockKnowledgeRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockKnowledgeRepository {
+	mock := &MockKnowledgeRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,204 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockMessageRepository is an autogenerated mock type for the MessageRepository type
+type MockMessageRepository struct {
+	mock.Mock
+}
+
+type MockMessageRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMessageRepository) EXPECT() *MockMessageRepository_Expecter {
+	return &MockMessageRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, message
+func (_m *MockMessageRepository) Create(ctx context.Context, message *models.Message) error {
+	ret := _m.Called(ctx, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Message) error); ok {
+		r0 = rf(ctx, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockMessageRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockMessageRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - message *models.Message
+func (_e *MockMessageRepository_Expecter) Create(ctx interface{}, message interface{}) *MockMessageRepository_Create_Call {
+	return &MockMessageRepository_Create_Call{Call: _e.mock.On("Create", ctx, message)}
+}
+
+func (_c *MockMessageRepository_Create_Call) Run(run func(ctx context.Context, message *models.Message)) *MockMessageRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Message))
+	})
+	return _c
+}
+
+func (_c *MockMessageRepository_Create_Call) Return(_a0 error) *MockMessageRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMessageRepository_Create_Call) RunAndReturn(run func(context.Context, *models.Message) error) *MockMessageRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByPhone provides a mock function with given fields: ctx, phone, limit, offset
+func (_m *MockMessageRepository) ListByPhone(ctx context.Context, phone string, limit int, offset int) ([]*models.Message, int, error) {
+	ret := _m.Called(ctx, phone, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByPhone")
+	}
+
+	var r0 []*models.Message
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]*models.Message, int, error)); ok {
+		return rf(ctx, phone, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []*models.Message); ok {
+		r0 = rf(ctx, phone, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) int); ok {
+		r1 = rf(ctx, phone, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, int, int) error); ok {
+		r2 = rf(ctx, phone, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockMessageRepository_ListByPhone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByPhone'
+type MockMessageRepository_ListByPhone_Call struct {
+	*mock.Call
+}
+
+// ListByPhone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - phone string
+//   - limit int
+//   - offset int
+func (_e *MockMessageRepository_Expecter) ListByPhone(ctx interface{}, phone interface{}, limit interface{}, offset interface{}) *MockMessageRepository_ListByPhone_Call {
+	return &MockMessageRepository_ListByPhone_Call{Call: _e.mock.On("ListByPhone", ctx, phone, limit, offset)}
+}
+
+func (_c *MockMessageRepository_ListByPhone_Call) Run(run func(ctx context.Context, phone string, limit int, offset int)) *MockMessageRepository_ListByPhone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockMessageRepository_ListByPhone_Call) Return(_a0 []*models.Message, _a1 int, _a2 error) *MockMessageRepository_ListByPhone_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockMessageRepository_ListByPhone_Call) RunAndReturn(run func(context.Context, string, int, int) ([]*models.Message, int, error)) *MockMessageRepository_ListByPhone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, id, status, workflowType, errMsg
+func (_m *MockMessageRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string, workflowType string, errMsg string) error {
+	ret := _m.Called(ctx, id, status, workflowType, errMsg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string, string) error); ok {
+		r0 = rf(ctx, id, status, workflowType, errMsg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockMessageRepository_UpdateStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStatus'
+type MockMessageRepository_UpdateStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - status string
+//   - workflowType string
+//   - errMsg string
+func (_e *MockMessageRepository_Expecter) UpdateStatus(ctx interface{}, id interface{}, status interface{}, workflowType interface{}, errMsg interface{}) *MockMessageRepository_UpdateStatus_Call {
+	return &MockMessageRepository_UpdateStatus_Call{Call: _e.mock.On("UpdateStatus", ctx, id, status, workflowType, errMsg)}
+}
+
+func (_c *MockMessageRepository_UpdateStatus_Call) Run(run func(ctx context.Context, id uuid.UUID, status string, workflowType string, errMsg string)) *MockMessageRepository_UpdateStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockMessageRepository_UpdateStatus_Call) Return(_a0 error) *MockMessageRepository_UpdateStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMessageRepository_UpdateStatus_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, string, string) error) *MockMessageRepository_UpdateStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockMessageRepository creates a new instance of MockMessageRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMessageRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMessageRepository {
+	mock := &MockMessageRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,153 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockOpenAIClient is an autogenerated mock type for the OpenAIClient type
+type MockOpenAIClient struct {
+	mock.Mock
+}
+
+type MockOpenAIClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOpenAIClient) EXPECT() *MockOpenAIClient_Expecter {
+	return &MockOpenAIClient_Expecter{mock: &_m.Mock}
+}
+
+// CreateChatCompletion provides a mock function with given fields: ctx, request
+func (_m *MockOpenAIClient) CreateChatCompletion(ctx context.Context, request *models.ChatCompletionRequest) (string, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateChatCompletion")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ChatCompletionRequest) (string, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ChatCompletionRequest) string); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.ChatCompletionRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOpenAIClient_CreateChatCompletion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateChatCompletion'
+type MockOpenAIClient_CreateChatCompletion_Call struct {
+	*mock.Call
+}
+
+// CreateChatCompletion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *models.ChatCompletionRequest
+func (_e *MockOpenAIClient_Expecter) CreateChatCompletion(ctx interface{}, request interface{}) *MockOpenAIClient_CreateChatCompletion_Call {
+	return &MockOpenAIClient_CreateChatCompletion_Call{Call: _e.mock.On("CreateChatCompletion", ctx, request)}
+}
+
+func (_c *MockOpenAIClient_CreateChatCompletion_Call) Run(run func(ctx context.Context, request *models.ChatCompletionRequest)) *MockOpenAIClient_CreateChatCompletion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.ChatCompletionRequest))
+	})
+	return _c
+}
+
+func (_c *MockOpenAIClient_CreateChatCompletion_Call) Return(_a0 string, _a1 error) *MockOpenAIClient_CreateChatCompletion_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOpenAIClient_CreateChatCompletion_Call) RunAndReturn(run func(context.Context, *models.ChatCompletionRequest) (string, error)) *MockOpenAIClient_CreateChatCompletion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateEmbeddings provides a mock function with given fields: ctx, input
+func (_m *MockOpenAIClient) CreateEmbeddings(ctx context.Context, input []string) ([][]float32, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEmbeddings")
+	}
+
+	var r0 [][]float32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([][]float32, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) [][]float32); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]float32)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOpenAIClient_CreateEmbeddings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEmbeddings'
+type MockOpenAIClient_CreateEmbeddings_Call struct {
+	*mock.Call
+}
+
+// CreateEmbeddings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input []string
+func (_e *MockOpenAIClient_Expecter) CreateEmbeddings(ctx interface{}, input interface{}) *MockOpenAIClient_CreateEmbeddings_Call {
+	return &MockOpenAIClient_CreateEmbeddings_Call{Call: _e.mock.On("CreateEmbeddings", ctx, input)}
+}
+
+func (_c *MockOpenAIClient_CreateEmbeddings_Call) Run(run func(ctx context.Context, input []string)) *MockOpenAIClient_CreateEmbeddings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockOpenAIClient_CreateEmbeddings_Call) Return(_a0 [][]float32, _a1 error) *MockOpenAIClient_CreateEmbeddings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOpenAIClient_CreateEmbeddings_Call) RunAndReturn(run func(context.Context, []string) ([][]float32, error)) *MockOpenAIClient_CreateEmbeddings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockOpenAIClient creates a new instance of MockOpenAIClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOpenAIClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOpenAIClient {
+	mock := &MockOpenAIClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,428 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockPendingRequestRepository is an autogenerated mock type for the PendingRequestRepository type
+type MockPendingRequestRepository struct {
+	mock.Mock
+}
+
+type MockPendingRequestRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPendingRequestRepository) EXPECT() *MockPendingRequestRepository_Expecter {
+	return &MockPendingRequestRepository_Expecter{mock: &_m.Mock}
+}
+
+// Complete provides a mock function with given fields: ctx, messageID
+func (_m *MockPendingRequestRepository) Complete(ctx context.Context, messageID string) (*models.PendingRequest, error) {
+	ret := _m.Called(ctx, messageID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 *models.PendingRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.PendingRequest, error)); ok {
+		return rf(ctx, messageID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.PendingRequest); ok {
+		r0 = rf(ctx, messageID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PendingRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, messageID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPendingRequestRepository_Complete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Complete'
+type MockPendingRequestRepository_Complete_Call struct {
+	*mock.Call
+}
+
+// Complete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - messageID string
+func (_e *MockPendingRequestRepository_Expecter) Complete(ctx interface{}, messageID interface{}) *MockPendingRequestRepository_Complete_Call {
+	return &MockPendingRequestRepository_Complete_Call{Call: _e.mock.On("Complete", ctx, messageID)}
+}
+
+func (_c *MockPendingRequestRepository_Complete_Call) Run(run func(ctx context.Context, messageID string)) *MockPendingRequestRepository_Complete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockPendingRequestRepository_Complete_Call) Return(_a0 *models.PendingRequest, _a1 error) *MockPendingRequestRepository_Complete_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPendingRequestRepository_Complete_Call) RunAndReturn(run func(context.Context, string) (*models.PendingRequest, error)) *MockPendingRequestRepository_Complete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, request
+func (_m *MockPendingRequestRepository) Create(ctx context.Context, request *models.PendingRequest) error {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PendingRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPendingRequestRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockPendingRequestRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *models.PendingRequest
+func (_e *MockPendingRequestRepository_Expecter) Create(ctx interface{}, request interface{}) *MockPendingRequestRepository_Create_Call {
+	return &MockPendingRequestRepository_Create_Call{Call: _e.mock.On("Create", ctx, request)}
+}
+
+func (_c *MockPendingRequestRepository_Create_Call) Run(run func(ctx context.Context, request *models.PendingRequest)) *MockPendingRequestRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.PendingRequest))
+	})
+	return _c
+}
+
+func (_c *MockPendingRequestRepository_Create_Call) Return(_a0 error) *MockPendingRequestRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPendingRequestRepository_Create_Call) RunAndReturn(run func(context.Context, *models.PendingRequest) error) *MockPendingRequestRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExpireOverdue provides a mock function with given fields: ctx, now, errMsg
+func (_m *MockPendingRequestRepository) ExpireOverdue(ctx context.Context, now time.Time, errMsg string) ([]*models.PendingRequest, error) {
+	ret := _m.Called(ctx, now, errMsg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpireOverdue")
+	}
+
+	var r0 []*models.PendingRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, string) ([]*models.PendingRequest, error)); ok {
+		return rf(ctx, now, errMsg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, string) []*models.PendingRequest); ok {
+		r0 = rf(ctx, now, errMsg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PendingRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, string) error); ok {
+		r1 = rf(ctx, now, errMsg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPendingRequestRepository_ExpireOverdue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExpireOverdue'
+type MockPendingRequestRepository_ExpireOverdue_Call struct {
+	*mock.Call
+}
+
+// ExpireOverdue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - errMsg string
+func (_e *MockPendingRequestRepository_Expecter) ExpireOverdue(ctx interface{}, now interface{}, errMsg interface{}) *MockPendingRequestRepository_ExpireOverdue_Call {
+	return &MockPendingRequestRepository_ExpireOverdue_Call{Call: _e.mock.On("ExpireOverdue", ctx, now, errMsg)}
+}
+
+func (_c *MockPendingRequestRepository_ExpireOverdue_Call) Run(run func(ctx context.Context, now time.Time, errMsg string)) *MockPendingRequestRepository_ExpireOverdue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockPendingRequestRepository_ExpireOverdue_Call) Return(_a0 []*models.PendingRequest, _a1 error) *MockPendingRequestRepository_ExpireOverdue_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPendingRequestRepository_ExpireOverdue_Call) RunAndReturn(run func(context.Context, time.Time, string) ([]*models.PendingRequest, error)) *MockPendingRequestRepository_ExpireOverdue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByMessageID provides a mock function with given fields: ctx, messageID
+func (_m *MockPendingRequestRepository) GetByMessageID(ctx context.Context, messageID string) (*models.PendingRequest, error) {
+	ret := _m.Called(ctx, messageID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByMessageID")
+	}
+
+	var r0 *models.PendingRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.PendingRequest, error)); ok {
+		return rf(ctx, messageID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.PendingRequest); ok {
+		r0 = rf(ctx, messageID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PendingRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, messageID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPendingRequestRepository_GetByMessageID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByMessageID'
+type MockPendingRequestRepository_GetByMessageID_Call struct {
+	*mock.Call
+}
+
+// GetByMessageID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - messageID string
+func (_e *MockPendingRequestRepository_Expecter) GetByMessageID(ctx interface{}, messageID interface{}) *MockPendingRequestRepository_GetByMessageID_Call {
+	return &MockPendingRequestRepository_GetByMessageID_Call{Call: _e.mock.On("GetByMessageID", ctx, messageID)}
+}
+
+func (_c *MockPendingRequestRepository_GetByMessageID_Call) Run(run func(ctx context.Context, messageID string)) *MockPendingRequestRepository_GetByMessageID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockPendingRequestRepository_GetByMessageID_Call) Return(_a0 *models.PendingRequest, _a1 error) *MockPendingRequestRepository_GetByMessageID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPendingRequestRepository_GetByMessageID_Call) RunAndReturn(run func(context.Context, string) (*models.PendingRequest, error)) *MockPendingRequestRepository_GetByMessageID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAwaitingSoftNotice provides a mock function with given fields: ctx, sentBefore
+func (_m *MockPendingRequestRepository) ListAwaitingSoftNotice(ctx context.Context, sentBefore time.Time) ([]*models.PendingRequest, error) {
+	ret := _m.Called(ctx, sentBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAwaitingSoftNotice")
+	}
+
+	var r0 []*models.PendingRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]*models.PendingRequest, error)); ok {
+		return rf(ctx, sentBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*models.PendingRequest); ok {
+		r0 = rf(ctx, sentBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PendingRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, sentBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPendingRequestRepository_ListAwaitingSoftNotice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAwaitingSoftNotice'
+type MockPendingRequestRepository_ListAwaitingSoftNotice_Call struct {
+	*mock.Call
+}
+
+// ListAwaitingSoftNotice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sentBefore time.Time
+func (_e *MockPendingRequestRepository_Expecter) ListAwaitingSoftNotice(ctx interface{}, sentBefore interface{}) *MockPendingRequestRepository_ListAwaitingSoftNotice_Call {
+	return &MockPendingRequestRepository_ListAwaitingSoftNotice_Call{Call: _e.mock.On("ListAwaitingSoftNotice", ctx, sentBefore)}
+}
+
+func (_c *MockPendingRequestRepository_ListAwaitingSoftNotice_Call) Run(run func(ctx context.Context, sentBefore time.Time)) *MockPendingRequestRepository_ListAwaitingSoftNotice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockPendingRequestRepository_ListAwaitingSoftNotice_Call) Return(_a0 []*models.PendingRequest, _a1 error) *MockPendingRequestRepository_ListAwaitingSoftNotice_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPendingRequestRepository_ListAwaitingSoftNotice_Call) RunAndReturn(run func(context.Context, time.Time) ([]*models.PendingRequest, error)) *MockPendingRequestRepository_ListAwaitingSoftNotice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkFailed provides a mock function with given fields: ctx, messageID, errMsg
+func (_m *MockPendingRequestRepository) MarkFailed(ctx context.Context, messageID string, errMsg string) error {
+	ret := _m.Called(ctx, messageID, errMsg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkFailed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, messageID, errMsg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPendingRequestRepository_MarkFailed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkFailed'
+type MockPendingRequestRepository_MarkFailed_Call struct {
+	*mock.Call
+}
+
+// MarkFailed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - messageID string
+//   - errMsg string
+func (_e *MockPendingRequestRepository_Expecter) MarkFailed(ctx interface{}, messageID interface{}, errMsg interface{}) *MockPendingRequestRepository_MarkFailed_Call {
+	return &MockPendingRequestRepository_MarkFailed_Call{Call: _e.mock.On("MarkFailed", ctx, messageID, errMsg)}
+}
+
+func (_c *MockPendingRequestRepository_MarkFailed_Call) Run(run func(ctx context.Context, messageID string, errMsg string)) *MockPendingRequestRepository_MarkFailed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockPendingRequestRepository_MarkFailed_Call) Return(_a0 error) *MockPendingRequestRepository_MarkFailed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPendingRequestRepository_MarkFailed_Call) RunAndReturn(run func(context.Context, string, string) error) *MockPendingRequestRepository_MarkFailed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkSoftNotified provides a mock function with given fields: ctx, messageID
+func (_m *MockPendingRequestRepository) MarkSoftNotified(ctx context.Context, messageID string) (bool, error) {
+	ret := _m.Called(ctx, messageID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkSoftNotified")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, messageID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, messageID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, messageID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPendingRequestRepository_MarkSoftNotified_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkSoftNotified'
+type MockPendingRequestRepository_MarkSoftNotified_Call struct {
+	*mock.Call
+}
+
+// MarkSoftNotified is a helper method to define mock.On call
+//   - ctx context.Context
+//   - messageID string
+func (_e *MockPendingRequestRepository_Expecter) MarkSoftNotified(ctx interface{}, messageID interface{}) *MockPendingRequestRepository_MarkSoftNotified_Call {
+	return &MockPendingRequestRepository_MarkSoftNotified_Call{Call: _e.mock.On("MarkSoftNotified", ctx, messageID)}
+}
+
+func (_c *MockPendingRequestRepository_MarkSoftNotified_Call) Run(run func(ctx context.Context, messageID string)) *MockPendingRequestRepository_MarkSoftNotified_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockPendingRequestRepository_MarkSoftNotified_Call) Return(_a0 bool, _a1 error) *MockPendingRequestRepository_MarkSoftNotified_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPendingRequestRepository_MarkSoftNotified_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *MockPendingRequestRepository_MarkSoftNotified_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPendingRequestRepository creates a new instance of MockPendingRequestRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPendingRequestRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPendingRequestRepository {
+	mock := &MockPendingRequestRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
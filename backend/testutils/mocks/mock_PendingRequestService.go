@@ -0,0 +1,369 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockPendingRequestService is an autogenerated mock type for the PendingRequestService type
+type MockPendingRequestService struct {
+	mock.Mock
+}
+
+type MockPendingRequestService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPendingRequestService) EXPECT() *MockPendingRequestService_Expecter {
+	return &MockPendingRequestService_Expecter{mock: &_m.Mock}
+}
+
+// Claim provides a mock function with given fields: ctx, messageID
+func (_m *MockPendingRequestService) Claim(ctx context.Context, messageID string) (*models.PendingRequest, error) {
+	ret := _m.Called(ctx, messageID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Claim")
+	}
+
+	var r0 *models.PendingRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.PendingRequest, error)); ok {
+		return rf(ctx, messageID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.PendingRequest); ok {
+		r0 = rf(ctx, messageID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PendingRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, messageID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPendingRequestService_Claim_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Claim'
+type MockPendingRequestService_Claim_Call struct {
+	*mock.Call
+}
+
+// Claim is a helper method to define mock.On call
+//   - ctx context.Context
+//   - messageID string
+func (_e *MockPendingRequestService_Expecter) Claim(ctx interface{}, messageID interface{}) *MockPendingRequestService_Claim_Call {
+	return &MockPendingRequestService_Claim_Call{Call: _e.mock.On("Claim", ctx, messageID)}
+}
+
+func (_c *MockPendingRequestService_Claim_Call) Run(run func(ctx context.Context, messageID string)) *MockPendingRequestService_Claim_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockPendingRequestService_Claim_Call) Return(_a0 *models.PendingRequest, _a1 error) *MockPendingRequestService_Claim_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPendingRequestService_Claim_Call) RunAndReturn(run func(context.Context, string) (*models.PendingRequest, error)) *MockPendingRequestService_Claim_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExpireOverdue provides a mock function with given fields: ctx
+func (_m *MockPendingRequestService) ExpireOverdue(ctx context.Context) ([]*models.PendingRequest, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpireOverdue")
+	}
+
+	var r0 []*models.PendingRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.PendingRequest, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.PendingRequest); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PendingRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPendingRequestService_ExpireOverdue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExpireOverdue'
+type MockPendingRequestService_ExpireOverdue_Call struct {
+	*mock.Call
+}
+
+// ExpireOverdue is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockPendingRequestService_Expecter) ExpireOverdue(ctx interface{}) *MockPendingRequestService_ExpireOverdue_Call {
+	return &MockPendingRequestService_ExpireOverdue_Call{Call: _e.mock.On("ExpireOverdue", ctx)}
+}
+
+func (_c *MockPendingRequestService_ExpireOverdue_Call) Run(run func(ctx context.Context)) *MockPendingRequestService_ExpireOverdue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockPendingRequestService_ExpireOverdue_Call) Return(_a0 []*models.PendingRequest, _a1 error) *MockPendingRequestService_ExpireOverdue_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPendingRequestService_ExpireOverdue_Call) RunAndReturn(run func(context.Context) ([]*models.PendingRequest, error)) *MockPendingRequestService_ExpireOverdue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAwaitingSoftNotice provides a mock function with given fields: ctx, age
+func (_m *MockPendingRequestService) ListAwaitingSoftNotice(ctx context.Context, age time.Duration) ([]*models.PendingRequest, error) {
+	ret := _m.Called(ctx, age)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAwaitingSoftNotice")
+	}
+
+	var r0 []*models.PendingRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) ([]*models.PendingRequest, error)); ok {
+		return rf(ctx, age)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) []*models.PendingRequest); ok {
+		r0 = rf(ctx, age)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PendingRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = rf(ctx, age)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPendingRequestService_ListAwaitingSoftNotice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAwaitingSoftNotice'
+type MockPendingRequestService_ListAwaitingSoftNotice_Call struct {
+	*mock.Call
+}
+
+// ListAwaitingSoftNotice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - age time.Duration
+func (_e *MockPendingRequestService_Expecter) ListAwaitingSoftNotice(ctx interface{}, age interface{}) *MockPendingRequestService_ListAwaitingSoftNotice_Call {
+	return &MockPendingRequestService_ListAwaitingSoftNotice_Call{Call: _e.mock.On("ListAwaitingSoftNotice", ctx, age)}
+}
+
+func (_c *MockPendingRequestService_ListAwaitingSoftNotice_Call) Run(run func(ctx context.Context, age time.Duration)) *MockPendingRequestService_ListAwaitingSoftNotice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockPendingRequestService_ListAwaitingSoftNotice_Call) Return(_a0 []*models.PendingRequest, _a1 error) *MockPendingRequestService_ListAwaitingSoftNotice_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPendingRequestService_ListAwaitingSoftNotice_Call) RunAndReturn(run func(context.Context, time.Duration) ([]*models.PendingRequest, error)) *MockPendingRequestService_ListAwaitingSoftNotice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkFailed provides a mock function with given fields: ctx, messageID, reason
+func (_m *MockPendingRequestService) MarkFailed(ctx context.Context, messageID string, reason string) error {
+	ret := _m.Called(ctx, messageID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkFailed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, messageID, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPendingRequestService_MarkFailed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkFailed'
+type MockPendingRequestService_MarkFailed_Call struct {
+	*mock.Call
+}
+
+// MarkFailed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - messageID string
+//   - reason string
+func (_e *MockPendingRequestService_Expecter) MarkFailed(ctx interface{}, messageID interface{}, reason interface{}) *MockPendingRequestService_MarkFailed_Call {
+	return &MockPendingRequestService_MarkFailed_Call{Call: _e.mock.On("MarkFailed", ctx, messageID, reason)}
+}
+
+func (_c *MockPendingRequestService_MarkFailed_Call) Run(run func(ctx context.Context, messageID string, reason string)) *MockPendingRequestService_MarkFailed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockPendingRequestService_MarkFailed_Call) Return(_a0 error) *MockPendingRequestService_MarkFailed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPendingRequestService_MarkFailed_Call) RunAndReturn(run func(context.Context, string, string) error) *MockPendingRequestService_MarkFailed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkSoftNotified provides a mock function with given fields: ctx, messageID
+func (_m *MockPendingRequestService) MarkSoftNotified(ctx context.Context, messageID string) (bool, error) {
+	ret := _m.Called(ctx, messageID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkSoftNotified")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, messageID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, messageID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, messageID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPendingRequestService_MarkSoftNotified_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkSoftNotified'
+type MockPendingRequestService_MarkSoftNotified_Call struct {
+	*mock.Call
+}
+
+// MarkSoftNotified is a helper method to define mock.On call
+//   - ctx context.Context
+//   - messageID string
+func (_e *MockPendingRequestService_Expecter) MarkSoftNotified(ctx interface{}, messageID interface{}) *MockPendingRequestService_MarkSoftNotified_Call {
+	return &MockPendingRequestService_MarkSoftNotified_Call{Call: _e.mock.On("MarkSoftNotified", ctx, messageID)}
+}
+
+func (_c *MockPendingRequestService_MarkSoftNotified_Call) Run(run func(ctx context.Context, messageID string)) *MockPendingRequestService_MarkSoftNotified_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockPendingRequestService_MarkSoftNotified_Call) Return(_a0 bool, _a1 error) *MockPendingRequestService_MarkSoftNotified_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPendingRequestService_MarkSoftNotified_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *MockPendingRequestService_MarkSoftNotified_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Register provides a mock function with given fields: ctx, messageID, phone, workflowType
+func (_m *MockPendingRequestService) Register(ctx context.Context, messageID string, phone string, workflowType string) error {
+	ret := _m.Called(ctx, messageID, phone, workflowType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Register")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, messageID, phone, workflowType)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPendingRequestService_Register_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Register'
+type MockPendingRequestService_Register_Call struct {
+	*mock.Call
+}
+
+// Register is a helper method to define mock.On call
+//   - ctx context.Context
+//   - messageID string
+//   - phone string
+//   - workflowType string
+func (_e *MockPendingRequestService_Expecter) Register(ctx interface{}, messageID interface{}, phone interface{}, workflowType interface{}) *MockPendingRequestService_Register_Call {
+	return &MockPendingRequestService_Register_Call{Call: _e.mock.On("Register", ctx, messageID, phone, workflowType)}
+}
+
+func (_c *MockPendingRequestService_Register_Call) Run(run func(ctx context.Context, messageID string, phone string, workflowType string)) *MockPendingRequestService_Register_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockPendingRequestService_Register_Call) Return(_a0 error) *MockPendingRequestService_Register_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPendingRequestService_Register_Call) RunAndReturn(run func(context.Context, string, string, string) error) *MockPendingRequestService_Register_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPendingRequestService creates a new instance of MockPendingRequestService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPendingRequestService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPendingRequestService {
+	mock := &MockPendingRequestService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
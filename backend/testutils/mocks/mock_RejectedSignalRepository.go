@@ -0,0 +1,144 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRejectedSignalRepository is an autogenerated mock type for the RejectedSignalRepository type
+type MockRejectedSignalRepository struct {
+	mock.Mock
+}
+
+type MockRejectedSignalRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRejectedSignalRepository) EXPECT() *MockRejectedSignalRepository_Expecter {
+	return &MockRejectedSignalRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, rejected
+func (_m *MockRejectedSignalRepository) Create(ctx context.Context, rejected *models.RejectedSignal) error {
+	ret := _m.Called(ctx, rejected)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.RejectedSignal) error); ok {
+		r0 = rf(ctx, rejected)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRejectedSignalRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockRejectedSignalRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - rejected *models.RejectedSignal
+func (_e *MockRejectedSignalRepository_Expecter) Create(ctx interface{}, rejected interface{}) *MockRejectedSignalRepository_Create_Call {
+	return &MockRejectedSignalRepository_Create_Call{Call: _e.mock.On("Create", ctx, rejected)}
+}
+
+func (_c *MockRejectedSignalRepository_Create_Call) Run(run func(ctx context.Context, rejected *models.RejectedSignal)) *MockRejectedSignalRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.RejectedSignal))
+	})
+	return _c
+}
+
+func (_c *MockRejectedSignalRepository_Create_Call) Return(_a0 error) *MockRejectedSignalRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRejectedSignalRepository_Create_Call) RunAndReturn(run func(context.Context, *models.RejectedSignal) error) *MockRejectedSignalRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, filter, limit
+func (_m *MockRejectedSignalRepository) List(ctx context.Context, filter *models.RejectedSignalFilter, limit int) ([]*models.RejectedSignal, error) {
+	ret := _m.Called(ctx, filter, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.RejectedSignal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.RejectedSignalFilter, int) ([]*models.RejectedSignal, error)); ok {
+		return rf(ctx, filter, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.RejectedSignalFilter, int) []*models.RejectedSignal); ok {
+		r0 = rf(ctx, filter, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.RejectedSignal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.RejectedSignalFilter, int) error); ok {
+		r1 = rf(ctx, filter, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRejectedSignalRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockRejectedSignalRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter *models.RejectedSignalFilter
+//   - limit int
+func (_e *MockRejectedSignalRepository_Expecter) List(ctx interface{}, filter interface{}, limit interface{}) *MockRejectedSignalRepository_List_Call {
+	return &MockRejectedSignalRepository_List_Call{Call: _e.mock.On("List", ctx, filter, limit)}
+}
+
+func (_c *MockRejectedSignalRepository_List_Call) Run(run func(ctx context.Context, filter *models.RejectedSignalFilter, limit int)) *MockRejectedSignalRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.RejectedSignalFilter), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockRejectedSignalRepository_List_Call) Return(_a0 []*models.RejectedSignal, _a1 error) *MockRejectedSignalRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRejectedSignalRepository_List_Call) RunAndReturn(run func(context.Context, *models.RejectedSignalFilter, int) ([]*models.RejectedSignal, error)) *MockRejectedSignalRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRejectedSignalRepository creates a new instance of MockRejectedSignalRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRejectedSignalRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRejectedSignalRepository {
+	mock := &MockRejectedSignalRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,263 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockRoleRepository is an autogenerated mock type for the RoleRepository type
+type MockRoleRepository struct {
+	mock.Mock
+}
+
+type MockRoleRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRoleRepository) EXPECT() *MockRoleRepository_Expecter {
+	return &MockRoleRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetPermissions provides a mock function with given fields: ctx, roles
+func (_m *MockRoleRepository) GetPermissions(ctx context.Context, roles []string) ([]string, error) {
+	ret := _m.Called(ctx, roles)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPermissions")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]string, error)); ok {
+		return rf(ctx, roles)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []string); ok {
+		r0 = rf(ctx, roles)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, roles)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRoleRepository_GetPermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPermissions'
+type MockRoleRepository_GetPermissions_Call struct {
+	*mock.Call
+}
+
+// GetPermissions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - roles []string
+func (_e *MockRoleRepository_Expecter) GetPermissions(ctx interface{}, roles interface{}) *MockRoleRepository_GetPermissions_Call {
+	return &MockRoleRepository_GetPermissions_Call{Call: _e.mock.On("GetPermissions", ctx, roles)}
+}
+
+func (_c *MockRoleRepository_GetPermissions_Call) Run(run func(ctx context.Context, roles []string)) *MockRoleRepository_GetPermissions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockRoleRepository_GetPermissions_Call) Return(_a0 []string, _a1 error) *MockRoleRepository_GetPermissions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRoleRepository_GetPermissions_Call) RunAndReturn(run func(context.Context, []string) ([]string, error)) *MockRoleRepository_GetPermissions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserRoles provides a mock function with given fields: ctx, userID
+func (_m *MockRoleRepository) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserRoles")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]string, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRoleRepository_GetUserRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserRoles'
+type MockRoleRepository_GetUserRoles_Call struct {
+	*mock.Call
+}
+
+// GetUserRoles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockRoleRepository_Expecter) GetUserRoles(ctx interface{}, userID interface{}) *MockRoleRepository_GetUserRoles_Call {
+	return &MockRoleRepository_GetUserRoles_Call{Call: _e.mock.On("GetUserRoles", ctx, userID)}
+}
+
+func (_c *MockRoleRepository_GetUserRoles_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockRoleRepository_GetUserRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockRoleRepository_GetUserRoles_Call) Return(_a0 []string, _a1 error) *MockRoleRepository_GetUserRoles_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRoleRepository_GetUserRoles_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]string, error)) *MockRoleRepository_GetUserRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *MockRoleRepository) List(ctx context.Context) ([]*models.Role, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.Role, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.Role); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRoleRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockRoleRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRoleRepository_Expecter) List(ctx interface{}) *MockRoleRepository_List_Call {
+	return &MockRoleRepository_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *MockRoleRepository_List_Call) Run(run func(ctx context.Context)) *MockRoleRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockRoleRepository_List_Call) Return(_a0 []*models.Role, _a1 error) *MockRoleRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRoleRepository_List_Call) RunAndReturn(run func(context.Context) ([]*models.Role, error)) *MockRoleRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetUserRoles provides a mock function with given fields: ctx, userID, roles
+func (_m *MockRoleRepository) SetUserRoles(ctx context.Context, userID uuid.UUID, roles []string) error {
+	ret := _m.Called(ctx, userID, roles)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserRoles")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []string) error); ok {
+		r0 = rf(ctx, userID, roles)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRoleRepository_SetUserRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUserRoles'
+type MockRoleRepository_SetUserRoles_Call struct {
+	*mock.Call
+}
+
+// SetUserRoles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - roles []string
+func (_e *MockRoleRepository_Expecter) SetUserRoles(ctx interface{}, userID interface{}, roles interface{}) *MockRoleRepository_SetUserRoles_Call {
+	return &MockRoleRepository_SetUserRoles_Call{Call: _e.mock.On("SetUserRoles", ctx, userID, roles)}
+}
+
+func (_c *MockRoleRepository_SetUserRoles_Call) Run(run func(ctx context.Context, userID uuid.UUID, roles []string)) *MockRoleRepository_SetUserRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *MockRoleRepository_SetUserRoles_Call) Return(_a0 error) *MockRoleRepository_SetUserRoles_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRoleRepository_SetUserRoles_Call) RunAndReturn(run func(context.Context, uuid.UUID, []string) error) *MockRoleRepository_SetUserRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRoleRepository creates a new instance of MockRoleRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRoleRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRoleRepository {
+	mock := &MockRoleRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,275 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockRoleService is an autogenerated mock type for the RoleService type
+type MockRoleService struct {
+	mock.Mock
+}
+
+type MockRoleService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRoleService) EXPECT() *MockRoleService_Expecter {
+	return &MockRoleService_Expecter{mock: &_m.Mock}
+}
+
+// GetPermissions provides a mock function with given fields: ctx, roles
+func (_m *MockRoleService) GetPermissions(ctx context.Context, roles []string) ([]string, error) {
+	ret := _m.Called(ctx, roles)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPermissions")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]string, error)); ok {
+		return rf(ctx, roles)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []string); ok {
+		r0 = rf(ctx, roles)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, roles)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRoleService_GetPermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPermissions'
+type MockRoleService_GetPermissions_Call struct {
+	*mock.Call
+}
+
+// GetPermissions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - roles []string
+func (_e *MockRoleService_Expecter) GetPermissions(ctx interface{}, roles interface{}) *MockRoleService_GetPermissions_Call {
+	return &MockRoleService_GetPermissions_Call{Call: _e.mock.On("GetPermissions", ctx, roles)}
+}
+
+func (_c *MockRoleService_GetPermissions_Call) Run(run func(ctx context.Context, roles []string)) *MockRoleService_GetPermissions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockRoleService_GetPermissions_Call) Return(_a0 []string, _a1 error) *MockRoleService_GetPermissions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRoleService_GetPermissions_Call) RunAndReturn(run func(context.Context, []string) ([]string, error)) *MockRoleService_GetPermissions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserRoles provides a mock function with given fields: ctx, userID
+func (_m *MockRoleService) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserRoles")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]string, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRoleService_GetUserRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserRoles'
+type MockRoleService_GetUserRoles_Call struct {
+	*mock.Call
+}
+
+// GetUserRoles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockRoleService_Expecter) GetUserRoles(ctx interface{}, userID interface{}) *MockRoleService_GetUserRoles_Call {
+	return &MockRoleService_GetUserRoles_Call{Call: _e.mock.On("GetUserRoles", ctx, userID)}
+}
+
+func (_c *MockRoleService_GetUserRoles_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockRoleService_GetUserRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockRoleService_GetUserRoles_Call) Return(_a0 []string, _a1 error) *MockRoleService_GetUserRoles_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRoleService_GetUserRoles_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]string, error)) *MockRoleService_GetUserRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRoles provides a mock function with given fields: ctx
+func (_m *MockRoleService) ListRoles(ctx context.Context) ([]*models.Role, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRoles")
+	}
+
+	var r0 []*models.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.Role, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.Role); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRoleService_ListRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRoles'
+type MockRoleService_ListRoles_Call struct {
+	*mock.Call
+}
+
+// ListRoles is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRoleService_Expecter) ListRoles(ctx interface{}) *MockRoleService_ListRoles_Call {
+	return &MockRoleService_ListRoles_Call{Call: _e.mock.On("ListRoles", ctx)}
+}
+
+func (_c *MockRoleService_ListRoles_Call) Run(run func(ctx context.Context)) *MockRoleService_ListRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockRoleService_ListRoles_Call) Return(_a0 []*models.Role, _a1 error) *MockRoleService_ListRoles_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRoleService_ListRoles_Call) RunAndReturn(run func(context.Context) ([]*models.Role, error)) *MockRoleService_ListRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetUserRoles provides a mock function with given fields: ctx, userID, roles
+func (_m *MockRoleService) SetUserRoles(ctx context.Context, userID uuid.UUID, roles []string) ([]string, error) {
+	ret := _m.Called(ctx, userID, roles)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserRoles")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []string) ([]string, error)); ok {
+		return rf(ctx, userID, roles)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []string) []string); ok {
+		r0 = rf(ctx, userID, roles)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, []string) error); ok {
+		r1 = rf(ctx, userID, roles)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRoleService_SetUserRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUserRoles'
+type MockRoleService_SetUserRoles_Call struct {
+	*mock.Call
+}
+
+// SetUserRoles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - roles []string
+func (_e *MockRoleService_Expecter) SetUserRoles(ctx interface{}, userID interface{}, roles interface{}) *MockRoleService_SetUserRoles_Call {
+	return &MockRoleService_SetUserRoles_Call{Call: _e.mock.On("SetUserRoles", ctx, userID, roles)}
+}
+
+func (_c *MockRoleService_SetUserRoles_Call) Run(run func(ctx context.Context, userID uuid.UUID, roles []string)) *MockRoleService_SetUserRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *MockRoleService_SetUserRoles_Call) Return(_a0 []string, _a1 error) *MockRoleService_SetUserRoles_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRoleService_SetUserRoles_Call) RunAndReturn(run func(context.Context, uuid.UUID, []string) ([]string, error)) *MockRoleService_SetUserRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRoleService creates a new instance of MockRoleService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRoleService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRoleService {
+	mock := &MockRoleService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,308 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockSessionRepository is an autogenerated mock type for the SessionRepository type
+type MockSessionRepository struct {
+	mock.Mock
+}
+
+type MockSessionRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSessionRepository) EXPECT() *MockSessionRepository_Expecter {
+	return &MockSessionRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, phone
+func (_m *MockSessionRepository) Create(ctx context.Context, phone string) (*models.ConversationSession, error) {
+	ret := _m.Called(ctx, phone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *models.ConversationSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.ConversationSession, error)); ok {
+		return rf(ctx, phone)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.ConversationSession); ok {
+		r0 = rf(ctx, phone)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ConversationSession)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, phone)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSessionRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockSessionRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - phone string
+func (_e *MockSessionRepository_Expecter) Create(ctx interface{}, phone interface{}) *MockSessionRepository_Create_Call {
+	return &MockSessionRepository_Create_Call{Call: _e.mock.On("Create", ctx, phone)}
+}
+
+func (_c *MockSessionRepository_Create_Call) Run(run func(ctx context.Context, phone string)) *MockSessionRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepository_Create_Call) Return(_a0 *models.ConversationSession, _a1 error) *MockSessionRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSessionRepository_Create_Call) RunAndReturn(run func(context.Context, string) (*models.ConversationSession, error)) *MockSessionRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// End provides a mock function with given fields: ctx, id
+func (_m *MockSessionRepository) End(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for End")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSessionRepository_End_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'End'
+type MockSessionRepository_End_Call struct {
+	*mock.Call
+}
+
+// End is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockSessionRepository_Expecter) End(ctx interface{}, id interface{}) *MockSessionRepository_End_Call {
+	return &MockSessionRepository_End_Call{Call: _e.mock.On("End", ctx, id)}
+}
+
+func (_c *MockSessionRepository_End_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockSessionRepository_End_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepository_End_Call) Return(_a0 error) *MockSessionRepository_End_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSessionRepository_End_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockSessionRepository_End_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EndActiveByPhone provides a mock function with given fields: ctx, phone
+func (_m *MockSessionRepository) EndActiveByPhone(ctx context.Context, phone string) (bool, error) {
+	ret := _m.Called(ctx, phone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EndActiveByPhone")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, phone)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, phone)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, phone)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSessionRepository_EndActiveByPhone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EndActiveByPhone'
+type MockSessionRepository_EndActiveByPhone_Call struct {
+	*mock.Call
+}
+
+// EndActiveByPhone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - phone string
+func (_e *MockSessionRepository_Expecter) EndActiveByPhone(ctx interface{}, phone interface{}) *MockSessionRepository_EndActiveByPhone_Call {
+	return &MockSessionRepository_EndActiveByPhone_Call{Call: _e.mock.On("EndActiveByPhone", ctx, phone)}
+}
+
+func (_c *MockSessionRepository_EndActiveByPhone_Call) Run(run func(ctx context.Context, phone string)) *MockSessionRepository_EndActiveByPhone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepository_EndActiveByPhone_Call) Return(_a0 bool, _a1 error) *MockSessionRepository_EndActiveByPhone_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSessionRepository_EndActiveByPhone_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *MockSessionRepository_EndActiveByPhone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActiveByPhone provides a mock function with given fields: ctx, phone
+func (_m *MockSessionRepository) GetActiveByPhone(ctx context.Context, phone string) (*models.ConversationSession, error) {
+	ret := _m.Called(ctx, phone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveByPhone")
+	}
+
+	var r0 *models.ConversationSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.ConversationSession, error)); ok {
+		return rf(ctx, phone)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.ConversationSession); ok {
+		r0 = rf(ctx, phone)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ConversationSession)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, phone)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSessionRepository_GetActiveByPhone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveByPhone'
+type MockSessionRepository_GetActiveByPhone_Call struct {
+	*mock.Call
+}
+
+// GetActiveByPhone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - phone string
+func (_e *MockSessionRepository_Expecter) GetActiveByPhone(ctx interface{}, phone interface{}) *MockSessionRepository_GetActiveByPhone_Call {
+	return &MockSessionRepository_GetActiveByPhone_Call{Call: _e.mock.On("GetActiveByPhone", ctx, phone)}
+}
+
+func (_c *MockSessionRepository_GetActiveByPhone_Call) Run(run func(ctx context.Context, phone string)) *MockSessionRepository_GetActiveByPhone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepository_GetActiveByPhone_Call) Return(_a0 *models.ConversationSession, _a1 error) *MockSessionRepository_GetActiveByPhone_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSessionRepository_GetActiveByPhone_Call) RunAndReturn(run func(context.Context, string) (*models.ConversationSession, error)) *MockSessionRepository_GetActiveByPhone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Touch provides a mock function with given fields: ctx, id
+func (_m *MockSessionRepository) Touch(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Touch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSessionRepository_Touch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Touch'
+type MockSessionRepository_Touch_Call struct {
+	*mock.Call
+}
+
+// Touch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockSessionRepository_Expecter) Touch(ctx interface{}, id interface{}) *MockSessionRepository_Touch_Call {
+	return &MockSessionRepository_Touch_Call{Call: _e.mock.On("Touch", ctx, id)}
+}
+
+func (_c *MockSessionRepository_Touch_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockSessionRepository_Touch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepository_Touch_Call) Return(_a0 error) *MockSessionRepository_Touch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSessionRepository_Touch_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockSessionRepository_Touch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSessionRepository creates a new instance of MockSessionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSessionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSessionRepository {
+	mock := &MockSessionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,708 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockSignalBroadcastRepository is an autogenerated mock type for the SignalBroadcastRepository type
+type MockSignalBroadcastRepository struct {
+	mock.Mock
+}
+
+type MockSignalBroadcastRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSignalBroadcastRepository) EXPECT() *MockSignalBroadcastRepository_Expecter {
+	return &MockSignalBroadcastRepository_Expecter{mock: &_m.Mock}
+}
+
+// CancelPending provides a mock function with given fields: ctx, signalID
+func (_m *MockSignalBroadcastRepository) CancelPending(ctx context.Context, signalID uuid.UUID) (int, error) {
+	ret := _m.Called(ctx, signalID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelPending")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int, error)); ok {
+		return rf(ctx, signalID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) int); ok {
+		r0 = rf(ctx, signalID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, signalID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSignalBroadcastRepository_CancelPending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelPending'
+type MockSignalBroadcastRepository_CancelPending_Call struct {
+	*mock.Call
+}
+
+// CancelPending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - signalID uuid.UUID
+func (_e *MockSignalBroadcastRepository_Expecter) CancelPending(ctx interface{}, signalID interface{}) *MockSignalBroadcastRepository_CancelPending_Call {
+	return &MockSignalBroadcastRepository_CancelPending_Call{Call: _e.mock.On("CancelPending", ctx, signalID)}
+}
+
+func (_c *MockSignalBroadcastRepository_CancelPending_Call) Run(run func(ctx context.Context, signalID uuid.UUID)) *MockSignalBroadcastRepository_CancelPending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSignalBroadcastRepository_CancelPending_Call) Return(_a0 int, _a1 error) *MockSignalBroadcastRepository_CancelPending_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSignalBroadcastRepository_CancelPending_Call) RunAndReturn(run func(context.Context, uuid.UUID) (int, error)) *MockSignalBroadcastRepository_CancelPending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimDelivery provides a mock function with given fields: ctx, id
+func (_m *MockSignalBroadcastRepository) ClaimDelivery(ctx context.Context, id uuid.UUID) (*models.SignalDelivery, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDelivery")
+	}
+
+	var r0 *models.SignalDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.SignalDelivery, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.SignalDelivery); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SignalDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSignalBroadcastRepository_ClaimDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDelivery'
+type MockSignalBroadcastRepository_ClaimDelivery_Call struct {
+	*mock.Call
+}
+
+// ClaimDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockSignalBroadcastRepository_Expecter) ClaimDelivery(ctx interface{}, id interface{}) *MockSignalBroadcastRepository_ClaimDelivery_Call {
+	return &MockSignalBroadcastRepository_ClaimDelivery_Call{Call: _e.mock.On("ClaimDelivery", ctx, id)}
+}
+
+func (_c *MockSignalBroadcastRepository_ClaimDelivery_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockSignalBroadcastRepository_ClaimDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSignalBroadcastRepository_ClaimDelivery_Call) Return(_a0 *models.SignalDelivery, _a1 error) *MockSignalBroadcastRepository_ClaimDelivery_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSignalBroadcastRepository_ClaimDelivery_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.SignalDelivery, error)) *MockSignalBroadcastRepository_ClaimDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, broadcast, deliveries
+func (_m *MockSignalBroadcastRepository) Create(ctx context.Context, broadcast *models.SignalBroadcast, deliveries []*models.SignalDelivery) (bool, error) {
+	ret := _m.Called(ctx, broadcast, deliveries)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SignalBroadcast, []*models.SignalDelivery) (bool, error)); ok {
+		return rf(ctx, broadcast, deliveries)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SignalBroadcast, []*models.SignalDelivery) bool); ok {
+		r0 = rf(ctx, broadcast, deliveries)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.SignalBroadcast, []*models.SignalDelivery) error); ok {
+		r1 = rf(ctx, broadcast, deliveries)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSignalBroadcastRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockSignalBroadcastRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - broadcast *models.SignalBroadcast
+//   - deliveries []*models.SignalDelivery
+func (_e *MockSignalBroadcastRepository_Expecter) Create(ctx interface{}, broadcast interface{}, deliveries interface{}) *MockSignalBroadcastRepository_Create_Call {
+	return &MockSignalBroadcastRepository_Create_Call{Call: _e.mock.On("Create", ctx, broadcast, deliveries)}
+}
+
+func (_c *MockSignalBroadcastRepository_Create_Call) Run(run func(ctx context.Context, broadcast *models.SignalBroadcast, deliveries []*models.SignalDelivery)) *MockSignalBroadcastRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.SignalBroadcast), args[2].([]*models.SignalDelivery))
+	})
+	return _c
+}
+
+func (_c *MockSignalBroadcastRepository_Create_Call) Return(_a0 bool, _a1 error) *MockSignalBroadcastRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSignalBroadcastRepository_Create_Call) RunAndReturn(run func(context.Context, *models.SignalBroadcast, []*models.SignalDelivery) (bool, error)) *MockSignalBroadcastRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FailInterrupted provides a mock function with given fields: ctx, errMsg
+func (_m *MockSignalBroadcastRepository) FailInterrupted(ctx context.Context, errMsg string) (int, error) {
+	ret := _m.Called(ctx, errMsg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailInterrupted")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, errMsg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, errMsg)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, errMsg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSignalBroadcastRepository_FailInterrupted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FailInterrupted'
+type MockSignalBroadcastRepository_FailInterrupted_Call struct {
+	*mock.Call
+}
+
+// FailInterrupted is a helper method to define mock.On call
+//   - ctx context.Context
+//   - errMsg string
+func (_e *MockSignalBroadcastRepository_Expecter) FailInterrupted(ctx interface{}, errMsg interface{}) *MockSignalBroadcastRepository_FailInterrupted_Call {
+	return &MockSignalBroadcastRepository_FailInterrupted_Call{Call: _e.mock.On("FailInterrupted", ctx, errMsg)}
+}
+
+func (_c *MockSignalBroadcastRepository_FailInterrupted_Call) Run(run func(ctx context.Context, errMsg string)) *MockSignalBroadcastRepository_FailInterrupted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSignalBroadcastRepository_FailInterrupted_Call) Return(_a0 int, _a1 error) *MockSignalBroadcastRepository_FailInterrupted_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSignalBroadcastRepository_FailInterrupted_Call) RunAndReturn(run func(context.Context, string) (int, error)) *MockSignalBroadcastRepository_FailInterrupted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Finish provides a mock function with given fields: ctx, id
+func (_m *MockSignalBroadcastRepository) Finish(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Finish")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSignalBroadcastRepository_Finish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Finish'
+type MockSignalBroadcastRepository_Finish_Call struct {
+	*mock.Call
+}
+
+// Finish is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockSignalBroadcastRepository_Expecter) Finish(ctx interface{}, id interface{}) *MockSignalBroadcastRepository_Finish_Call {
+	return &MockSignalBroadcastRepository_Finish_Call{Call: _e.mock.On("Finish", ctx, id)}
+}
+
+func (_c *MockSignalBroadcastRepository_Finish_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockSignalBroadcastRepository_Finish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSignalBroadcastRepository_Finish_Call) Return(_a0 error) *MockSignalBroadcastRepository_Finish_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSignalBroadcastRepository_Finish_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockSignalBroadcastRepository_Finish_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *MockSignalBroadcastRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.SignalBroadcast, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.SignalBroadcast
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.SignalBroadcast, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.SignalBroadcast); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SignalBroadcast)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSignalBroadcastRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockSignalBroadcastRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockSignalBroadcastRepository_Expecter) GetByID(ctx interface{}, id interface{}) *MockSignalBroadcastRepository_GetByID_Call {
+	return &MockSignalBroadcastRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *MockSignalBroadcastRepository_GetByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockSignalBroadcastRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSignalBroadcastRepository_GetByID_Call) Return(_a0 *models.SignalBroadcast, _a1 error) *MockSignalBroadcastRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSignalBroadcastRepository_GetByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.SignalBroadcast, error)) *MockSignalBroadcastRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLatest provides a mock function with given fields: ctx, signalID, kind
+func (_m *MockSignalBroadcastRepository) GetLatest(ctx context.Context, signalID uuid.UUID, kind string) (*models.SignalBroadcast, error) {
+	ret := _m.Called(ctx, signalID, kind)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatest")
+	}
+
+	var r0 *models.SignalBroadcast
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*models.SignalBroadcast, error)); ok {
+		return rf(ctx, signalID, kind)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *models.SignalBroadcast); ok {
+		r0 = rf(ctx, signalID, kind)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SignalBroadcast)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, signalID, kind)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSignalBroadcastRepository_GetLatest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLatest'
+type MockSignalBroadcastRepository_GetLatest_Call struct {
+	*mock.Call
+}
+
+// GetLatest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - signalID uuid.UUID
+//   - kind string
+func (_e *MockSignalBroadcastRepository_Expecter) GetLatest(ctx interface{}, signalID interface{}, kind interface{}) *MockSignalBroadcastRepository_GetLatest_Call {
+	return &MockSignalBroadcastRepository_GetLatest_Call{Call: _e.mock.On("GetLatest", ctx, signalID, kind)}
+}
+
+func (_c *MockSignalBroadcastRepository_GetLatest_Call) Run(run func(ctx context.Context, signalID uuid.UUID, kind string)) *MockSignalBroadcastRepository_GetLatest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSignalBroadcastRepository_GetLatest_Call) Return(_a0 *models.SignalBroadcast, _a1 error) *MockSignalBroadcastRepository_GetLatest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSignalBroadcastRepository_GetLatest_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) (*models.SignalBroadcast, error)) *MockSignalBroadcastRepository_GetLatest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDeliveries provides a mock function with given fields: ctx, broadcastID, status
+func (_m *MockSignalBroadcastRepository) ListDeliveries(ctx context.Context, broadcastID uuid.UUID, status string) ([]*models.SignalDelivery, error) {
+	ret := _m.Called(ctx, broadcastID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeliveries")
+	}
+
+	var r0 []*models.SignalDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) ([]*models.SignalDelivery, error)); ok {
+		return rf(ctx, broadcastID, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) []*models.SignalDelivery); ok {
+		r0 = rf(ctx, broadcastID, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SignalDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, broadcastID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSignalBroadcastRepository_ListDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeliveries'
+type MockSignalBroadcastRepository_ListDeliveries_Call struct {
+	*mock.Call
+}
+
+// ListDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - broadcastID uuid.UUID
+//   - status string
+func (_e *MockSignalBroadcastRepository_Expecter) ListDeliveries(ctx interface{}, broadcastID interface{}, status interface{}) *MockSignalBroadcastRepository_ListDeliveries_Call {
+	return &MockSignalBroadcastRepository_ListDeliveries_Call{Call: _e.mock.On("ListDeliveries", ctx, broadcastID, status)}
+}
+
+func (_c *MockSignalBroadcastRepository_ListDeliveries_Call) Run(run func(ctx context.Context, broadcastID uuid.UUID, status string)) *MockSignalBroadcastRepository_ListDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSignalBroadcastRepository_ListDeliveries_Call) Return(_a0 []*models.SignalDelivery, _a1 error) *MockSignalBroadcastRepository_ListDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSignalBroadcastRepository_ListDeliveries_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) ([]*models.SignalDelivery, error)) *MockSignalBroadcastRepository_ListDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUnfinished provides a mock function with given fields: ctx
+func (_m *MockSignalBroadcastRepository) ListUnfinished(ctx context.Context) ([]*models.SignalBroadcast, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUnfinished")
+	}
+
+	var r0 []*models.SignalBroadcast
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.SignalBroadcast, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.SignalBroadcast); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SignalBroadcast)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSignalBroadcastRepository_ListUnfinished_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUnfinished'
+type MockSignalBroadcastRepository_ListUnfinished_Call struct {
+	*mock.Call
+}
+
+// ListUnfinished is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSignalBroadcastRepository_Expecter) ListUnfinished(ctx interface{}) *MockSignalBroadcastRepository_ListUnfinished_Call {
+	return &MockSignalBroadcastRepository_ListUnfinished_Call{Call: _e.mock.On("ListUnfinished", ctx)}
+}
+
+func (_c *MockSignalBroadcastRepository_ListUnfinished_Call) Run(run func(ctx context.Context)) *MockSignalBroadcastRepository_ListUnfinished_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSignalBroadcastRepository_ListUnfinished_Call) Return(_a0 []*models.SignalBroadcast, _a1 error) *MockSignalBroadcastRepository_ListUnfinished_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSignalBroadcastRepository_ListUnfinished_Call) RunAndReturn(run func(context.Context) ([]*models.SignalBroadcast, error)) *MockSignalBroadcastRepository_ListUnfinished_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordDelivery provides a mock function with given fields: ctx, id, status, whatsAppMessageID, errMsg
+func (_m *MockSignalBroadcastRepository) RecordDelivery(ctx context.Context, id uuid.UUID, status string, whatsAppMessageID string, errMsg string) error {
+	ret := _m.Called(ctx, id, status, whatsAppMessageID, errMsg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string, string) error); ok {
+		r0 = rf(ctx, id, status, whatsAppMessageID, errMsg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSignalBroadcastRepository_RecordDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordDelivery'
+type MockSignalBroadcastRepository_RecordDelivery_Call struct {
+	*mock.Call
+}
+
+// RecordDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - status string
+//   - whatsAppMessageID string
+//   - errMsg string
+func (_e *MockSignalBroadcastRepository_Expecter) RecordDelivery(ctx interface{}, id interface{}, status interface{}, whatsAppMessageID interface{}, errMsg interface{}) *MockSignalBroadcastRepository_RecordDelivery_Call {
+	return &MockSignalBroadcastRepository_RecordDelivery_Call{Call: _e.mock.On("RecordDelivery", ctx, id, status, whatsAppMessageID, errMsg)}
+}
+
+func (_c *MockSignalBroadcastRepository_RecordDelivery_Call) Run(run func(ctx context.Context, id uuid.UUID, status string, whatsAppMessageID string, errMsg string)) *MockSignalBroadcastRepository_RecordDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockSignalBroadcastRepository_RecordDelivery_Call) Return(_a0 error) *MockSignalBroadcastRepository_RecordDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSignalBroadcastRepository_RecordDelivery_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, string, string) error) *MockSignalBroadcastRepository_RecordDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RewritePending provides a mock function with given fields: ctx, deliveries
+func (_m *MockSignalBroadcastRepository) RewritePending(ctx context.Context, deliveries []*models.SignalDelivery) (int, error) {
+	ret := _m.Called(ctx, deliveries)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RewritePending")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.SignalDelivery) (int, error)); ok {
+		return rf(ctx, deliveries)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.SignalDelivery) int); ok {
+		r0 = rf(ctx, deliveries)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*models.SignalDelivery) error); ok {
+		r1 = rf(ctx, deliveries)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSignalBroadcastRepository_RewritePending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RewritePending'
+type MockSignalBroadcastRepository_RewritePending_Call struct {
+	*mock.Call
+}
+
+// RewritePending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deliveries []*models.SignalDelivery
+func (_e *MockSignalBroadcastRepository_Expecter) RewritePending(ctx interface{}, deliveries interface{}) *MockSignalBroadcastRepository_RewritePending_Call {
+	return &MockSignalBroadcastRepository_RewritePending_Call{Call: _e.mock.On("RewritePending", ctx, deliveries)}
+}
+
+func (_c *MockSignalBroadcastRepository_RewritePending_Call) Run(run func(ctx context.Context, deliveries []*models.SignalDelivery)) *MockSignalBroadcastRepository_RewritePending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*models.SignalDelivery))
+	})
+	return _c
+}
+
+func (_c *MockSignalBroadcastRepository_RewritePending_Call) Return(_a0 int, _a1 error) *MockSignalBroadcastRepository_RewritePending_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSignalBroadcastRepository_RewritePending_Call) RunAndReturn(run func(context.Context, []*models.SignalDelivery) (int, error)) *MockSignalBroadcastRepository_RewritePending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Start provides a mock function with given fields: ctx, id
+func (_m *MockSignalBroadcastRepository) Start(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Start")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSignalBroadcastRepository_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
+type MockSignalBroadcastRepository_Start_Call struct {
+	*mock.Call
+}
+
+// Start is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockSignalBroadcastRepository_Expecter) Start(ctx interface{}, id interface{}) *MockSignalBroadcastRepository_Start_Call {
+	return &MockSignalBroadcastRepository_Start_Call{Call: _e.mock.On("Start", ctx, id)}
+}
+
+func (_c *MockSignalBroadcastRepository_Start_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockSignalBroadcastRepository_Start_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSignalBroadcastRepository_Start_Call) Return(_a0 error) *MockSignalBroadcastRepository_Start_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSignalBroadcastRepository_Start_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockSignalBroadcastRepository_Start_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSignalBroadcastRepository creates a new instance of MockSignalBroadcastRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSignalBroadcastRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSignalBroadcastRepository {
+	mock := &MockSignalBroadcastRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,149 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockSignalDispatcher is an autogenerated mock type for the SignalDispatcher type
+type MockSignalDispatcher struct {
+	mock.Mock
+}
+
+type MockSignalDispatcher_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSignalDispatcher) EXPECT() *MockSignalDispatcher_Expecter {
+	return &MockSignalDispatcher_Expecter{mock: &_m.Mock}
+}
+
+// Dispatch provides a mock function with given fields: broadcast, deliveries
+func (_m *MockSignalDispatcher) Dispatch(broadcast *models.SignalBroadcast, deliveries []*models.SignalDelivery) {
+	_m.Called(broadcast, deliveries)
+}
+
+// MockSignalDispatcher_Dispatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Dispatch'
+type MockSignalDispatcher_Dispatch_Call struct {
+	*mock.Call
+}
+
+// Dispatch is a helper method to define mock.On call
+//   - broadcast *models.SignalBroadcast
+//   - deliveries []*models.SignalDelivery
+func (_e *MockSignalDispatcher_Expecter) Dispatch(broadcast interface{}, deliveries interface{}) *MockSignalDispatcher_Dispatch_Call {
+	return &MockSignalDispatcher_Dispatch_Call{Call: _e.mock.On("Dispatch", broadcast, deliveries)}
+}
+
+func (_c *MockSignalDispatcher_Dispatch_Call) Run(run func(broadcast *models.SignalBroadcast, deliveries []*models.SignalDelivery)) *MockSignalDispatcher_Dispatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.SignalBroadcast), args[1].([]*models.SignalDelivery))
+	})
+	return _c
+}
+
+func (_c *MockSignalDispatcher_Dispatch_Call) Return() *MockSignalDispatcher_Dispatch_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSignalDispatcher_Dispatch_Call) RunAndReturn(run func(*models.SignalBroadcast, []*models.SignalDelivery)) *MockSignalDispatcher_Dispatch_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Start provides a mock function with given fields: ctx
+func (_m *MockSignalDispatcher) Start(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Start")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSignalDispatcher_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
+type MockSignalDispatcher_Start_Call struct {
+	*mock.Call
+}
+
+// Start is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSignalDispatcher_Expecter) Start(ctx interface{}) *MockSignalDispatcher_Start_Call {
+	return &MockSignalDispatcher_Start_Call{Call: _e.mock.On("Start", ctx)}
+}
+
+func (_c *MockSignalDispatcher_Start_Call) Run(run func(ctx context.Context)) *MockSignalDispatcher_Start_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSignalDispatcher_Start_Call) Return(_a0 error) *MockSignalDispatcher_Start_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSignalDispatcher_Start_Call) RunAndReturn(run func(context.Context) error) *MockSignalDispatcher_Start_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Stop provides a mock function with no fields
+func (_m *MockSignalDispatcher) Stop() {
+	_m.Called()
+}
+
+// MockSignalDispatcher_Stop_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stop'
+type MockSignalDispatcher_Stop_Call struct {
+	*mock.Call
+}
+
+// Stop is a helper method to define mock.On call
+func (_e *MockSignalDispatcher_Expecter) Stop() *MockSignalDispatcher_Stop_Call {
+	return &MockSignalDispatcher_Stop_Call{Call: _e.mock.On("Stop")}
+}
+
+func (_c *MockSignalDispatcher_Stop_Call) Run(run func()) *MockSignalDispatcher_Stop_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockSignalDispatcher_Stop_Call) Return() *MockSignalDispatcher_Stop_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSignalDispatcher_Stop_Call) RunAndReturn(run func()) *MockSignalDispatcher_Stop_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockSignalDispatcher creates a new instance of MockSignalDispatcher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSignalDispatcher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSignalDispatcher {
+	mock := &MockSignalDispatcher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,414 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockSignalOutcomeService is an autogenerated mock type for the SignalOutcomeService type
+type MockSignalOutcomeService struct {
+	mock.Mock
+}
+
+type MockSignalOutcomeService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSignalOutcomeService) EXPECT() *MockSignalOutcomeService_Expecter {
+	return &MockSignalOutcomeService_Expecter{mock: &_m.Mock}
+}
+
+// Amend provides a mock function with given fields: ctx, signal
+func (_m *MockSignalOutcomeService) Amend(ctx context.Context, signal *models.Signal) (*models.TrackedSignal, bool, error) {
+	ret := _m.Called(ctx, signal)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Amend")
+	}
+
+	var r0 *models.TrackedSignal
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Signal) (*models.TrackedSignal, bool, error)); ok {
+		return rf(ctx, signal)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Signal) *models.TrackedSignal); ok {
+		r0 = rf(ctx, signal)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TrackedSignal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Signal) bool); ok {
+		r1 = rf(ctx, signal)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *models.Signal) error); ok {
+		r2 = rf(ctx, signal)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockSignalOutcomeService_Amend_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Amend'
+type MockSignalOutcomeService_Amend_Call struct {
+	*mock.Call
+}
+
+// Amend is a helper method to define mock.On call
+//   - ctx context.Context
+//   - signal *models.Signal
+func (_e *MockSignalOutcomeService_Expecter) Amend(ctx interface{}, signal interface{}) *MockSignalOutcomeService_Amend_Call {
+	return &MockSignalOutcomeService_Amend_Call{Call: _e.mock.On("Amend", ctx, signal)}
+}
+
+func (_c *MockSignalOutcomeService_Amend_Call) Run(run func(ctx context.Context, signal *models.Signal)) *MockSignalOutcomeService_Amend_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Signal))
+	})
+	return _c
+}
+
+func (_c *MockSignalOutcomeService_Amend_Call) Return(_a0 *models.TrackedSignal, _a1 bool, _a2 error) *MockSignalOutcomeService_Amend_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockSignalOutcomeService_Amend_Call) RunAndReturn(run func(context.Context, *models.Signal) (*models.TrackedSignal, bool, error)) *MockSignalOutcomeService_Amend_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Cancel provides a mock function with given fields: ctx, signal
+func (_m *MockSignalOutcomeService) Cancel(ctx context.Context, signal *models.Signal) (*models.TrackedSignal, bool, error) {
+	ret := _m.Called(ctx, signal)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cancel")
+	}
+
+	var r0 *models.TrackedSignal
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Signal) (*models.TrackedSignal, bool, error)); ok {
+		return rf(ctx, signal)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Signal) *models.TrackedSignal); ok {
+		r0 = rf(ctx, signal)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TrackedSignal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Signal) bool); ok {
+		r1 = rf(ctx, signal)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *models.Signal) error); ok {
+		r2 = rf(ctx, signal)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockSignalOutcomeService_Cancel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Cancel'
+type MockSignalOutcomeService_Cancel_Call struct {
+	*mock.Call
+}
+
+// Cancel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - signal *models.Signal
+func (_e *MockSignalOutcomeService_Expecter) Cancel(ctx interface{}, signal interface{}) *MockSignalOutcomeService_Cancel_Call {
+	return &MockSignalOutcomeService_Cancel_Call{Call: _e.mock.On("Cancel", ctx, signal)}
+}
+
+func (_c *MockSignalOutcomeService_Cancel_Call) Run(run func(ctx context.Context, signal *models.Signal)) *MockSignalOutcomeService_Cancel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Signal))
+	})
+	return _c
+}
+
+func (_c *MockSignalOutcomeService_Cancel_Call) Return(_a0 *models.TrackedSignal, _a1 bool, _a2 error) *MockSignalOutcomeService_Cancel_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockSignalOutcomeService_Cancel_Call) RunAndReturn(run func(context.Context, *models.Signal) (*models.TrackedSignal, bool, error)) *MockSignalOutcomeService_Cancel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSignals provides a mock function with given fields: ctx, filter, limit
+func (_m *MockSignalOutcomeService) ListSignals(ctx context.Context, filter *models.TrackedSignalFilter, limit int) ([]*models.TrackedSignal, error) {
+	ret := _m.Called(ctx, filter, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSignals")
+	}
+
+	var r0 []*models.TrackedSignal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TrackedSignalFilter, int) ([]*models.TrackedSignal, error)); ok {
+		return rf(ctx, filter, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TrackedSignalFilter, int) []*models.TrackedSignal); ok {
+		r0 = rf(ctx, filter, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.TrackedSignal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.TrackedSignalFilter, int) error); ok {
+		r1 = rf(ctx, filter, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSignalOutcomeService_ListSignals_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSignals'
+type MockSignalOutcomeService_ListSignals_Call struct {
+	*mock.Call
+}
+
+// ListSignals is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter *models.TrackedSignalFilter
+//   - limit int
+func (_e *MockSignalOutcomeService_Expecter) ListSignals(ctx interface{}, filter interface{}, limit interface{}) *MockSignalOutcomeService_ListSignals_Call {
+	return &MockSignalOutcomeService_ListSignals_Call{Call: _e.mock.On("ListSignals", ctx, filter, limit)}
+}
+
+func (_c *MockSignalOutcomeService_ListSignals_Call) Run(run func(ctx context.Context, filter *models.TrackedSignalFilter, limit int)) *MockSignalOutcomeService_ListSignals_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.TrackedSignalFilter), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockSignalOutcomeService_ListSignals_Call) Return(_a0 []*models.TrackedSignal, _a1 error) *MockSignalOutcomeService_ListSignals_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSignalOutcomeService_ListSignals_Call) RunAndReturn(run func(context.Context, *models.TrackedSignalFilter, int) ([]*models.TrackedSignal, error)) *MockSignalOutcomeService_ListSignals_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordPrices provides a mock function with given fields: ctx, prices
+func (_m *MockSignalOutcomeService) RecordPrices(ctx context.Context, prices []*models.SignalPrice) (*models.SignalPriceReport, error) {
+	ret := _m.Called(ctx, prices)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordPrices")
+	}
+
+	var r0 *models.SignalPriceReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.SignalPrice) (*models.SignalPriceReport, error)); ok {
+		return rf(ctx, prices)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.SignalPrice) *models.SignalPriceReport); ok {
+		r0 = rf(ctx, prices)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SignalPriceReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*models.SignalPrice) error); ok {
+		r1 = rf(ctx, prices)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSignalOutcomeService_RecordPrices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordPrices'
+type MockSignalOutcomeService_RecordPrices_Call struct {
+	*mock.Call
+}
+
+// RecordPrices is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prices []*models.SignalPrice
+func (_e *MockSignalOutcomeService_Expecter) RecordPrices(ctx interface{}, prices interface{}) *MockSignalOutcomeService_RecordPrices_Call {
+	return &MockSignalOutcomeService_RecordPrices_Call{Call: _e.mock.On("RecordPrices", ctx, prices)}
+}
+
+func (_c *MockSignalOutcomeService_RecordPrices_Call) Run(run func(ctx context.Context, prices []*models.SignalPrice)) *MockSignalOutcomeService_RecordPrices_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*models.SignalPrice))
+	})
+	return _c
+}
+
+func (_c *MockSignalOutcomeService_RecordPrices_Call) Return(_a0 *models.SignalPriceReport, _a1 error) *MockSignalOutcomeService_RecordPrices_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSignalOutcomeService_RecordPrices_Call) RunAndReturn(run func(context.Context, []*models.SignalPrice) (*models.SignalPriceReport, error)) *MockSignalOutcomeService_RecordPrices_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Stats provides a mock function with given fields: ctx, groupBy, bucketSize
+func (_m *MockSignalOutcomeService) Stats(ctx context.Context, groupBy string, bucketSize float64) (*models.SignalStatsReport, error) {
+	ret := _m.Called(ctx, groupBy, bucketSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stats")
+	}
+
+	var r0 *models.SignalStatsReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64) (*models.SignalStatsReport, error)); ok {
+		return rf(ctx, groupBy, bucketSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64) *models.SignalStatsReport); ok {
+		r0 = rf(ctx, groupBy, bucketSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SignalStatsReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, float64) error); ok {
+		r1 = rf(ctx, groupBy, bucketSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSignalOutcomeService_Stats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stats'
+type MockSignalOutcomeService_Stats_Call struct {
+	*mock.Call
+}
+
+// Stats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupBy string
+//   - bucketSize float64
+func (_e *MockSignalOutcomeService_Expecter) Stats(ctx interface{}, groupBy interface{}, bucketSize interface{}) *MockSignalOutcomeService_Stats_Call {
+	return &MockSignalOutcomeService_Stats_Call{Call: _e.mock.On("Stats", ctx, groupBy, bucketSize)}
+}
+
+func (_c *MockSignalOutcomeService_Stats_Call) Run(run func(ctx context.Context, groupBy string, bucketSize float64)) *MockSignalOutcomeService_Stats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(float64))
+	})
+	return _c
+}
+
+func (_c *MockSignalOutcomeService_Stats_Call) Return(_a0 *models.SignalStatsReport, _a1 error) *MockSignalOutcomeService_Stats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSignalOutcomeService_Stats_Call) RunAndReturn(run func(context.Context, string, float64) (*models.SignalStatsReport, error)) *MockSignalOutcomeService_Stats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Track provides a mock function with given fields: ctx, signal
+func (_m *MockSignalOutcomeService) Track(ctx context.Context, signal *models.Signal) (*models.TrackedSignal, bool, error) {
+	ret := _m.Called(ctx, signal)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Track")
+	}
+
+	var r0 *models.TrackedSignal
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Signal) (*models.TrackedSignal, bool, error)); ok {
+		return rf(ctx, signal)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Signal) *models.TrackedSignal); ok {
+		r0 = rf(ctx, signal)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TrackedSignal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Signal) bool); ok {
+		r1 = rf(ctx, signal)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *models.Signal) error); ok {
+		r2 = rf(ctx, signal)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockSignalOutcomeService_Track_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Track'
+type MockSignalOutcomeService_Track_Call struct {
+	*mock.Call
+}
+
+// Track is a helper method to define mock.On call
+//   - ctx context.Context
+//   - signal *models.Signal
+func (_e *MockSignalOutcomeService_Expecter) Track(ctx interface{}, signal interface{}) *MockSignalOutcomeService_Track_Call {
+	return &MockSignalOutcomeService_Track_Call{Call: _e.mock.On("Track", ctx, signal)}
+}
+
+func (_c *MockSignalOutcomeService_Track_Call) Run(run func(ctx context.Context, signal *models.Signal)) *MockSignalOutcomeService_Track_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Signal))
+	})
+	return _c
+}
+
+func (_c *MockSignalOutcomeService_Track_Call) Return(_a0 *models.TrackedSignal, _a1 bool, _a2 error) *MockSignalOutcomeService_Track_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockSignalOutcomeService_Track_Call) RunAndReturn(run func(context.Context, *models.Signal) (*models.TrackedSignal, bool, error)) *MockSignalOutcomeService_Track_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSignalOutcomeService creates a new instance of MockSignalOutcomeService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSignalOutcomeService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSignalOutcomeService {
+	mock := &MockSignalOutcomeService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
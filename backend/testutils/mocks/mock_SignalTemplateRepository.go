@@ -0,0 +1,260 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockSignalTemplateRepository is an autogenerated mock type for the SignalTemplateRepository type
+type MockSignalTemplateRepository struct {
+	mock.Mock
+}
+
+type MockSignalTemplateRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSignalTemplateRepository) EXPECT() *MockSignalTemplateRepository_Expecter {
+	return &MockSignalTemplateRepository_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, kind, language
+func (_m *MockSignalTemplateRepository) Delete(ctx context.Context, kind string, language string) (bool, error) {
+	ret := _m.Called(ctx, kind, language)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, kind, language)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, kind, language)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, kind, language)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSignalTemplateRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockSignalTemplateRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - kind string
+//   - language string
+func (_e *MockSignalTemplateRepository_Expecter) Delete(ctx interface{}, kind interface{}, language interface{}) *MockSignalTemplateRepository_Delete_Call {
+	return &MockSignalTemplateRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, kind, language)}
+}
+
+func (_c *MockSignalTemplateRepository_Delete_Call) Run(run func(ctx context.Context, kind string, language string)) *MockSignalTemplateRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSignalTemplateRepository_Delete_Call) Return(_a0 bool, _a1 error) *MockSignalTemplateRepository_Delete_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSignalTemplateRepository_Delete_Call) RunAndReturn(run func(context.Context, string, string) (bool, error)) *MockSignalTemplateRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, kind, language
+func (_m *MockSignalTemplateRepository) Get(ctx context.Context, kind string, language string) (*models.SignalTemplate, error) {
+	ret := _m.Called(ctx, kind, language)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *models.SignalTemplate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.SignalTemplate, error)); ok {
+		return rf(ctx, kind, language)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.SignalTemplate); ok {
+		r0 = rf(ctx, kind, language)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SignalTemplate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, kind, language)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSignalTemplateRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockSignalTemplateRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - kind string
+//   - language string
+func (_e *MockSignalTemplateRepository_Expecter) Get(ctx interface{}, kind interface{}, language interface{}) *MockSignalTemplateRepository_Get_Call {
+	return &MockSignalTemplateRepository_Get_Call{Call: _e.mock.On("Get", ctx, kind, language)}
+}
+
+func (_c *MockSignalTemplateRepository_Get_Call) Run(run func(ctx context.Context, kind string, language string)) *MockSignalTemplateRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSignalTemplateRepository_Get_Call) Return(_a0 *models.SignalTemplate, _a1 error) *MockSignalTemplateRepository_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSignalTemplateRepository_Get_Call) RunAndReturn(run func(context.Context, string, string) (*models.SignalTemplate, error)) *MockSignalTemplateRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *MockSignalTemplateRepository) List(ctx context.Context) ([]*models.SignalTemplate, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.SignalTemplate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.SignalTemplate, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.SignalTemplate); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SignalTemplate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSignalTemplateRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockSignalTemplateRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSignalTemplateRepository_Expecter) List(ctx interface{}) *MockSignalTemplateRepository_List_Call {
+	return &MockSignalTemplateRepository_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *MockSignalTemplateRepository_List_Call) Run(run func(ctx context.Context)) *MockSignalTemplateRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSignalTemplateRepository_List_Call) Return(_a0 []*models.SignalTemplate, _a1 error) *MockSignalTemplateRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSignalTemplateRepository_List_Call) RunAndReturn(run func(context.Context) ([]*models.SignalTemplate, error)) *MockSignalTemplateRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function with given fields: ctx, template
+func (_m *MockSignalTemplateRepository) Save(ctx context.Context, template *models.SignalTemplate) error {
+	ret := _m.Called(ctx, template)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SignalTemplate) error); ok {
+		r0 = rf(ctx, template)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSignalTemplateRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockSignalTemplateRepository_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - template *models.SignalTemplate
+func (_e *MockSignalTemplateRepository_Expecter) Save(ctx interface{}, template interface{}) *MockSignalTemplateRepository_Save_Call {
+	return &MockSignalTemplateRepository_Save_Call{Call: _e.mock.On("Save", ctx, template)}
+}
+
+func (_c *MockSignalTemplateRepository_Save_Call) Run(run func(ctx context.Context, template *models.SignalTemplate)) *MockSignalTemplateRepository_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.SignalTemplate))
+	})
+	return _c
+}
+
+func (_c *MockSignalTemplateRepository_Save_Call) Return(_a0 error) *MockSignalTemplateRepository_Save_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSignalTemplateRepository_Save_Call) RunAndReturn(run func(context.Context, *models.SignalTemplate) error) *MockSignalTemplateRepository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSignalTemplateRepository creates a new instance of MockSignalTemplateRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSignalTemplateRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSignalTemplateRepository {
+	mock := &MockSignalTemplateRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
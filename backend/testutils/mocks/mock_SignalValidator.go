@@ -0,0 +1,83 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockSignalValidator is an autogenerated mock type for the SignalValidator type
+type MockSignalValidator struct {
+	mock.Mock
+}
+
+type MockSignalValidator_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSignalValidator) EXPECT() *MockSignalValidator_Expecter {
+	return &MockSignalValidator_Expecter{mock: &_m.Mock}
+}
+
+// Validate provides a mock function with given fields: signal
+func (_m *MockSignalValidator) Validate(signal *models.Signal) []models.SignalViolation {
+	ret := _m.Called(signal)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Validate")
+	}
+
+	var r0 []models.SignalViolation
+	if rf, ok := ret.Get(0).(func(*models.Signal) []models.SignalViolation); ok {
+		r0 = rf(signal)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SignalViolation)
+		}
+	}
+
+	return r0
+}
+
+// MockSignalValidator_Validate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Validate'
+type MockSignalValidator_Validate_Call struct {
+	*mock.Call
+}
+
+// Validate is a helper method to define mock.On call
+//   - signal *models.Signal
+func (_e *MockSignalValidator_Expecter) Validate(signal interface{}) *MockSignalValidator_Validate_Call {
+	return &MockSignalValidator_Validate_Call{Call: _e.mock.On("Validate", signal)}
+}
+
+func (_c *MockSignalValidator_Validate_Call) Run(run func(signal *models.Signal)) *MockSignalValidator_Validate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Signal))
+	})
+	return _c
+}
+
+func (_c *MockSignalValidator_Validate_Call) Return(_a0 []models.SignalViolation) *MockSignalValidator_Validate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSignalValidator_Validate_Call) RunAndReturn(run func(*models.Signal) []models.SignalViolation) *MockSignalValidator_Validate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSignalValidator creates a new instance of MockSignalValidator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSignalValidator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSignalValidator {
+	mock := &MockSignalValidator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
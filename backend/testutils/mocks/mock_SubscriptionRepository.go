@@ -0,0 +1,389 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockSubscriptionRepository is an autogenerated mock type for the SubscriptionRepository type
+type MockSubscriptionRepository struct {
+	mock.Mock
+}
+
+type MockSubscriptionRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSubscriptionRepository) EXPECT() *MockSubscriptionRepository_Expecter {
+	return &MockSubscriptionRepository_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *MockSubscriptionRepository) Delete(ctx context.Context, id uuid.UUID) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockSubscriptionRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockSubscriptionRepository_Expecter) Delete(ctx interface{}, id interface{}) *MockSubscriptionRepository_Delete_Call {
+	return &MockSubscriptionRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *MockSubscriptionRepository_Delete_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockSubscriptionRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_Delete_Call) Return(_a0 bool, _a1 error) *MockSubscriptionRepository_Delete_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_Delete_Call) RunAndReturn(run func(context.Context, uuid.UUID) (bool, error)) *MockSubscriptionRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteByUser provides a mock function with given fields: ctx, userID, ticker
+func (_m *MockSubscriptionRepository) DeleteByUser(ctx context.Context, userID uuid.UUID, ticker *string) (int, error) {
+	ret := _m.Called(ctx, userID, ticker)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByUser")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *string) (int, error)); ok {
+		return rf(ctx, userID, ticker)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *string) int); ok {
+		r0 = rf(ctx, userID, ticker)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *string) error); ok {
+		r1 = rf(ctx, userID, ticker)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionRepository_DeleteByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByUser'
+type MockSubscriptionRepository_DeleteByUser_Call struct {
+	*mock.Call
+}
+
+// DeleteByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - ticker *string
+func (_e *MockSubscriptionRepository_Expecter) DeleteByUser(ctx interface{}, userID interface{}, ticker interface{}) *MockSubscriptionRepository_DeleteByUser_Call {
+	return &MockSubscriptionRepository_DeleteByUser_Call{Call: _e.mock.On("DeleteByUser", ctx, userID, ticker)}
+}
+
+func (_c *MockSubscriptionRepository_DeleteByUser_Call) Run(run func(ctx context.Context, userID uuid.UUID, ticker *string)) *MockSubscriptionRepository_DeleteByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*string))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_DeleteByUser_Call) Return(_a0 int, _a1 error) *MockSubscriptionRepository_DeleteByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_DeleteByUser_Call) RunAndReturn(run func(context.Context, uuid.UUID, *string) (int, error)) *MockSubscriptionRepository_DeleteByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExcludeTicker provides a mock function with given fields: ctx, userID, ticker
+func (_m *MockSubscriptionRepository) ExcludeTicker(ctx context.Context, userID uuid.UUID, ticker string) (bool, error) {
+	ret := _m.Called(ctx, userID, ticker)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExcludeTicker")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (bool, error)); ok {
+		return rf(ctx, userID, ticker)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) bool); ok {
+		r0 = rf(ctx, userID, ticker)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, userID, ticker)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionRepository_ExcludeTicker_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExcludeTicker'
+type MockSubscriptionRepository_ExcludeTicker_Call struct {
+	*mock.Call
+}
+
+// ExcludeTicker is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - ticker string
+func (_e *MockSubscriptionRepository_Expecter) ExcludeTicker(ctx interface{}, userID interface{}, ticker interface{}) *MockSubscriptionRepository_ExcludeTicker_Call {
+	return &MockSubscriptionRepository_ExcludeTicker_Call{Call: _e.mock.On("ExcludeTicker", ctx, userID, ticker)}
+}
+
+func (_c *MockSubscriptionRepository_ExcludeTicker_Call) Run(run func(ctx context.Context, userID uuid.UUID, ticker string)) *MockSubscriptionRepository_ExcludeTicker_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_ExcludeTicker_Call) Return(_a0 bool, _a1 error) *MockSubscriptionRepository_ExcludeTicker_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_ExcludeTicker_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) (bool, error)) *MockSubscriptionRepository_ExcludeTicker_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindRecipients provides a mock function with given fields: ctx, signal
+func (_m *MockSubscriptionRepository) FindRecipients(ctx context.Context, signal *models.Signal) ([]*models.User, error) {
+	ret := _m.Called(ctx, signal)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRecipients")
+	}
+
+	var r0 []*models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Signal) ([]*models.User, error)); ok {
+		return rf(ctx, signal)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Signal) []*models.User); ok {
+		r0 = rf(ctx, signal)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Signal) error); ok {
+		r1 = rf(ctx, signal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionRepository_FindRecipients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindRecipients'
+type MockSubscriptionRepository_FindRecipients_Call struct {
+	*mock.Call
+}
+
+// FindRecipients is a helper method to define mock.On call
+//   - ctx context.Context
+//   - signal *models.Signal
+func (_e *MockSubscriptionRepository_Expecter) FindRecipients(ctx interface{}, signal interface{}) *MockSubscriptionRepository_FindRecipients_Call {
+	return &MockSubscriptionRepository_FindRecipients_Call{Call: _e.mock.On("FindRecipients", ctx, signal)}
+}
+
+func (_c *MockSubscriptionRepository_FindRecipients_Call) Run(run func(ctx context.Context, signal *models.Signal)) *MockSubscriptionRepository_FindRecipients_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Signal))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_FindRecipients_Call) Return(_a0 []*models.User, _a1 error) *MockSubscriptionRepository_FindRecipients_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_FindRecipients_Call) RunAndReturn(run func(context.Context, *models.Signal) ([]*models.User, error)) *MockSubscriptionRepository_FindRecipients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByUser provides a mock function with given fields: ctx, userID
+func (_m *MockSubscriptionRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.SignalSubscription, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByUser")
+	}
+
+	var r0 []*models.SignalSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*models.SignalSubscription, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*models.SignalSubscription); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SignalSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionRepository_ListByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByUser'
+type MockSubscriptionRepository_ListByUser_Call struct {
+	*mock.Call
+}
+
+// ListByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockSubscriptionRepository_Expecter) ListByUser(ctx interface{}, userID interface{}) *MockSubscriptionRepository_ListByUser_Call {
+	return &MockSubscriptionRepository_ListByUser_Call{Call: _e.mock.On("ListByUser", ctx, userID)}
+}
+
+func (_c *MockSubscriptionRepository_ListByUser_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockSubscriptionRepository_ListByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_ListByUser_Call) Return(_a0 []*models.SignalSubscription, _a1 error) *MockSubscriptionRepository_ListByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_ListByUser_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*models.SignalSubscription, error)) *MockSubscriptionRepository_ListByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function with given fields: ctx, subscription
+func (_m *MockSubscriptionRepository) Upsert(ctx context.Context, subscription *models.SignalSubscription) (*models.SignalSubscription, error) {
+	ret := _m.Called(ctx, subscription)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 *models.SignalSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SignalSubscription) (*models.SignalSubscription, error)); ok {
+		return rf(ctx, subscription)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SignalSubscription) *models.SignalSubscription); ok {
+		r0 = rf(ctx, subscription)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SignalSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.SignalSubscription) error); ok {
+		r1 = rf(ctx, subscription)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionRepository_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type MockSubscriptionRepository_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscription *models.SignalSubscription
+func (_e *MockSubscriptionRepository_Expecter) Upsert(ctx interface{}, subscription interface{}) *MockSubscriptionRepository_Upsert_Call {
+	return &MockSubscriptionRepository_Upsert_Call{Call: _e.mock.On("Upsert", ctx, subscription)}
+}
+
+func (_c *MockSubscriptionRepository_Upsert_Call) Run(run func(ctx context.Context, subscription *models.SignalSubscription)) *MockSubscriptionRepository_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.SignalSubscription))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_Upsert_Call) Return(_a0 *models.SignalSubscription, _a1 error) *MockSubscriptionRepository_Upsert_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_Upsert_Call) RunAndReturn(run func(context.Context, *models.SignalSubscription) (*models.SignalSubscription, error)) *MockSubscriptionRepository_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSubscriptionRepository creates a new instance of MockSubscriptionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSubscriptionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSubscriptionRepository {
+	mock := &MockSubscriptionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
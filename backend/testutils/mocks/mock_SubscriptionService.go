@@ -0,0 +1,380 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockSubscriptionService is an autogenerated mock type for the SubscriptionService type
+type MockSubscriptionService struct {
+	mock.Mock
+}
+
+type MockSubscriptionService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSubscriptionService) EXPECT() *MockSubscriptionService_Expecter {
+	return &MockSubscriptionService_Expecter{mock: &_m.Mock}
+}
+
+// DeleteSubscription provides a mock function with given fields: ctx, id
+func (_m *MockSubscriptionService) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSubscription")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionService_DeleteSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSubscription'
+type MockSubscriptionService_DeleteSubscription_Call struct {
+	*mock.Call
+}
+
+// DeleteSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockSubscriptionService_Expecter) DeleteSubscription(ctx interface{}, id interface{}) *MockSubscriptionService_DeleteSubscription_Call {
+	return &MockSubscriptionService_DeleteSubscription_Call{Call: _e.mock.On("DeleteSubscription", ctx, id)}
+}
+
+func (_c *MockSubscriptionService_DeleteSubscription_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockSubscriptionService_DeleteSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_DeleteSubscription_Call) Return(_a0 error) *MockSubscriptionService_DeleteSubscription_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionService_DeleteSubscription_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockSubscriptionService_DeleteSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindRecipients provides a mock function with given fields: ctx, signal
+func (_m *MockSubscriptionService) FindRecipients(ctx context.Context, signal *models.Signal) ([]*models.User, error) {
+	ret := _m.Called(ctx, signal)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRecipients")
+	}
+
+	var r0 []*models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Signal) ([]*models.User, error)); ok {
+		return rf(ctx, signal)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Signal) []*models.User); ok {
+		r0 = rf(ctx, signal)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Signal) error); ok {
+		r1 = rf(ctx, signal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_FindRecipients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindRecipients'
+type MockSubscriptionService_FindRecipients_Call struct {
+	*mock.Call
+}
+
+// FindRecipients is a helper method to define mock.On call
+//   - ctx context.Context
+//   - signal *models.Signal
+func (_e *MockSubscriptionService_Expecter) FindRecipients(ctx interface{}, signal interface{}) *MockSubscriptionService_FindRecipients_Call {
+	return &MockSubscriptionService_FindRecipients_Call{Call: _e.mock.On("FindRecipients", ctx, signal)}
+}
+
+func (_c *MockSubscriptionService_FindRecipients_Call) Run(run func(ctx context.Context, signal *models.Signal)) *MockSubscriptionService_FindRecipients_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Signal))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_FindRecipients_Call) Return(_a0 []*models.User, _a1 error) *MockSubscriptionService_FindRecipients_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_FindRecipients_Call) RunAndReturn(run func(context.Context, *models.Signal) ([]*models.User, error)) *MockSubscriptionService_FindRecipients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleCommand provides a mock function with given fields: ctx, user, text
+func (_m *MockSubscriptionService) HandleCommand(ctx context.Context, user *models.User, text string) (string, bool) {
+	ret := _m.Called(ctx, user, text)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleCommand")
+	}
+
+	var r0 string
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User, string) (string, bool)); ok {
+		return rf(ctx, user, text)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User, string) string); ok {
+		r0 = rf(ctx, user, text)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.User, string) bool); ok {
+		r1 = rf(ctx, user, text)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_HandleCommand_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleCommand'
+type MockSubscriptionService_HandleCommand_Call struct {
+	*mock.Call
+}
+
+// HandleCommand is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *models.User
+//   - text string
+func (_e *MockSubscriptionService_Expecter) HandleCommand(ctx interface{}, user interface{}, text interface{}) *MockSubscriptionService_HandleCommand_Call {
+	return &MockSubscriptionService_HandleCommand_Call{Call: _e.mock.On("HandleCommand", ctx, user, text)}
+}
+
+func (_c *MockSubscriptionService_HandleCommand_Call) Run(run func(ctx context.Context, user *models.User, text string)) *MockSubscriptionService_HandleCommand_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.User), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_HandleCommand_Call) Return(_a0 string, _a1 bool) *MockSubscriptionService_HandleCommand_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_HandleCommand_Call) RunAndReturn(run func(context.Context, *models.User, string) (string, bool)) *MockSubscriptionService_HandleCommand_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSubscriptions provides a mock function with given fields: ctx, userID
+func (_m *MockSubscriptionService) ListSubscriptions(ctx context.Context, userID uuid.UUID) ([]*models.SignalSubscription, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSubscriptions")
+	}
+
+	var r0 []*models.SignalSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*models.SignalSubscription, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*models.SignalSubscription); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SignalSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_ListSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSubscriptions'
+type MockSubscriptionService_ListSubscriptions_Call struct {
+	*mock.Call
+}
+
+// ListSubscriptions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockSubscriptionService_Expecter) ListSubscriptions(ctx interface{}, userID interface{}) *MockSubscriptionService_ListSubscriptions_Call {
+	return &MockSubscriptionService_ListSubscriptions_Call{Call: _e.mock.On("ListSubscriptions", ctx, userID)}
+}
+
+func (_c *MockSubscriptionService_ListSubscriptions_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockSubscriptionService_ListSubscriptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_ListSubscriptions_Call) Return(_a0 []*models.SignalSubscription, _a1 error) *MockSubscriptionService_ListSubscriptions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_ListSubscriptions_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*models.SignalSubscription, error)) *MockSubscriptionService_ListSubscriptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Subscribe provides a mock function with given fields: ctx, userID, req
+func (_m *MockSubscriptionService) Subscribe(ctx context.Context, userID uuid.UUID, req *models.SubscribeRequest) (*models.SignalSubscription, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 *models.SignalSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *models.SubscribeRequest) (*models.SignalSubscription, error)); ok {
+		return rf(ctx, userID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *models.SubscribeRequest) *models.SignalSubscription); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SignalSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *models.SubscribeRequest) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_Subscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscribe'
+type MockSubscriptionService_Subscribe_Call struct {
+	*mock.Call
+}
+
+// Subscribe is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - req *models.SubscribeRequest
+func (_e *MockSubscriptionService_Expecter) Subscribe(ctx interface{}, userID interface{}, req interface{}) *MockSubscriptionService_Subscribe_Call {
+	return &MockSubscriptionService_Subscribe_Call{Call: _e.mock.On("Subscribe", ctx, userID, req)}
+}
+
+func (_c *MockSubscriptionService_Subscribe_Call) Run(run func(ctx context.Context, userID uuid.UUID, req *models.SubscribeRequest)) *MockSubscriptionService_Subscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*models.SubscribeRequest))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_Subscribe_Call) Return(_a0 *models.SignalSubscription, _a1 error) *MockSubscriptionService_Subscribe_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_Subscribe_Call) RunAndReturn(run func(context.Context, uuid.UUID, *models.SubscribeRequest) (*models.SignalSubscription, error)) *MockSubscriptionService_Subscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unsubscribe provides a mock function with given fields: ctx, userID, ticker
+func (_m *MockSubscriptionService) Unsubscribe(ctx context.Context, userID uuid.UUID, ticker string) (int, error) {
+	ret := _m.Called(ctx, userID, ticker)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unsubscribe")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (int, error)); ok {
+		return rf(ctx, userID, ticker)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) int); ok {
+		r0 = rf(ctx, userID, ticker)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, userID, ticker)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionService_Unsubscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unsubscribe'
+type MockSubscriptionService_Unsubscribe_Call struct {
+	*mock.Call
+}
+
+// Unsubscribe is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - ticker string
+func (_e *MockSubscriptionService_Expecter) Unsubscribe(ctx interface{}, userID interface{}, ticker interface{}) *MockSubscriptionService_Unsubscribe_Call {
+	return &MockSubscriptionService_Unsubscribe_Call{Call: _e.mock.On("Unsubscribe", ctx, userID, ticker)}
+}
+
+func (_c *MockSubscriptionService_Unsubscribe_Call) Run(run func(ctx context.Context, userID uuid.UUID, ticker string)) *MockSubscriptionService_Unsubscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_Unsubscribe_Call) Return(_a0 int, _a1 error) *MockSubscriptionService_Unsubscribe_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionService_Unsubscribe_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) (int, error)) *MockSubscriptionService_Unsubscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSubscriptionService creates a new instance of MockSubscriptionService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSubscriptionService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSubscriptionService {
+	mock := &MockSubscriptionService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,268 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockTakeoverRepository is an autogenerated mock type for the TakeoverRepository type
+type MockTakeoverRepository struct {
+	mock.Mock
+}
+
+type MockTakeoverRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTakeoverRepository) EXPECT() *MockTakeoverRepository_Expecter {
+	return &MockTakeoverRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, takeover
+func (_m *MockTakeoverRepository) Create(ctx context.Context, takeover *models.ConversationTakeover) (bool, error) {
+	ret := _m.Called(ctx, takeover)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ConversationTakeover) (bool, error)); ok {
+		return rf(ctx, takeover)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ConversationTakeover) bool); ok {
+		r0 = rf(ctx, takeover)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.ConversationTakeover) error); ok {
+		r1 = rf(ctx, takeover)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTakeoverRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockTakeoverRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - takeover *models.ConversationTakeover
+func (_e *MockTakeoverRepository_Expecter) Create(ctx interface{}, takeover interface{}) *MockTakeoverRepository_Create_Call {
+	return &MockTakeoverRepository_Create_Call{Call: _e.mock.On("Create", ctx, takeover)}
+}
+
+func (_c *MockTakeoverRepository_Create_Call) Run(run func(ctx context.Context, takeover *models.ConversationTakeover)) *MockTakeoverRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.ConversationTakeover))
+	})
+	return _c
+}
+
+func (_c *MockTakeoverRepository_Create_Call) Return(_a0 bool, _a1 error) *MockTakeoverRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTakeoverRepository_Create_Call) RunAndReturn(run func(context.Context, *models.ConversationTakeover) (bool, error)) *MockTakeoverRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, phone
+func (_m *MockTakeoverRepository) Delete(ctx context.Context, phone string) (bool, error) {
+	ret := _m.Called(ctx, phone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, phone)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, phone)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, phone)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTakeoverRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockTakeoverRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - phone string
+func (_e *MockTakeoverRepository_Expecter) Delete(ctx interface{}, phone interface{}) *MockTakeoverRepository_Delete_Call {
+	return &MockTakeoverRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, phone)}
+}
+
+func (_c *MockTakeoverRepository_Delete_Call) Run(run func(ctx context.Context, phone string)) *MockTakeoverRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockTakeoverRepository_Delete_Call) Return(_a0 bool, _a1 error) *MockTakeoverRepository_Delete_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTakeoverRepository_Delete_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *MockTakeoverRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, phone
+func (_m *MockTakeoverRepository) Get(ctx context.Context, phone string) (*models.ConversationTakeover, error) {
+	ret := _m.Called(ctx, phone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *models.ConversationTakeover
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.ConversationTakeover, error)); ok {
+		return rf(ctx, phone)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.ConversationTakeover); ok {
+		r0 = rf(ctx, phone)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ConversationTakeover)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, phone)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTakeoverRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockTakeoverRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - phone string
+func (_e *MockTakeoverRepository_Expecter) Get(ctx interface{}, phone interface{}) *MockTakeoverRepository_Get_Call {
+	return &MockTakeoverRepository_Get_Call{Call: _e.mock.On("Get", ctx, phone)}
+}
+
+func (_c *MockTakeoverRepository_Get_Call) Run(run func(ctx context.Context, phone string)) *MockTakeoverRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockTakeoverRepository_Get_Call) Return(_a0 *models.ConversationTakeover, _a1 error) *MockTakeoverRepository_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTakeoverRepository_Get_Call) RunAndReturn(run func(context.Context, string) (*models.ConversationTakeover, error)) *MockTakeoverRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *MockTakeoverRepository) List(ctx context.Context) ([]*models.ConversationTakeover, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.ConversationTakeover
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.ConversationTakeover, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.ConversationTakeover); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ConversationTakeover)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTakeoverRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockTakeoverRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockTakeoverRepository_Expecter) List(ctx interface{}) *MockTakeoverRepository_List_Call {
+	return &MockTakeoverRepository_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *MockTakeoverRepository_List_Call) Run(run func(ctx context.Context)) *MockTakeoverRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockTakeoverRepository_List_Call) Return(_a0 []*models.ConversationTakeover, _a1 error) *MockTakeoverRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTakeoverRepository_List_Call) RunAndReturn(run func(context.Context) ([]*models.ConversationTakeover, error)) *MockTakeoverRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTakeoverRepository creates a new instance of MockTakeoverRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTakeoverRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTakeoverRepository {
+	mock := &MockTakeoverRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
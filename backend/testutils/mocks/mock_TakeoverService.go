@@ -0,0 +1,319 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockTakeoverService is an autogenerated mock type for the TakeoverService type
+type MockTakeoverService struct {
+	mock.Mock
+}
+
+type MockTakeoverService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTakeoverService) EXPECT() *MockTakeoverService_Expecter {
+	return &MockTakeoverService_Expecter{mock: &_m.Mock}
+}
+
+// CheckAgent provides a mock function with given fields: ctx, phone, agent
+func (_m *MockTakeoverService) CheckAgent(ctx context.Context, phone string, agent *models.Principal) (*models.ConversationTakeover, error) {
+	ret := _m.Called(ctx, phone, agent)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckAgent")
+	}
+
+	var r0 *models.ConversationTakeover
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.Principal) (*models.ConversationTakeover, error)); ok {
+		return rf(ctx, phone, agent)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.Principal) *models.ConversationTakeover); ok {
+		r0 = rf(ctx, phone, agent)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ConversationTakeover)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.Principal) error); ok {
+		r1 = rf(ctx, phone, agent)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTakeoverService_CheckAgent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckAgent'
+type MockTakeoverService_CheckAgent_Call struct {
+	*mock.Call
+}
+
+// CheckAgent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - phone string
+//   - agent *models.Principal
+func (_e *MockTakeoverService_Expecter) CheckAgent(ctx interface{}, phone interface{}, agent interface{}) *MockTakeoverService_CheckAgent_Call {
+	return &MockTakeoverService_CheckAgent_Call{Call: _e.mock.On("CheckAgent", ctx, phone, agent)}
+}
+
+func (_c *MockTakeoverService_CheckAgent_Call) Run(run func(ctx context.Context, phone string, agent *models.Principal)) *MockTakeoverService_CheckAgent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.Principal))
+	})
+	return _c
+}
+
+func (_c *MockTakeoverService_CheckAgent_Call) Return(_a0 *models.ConversationTakeover, _a1 error) *MockTakeoverService_CheckAgent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTakeoverService_CheckAgent_Call) RunAndReturn(run func(context.Context, string, *models.Principal) (*models.ConversationTakeover, error)) *MockTakeoverService_CheckAgent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsTakenOver provides a mock function with given fields: ctx, phone
+func (_m *MockTakeoverService) IsTakenOver(ctx context.Context, phone string) (bool, error) {
+	ret := _m.Called(ctx, phone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsTakenOver")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, phone)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, phone)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, phone)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTakeoverService_IsTakenOver_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsTakenOver'
+type MockTakeoverService_IsTakenOver_Call struct {
+	*mock.Call
+}
+
+// IsTakenOver is a helper method to define mock.On call
+//   - ctx context.Context
+//   - phone string
+func (_e *MockTakeoverService_Expecter) IsTakenOver(ctx interface{}, phone interface{}) *MockTakeoverService_IsTakenOver_Call {
+	return &MockTakeoverService_IsTakenOver_Call{Call: _e.mock.On("IsTakenOver", ctx, phone)}
+}
+
+func (_c *MockTakeoverService_IsTakenOver_Call) Run(run func(ctx context.Context, phone string)) *MockTakeoverService_IsTakenOver_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockTakeoverService_IsTakenOver_Call) Return(_a0 bool, _a1 error) *MockTakeoverService_IsTakenOver_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTakeoverService_IsTakenOver_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *MockTakeoverService_IsTakenOver_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *MockTakeoverService) List(ctx context.Context) ([]*models.ConversationTakeover, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.ConversationTakeover
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.ConversationTakeover, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.ConversationTakeover); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ConversationTakeover)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTakeoverService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockTakeoverService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockTakeoverService_Expecter) List(ctx interface{}) *MockTakeoverService_List_Call {
+	return &MockTakeoverService_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *MockTakeoverService_List_Call) Run(run func(ctx context.Context)) *MockTakeoverService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockTakeoverService_List_Call) Return(_a0 []*models.ConversationTakeover, _a1 error) *MockTakeoverService_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTakeoverService_List_Call) RunAndReturn(run func(context.Context) ([]*models.ConversationTakeover, error)) *MockTakeoverService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Release provides a mock function with given fields: ctx, phone
+func (_m *MockTakeoverService) Release(ctx context.Context, phone string) error {
+	ret := _m.Called(ctx, phone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Release")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, phone)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTakeoverService_Release_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Release'
+type MockTakeoverService_Release_Call struct {
+	*mock.Call
+}
+
+// Release is a helper method to define mock.On call
+//   - ctx context.Context
+//   - phone string
+func (_e *MockTakeoverService_Expecter) Release(ctx interface{}, phone interface{}) *MockTakeoverService_Release_Call {
+	return &MockTakeoverService_Release_Call{Call: _e.mock.On("Release", ctx, phone)}
+}
+
+func (_c *MockTakeoverService_Release_Call) Run(run func(ctx context.Context, phone string)) *MockTakeoverService_Release_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockTakeoverService_Release_Call) Return(_a0 error) *MockTakeoverService_Release_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTakeoverService_Release_Call) RunAndReturn(run func(context.Context, string) error) *MockTakeoverService_Release_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Start provides a mock function with given fields: ctx, phone, agent
+func (_m *MockTakeoverService) Start(ctx context.Context, phone string, agent *models.Principal) (*models.ConversationTakeover, error) {
+	ret := _m.Called(ctx, phone, agent)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Start")
+	}
+
+	var r0 *models.ConversationTakeover
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.Principal) (*models.ConversationTakeover, error)); ok {
+		return rf(ctx, phone, agent)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.Principal) *models.ConversationTakeover); ok {
+		r0 = rf(ctx, phone, agent)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ConversationTakeover)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.Principal) error); ok {
+		r1 = rf(ctx, phone, agent)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTakeoverService_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
+type MockTakeoverService_Start_Call struct {
+	*mock.Call
+}
+
+// Start is a helper method to define mock.On call
+//   - ctx context.Context
+//   - phone string
+//   - agent *models.Principal
+func (_e *MockTakeoverService_Expecter) Start(ctx interface{}, phone interface{}, agent interface{}) *MockTakeoverService_Start_Call {
+	return &MockTakeoverService_Start_Call{Call: _e.mock.On("Start", ctx, phone, agent)}
+}
+
+func (_c *MockTakeoverService_Start_Call) Run(run func(ctx context.Context, phone string, agent *models.Principal)) *MockTakeoverService_Start_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.Principal))
+	})
+	return _c
+}
+
+func (_c *MockTakeoverService_Start_Call) Return(_a0 *models.ConversationTakeover, _a1 error) *MockTakeoverService_Start_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTakeoverService_Start_Call) RunAndReturn(run func(context.Context, string, *models.Principal) (*models.ConversationTakeover, error)) *MockTakeoverService_Start_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTakeoverService creates a new instance of MockTakeoverService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTakeoverService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTakeoverService {
+	mock := &MockTakeoverService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,617 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockTrackedSignalRepository is an autogenerated mock type for the TrackedSignalRepository type
+type MockTrackedSignalRepository struct {
+	mock.Mock
+}
+
+type MockTrackedSignalRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTrackedSignalRepository) EXPECT() *MockTrackedSignalRepository_Expecter {
+	return &MockTrackedSignalRepository_Expecter{mock: &_m.Mock}
+}
+
+// Amend provides a mock function with given fields: ctx, tracked, signal
+func (_m *MockTrackedSignalRepository) Amend(ctx context.Context, tracked *models.TrackedSignal, signal *models.Signal) (bool, error) {
+	ret := _m.Called(ctx, tracked, signal)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Amend")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TrackedSignal, *models.Signal) (bool, error)); ok {
+		return rf(ctx, tracked, signal)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TrackedSignal, *models.Signal) bool); ok {
+		r0 = rf(ctx, tracked, signal)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.TrackedSignal, *models.Signal) error); ok {
+		r1 = rf(ctx, tracked, signal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTrackedSignalRepository_Amend_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Amend'
+type MockTrackedSignalRepository_Amend_Call struct {
+	*mock.Call
+}
+
+// Amend is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tracked *models.TrackedSignal
+//   - signal *models.Signal
+func (_e *MockTrackedSignalRepository_Expecter) Amend(ctx interface{}, tracked interface{}, signal interface{}) *MockTrackedSignalRepository_Amend_Call {
+	return &MockTrackedSignalRepository_Amend_Call{Call: _e.mock.On("Amend", ctx, tracked, signal)}
+}
+
+func (_c *MockTrackedSignalRepository_Amend_Call) Run(run func(ctx context.Context, tracked *models.TrackedSignal, signal *models.Signal)) *MockTrackedSignalRepository_Amend_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.TrackedSignal), args[2].(*models.Signal))
+	})
+	return _c
+}
+
+func (_c *MockTrackedSignalRepository_Amend_Call) Return(_a0 bool, _a1 error) *MockTrackedSignalRepository_Amend_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTrackedSignalRepository_Amend_Call) RunAndReturn(run func(context.Context, *models.TrackedSignal, *models.Signal) (bool, error)) *MockTrackedSignalRepository_Amend_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Cancel provides a mock function with given fields: ctx, id
+func (_m *MockTrackedSignalRepository) Cancel(ctx context.Context, id uuid.UUID) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cancel")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTrackedSignalRepository_Cancel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Cancel'
+type MockTrackedSignalRepository_Cancel_Call struct {
+	*mock.Call
+}
+
+// Cancel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockTrackedSignalRepository_Expecter) Cancel(ctx interface{}, id interface{}) *MockTrackedSignalRepository_Cancel_Call {
+	return &MockTrackedSignalRepository_Cancel_Call{Call: _e.mock.On("Cancel", ctx, id)}
+}
+
+func (_c *MockTrackedSignalRepository_Cancel_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockTrackedSignalRepository_Cancel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockTrackedSignalRepository_Cancel_Call) Return(_a0 bool, _a1 error) *MockTrackedSignalRepository_Cancel_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTrackedSignalRepository_Cancel_Call) RunAndReturn(run func(context.Context, uuid.UUID) (bool, error)) *MockTrackedSignalRepository_Cancel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, tracked, signal
+func (_m *MockTrackedSignalRepository) Create(ctx context.Context, tracked *models.TrackedSignal, signal *models.Signal) (bool, error) {
+	ret := _m.Called(ctx, tracked, signal)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TrackedSignal, *models.Signal) (bool, error)); ok {
+		return rf(ctx, tracked, signal)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TrackedSignal, *models.Signal) bool); ok {
+		r0 = rf(ctx, tracked, signal)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.TrackedSignal, *models.Signal) error); ok {
+		r1 = rf(ctx, tracked, signal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTrackedSignalRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockTrackedSignalRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tracked *models.TrackedSignal
+//   - signal *models.Signal
+func (_e *MockTrackedSignalRepository_Expecter) Create(ctx interface{}, tracked interface{}, signal interface{}) *MockTrackedSignalRepository_Create_Call {
+	return &MockTrackedSignalRepository_Create_Call{Call: _e.mock.On("Create", ctx, tracked, signal)}
+}
+
+func (_c *MockTrackedSignalRepository_Create_Call) Run(run func(ctx context.Context, tracked *models.TrackedSignal, signal *models.Signal)) *MockTrackedSignalRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.TrackedSignal), args[2].(*models.Signal))
+	})
+	return _c
+}
+
+func (_c *MockTrackedSignalRepository_Create_Call) Return(_a0 bool, _a1 error) *MockTrackedSignalRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTrackedSignalRepository_Create_Call) RunAndReturn(run func(context.Context, *models.TrackedSignal, *models.Signal) (bool, error)) *MockTrackedSignalRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByIdentity provides a mock function with given fields: ctx, ticker, lastDate, direction
+func (_m *MockTrackedSignalRepository) GetByIdentity(ctx context.Context, ticker string, lastDate string, direction string) (*models.TrackedSignal, error) {
+	ret := _m.Called(ctx, ticker, lastDate, direction)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIdentity")
+	}
+
+	var r0 *models.TrackedSignal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*models.TrackedSignal, error)); ok {
+		return rf(ctx, ticker, lastDate, direction)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.TrackedSignal); ok {
+		r0 = rf(ctx, ticker, lastDate, direction)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TrackedSignal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, ticker, lastDate, direction)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTrackedSignalRepository_GetByIdentity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIdentity'
+type MockTrackedSignalRepository_GetByIdentity_Call struct {
+	*mock.Call
+}
+
+// GetByIdentity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ticker string
+//   - lastDate string
+//   - direction string
+func (_e *MockTrackedSignalRepository_Expecter) GetByIdentity(ctx interface{}, ticker interface{}, lastDate interface{}, direction interface{}) *MockTrackedSignalRepository_GetByIdentity_Call {
+	return &MockTrackedSignalRepository_GetByIdentity_Call{Call: _e.mock.On("GetByIdentity", ctx, ticker, lastDate, direction)}
+}
+
+func (_c *MockTrackedSignalRepository_GetByIdentity_Call) Run(run func(ctx context.Context, ticker string, lastDate string, direction string)) *MockTrackedSignalRepository_GetByIdentity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockTrackedSignalRepository_GetByIdentity_Call) Return(_a0 *models.TrackedSignal, _a1 error) *MockTrackedSignalRepository_GetByIdentity_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTrackedSignalRepository_GetByIdentity_Call) RunAndReturn(run func(context.Context, string, string, string) (*models.TrackedSignal, error)) *MockTrackedSignalRepository_GetByIdentity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, filter, limit
+func (_m *MockTrackedSignalRepository) List(ctx context.Context, filter *models.TrackedSignalFilter, limit int) ([]*models.TrackedSignal, error) {
+	ret := _m.Called(ctx, filter, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.TrackedSignal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TrackedSignalFilter, int) ([]*models.TrackedSignal, error)); ok {
+		return rf(ctx, filter, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TrackedSignalFilter, int) []*models.TrackedSignal); ok {
+		r0 = rf(ctx, filter, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.TrackedSignal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.TrackedSignalFilter, int) error); ok {
+		r1 = rf(ctx, filter, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTrackedSignalRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockTrackedSignalRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter *models.TrackedSignalFilter
+//   - limit int
+func (_e *MockTrackedSignalRepository_Expecter) List(ctx interface{}, filter interface{}, limit interface{}) *MockTrackedSignalRepository_List_Call {
+	return &MockTrackedSignalRepository_List_Call{Call: _e.mock.On("List", ctx, filter, limit)}
+}
+
+func (_c *MockTrackedSignalRepository_List_Call) Run(run func(ctx context.Context, filter *models.TrackedSignalFilter, limit int)) *MockTrackedSignalRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.TrackedSignalFilter), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockTrackedSignalRepository_List_Call) Return(_a0 []*models.TrackedSignal, _a1 error) *MockTrackedSignalRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTrackedSignalRepository_List_Call) RunAndReturn(run func(context.Context, *models.TrackedSignalFilter, int) ([]*models.TrackedSignal, error)) *MockTrackedSignalRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOpen provides a mock function with given fields: ctx, ticker, date
+func (_m *MockTrackedSignalRepository) ListOpen(ctx context.Context, ticker string, date string) ([]*models.TrackedSignal, error) {
+	ret := _m.Called(ctx, ticker, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOpen")
+	}
+
+	var r0 []*models.TrackedSignal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*models.TrackedSignal, error)); ok {
+		return rf(ctx, ticker, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*models.TrackedSignal); ok {
+		r0 = rf(ctx, ticker, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.TrackedSignal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, ticker, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTrackedSignalRepository_ListOpen_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOpen'
+type MockTrackedSignalRepository_ListOpen_Call struct {
+	*mock.Call
+}
+
+// ListOpen is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ticker string
+//   - date string
+func (_e *MockTrackedSignalRepository_Expecter) ListOpen(ctx interface{}, ticker interface{}, date interface{}) *MockTrackedSignalRepository_ListOpen_Call {
+	return &MockTrackedSignalRepository_ListOpen_Call{Call: _e.mock.On("ListOpen", ctx, ticker, date)}
+}
+
+func (_c *MockTrackedSignalRepository_ListOpen_Call) Run(run func(ctx context.Context, ticker string, date string)) *MockTrackedSignalRepository_ListOpen_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockTrackedSignalRepository_ListOpen_Call) Return(_a0 []*models.TrackedSignal, _a1 error) *MockTrackedSignalRepository_ListOpen_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTrackedSignalRepository_ListOpen_Call) RunAndReturn(run func(context.Context, string, string) ([]*models.TrackedSignal, error)) *MockTrackedSignalRepository_ListOpen_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPrices provides a mock function with given fields: ctx, ticker, date
+func (_m *MockTrackedSignalRepository) ListPrices(ctx context.Context, ticker string, date string) ([]*models.SignalPrice, error) {
+	ret := _m.Called(ctx, ticker, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPrices")
+	}
+
+	var r0 []*models.SignalPrice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*models.SignalPrice, error)); ok {
+		return rf(ctx, ticker, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*models.SignalPrice); ok {
+		r0 = rf(ctx, ticker, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SignalPrice)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, ticker, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTrackedSignalRepository_ListPrices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPrices'
+type MockTrackedSignalRepository_ListPrices_Call struct {
+	*mock.Call
+}
+
+// ListPrices is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ticker string
+//   - date string
+func (_e *MockTrackedSignalRepository_Expecter) ListPrices(ctx interface{}, ticker interface{}, date interface{}) *MockTrackedSignalRepository_ListPrices_Call {
+	return &MockTrackedSignalRepository_ListPrices_Call{Call: _e.mock.On("ListPrices", ctx, ticker, date)}
+}
+
+func (_c *MockTrackedSignalRepository_ListPrices_Call) Run(run func(ctx context.Context, ticker string, date string)) *MockTrackedSignalRepository_ListPrices_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockTrackedSignalRepository_ListPrices_Call) Return(_a0 []*models.SignalPrice, _a1 error) *MockTrackedSignalRepository_ListPrices_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTrackedSignalRepository_ListPrices_Call) RunAndReturn(run func(context.Context, string, string) ([]*models.SignalPrice, error)) *MockTrackedSignalRepository_ListPrices_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Resolve provides a mock function with given fields: ctx, tracked
+func (_m *MockTrackedSignalRepository) Resolve(ctx context.Context, tracked *models.TrackedSignal) (bool, error) {
+	ret := _m.Called(ctx, tracked)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resolve")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TrackedSignal) (bool, error)); ok {
+		return rf(ctx, tracked)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TrackedSignal) bool); ok {
+		r0 = rf(ctx, tracked)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.TrackedSignal) error); ok {
+		r1 = rf(ctx, tracked)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTrackedSignalRepository_Resolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resolve'
+type MockTrackedSignalRepository_Resolve_Call struct {
+	*mock.Call
+}
+
+// Resolve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tracked *models.TrackedSignal
+func (_e *MockTrackedSignalRepository_Expecter) Resolve(ctx interface{}, tracked interface{}) *MockTrackedSignalRepository_Resolve_Call {
+	return &MockTrackedSignalRepository_Resolve_Call{Call: _e.mock.On("Resolve", ctx, tracked)}
+}
+
+func (_c *MockTrackedSignalRepository_Resolve_Call) Run(run func(ctx context.Context, tracked *models.TrackedSignal)) *MockTrackedSignalRepository_Resolve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.TrackedSignal))
+	})
+	return _c
+}
+
+func (_c *MockTrackedSignalRepository_Resolve_Call) Return(_a0 bool, _a1 error) *MockTrackedSignalRepository_Resolve_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTrackedSignalRepository_Resolve_Call) RunAndReturn(run func(context.Context, *models.TrackedSignal) (bool, error)) *MockTrackedSignalRepository_Resolve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SavePrice provides a mock function with given fields: ctx, price
+func (_m *MockTrackedSignalRepository) SavePrice(ctx context.Context, price *models.SignalPrice) error {
+	ret := _m.Called(ctx, price)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SavePrice")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SignalPrice) error); ok {
+		r0 = rf(ctx, price)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTrackedSignalRepository_SavePrice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SavePrice'
+type MockTrackedSignalRepository_SavePrice_Call struct {
+	*mock.Call
+}
+
+// SavePrice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - price *models.SignalPrice
+func (_e *MockTrackedSignalRepository_Expecter) SavePrice(ctx interface{}, price interface{}) *MockTrackedSignalRepository_SavePrice_Call {
+	return &MockTrackedSignalRepository_SavePrice_Call{Call: _e.mock.On("SavePrice", ctx, price)}
+}
+
+func (_c *MockTrackedSignalRepository_SavePrice_Call) Run(run func(ctx context.Context, price *models.SignalPrice)) *MockTrackedSignalRepository_SavePrice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.SignalPrice))
+	})
+	return _c
+}
+
+func (_c *MockTrackedSignalRepository_SavePrice_Call) Return(_a0 error) *MockTrackedSignalRepository_SavePrice_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTrackedSignalRepository_SavePrice_Call) RunAndReturn(run func(context.Context, *models.SignalPrice) error) *MockTrackedSignalRepository_SavePrice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Stats provides a mock function with given fields: ctx, groupBy, bucketSize
+func (_m *MockTrackedSignalRepository) Stats(ctx context.Context, groupBy string, bucketSize float64) ([]*models.SignalStats, error) {
+	ret := _m.Called(ctx, groupBy, bucketSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stats")
+	}
+
+	var r0 []*models.SignalStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64) ([]*models.SignalStats, error)); ok {
+		return rf(ctx, groupBy, bucketSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64) []*models.SignalStats); ok {
+		r0 = rf(ctx, groupBy, bucketSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SignalStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, float64) error); ok {
+		r1 = rf(ctx, groupBy, bucketSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTrackedSignalRepository_Stats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stats'
+type MockTrackedSignalRepository_Stats_Call struct {
+	*mock.Call
+}
+
+// Stats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupBy string
+//   - bucketSize float64
+func (_e *MockTrackedSignalRepository_Expecter) Stats(ctx interface{}, groupBy interface{}, bucketSize interface{}) *MockTrackedSignalRepository_Stats_Call {
+	return &MockTrackedSignalRepository_Stats_Call{Call: _e.mock.On("Stats", ctx, groupBy, bucketSize)}
+}
+
+func (_c *MockTrackedSignalRepository_Stats_Call) Run(run func(ctx context.Context, groupBy string, bucketSize float64)) *MockTrackedSignalRepository_Stats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(float64))
+	})
+	return _c
+}
+
+func (_c *MockTrackedSignalRepository_Stats_Call) Return(_a0 []*models.SignalStats, _a1 error) *MockTrackedSignalRepository_Stats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTrackedSignalRepository_Stats_Call) RunAndReturn(run func(context.Context, string, float64) ([]*models.SignalStats, error)) *MockTrackedSignalRepository_Stats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTrackedSignalRepository creates a new instance of MockTrackedSignalRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTrackedSignalRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTrackedSignalRepository {
+	mock := &MockTrackedSignalRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -4,8 +4,8 @@ package mocks
 
 import (
 	context "context"
-	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
 
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
 	mock "github.com/stretchr/testify/mock"
 
 	uuid "github.com/google/uuid"
@@ -363,6 +363,74 @@ func (_c *MockUserRepository_IsEligible_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// List provides a mock function with given fields: ctx, filter, limit, offset
+func (_m *MockUserRepository) List(ctx context.Context, filter *models.UserFilter, limit int, offset int) ([]*models.User, int, error) {
+	ret := _m.Called(ctx, filter, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.User
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserFilter, int, int) ([]*models.User, int, error)); ok {
+		return rf(ctx, filter, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserFilter, int, int) []*models.User); ok {
+		r0 = rf(ctx, filter, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.UserFilter, int, int) int); ok {
+		r1 = rf(ctx, filter, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *models.UserFilter, int, int) error); ok {
+		r2 = rf(ctx, filter, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockUserRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockUserRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter *models.UserFilter
+//   - limit int
+//   - offset int
+func (_e *MockUserRepository_Expecter) List(ctx interface{}, filter interface{}, limit interface{}, offset interface{}) *MockUserRepository_List_Call {
+	return &MockUserRepository_List_Call{Call: _e.mock.On("List", ctx, filter, limit, offset)}
+}
+
+func (_c *MockUserRepository_List_Call) Run(run func(ctx context.Context, filter *models.UserFilter, limit int, offset int)) *MockUserRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.UserFilter), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_List_Call) Return(_a0 []*models.User, _a1 int, _a2 error) *MockUserRepository_List_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockUserRepository_List_Call) RunAndReturn(run func(context.Context, *models.UserFilter, int, int) ([]*models.User, int, error)) *MockUserRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: ctx, id, user
 func (_m *MockUserRepository) Update(ctx context.Context, id uuid.UUID, user *models.UpdateUserRequest) (*models.User, error) {
 	ret := _m.Called(ctx, id, user)
@@ -423,6 +491,72 @@ func (_c *MockUserRepository_Update_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// Upsert provides a mock function with given fields: ctx, user
+func (_m *MockUserRepository) Upsert(ctx context.Context, user *models.CreateUserRequest) (*models.User, bool, error) {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 *models.User
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CreateUserRequest) (*models.User, bool, error)); ok {
+		return rf(ctx, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CreateUserRequest) *models.User); ok {
+		r0 = rf(ctx, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.CreateUserRequest) bool); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *models.CreateUserRequest) error); ok {
+		r2 = rf(ctx, user)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockUserRepository_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type MockUserRepository_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *models.CreateUserRequest
+func (_e *MockUserRepository_Expecter) Upsert(ctx interface{}, user interface{}) *MockUserRepository_Upsert_Call {
+	return &MockUserRepository_Upsert_Call{Call: _e.mock.On("Upsert", ctx, user)}
+}
+
+func (_c *MockUserRepository_Upsert_Call) Run(run func(ctx context.Context, user *models.CreateUserRequest)) *MockUserRepository_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.CreateUserRequest))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_Upsert_Call) Return(_a0 *models.User, _a1 bool, _a2 error) *MockUserRepository_Upsert_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockUserRepository_Upsert_Call) RunAndReturn(run func(context.Context, *models.CreateUserRequest) (*models.User, bool, error)) *MockUserRepository_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockUserRepository creates a new instance of MockUserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockUserRepository(t interface {
@@ -4,8 +4,8 @@ package mocks
 
 import (
 	context "context"
-	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
 
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
 	mock "github.com/stretchr/testify/mock"
 
 	uuid "github.com/google/uuid"
@@ -130,6 +130,65 @@ func (_c *MockUserService_DeleteUser_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// ExportUsers provides a mock function with given fields: ctx, filter
+func (_m *MockUserService) ExportUsers(ctx context.Context, filter *models.UserFilter) ([]*models.User, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportUsers")
+	}
+
+	var r0 []*models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserFilter) ([]*models.User, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserFilter) []*models.User); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.UserFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserService_ExportUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportUsers'
+type MockUserService_ExportUsers_Call struct {
+	*mock.Call
+}
+
+// ExportUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter *models.UserFilter
+func (_e *MockUserService_Expecter) ExportUsers(ctx interface{}, filter interface{}) *MockUserService_ExportUsers_Call {
+	return &MockUserService_ExportUsers_Call{Call: _e.mock.On("ExportUsers", ctx, filter)}
+}
+
+func (_c *MockUserService_ExportUsers_Call) Run(run func(ctx context.Context, filter *models.UserFilter)) *MockUserService_ExportUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.UserFilter))
+	})
+	return _c
+}
+
+func (_c *MockUserService_ExportUsers_Call) Return(_a0 []*models.User, _a1 error) *MockUserService_ExportUsers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserService_ExportUsers_Call) RunAndReturn(run func(context.Context, *models.UserFilter) ([]*models.User, error)) *MockUserService_ExportUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetEligibleUsers provides a mock function with given fields: ctx
 func (_m *MockUserService) GetEligibleUsers(ctx context.Context) ([]*models.User, error) {
 	ret := _m.Called(ctx)
@@ -306,6 +365,56 @@ func (_c *MockUserService_GetUserByPhone_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// ImportUsers provides a mock function with given fields: ctx, rows, dryRun
+func (_m *MockUserService) ImportUsers(ctx context.Context, rows []*models.CreateUserRequest, dryRun bool) *models.UserImportReport {
+	ret := _m.Called(ctx, rows, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportUsers")
+	}
+
+	var r0 *models.UserImportReport
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.CreateUserRequest, bool) *models.UserImportReport); ok {
+		r0 = rf(ctx, rows, dryRun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserImportReport)
+		}
+	}
+
+	return r0
+}
+
+// MockUserService_ImportUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportUsers'
+type MockUserService_ImportUsers_Call struct {
+	*mock.Call
+}
+
+// ImportUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - rows []*models.CreateUserRequest
+//   - dryRun bool
+func (_e *MockUserService_Expecter) ImportUsers(ctx interface{}, rows interface{}, dryRun interface{}) *MockUserService_ImportUsers_Call {
+	return &MockUserService_ImportUsers_Call{Call: _e.mock.On("ImportUsers", ctx, rows, dryRun)}
+}
+
+func (_c *MockUserService_ImportUsers_Call) Run(run func(ctx context.Context, rows []*models.CreateUserRequest, dryRun bool)) *MockUserService_ImportUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*models.CreateUserRequest), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockUserService_ImportUsers_Call) Return(_a0 *models.UserImportReport) *MockUserService_ImportUsers_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserService_ImportUsers_Call) RunAndReturn(run func(context.Context, []*models.CreateUserRequest, bool) *models.UserImportReport) *MockUserService_ImportUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IsUserEligible provides a mock function with given fields: ctx, phone
 func (_m *MockUserService) IsUserEligible(ctx context.Context, phone string) (bool, error) {
 	ret := _m.Called(ctx, phone)
@@ -363,6 +472,127 @@ func (_c *MockUserService_IsUserEligible_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// ListUsers provides a mock function with given fields: ctx, filter, page, pageSize
+func (_m *MockUserService) ListUsers(ctx context.Context, filter *models.UserFilter, page int, pageSize int) (*models.UserPage, error) {
+	ret := _m.Called(ctx, filter, page, pageSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsers")
+	}
+
+	var r0 *models.UserPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserFilter, int, int) (*models.UserPage, error)); ok {
+		return rf(ctx, filter, page, pageSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserFilter, int, int) *models.UserPage); ok {
+		r0 = rf(ctx, filter, page, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.UserFilter, int, int) error); ok {
+		r1 = rf(ctx, filter, page, pageSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserService_ListUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUsers'
+type MockUserService_ListUsers_Call struct {
+	*mock.Call
+}
+
+// ListUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter *models.UserFilter
+//   - page int
+//   - pageSize int
+func (_e *MockUserService_Expecter) ListUsers(ctx interface{}, filter interface{}, page interface{}, pageSize interface{}) *MockUserService_ListUsers_Call {
+	return &MockUserService_ListUsers_Call{Call: _e.mock.On("ListUsers", ctx, filter, page, pageSize)}
+}
+
+func (_c *MockUserService_ListUsers_Call) Run(run func(ctx context.Context, filter *models.UserFilter, page int, pageSize int)) *MockUserService_ListUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.UserFilter), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockUserService_ListUsers_Call) Return(_a0 *models.UserPage, _a1 error) *MockUserService_ListUsers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserService_ListUsers_Call) RunAndReturn(run func(context.Context, *models.UserFilter, int, int) (*models.UserPage, error)) *MockUserService_ListUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetUserActive provides a mock function with given fields: ctx, id, active
+func (_m *MockUserService) SetUserActive(ctx context.Context, id uuid.UUID, active bool) (*models.User, error) {
+	ret := _m.Called(ctx, id, active)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserActive")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool) (*models.User, error)); ok {
+		return rf(ctx, id, active)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool) *models.User); ok {
+		r0 = rf(ctx, id, active)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, bool) error); ok {
+		r1 = rf(ctx, id, active)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserService_SetUserActive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUserActive'
+type MockUserService_SetUserActive_Call struct {
+	*mock.Call
+}
+
+// SetUserActive is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - active bool
+func (_e *MockUserService_Expecter) SetUserActive(ctx interface{}, id interface{}, active interface{}) *MockUserService_SetUserActive_Call {
+	return &MockUserService_SetUserActive_Call{Call: _e.mock.On("SetUserActive", ctx, id, active)}
+}
+
+func (_c *MockUserService_SetUserActive_Call) Run(run func(ctx context.Context, id uuid.UUID, active bool)) *MockUserService_SetUserActive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockUserService_SetUserActive_Call) Return(_a0 *models.User, _a1 error) *MockUserService_SetUserActive_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserService_SetUserActive_Call) RunAndReturn(run func(context.Context, uuid.UUID, bool) (*models.User, error)) *MockUserService_SetUserActive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateUser provides a mock function with given fields: ctx, id, req
 func (_m *MockUserService) UpdateUser(ctx context.Context, id uuid.UUID, req *models.UpdateUserRequest) (*models.User, error) {
 	ret := _m.Called(ctx, id, req)
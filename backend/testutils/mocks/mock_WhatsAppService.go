@@ -214,6 +214,124 @@ func (_c *MockWhatsAppService_SendMessage_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// SendMessageWithID provides a mock function with given fields: ctx, phone, message
+func (_m *MockWhatsAppService) SendMessageWithID(ctx context.Context, phone string, message string) (string, error) {
+	ret := _m.Called(ctx, phone, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMessageWithID")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, phone, message)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, phone, message)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, phone, message)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWhatsAppService_SendMessageWithID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendMessageWithID'
+type MockWhatsAppService_SendMessageWithID_Call struct {
+	*mock.Call
+}
+
+// SendMessageWithID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - phone string
+//   - message string
+func (_e *MockWhatsAppService_Expecter) SendMessageWithID(ctx interface{}, phone interface{}, message interface{}) *MockWhatsAppService_SendMessageWithID_Call {
+	return &MockWhatsAppService_SendMessageWithID_Call{Call: _e.mock.On("SendMessageWithID", ctx, phone, message)}
+}
+
+func (_c *MockWhatsAppService_SendMessageWithID_Call) Run(run func(ctx context.Context, phone string, message string)) *MockWhatsAppService_SendMessageWithID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockWhatsAppService_SendMessageWithID_Call) Return(_a0 string, _a1 error) *MockWhatsAppService_SendMessageWithID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWhatsAppService_SendMessageWithID_Call) RunAndReturn(run func(context.Context, string, string) (string, error)) *MockWhatsAppService_SendMessageWithID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendReplyWithID provides a mock function with given fields: ctx, phone, message, quotedID, quotedMessage
+func (_m *MockWhatsAppService) SendReplyWithID(ctx context.Context, phone string, message string, quotedID string, quotedMessage string) (string, error) {
+	ret := _m.Called(ctx, phone, message, quotedID, quotedMessage)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendReplyWithID")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) (string, error)); ok {
+		return rf(ctx, phone, message, quotedID, quotedMessage)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) string); ok {
+		r0 = rf(ctx, phone, message, quotedID, quotedMessage)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, phone, message, quotedID, quotedMessage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWhatsAppService_SendReplyWithID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendReplyWithID'
+type MockWhatsAppService_SendReplyWithID_Call struct {
+	*mock.Call
+}
+
+// SendReplyWithID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - phone string
+//   - message string
+//   - quotedID string
+//   - quotedMessage string
+func (_e *MockWhatsAppService_Expecter) SendReplyWithID(ctx interface{}, phone interface{}, message interface{}, quotedID interface{}, quotedMessage interface{}) *MockWhatsAppService_SendReplyWithID_Call {
+	return &MockWhatsAppService_SendReplyWithID_Call{Call: _e.mock.On("SendReplyWithID", ctx, phone, message, quotedID, quotedMessage)}
+}
+
+func (_c *MockWhatsAppService_SendReplyWithID_Call) Run(run func(ctx context.Context, phone string, message string, quotedID string, quotedMessage string)) *MockWhatsAppService_SendReplyWithID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockWhatsAppService_SendReplyWithID_Call) Return(_a0 string, _a1 error) *MockWhatsAppService_SendReplyWithID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWhatsAppService_SendReplyWithID_Call) RunAndReturn(run func(context.Context, string, string, string, string) (string, error)) *MockWhatsAppService_SendReplyWithID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Start provides a mock function with given fields: ctx
 func (_m *MockWhatsAppService) Start(ctx context.Context) error {
 	ret := _m.Called(ctx)
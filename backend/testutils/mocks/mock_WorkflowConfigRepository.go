@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 
+	models "github.com/fajarAnd/workshop-brin/wa-service/internal/app/models"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -77,6 +78,123 @@ func (_c *MockWorkflowConfigRepository_GetActiveWorkflowType_Call) RunAndReturn(
 	return _c
 }
 
+// GetConfig provides a mock function with given fields: ctx
+func (_m *MockWorkflowConfigRepository) GetConfig(ctx context.Context) (*models.WorkflowConfig, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConfig")
+	}
+
+	var r0 *models.WorkflowConfig
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*models.WorkflowConfig, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *models.WorkflowConfig); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WorkflowConfig)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWorkflowConfigRepository_GetConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetConfig'
+type MockWorkflowConfigRepository_GetConfig_Call struct {
+	*mock.Call
+}
+
+// GetConfig is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockWorkflowConfigRepository_Expecter) GetConfig(ctx interface{}) *MockWorkflowConfigRepository_GetConfig_Call {
+	return &MockWorkflowConfigRepository_GetConfig_Call{Call: _e.mock.On("GetConfig", ctx)}
+}
+
+func (_c *MockWorkflowConfigRepository_GetConfig_Call) Run(run func(ctx context.Context)) *MockWorkflowConfigRepository_GetConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockWorkflowConfigRepository_GetConfig_Call) Return(_a0 *models.WorkflowConfig, _a1 error) *MockWorkflowConfigRepository_GetConfig_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWorkflowConfigRepository_GetConfig_Call) RunAndReturn(run func(context.Context) (*models.WorkflowConfig, error)) *MockWorkflowConfigRepository_GetConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetWorkflowType provides a mock function with given fields: ctx, workflowType
+func (_m *MockWorkflowConfigRepository) SetWorkflowType(ctx context.Context, workflowType string) (*models.WorkflowConfig, error) {
+	ret := _m.Called(ctx, workflowType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetWorkflowType")
+	}
+
+	var r0 *models.WorkflowConfig
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.WorkflowConfig, error)); ok {
+		return rf(ctx, workflowType)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.WorkflowConfig); ok {
+		r0 = rf(ctx, workflowType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WorkflowConfig)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, workflowType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWorkflowConfigRepository_SetWorkflowType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetWorkflowType'
+type MockWorkflowConfigRepository_SetWorkflowType_Call struct {
+	*mock.Call
+}
+
+// SetWorkflowType is a helper method to define mock.On call
+//   - ctx context.Context
+//   - workflowType string
+func (_e *MockWorkflowConfigRepository_Expecter) SetWorkflowType(ctx interface{}, workflowType interface{}) *MockWorkflowConfigRepository_SetWorkflowType_Call {
+	return &MockWorkflowConfigRepository_SetWorkflowType_Call{Call: _e.mock.On("SetWorkflowType", ctx, workflowType)}
+}
+
+func (_c *MockWorkflowConfigRepository_SetWorkflowType_Call) Run(run func(ctx context.Context, workflowType string)) *MockWorkflowConfigRepository_SetWorkflowType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockWorkflowConfigRepository_SetWorkflowType_Call) Return(_a0 *models.WorkflowConfig, _a1 error) *MockWorkflowConfigRepository_SetWorkflowType_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWorkflowConfigRepository_SetWorkflowType_Call) RunAndReturn(run func(context.Context, string) (*models.WorkflowConfig, error)) *MockWorkflowConfigRepository_SetWorkflowType_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockWorkflowConfigRepository creates a new instance of MockWorkflowConfigRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockWorkflowConfigRepository(t interface {